@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// cleanCmd represents the clean command
+var cleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Remove merged or stale worktrees in bulk",
+	Long: `Remove the hatcher-managed worktrees whose branch is merged into the
+default branch and/or whose files were not modified for a while, together
+with their local branches.
+
+Branches that are not merged are kept unless --force is given. The main
+repository and the current worktree are never removed.
+
+Examples:
+  hch clean                      # Remove worktrees of merged branches
+  hch clean --stale 720h         # Remove worktrees unused for 30 days
+  hch clean --merged --stale 720h --dry-run
+  hch clean --into develop --yes # Check merges against develop, no prompt`,
+	Args: cobra.NoArgs,
+	RunE: runClean,
+}
+
+func init() {
+	rootCmd.AddCommand(cleanCmd)
+
+	cleanCmd.Flags().Bool("merged", false, "Remove worktrees whose branch is merged (the default without --stale)")
+	cleanCmd.Flags().Duration("stale", 0, "Remove worktrees whose files were not modified for this long (e.g. 720h)")
+	cleanCmd.Flags().String("into", "", "Branch merges are checked against (default: the default branch)")
+	cleanCmd.Flags().BoolP("force", "f", false, "Remove worktrees with uncommitted changes and delete unmerged branches")
+	cleanCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+}
+
+func runClean(cmd *cobra.Command, args []string) error {
+	merged, _ := cmd.Flags().GetBool("merged")
+	staleFor, _ := cmd.Flags().GetDuration("stale")
+	into, _ := cmd.Flags().GetString("into")
+	force, _ := cmd.Flags().GetBool("force")
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+
+	if staleFor < 0 {
+		return fmt.Errorf("❌ Invalid --stale: must not be negative")
+	}
+	if !merged && staleFor == 0 {
+		merged = true
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	layout := loadWorktreeLayout(repo)
+	cleaner := worktree.NewCleaner(repo)
+	cleaner.SetLayout(layout)
+	candidates, err := cleaner.Candidates(worktree.CleanOptions{
+		Merged:   merged,
+		Into:     into,
+		StaleFor: staleFor,
+	})
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	if len(candidates) == 0 {
+		fmt.Println("✅ No worktrees to clean")
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("🔍 Dry run mode - %d worktrees would be removed:\n", len(candidates))
+	} else {
+		fmt.Printf("🧹 %d worktrees to remove:\n", len(candidates))
+	}
+	for _, candidate := range candidates {
+		fmt.Printf("  - %s (%s): %s\n", candidate.Branch, candidate.Path, strings.Join(candidate.Reasons, ", "))
+		if !candidate.Merged && !force {
+			fmt.Printf("    ⚠️  branch is not merged and will be kept\n")
+		}
+	}
+	if dryRun {
+		return nil
+	}
+
+	if !skipConfirm && !confirm("Remove these worktrees?") {
+		fmt.Println("❌ Clean cancelled")
+		return nil
+	}
+
+	remover := worktree.NewRemover(repo)
+	remover.SetLayout(layout)
+	removed, failed := 0, 0
+	for _, candidate := range candidates {
+		result, err := remover.RemoveWorktree(worktree.RemoveOptions{
+			BranchName:   candidate.Branch,
+			RemoveBranch: candidate.Merged || force,
+			Force:        force,
+			SkipConfirm:  true,
+		})
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", candidate.Branch, err)
+			failed++
+			continue
+		}
+		removed++
+		if result.LocalBranchRemoved {
+			fmt.Printf("🗂️  Removed worktree and branch: %s\n", candidate.Branch)
+		} else {
+			fmt.Printf("🗂️  Removed worktree: %s\n", candidate.Path)
+		}
+	}
+
+	fmt.Printf("🧹 Removed %d worktrees\n", removed)
+	if failed > 0 {
+		return fmt.Errorf("❌ Failed to remove %d worktrees", failed)
+	}
+	return nil
+}
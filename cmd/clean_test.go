@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "clean-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+	defer func() { dryRun = false }()
+	defer cleanCmd.Flags().Set("yes", "false")
+
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	mainBranch := testRepo.GetCurrentBranch()
+
+	mergedPath := filepath.Join(testRepo.TempDir, "clean-project-merged")
+	require.NoError(t, repo.CreateWorktree(mergedPath, "merged", true))
+
+	unmergedPath := filepath.Join(testRepo.TempDir, "clean-project-unmerged")
+	require.NoError(t, repo.CreateWorktree(unmergedPath, "unmerged", true))
+	require.NoError(t, os.WriteFile(filepath.Join(unmergedPath, "work.txt"), []byte("work"), 0644))
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "Work in progress"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = unmergedPath
+		require.NoError(t, cmd.Run())
+	}
+
+	t.Run("dry run keeps the worktrees", func(t *testing.T) {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "clean", "--into", mainBranch, "--dry-run"))
+		})
+		assert.Contains(t, stdout, "1 worktrees would be removed")
+		assert.Contains(t, stdout, "merged into "+mainBranch)
+		assert.NotContains(t, stdout, "unmerged")
+		assert.DirExists(t, mergedPath)
+	})
+
+	t.Run("removes merged worktrees and their branches", func(t *testing.T) {
+		dryRun = false
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "clean", "--into", mainBranch, "--yes"))
+
+		assert.NoDirExists(t, mergedPath)
+		assert.False(t, testRepo.BranchExists("merged"))
+		assert.DirExists(t, unmergedPath)
+		assert.True(t, testRepo.BranchExists("unmerged"))
+		assert.DirExists(t, testRepo.RepoDir)
+	})
+
+	t.Run("nothing left to clean", func(t *testing.T) {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "clean", "--into", mainBranch, "--yes"))
+		})
+		assert.Contains(t, stdout, "No worktrees to clean")
+	})
+}
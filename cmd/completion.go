@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the autocompletion script for your shell",
+	Long: `Generate the autocompletion script for hatcher for the given shell.
+
+Branch arguments of move, remove and rename complete to the branches of
+existing worktrees.
+
+Examples:
+  source <(hatcher completion bash)                       # Current bash session
+  hatcher completion bash > /etc/bash_completion.d/hatcher
+  hatcher completion zsh > "${fpath[1]}/_hatcher"
+  hatcher completion fish > ~/.config/fish/completions/hatcher.fish
+  hatcher completion powershell | Out-String | Invoke-Expression
+
+The scripts complete the hatcher command. For the hch alias in bash, add:
+  complete -o default -F __start_hatcher hch`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE:                  runCompletion,
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+
+	moveCmd.ValidArgsFunction = completeWorktreeBranches(false)
+	removeCmd.ValidArgsFunction = completeWorktreeBranches(true)
+	renameCmd.ValidArgsFunction = completeWorktreeBranches(true)
+}
+
+func runCompletion(cmd *cobra.Command, args []string) error {
+	out := cmd.OutOrStdout()
+	switch args[0] {
+	case "bash":
+		return rootCmd.GenBashCompletionV2(out, true)
+	case "zsh":
+		return rootCmd.GenZshCompletion(out)
+	case "fish":
+		return rootCmd.GenFishCompletion(out, true)
+	case "powershell":
+		return rootCmd.GenPowerShellCompletionWithDesc(out)
+	default:
+		return fmt.Errorf("❌ Unsupported shell: %s", args[0])
+	}
+}
+
+// completeWorktreeBranches completes the first argument with the branches of
+// worktrees. With managedOnly, the main repository and worktrees hatcher did
+// not create are left out.
+func completeWorktreeBranches(managedOnly bool) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		repo, err := git.NewRepository()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		finder := worktree.NewFinder(repo)
+		finder.SetLayout(loadWorktreeLayout(repo))
+		worktrees, err := finder.ListHatcherWorktrees()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var branches []string
+		for _, wt := range worktrees {
+			if wt.Branch == "" || !strings.HasPrefix(wt.Branch, toComplete) {
+				continue
+			}
+			if managedOnly && (wt.IsMain || !wt.IsHatcherManaged) {
+				continue
+			}
+			branches = append(branches, wt.Branch)
+		}
+		sort.Strings(branches)
+		return branches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "completion", shell))
+			assert.Contains(t, cliHelper.GetStdout(), "hatcher")
+		})
+	}
+
+	t.Run("unknown shell", func(t *testing.T) {
+		assert.Error(t, cliHelper.ExecuteCommand(rootCmd, "completion", "tcsh"))
+	})
+}
+
+func TestCompleteWorktreeBranches(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "completion-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	mainBranch := testRepo.GetCurrentBranch()
+
+	for _, branch := range []string{"feature/login", "feature/logout", "bugfix/crash"} {
+		path := filepath.Join(testRepo.TempDir, "completion-project-"+strings.ReplaceAll(branch, "/", "-"))
+		require.NoError(t, repo.CreateWorktree(path, branch, true))
+	}
+	// Not hatcher-managed
+	require.NoError(t, repo.CreateWorktree(filepath.Join(t.TempDir(), "external"), "feature/external", true))
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	t.Run("remove completes managed worktrees with the prefix", func(t *testing.T) {
+		branches, directive := removeCmd.ValidArgsFunction(removeCmd, nil, "feature/log")
+		assert.Equal(t, []string{"feature/login", "feature/logout"}, branches)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+
+	t.Run("rename leaves out the main repository", func(t *testing.T) {
+		branches, _ := renameCmd.ValidArgsFunction(renameCmd, nil, "")
+		assert.Equal(t, []string{"bugfix/crash", "feature/login", "feature/logout"}, branches)
+	})
+
+	t.Run("move completes every worktree", func(t *testing.T) {
+		branches, _ := moveCmd.ValidArgsFunction(moveCmd, nil, "")
+		assert.Contains(t, branches, mainBranch)
+		assert.Contains(t, branches, "feature/external")
+		assert.Contains(t, branches, "bugfix/crash")
+	})
+
+	t.Run("only the first argument is completed", func(t *testing.T) {
+		branches, _ := renameCmd.ValidArgsFunction(renameCmd, []string{"feature/login"}, "feature/")
+		assert.Empty(t, branches)
+	})
+
+	t.Run("through the shell completion request", func(t *testing.T) {
+		cliHelper := testutil.NewCLITestHelper(t)
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, cobra.ShellCompRequestCmd, "remove", "bug"))
+		lines := strings.Split(strings.TrimSpace(cliHelper.GetStdout()), "\n")
+		assert.Equal(t, []string{"bugfix/crash", ":4"}, lines)
+	})
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/keisukeshimizu/hatcher/internal/config"
 	"github.com/spf13/cobra"
@@ -24,7 +27,10 @@ Examples:
   hch config init                    # Initialize default config
   hch config show                    # Show current configuration
   hch config edit                    # Edit configuration interactively
-  hch config validate                # Validate configuration files`,
+  hch config get editor.preferred    # Print a single value
+  hch config set editor.preferred vim  # Change a single value
+  hch config validate                # Validate configuration files
+  hch config schema                  # Print a JSON Schema of the format`,
 	Aliases: []string{"cfg"},
 }
 
@@ -39,12 +45,17 @@ and other Hatcher features.
 
 Examples:
   hch config init                    # Initialize project config
+  hch config init --format yaml      # Initialize project config as YAML
   hch config init --global           # Initialize global config
-  hch config init --force            # Overwrite existing config`,
+  hch config init --force            # Overwrite existing config
+  hch config init --force --dry-run  # Show the change without writing it`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		global, _ := cmd.Flags().GetBool("global")
 		force, _ := cmd.Flags().GetBool("force")
-		_, _ = cmd.Flags().GetString("format") // format not used in init
+		format, _ := cmd.Flags().GetString("format")
+		if format != config.FormatJSON && format != config.FormatYAML {
+			return fmt.Errorf("unsupported format %q (use json or yaml)", format)
+		}
 
 		manager := config.NewManager()
 
@@ -78,8 +89,12 @@ Examples:
 			return fmt.Errorf("failed to load default config: %w", err)
 		}
 
+		if dryRun {
+			return previewConfigChange(manager, defaultConfig, projectPath, global, format)
+		}
+
 		// Save config
-		if err := manager.SaveConfig(defaultConfig, projectPath, global); err != nil {
+		if err := manager.SaveConfig(defaultConfig, projectPath, global, format); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
@@ -96,7 +111,14 @@ Examples:
 			homeDir, _ := os.UserHomeDir()
 			fmt.Printf("📁 Config location: %s\n", filepath.Join(homeDir, ".hatcher", "config.yaml"))
 		} else {
-			fmt.Printf("📁 Config location: %s\n", filepath.Join(projectPath, ".hatcher-auto-copy.json"))
+			configPath := config.ProjectConfigPath(projectPath, format)
+			fmt.Printf("📁 Config location: %s\n", configPath)
+			// The loader reads .hatcher-auto-copy.json before the YAML variant
+			if other := config.ProjectConfigPath(projectPath, config.FormatJSON); other != configPath {
+				if _, err := os.Stat(other); err == nil {
+					fmt.Printf("⚠️  %s takes precedence over the new file; remove it to use %s\n", other, configPath)
+				}
+			}
 		}
 
 		return nil
@@ -117,7 +139,7 @@ Examples:
   hch config show --format json     # Show as JSON
   hch config show --format yaml     # Show as YAML
   hch config show --paths            # Show config file paths`,
-	Aliases: []string{"get", "view"},
+	Aliases: []string{"view"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		format, _ := cmd.Flags().GetString("format")
 		showPaths, _ := cmd.Flags().GetBool("paths")
@@ -186,6 +208,7 @@ Examples:
 
 		var configPath string
 		var projectPath string
+		format := config.FormatJSON
 
 		if global {
 			homeDir, err := os.UserHomeDir()
@@ -203,7 +226,13 @@ Examples:
 			if err != nil {
 				return fmt.Errorf("failed to get current directory: %w", err)
 			}
-			configPath = filepath.Join(projectPath, ".hatcher-auto-copy.json")
+			// Edit an existing YAML project config instead of creating a JSON one
+			if _, err := os.Stat(config.ProjectConfigPath(projectPath, config.FormatYAML)); err == nil {
+				if _, err := os.Stat(config.ProjectConfigPath(projectPath, config.FormatJSON)); os.IsNotExist(err) {
+					format = config.FormatYAML
+				}
+			}
+			configPath = config.ProjectConfigPath(projectPath, format)
 		}
 
 		// Create config file if it doesn't exist
@@ -213,7 +242,7 @@ Examples:
 				return fmt.Errorf("failed to load default config: %w", err)
 			}
 
-			if err := manager.SaveConfig(defaultConfig, projectPath, global); err != nil {
+			if err := manager.SaveConfig(defaultConfig, projectPath, global, format); err != nil {
 				return fmt.Errorf("failed to create config file: %w", err)
 			}
 
@@ -249,9 +278,15 @@ var configValidateCmd = &cobra.Command{
 Checks all configuration files for proper JSON/YAML syntax, valid settings,
 and logical consistency.
 
+With --fix, common issues are repaired in the config files, which keep their
+JSON or YAML format: an unsupported autocopy version becomes 2, items with an
+empty path or a path containing ".." are removed and an unsupported output
+format becomes "table". Remaining errors must be fixed by hand.
+
 Examples:
   hch config validate                # Validate current config
-  hch config validate --fix          # Attempt to fix issues automatically`,
+  hch config validate --fix          # Attempt to fix issues automatically
+  hch config validate --fix --dry-run  # Show the fixes without writing them`,
 	Aliases: []string{"check"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		fix, _ := cmd.Flags().GetBool("fix")
@@ -265,36 +300,235 @@ Examples:
 		}
 
 		// Load configuration
-		cfg, err := manager.LoadConfig(projectPath)
+		errors, err := readConfigErrors(manager, projectPath)
 		if err != nil {
 			fmt.Printf("❌ Configuration loading failed: %v\n", err)
+			if !fix {
+				return err
+			}
+		} else if len(errors) == 0 {
+			fmt.Println("✅ Configuration is valid")
+			return nil
+		} else {
+			fmt.Printf("❌ Found %d validation error(s):\n", len(errors))
+			for i, err := range errors {
+				fmt.Printf("%d. %s\n", i+1, err)
+			}
+		}
+
+		if !fix {
+			fmt.Println("\n💡 Use --fix to attempt automatic repairs")
+			return fmt.Errorf("configuration validation failed")
+		}
+
+		fmt.Println("\n🔧 Attempting to fix issues...")
+		fixed, err := fixConfigFiles(manager, projectPath)
+		if err != nil {
 			return err
 		}
+		if dryRun {
+			fmt.Println("🔍 Dry run mode - configuration not written")
+			return fmt.Errorf("configuration validation failed")
+		}
 
-		// Validate configuration
-		errors := manager.ValidateConfig(cfg)
+		// Re-validate the repaired files
+		errors, err = readConfigErrors(manager, projectPath)
+		if err != nil {
+			fmt.Printf("❌ Configuration loading still fails and needs manual intervention: %v\n", err)
+			return err
+		}
 		if len(errors) == 0 {
-			fmt.Println("✅ Configuration is valid")
+			fmt.Printf("✅ Configuration is valid after %d fix(es)\n", fixed)
 			return nil
 		}
 
-		fmt.Printf("❌ Found %d validation error(s):\n", len(errors))
+		if fixed == 0 {
+			fmt.Println("ℹ️  No automatic fixes available")
+		}
+		fmt.Printf("⚠️  %d error(s) need manual intervention:\n", len(errors))
 		for i, err := range errors {
 			fmt.Printf("%d. %s\n", i+1, err)
 		}
+		return fmt.Errorf("configuration validation failed")
+	},
+}
 
-		if fix {
-			fmt.Println("\n🔧 Attempting to fix issues...")
-			// This would implement automatic fixes
-			fmt.Println("💡 Automatic fixes not yet implemented")
-		} else {
-			fmt.Println("\n💡 Use --fix to attempt automatic repairs")
+// configGetCmd prints a single setting
+// configSchemaCmd prints a JSON Schema of the configuration files
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print a JSON Schema of the configuration format",
+	Long: `Print a JSON Schema (draft 2020-12) of .hatcher-auto-copy.json, for
+autocompletion and validation in editors. With --full, the schema covers the
+complete configuration in .hatcher/config.json and ~/.hatcher/config.yaml.
+
+Examples:
+  hch config schema > hatcher.schema.json
+  hch config schema --full --format yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		full, _ := cmd.Flags().GetBool("full")
+
+		schema := config.Schema(full)
+		switch format {
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(schema)
+		case "yaml":
+			encoder := yaml.NewEncoder(os.Stdout)
+			defer encoder.Close()
+			return encoder.Encode(schema)
+		default:
+			return fmt.Errorf("unsupported format %q (use json or yaml)", format)
 		}
+	},
+}
 
-		return fmt.Errorf("configuration validation failed")
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a configuration value",
+	Long: `Print a single value of the merged configuration.
+
+Keys are dotted paths as in the JSON config files. Items of a list are
+addressed by their index.
+
+Examples:
+  hch config get editor.preferred
+  hch config get global.outputFormat
+  hch config get autocopy.items.0.recursive`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectPath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		cfg, err := config.NewManager().ReadConfig(projectPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+
+		value, err := cfg.Get(args[0])
+		if err != nil {
+			return configKeyError(err)
+		}
+
+		switch v := value.(type) {
+		case nil:
+			fmt.Println()
+		case []string:
+			fmt.Println(strings.Join(v, ","))
+		default:
+			fmt.Println(v)
+		}
+		return nil
+	},
+}
+
+// configSetCmd changes a single setting
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a configuration value",
+	Long: `Change a single value in the project or global config file.
+
+Keys are dotted paths as in the JSON config files. Items of a list are
+addressed by their index, and list values are comma-separated. The value
+is validated before the file is written, and the file keeps its JSON or
+YAML format and its other settings.
+
+Examples:
+  hch config set editor.preferred cursor
+  hch config set global.outputFormat json --global
+  hch config set autocopy.items.0.recursive false
+  hch config set autocopy.items.0.exclude "*.log,tmp"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		global, _ := cmd.Flags().GetBool("global")
+
+		projectPath, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+
+		path, err := config.NewManager().SetConfigValue(projectPath, global, args[0], args[1])
+		if err != nil {
+			return configKeyError(err)
+		}
+
+		fmt.Printf("✅ Set %s = %s in %s\n", args[0], args[1], path)
+		return nil
 	},
 }
 
+// configKeyError adds the valid keys to an unknown key error
+func configKeyError(err error) error {
+	if !errors.Is(err, config.ErrUnknownKey) {
+		return err
+	}
+	return fmt.Errorf("%w\nvalid keys:\n  %s", err, strings.Join(config.Keys(), "\n  "))
+}
+
+// readConfigErrors loads the configuration for projectPath and returns its
+// validation errors
+func readConfigErrors(manager *config.Manager, projectPath string) ([]string, error) {
+	cfg, err := manager.ReadConfig(projectPath)
+	if err != nil {
+		return nil, err
+	}
+	return manager.ValidateConfig(cfg), nil
+}
+
+// fixConfigFiles repairs the config files loaded for projectPath, printing
+// each change, and returns how many changes were made
+func fixConfigFiles(manager *config.Manager, projectPath string) (int, error) {
+	fixed := 0
+	for _, path := range manager.ConfigFiles(projectPath) {
+		result, err := manager.FixConfigFile(path, dryRun)
+		if err != nil {
+			return fixed, fmt.Errorf("failed to fix %s: %w", path, err)
+		}
+		if len(result.Fixes) == 0 {
+			continue
+		}
+
+		fmt.Printf("📝 %s:\n", result.Path)
+		for _, change := range result.Fixes {
+			fmt.Printf("  - %s: %s\n", change.Key, change.Before)
+			if change.After != "" {
+				fmt.Printf("  + %s: %s\n", change.Key, change.After)
+			}
+			fmt.Printf("    (%s)\n", change.Reason)
+		}
+		fixed += len(result.Fixes)
+	}
+	return fixed, nil
+}
+
+// previewConfigChange prints the diff SaveConfig would apply to the config
+// file without writing it
+func previewConfigChange(manager *config.Manager, cfg *config.Config, projectPath string, global bool, format string) error {
+	configPath, data, err := manager.MarshalConfig(cfg, projectPath, global, format)
+	if err != nil {
+		return err
+	}
+
+	existing, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	fmt.Println("🔍 Dry run mode - configuration not written")
+	diff := config.UnifiedDiff(configPath, existing, data)
+	if diff == "" {
+		fmt.Printf("ℹ️  No changes to %s\n", configPath)
+		return nil
+	}
+	fmt.Print(diff)
+	return nil
+}
+
 // displayConfigTable displays configuration in a readable table format
 func displayConfigTable(cfg *config.Config) error {
 	fmt.Println("📋 Current Hatcher Configuration")
@@ -332,6 +566,9 @@ func displayConfigTable(cfg *config.Config) error {
 	fmt.Printf("  Preferred: %s\n", cfg.Editor.Preferred)
 	fmt.Printf("  Auto-switch: %t\n", cfg.Editor.AutoSwitch)
 	fmt.Printf("  Window reuse: %t\n", cfg.Editor.WindowReuse)
+	if len(cfg.Editor.Order) > 0 {
+		fmt.Printf("  Order: %s\n", strings.Join(cfg.Editor.Order, ", "))
+	}
 	fmt.Println()
 
 	// Global settings
@@ -340,6 +577,39 @@ func displayConfigTable(cfg *config.Config) error {
 	fmt.Printf("  Output format: %s\n", cfg.Global.OutputFormat)
 	fmt.Printf("  Color output: %t\n", cfg.Global.ColorOutput)
 
+	if cfg.Worktree != (config.WorktreeConfig{}) {
+		fmt.Println()
+		fmt.Println("📁 Worktree Settings:")
+		if cfg.Worktree.BaseDir != "" {
+			fmt.Printf("  Base directory: %s\n", cfg.Worktree.BaseDir)
+		}
+		if cfg.Worktree.Sanitize.Separator != "" {
+			fmt.Printf("  Sanitize separator: %q\n", cfg.Worktree.Sanitize.Separator)
+		}
+		fmt.Printf("  Nested directories: %t\n", cfg.Worktree.Sanitize.Nested)
+	}
+
+	if cfg.List != (config.ListConfig{}) {
+		fmt.Println()
+		fmt.Println("📋 List Settings:")
+		fmt.Printf("  Exclude main repository: %t\n", cfg.List.ExcludeMain)
+	}
+
+	if len(cfg.Environments) > 0 {
+		names := make([]string, 0, len(cfg.Environments))
+		for name := range cfg.Environments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		fmt.Println()
+		fmt.Println("🌍 Environments:")
+		fmt.Printf("  Configured: %s\n", strings.Join(names, ", "))
+		if selected := os.Getenv(config.EnvironmentVariable); selected != "" {
+			fmt.Printf("  Selected: %s\n", selected)
+		}
+	}
+
 	return nil
 }
 
@@ -351,6 +621,9 @@ func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configEditCmd)
 	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configSchemaCmd)
 
 	// Flags for init command
 	configInitCmd.Flags().Bool("global", false, "Initialize global configuration")
@@ -361,10 +634,17 @@ func init() {
 	configShowCmd.Flags().StringP("format", "f", "table", "Output format (table, json, yaml)")
 	configShowCmd.Flags().Bool("paths", false, "Show configuration file paths")
 
+	// Flags for schema command
+	configSchemaCmd.Flags().String("format", "json", "Schema format (json, yaml)")
+	configSchemaCmd.Flags().Bool("full", false, "Describe the complete configuration instead of .hatcher-auto-copy.json")
+
 	// Flags for edit command
 	configEditCmd.Flags().Bool("global", false, "Edit global configuration")
 	configEditCmd.Flags().String("editor", "", "Editor to use (overrides $EDITOR)")
 
 	// Flags for validate command
 	configValidateCmd.Flags().Bool("fix", false, "Attempt to fix issues automatically")
+
+	// Flags for set command
+	configSetCmd.Flags().Bool("global", false, "Change global configuration")
 }
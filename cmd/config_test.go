@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidateFix(t *testing.T) {
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	defer configValidateCmd.Flags().Set("fix", "false")
+
+	writeConfig := func(t *testing.T, content string) string {
+		projectDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".hatcher"), 0755))
+		configPath := filepath.Join(projectDir, ".hatcher", "config.json")
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+		mockEnv.ChangeDir(projectDir)
+		return configPath
+	}
+
+	t.Run("fixes common issues", func(t *testing.T) {
+		configPath := writeConfig(t, `{
+			"autocopy": {"version": 7, "items": [{"path": "../outside"}, {"path": ".cursorrules"}]},
+			"global": {"outputFormat": "xml"}
+		}`)
+
+		require.Error(t, cliHelper.ExecuteCommand(rootCmd, "config", "validate"))
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "validate", "--fix"))
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		autoCopy := raw["autocopy"].(map[string]interface{})
+		assert.Equal(t, float64(2), autoCopy["version"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"path": ".cursorrules"}}, autoCopy["items"])
+		assert.Equal(t, "table", raw["global"].(map[string]interface{})["outputFormat"])
+	})
+
+	t.Run("reports errors that need manual intervention", func(t *testing.T) {
+		configPath := writeConfig(t, `{"editor": {"preferred": "notepad"}, "global": {"outputFormat": "xml"}}`)
+
+		err := cliHelper.ExecuteCommand(rootCmd, "config", "validate", "--fix")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed")
+
+		// The fixable part was still repaired
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		assert.Equal(t, "table", raw["global"].(map[string]interface{})["outputFormat"])
+		assert.Equal(t, "notepad", raw["editor"].(map[string]interface{})["preferred"])
+	})
+}
+
+func TestConfigGetSet(t *testing.T) {
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".hatcher"), 0755))
+	configPath := filepath.Join(projectDir, ".hatcher", "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+		"autocopy": {"version": 2, "items": [{"path": ".cursor/", "recursive": true}]}
+	}`), 0644))
+	mockEnv.ChangeDir(projectDir)
+
+	t.Run("sets nested item settings", func(t *testing.T) {
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "set", "autocopy.items.0.recursive", "false"))
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "get", "autocopy.items.0.recursive"))
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		item := raw["autocopy"].(map[string]interface{})["items"].([]interface{})[0].(map[string]interface{})
+		assert.Equal(t, false, item["recursive"])
+	})
+
+	t.Run("unknown key lists valid keys", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "config", "get", "editor.favourite")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "editor.preferred")
+
+		err = cliHelper.ExecuteCommand(rootCmd, "config", "set", "autocopy.items.3.recursive", "true")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "autocopy.items.<index>.recursive")
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "config", "set", "global.verbose", "loud")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "true or false")
+	})
+}
+
+func TestConfigInitFormat(t *testing.T) {
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	defer configInitCmd.Flags().Set("format", "json")
+	defer configShowCmd.Flags().Set("format", "table")
+
+	t.Run("yaml project config round-trips", func(t *testing.T) {
+		projectDir := t.TempDir()
+		mockEnv.ChangeDir(projectDir)
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "init", "--format", "yaml"))
+
+		assert.FileExists(t, filepath.Join(projectDir, ".hatcher-auto-copy.yaml"))
+		assert.NoFileExists(t, filepath.Join(projectDir, ".hatcher-auto-copy.json"))
+		data, err := os.ReadFile(filepath.Join(projectDir, ".hatcher-auto-copy.yaml"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "version: 2")
+
+		// Make the saved file distinguishable from the defaults
+		edited := strings.Replace(string(data), "items:", "ignoreTarget: exclude\nitems:", 1)
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".hatcher-auto-copy.yaml"), []byte(edited), 0644))
+
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "show", "--format", "json"))
+		})
+		var shown struct {
+			AutoCopy struct {
+				Version      int               `json:"version"`
+				IgnoreTarget string            `json:"ignoreTarget"`
+				Items        []json.RawMessage `json:"items"`
+			} `json:"autocopy"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &shown), stdout)
+		assert.Equal(t, 2, shown.AutoCopy.Version)
+		assert.Equal(t, "exclude", shown.AutoCopy.IgnoreTarget)
+		assert.NotEmpty(t, shown.AutoCopy.Items)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		mockEnv.ChangeDir(t.TempDir())
+		err := cliHelper.ExecuteCommand(rootCmd, "config", "init", "--format", "toml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported format")
+	})
+}
+
+func TestConfigSchema(t *testing.T) {
+	cliHelper := testutil.NewCLITestHelper(t)
+	defer configSchemaCmd.Flags().Set("format", "json")
+
+	t.Run("json", func(t *testing.T) {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "schema"))
+		})
+
+		var schema struct {
+			Defs map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"$defs"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(stdout), &schema), stdout)
+		assert.Contains(t, schema.Defs["AutoCopyConfig"].Properties, "items")
+		assert.JSONEq(t, `{"type": "integer", "minimum": 1, "maximum": 2}`, string(schema.Defs["AutoCopyConfig"].Properties["version"]))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "config", "schema", "--format", "yaml"))
+		})
+		assert.Contains(t, stdout, "$schema: https://json-schema.org/draft/2020-12/schema")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "config", "schema", "--format", "xml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported format")
+	})
+}
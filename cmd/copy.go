@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy <destination>",
+	Short: "Copy the configured auto-copy files into an existing directory",
+	Long: `Run auto-copy from the current repository into an existing directory,
+such as another checkout or a sibling worktree, without creating a worktree.
+
+The destination must exist and must not be inside the repository, which
+would make the copy recursive.
+
+Examples:
+  hch copy ../myapp-feature         # Copy configuration files into a checkout
+  hch copy --parallel ../other      # Copy with parallel workers
+  hch copy --sequential ../other    # Copy one file after another despite useParallel
+  hch copy --dry-run ../other       # Show what would be copied
+  hch copy --output json ../other   # Print a JSON copy report for scripts`,
+	Args: cobra.ExactArgs(1),
+	RunE: runCopy,
+}
+
+func init() {
+	rootCmd.AddCommand(copyCmd)
+
+	copyCmd.Flags().Bool("no-gitignore-update", false, "skip .gitignore update")
+	copyCmd.Flags().BoolVar(&copyParallel, "parallel", false, "copy files with parallel workers (faster for many files)")
+	copyCmd.Flags().BoolVar(&copySequential, "sequential", false, "copy files one after another (default unless autoCopy.useParallel is set)")
+	copyCmd.Flags().String("output", outputText, "summary format: text, or json for a copy report on stdout (other output goes to stderr)")
+
+	copyCmd.MarkFlagsMutuallyExclusive("parallel", "sequential")
+}
+
+func runCopy(cmd *cobra.Command, args []string) error {
+	skipIgnoreUpdate, _ := cmd.Flags().GetBool("no-gitignore-update")
+	output, _ := cmd.Flags().GetString("output")
+	if err := validateOutputFormat(output); err != nil {
+		return fmt.Errorf("❌ Invalid --output: %w", err)
+	}
+
+	// The JSON report is the only thing written to stdout
+	var jsonOut *jsonOutput
+	if output == outputJSON {
+		jsonOut = startJSONOutput()
+		defer jsonOut.Close()
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+	srcRoot, err := repo.GetRoot()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to get repository root: %w", err)
+	}
+
+	destDir, err := resolveCopyDestination(srcRoot, args[0])
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(srcRoot)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	if autoCopyConfig.Version == 0 && len(autoCopyConfig.Items) == 0 && len(autoCopyConfig.Files) == 0 {
+		fmt.Println("ℹ️  No auto-copy configuration found, nothing to copy")
+		if jsonOut != nil {
+			return jsonOut.Write(autocopy.NewCopyReport())
+		}
+		return nil
+	}
+
+	mode := resolveCopyMode(hatcherConfig.AutoCopy.UseParallel)
+	copyOptions := copyOptionsFromConfig(hatcherConfig)
+	copyOptions.UseParallel = mode == copyModeParallel
+	copyOptions.DryRun = dryRun
+	copyOptions.NoGitignoreUpdate = true // Updated below
+
+	if dryRun {
+		fmt.Printf("🔍 Dry run mode - files that would be copied to %s:\n", destDir)
+	}
+	report, err := autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions).Report(srcRoot, destDir)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to copy files: %w", err)
+	}
+	copiedFiles := report.CopiedFiles
+
+	if dryRun {
+		if !skipIgnoreUpdate && len(copiedFiles) > 0 {
+			fmt.Println("  - Update .gitignore")
+		}
+	} else if len(copiedFiles) == 0 {
+		fmt.Println("ℹ️  No files matched auto-copy configuration")
+	} else {
+		if jsonOut == nil {
+			fmt.Printf("📋 Auto-copied %d files/directories:\n", len(copiedFiles))
+			for _, file := range copiedFiles {
+				fmt.Printf("  ✅ %s\n", file)
+			}
+		}
+
+		if !skipIgnoreUpdate {
+			ignoreName := ".gitignore"
+			if autoCopyConfig.IgnoreTarget == autocopy.IgnoreTargetExclude {
+				ignoreName = "info/exclude"
+			}
+			if err := autocopy.UpdateIgnoreFile(destDir, autoCopyConfig.IgnoreTarget, copiedFiles); err != nil {
+				fmt.Printf("⚠️  Failed to update %s: %v\n", ignoreName, err)
+			} else {
+				report.GitignoreUpdated = true
+				if jsonOut == nil {
+					fmt.Printf("  ✅ Updated %s with %d entries\n", ignoreName, len(copiedFiles))
+				}
+			}
+		}
+
+		if jsonOut == nil {
+			elapsed := time.Duration(report.DurationMs) * time.Millisecond
+			fmt.Printf("  ⏱️  Copied in %s (%s)\n", elapsed, mode)
+		}
+	}
+
+	if jsonOut != nil {
+		return jsonOut.Write(report)
+	}
+	return nil
+}
+
+// resolveCopyDestination returns the absolute path of dest, which must be an
+// existing directory outside srcRoot so the copy cannot recurse into itself
+func resolveCopyDestination(srcRoot, dest string) (string, error) {
+	destDir, err := filepath.Abs(dest)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination %s: %w", dest, err)
+	}
+
+	info, err := os.Stat(destDir)
+	if err != nil {
+		return "", fmt.Errorf("destination not found: %s", destDir)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("destination is not a directory: %s", destDir)
+	}
+
+	// Compare resolved paths so symlinked destinations are caught too
+	realSrc, err := filepath.EvalSymlinks(srcRoot)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", srcRoot, err)
+	}
+	realDest, err := filepath.EvalSymlinks(destDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", destDir, err)
+	}
+	if rel, err := filepath.Rel(realSrc, realDest); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("destination %s is inside the repository %s; copying would recurse into itself", destDir, srcRoot)
+	}
+
+	return destDir, nil
+}
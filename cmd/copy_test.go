@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/logger"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "copy-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+		{"path": ".cursorrules", "directory": false}
+	]}}`)
+	testRepo.CreateFile(".cursorrules", "# Cursor rules")
+
+	defer func() { dryRun = false }()
+
+	t.Run("copies into an existing directory", func(t *testing.T) {
+		dest := t.TempDir()
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "copy", dest))
+
+		content, err := os.ReadFile(filepath.Join(dest, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Cursor rules", string(content))
+
+		ignore, err := os.ReadFile(filepath.Join(dest, ".gitignore"))
+		require.NoError(t, err)
+		assert.Contains(t, string(ignore), ".cursorrules")
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "copy", filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "destination not found")
+	})
+
+	t.Run("destination inside the repository", func(t *testing.T) {
+		for _, dest := range []string{".", filepath.Join(testRepo.RepoDir, ".hatcher")} {
+			err := cliHelper.ExecuteCommand(rootCmd, "copy", dest)
+			require.Error(t, err, dest)
+			assert.Contains(t, err.Error(), "inside the repository")
+		}
+	})
+
+	t.Run("json report", func(t *testing.T) {
+		defer copyCmd.Flags().Set("output", outputText)
+		dest := t.TempDir()
+
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "copy", "--output", "json", dest))
+		})
+
+		var report autocopy.CopyReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report), stdout)
+		assert.Equal(t, []string{".cursorrules"}, report.CopiedFiles)
+		assert.Empty(t, report.Skipped)
+		assert.Equal(t, int64(len("# Cursor rules")), report.BytesCopied)
+		assert.True(t, report.GitignoreUpdated)
+		assert.NotContains(t, stdout, "✅")
+	})
+
+	t.Run("unknown output format", func(t *testing.T) {
+		defer copyCmd.Flags().Set("output", outputText)
+		err := cliHelper.ExecuteCommand(rootCmd, "copy", "--output", "yaml", t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown output format")
+	})
+
+	t.Run("trace copy", func(t *testing.T) {
+		defer func() { traceCopy = false }()
+		defer logger.GetLogger().SetVerbose(false)
+		dest := t.TempDir()
+
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "copy", "--trace-copy", dest))
+		})
+		assert.Contains(t, stdout, "copy .cursorrules: copied (new file)")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		dest := t.TempDir()
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "copy", "--dry-run", dest))
+
+		assert.NoFileExists(t, filepath.Join(dest, ".cursorrules"))
+		assert.NoFileExists(t, filepath.Join(dest, ".gitignore"))
+	})
+}
+
+func TestCopyCommandCopyMode(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "copy-mode-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "useParallel": true, "items": [
+		{"path": ".cursorrules", "directory": false}
+	]}}`)
+	testRepo.CreateFile(".cursorrules", "# Cursor rules")
+
+	defer func() {
+		copyParallel, copySequential = false, false
+		copyCmd.Flags().Lookup("parallel").Changed = false
+		copyCmd.Flags().Lookup("sequential").Changed = false
+	}()
+
+	t.Run("configured useParallel", func(t *testing.T) {
+		dest := t.TempDir()
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "copy", dest))
+		})
+		assert.Contains(t, stdout, "(parallel)")
+		assert.FileExists(t, filepath.Join(dest, ".cursorrules"))
+	})
+
+	t.Run("sequential flag overrides config", func(t *testing.T) {
+		dest := t.TempDir()
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "copy", "--sequential", dest))
+		})
+		assert.Contains(t, stdout, "(sequential)")
+		assert.FileExists(t, filepath.Join(dest, ".cursorrules"))
+	})
+
+	t.Run("flags are mutually exclusive", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "copy", "--parallel", "--sequential", t.TempDir())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parallel")
+	})
+}
+
+func TestResolveCopyDestination(t *testing.T) {
+	srcRoot := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(srcRoot, "nested"), 0755))
+	sibling := t.TempDir()
+
+	t.Run("sibling directory", func(t *testing.T) {
+		dest, err := resolveCopyDestination(srcRoot, sibling)
+		require.NoError(t, err)
+		assert.Equal(t, sibling, dest)
+	})
+
+	t.Run("directory named like the source", func(t *testing.T) {
+		prefixed := srcRoot + "-feature"
+		require.NoError(t, os.MkdirAll(prefixed, 0755))
+		t.Cleanup(func() { os.RemoveAll(prefixed) })
+
+		_, err := resolveCopyDestination(srcRoot, prefixed)
+		assert.NoError(t, err)
+	})
+
+	t.Run("symlink into the source", func(t *testing.T) {
+		link := filepath.Join(sibling, "link")
+		require.NoError(t, os.Symlink(filepath.Join(srcRoot, "nested"), link))
+
+		_, err := resolveCopyDestination(srcRoot, link)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "inside the repository")
+	})
+
+	t.Run("file destination", func(t *testing.T) {
+		file := filepath.Join(sibling, "file.txt")
+		require.NoError(t, os.WriteFile(file, nil, 0644))
+
+		_, err := resolveCopyDestination(srcRoot, file)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a directory")
+	})
+}
+
+func TestExportCopyCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "export-copy-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+	defer exportCopyCmd.Flags().Set("output", "config.tar.gz")
+	defer restoreCmd.Flags().Set("force", "false")
+
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+		{"path": ".cursorrules", "directory": false}
+	]}}`)
+	testRepo.CreateFile(".cursorrules", "# Cursor rules")
+
+	t.Run("bundles the copy set of a worktree", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		currentBranch := testRepo.GetCurrentBranch()
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "export-copy", currentBranch, "-o", output))
+
+		assert.NoFileExists(t, output+".tmp")
+
+		// Restore into another checkout
+		dest := t.TempDir()
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "restore", dest, output))
+		content, err := os.ReadFile(filepath.Join(dest, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Cursor rules", string(content))
+		ignore, err := os.ReadFile(filepath.Join(dest, ".gitignore"))
+		require.NoError(t, err)
+		assert.Contains(t, string(ignore), ".cursorrules")
+
+		// Modified files are only overwritten with --force
+		require.NoError(t, os.WriteFile(filepath.Join(dest, ".cursorrules"), []byte("# Local rules"), 0644))
+		err = cliHelper.ExecuteCommand(rootCmd, "restore", dest, output)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 modified files")
+		content, err = os.ReadFile(filepath.Join(dest, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Local rules", string(content))
+
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "restore", "--force", dest, output))
+		content, err = os.ReadFile(filepath.Join(dest, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Cursor rules", string(content))
+	})
+
+	t.Run("unknown branch", func(t *testing.T) {
+		output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+		err := cliHelper.ExecuteCommand(rootCmd, "export-copy", "feature/missing", "-o", output)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		assert.NoFileExists(t, output)
+	})
+}
@@ -1,7 +1,13 @@
 package cmd
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/keisukeshimizu/hatcher/internal/autocopy"
 	"github.com/keisukeshimizu/hatcher/internal/config"
@@ -16,11 +22,47 @@ var (
 	noGitignoreUpdate bool
 	force             bool
 	editor            string
+	createYes         bool
+	maxConfirmFiles   int
+	maxTotalFiles     int
+	maxTotalBytes     int64
+	copyGitignored    bool
+	stripPrefix       string
+	addPrefix         string
+	copyParallel      bool
+	copySequential    bool
+	copyBackup        bool
+	createFromFile    string
+	createJobs        int
+	copyManifestPath  string
+	copyIntegrity     string
+	copyOnlyNew       bool
+	createBase        string
+	forceBranch       bool
+	createOutput      string
+	trackRemote       bool
+	deferVerifyMin    int64
+	copyMaxRetries    int
+	copyRetryBackoff  time.Duration
 )
 
+// Copy modes selected with --parallel and --sequential
+const (
+	copyModeSequential = "sequential"
+	copyModeParallel   = "parallel"
+)
+
+// parallelHintFiles is the number of files from which sequential copies
+// suggest --parallel
+const parallelHintFiles = 500
+
+// parallelHintFile marks, inside the main git directory, that the --parallel
+// hint has been shown
+const parallelHintFile = "hatcher-parallel-hint"
+
 // createCmd represents the create command
 var createCmd = &cobra.Command{
-	Use:   "create <branch-name>",
+	Use:   "create <branch-name> | --from-file <file>",
 	Short: "Create a new worktree for the specified branch",
 	Long: `Create a new Git worktree with automatic directory naming and file copying.
 
@@ -31,8 +73,22 @@ Examples:
   hatcher create feature/user-auth    # Creates: ../myapp-feature-user-auth
   hatcher feature/user-auth           # Same as above (default command)
   hatcher create --no-copy main       # Skip auto file copying
-  hatcher create --force test         # Overwrite existing directory`,
-	Args: cobra.ExactArgs(1),
+  hatcher create --force test         # Overwrite existing directory
+  hatcher create --yes big-feature    # Copy without confirming large copies
+  hatcher create --strip-prefix config/ai/ feat  # Copy config/ai/* into the worktree root
+  hatcher create --parallel big-feature # Copy files with parallel workers
+  hatcher create --integrity safe feat # Atomic, verified copies with backups
+  hatcher create --copy-only-new feat # Keep files the branch already has
+  hatcher create --from main hotfix   # Start a new branch from main
+  hatcher create --force-branch --from main hotfix # Reset an existing branch to main
+  hatcher create --from-file prs.txt  # Create a worktree per branch listed in prs.txt
+  hatcher create --output json feat   # Print a JSON copy report for scripts`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if createFromFile != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
 	RunE: runCreate,
 }
 
@@ -42,13 +98,62 @@ func init() {
 	// Flags for create command
 	createCmd.Flags().BoolVar(&noCopy, "no-copy", false, "skip automatic file copying")
 	createCmd.Flags().BoolVar(&noGitignoreUpdate, "no-gitignore-update", false, "skip .gitignore update")
-	createCmd.Flags().BoolVar(&force, "force", false, "force overwrite existing directory")
+	createCmd.Flags().BoolVar(&force, "force", false, "force overwrite existing directory and, with preserveSymlinks, copied files or links of the other type")
 	createCmd.Flags().StringVar(&editor, "editor", "", "open in specified editor after creation (cursor, code)")
+	createCmd.Flags().BoolVarP(&createYes, "yes", "y", false, "skip confirmations for large copies and --force-branch resets")
+	createCmd.Flags().IntVar(&maxConfirmFiles, "max-confirm-files", 0, "ask for confirmation when copying more files than this (default from config, or 1000)")
+	createCmd.Flags().IntVar(&maxTotalFiles, "max-total-files", 0, "abort copying when more files than this match (default from config, or 10000; negative disables)")
+	createCmd.Flags().Int64Var(&maxTotalBytes, "max-bytes", 0, "abort copying when the matched files add up to more bytes than this (default from config, or unlimited)")
+	createCmd.Flags().BoolVar(&copyGitignored, "copy-gitignored", true, "copy gitignored files inside copied directories (default from config)")
+	createCmd.Flags().StringVar(&stripPrefix, "strip-prefix", "", "remove this leading directory from copied paths (e.g. config/ai/)")
+	createCmd.Flags().StringVar(&addPrefix, "add-prefix", "", "place copied paths below this directory in the worktree")
+	createCmd.Flags().BoolVar(&copyParallel, "parallel", false, "copy files with parallel workers (faster for many files)")
+	createCmd.Flags().BoolVar(&copySequential, "sequential", false, "copy files one after another (default unless autoCopy.useParallel is set)")
+	createCmd.Flags().BoolVar(&copyOnlyNew, "copy-only-new", false, "only copy files missing from the worktree, keeping committed versions")
+	createCmd.Flags().BoolVar(&copyBackup, "backup", false, "keep files overwritten with different content as <name>"+autocopy.BackupSuffix)
+	createCmd.Flags().StringVar(&createFromFile, "from-file", "", "create worktrees for the branches listed in this file (one per line, # comments)")
+	createCmd.Flags().IntVar(&createJobs, "jobs", 4, "with --from-file, how many worktrees to create at the same time")
+	createCmd.Flags().StringVar(&copyIntegrity, "integrity", "", "copy option preset: fast (parallel, no verification), safe (atomic writes, sha256 verification, backups) or mirror (safe plus source timestamps)")
+	createCmd.Flags().Int64Var(&deferVerifyMin, "defer-verify-min-size", 0, "with verified parallel copies, verify files of at least this many bytes in a separate phase (default from config, or inline)")
+	createCmd.Flags().IntVar(&copyMaxRetries, "max-retries", 0, "retry parallel copies failing with a transient error this many times (default from config, or 0)")
+	createCmd.Flags().DurationVar(&copyRetryBackoff, "retry-backoff", 0, "delay before the first retry, doubled for each further one (default from config, or 100ms)")
+	createCmd.Flags().StringVar(&copyManifestPath, "copy-manifest-path", "", "keep the copy manifest at this path in the worktree (default from config, or the worktree's git directory)")
+	createCmd.Flags().StringVar(&createBase, "from", "", "start the branch from this ref instead of HEAD")
+	createCmd.Flags().BoolVar(&forceBranch, "force-branch", false, "with --from, reset an existing branch to that ref (asks for confirmation unless --yes)")
+	createCmd.Flags().BoolVar(&trackRemote, "track-remote", false, "for a branch that only exists on origin, fetch and create a local branch tracking origin/<branch>")
+	createCmd.Flags().StringVar(&createOutput, "output", outputText, "summary format: text, or json for a copy report on stdout (other output goes to stderr)")
+	createCmd.MarkFlagsMutuallyExclusive("parallel", "sequential")
+	createCmd.MarkFlagsMutuallyExclusive("from-file", "from")
+	createCmd.MarkFlagsMutuallyExclusive("from-file", "force-branch")
+	createCmd.MarkFlagsMutuallyExclusive("from-file", "output")
 }
 
 func runCreate(cmd *cobra.Command, args []string) error {
+	if err := autocopy.ValidateManifestPath(copyManifestPath); err != nil {
+		return fmt.Errorf("❌ Invalid --copy-manifest-path: %w", err)
+	}
+	if err := autocopy.ApplyIntegrityPreset(copyIntegrity, &autocopy.AutoCopierOptions{}, nil); err != nil {
+		return fmt.Errorf("❌ Invalid --integrity: %w", err)
+	}
+	if forceBranch && createBase == "" {
+		return fmt.Errorf("❌ --force-branch requires --from <ref>")
+	}
+	if err := validateOutputFormat(createOutput); err != nil {
+		return fmt.Errorf("❌ Invalid --output: %w", err)
+	}
+	if createFromFile != "" {
+		return runCreateFromFile(cmd, createFromFile)
+	}
 	branchName := args[0]
 
+	// The JSON report is the only thing written to stdout
+	var jsonOut *jsonOutput
+	if createOutput == outputJSON {
+		jsonOut = startJSONOutput()
+		defer jsonOut.Close()
+	}
+	report := autocopy.NewCopyReport()
+
 	// Update logger verbose setting
 	logger.UpdateVerbose()
 	log := logger.GetLogger()
@@ -71,7 +176,9 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	log.Debug("Git repository initialized successfully")
 
 	// Create worktree creator
+	layout := loadWorktreeLayout(repo)
 	creator := worktree.NewCreator(repo)
+	creator.SetLayout(layout)
 
 	// Prepare creation options
 	opts := worktree.CreateOptions{
@@ -80,9 +187,17 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		NoCopy:            noCopy,
 		NoGitignoreUpdate: noGitignoreUpdate,
 		DryRun:            dryRun,
+		BaseRef:           createBase,
+		ForceBranch:       forceBranch,
+		TrackRemote:       trackRemote,
+	}
+
+	if forceBranch && !dryRun && !createYes && !confirmBranchReset(repo, branchName, createBase) {
+		fmt.Println("❌ Branch reset cancelled")
+		return nil
 	}
 
-	fmt.Printf("📁 Target directory: %s\n", worktree.GenerateWorktreePath(
+	fmt.Printf("📁 Target directory: %s\n", layout.WorktreePath(
 		func() string { root, _ := repo.GetRoot(); return root }(),
 		repo.GetProjectName(),
 		branchName,
@@ -91,28 +206,49 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Create the worktree
 	result, err := creator.Create(opts)
 	if err != nil {
+		if errors.Is(err, git.ErrBranchCheckedOutElsewhere) && useExistingWorktree(repo, branchName) {
+			return nil
+		}
 		return fmt.Errorf("❌ Failed to create worktree: %w", err)
 	}
 
 	if dryRun {
 		fmt.Println("🔍 Dry run mode - showing what would be done:")
 		fmt.Printf("  - %s\n", result.Message)
-		if result.IsNewBranch {
+		if result.PreviousTip != "" {
+			fmt.Printf("  - Reset branch %s from %s to %s\n", result.BranchName, shortCommit(result.PreviousTip), createBase)
+		} else if result.TracksRemote {
+			fmt.Printf("  - Create branch %s tracking origin/%s\n", result.BranchName, result.BranchName)
+		} else if result.IsNewBranch {
 			fmt.Printf("  - Create new branch: %s\n", result.BranchName)
 		} else {
 			fmt.Printf("  - Use existing branch: %s\n", result.BranchName)
 		}
 		if !noCopy {
 			fmt.Println("  - Copy configuration files")
+			root, _ := repo.GetRoot()
+			if preview, err := previewAutoCopy(cmd, repo, root, result.WorktreePath); err != nil {
+				fmt.Printf("⚠️  Failed to preview auto-copy: %v\n", err)
+			} else {
+				report = preview
+			}
 		}
 		if !noGitignoreUpdate {
 			fmt.Println("  - Update .gitignore")
 		}
+		if jsonOut != nil {
+			return jsonOut.Write(report)
+		}
 		return nil
 	}
 
 	// Show creation result
-	if result.IsNewBranch {
+	if result.PreviousTip != "" {
+		fmt.Printf("♻️  Reset branch %s to %s (previous tip %s)\n", result.BranchName, createBase, shortCommit(result.PreviousTip))
+		fmt.Printf("💡 To recover the previous tip: git branch %s-previous %s\n", result.BranchName, result.PreviousTip)
+	} else if result.TracksRemote {
+		fmt.Printf("🔗 Created branch %s tracking origin/%s\n", result.BranchName, result.BranchName)
+	} else if result.IsNewBranch {
 		fmt.Printf("🆕 Created new branch: %s\n", result.BranchName)
 	} else {
 		fmt.Printf("🔍 Using existing branch: %s\n", result.BranchName)
@@ -122,8 +258,15 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Auto-copy files if enabled
 	if !noCopy {
 		root, _ := repo.GetRoot()
-		if err := autoCopyFiles(root, result.WorktreePath); err != nil {
+		copyReport, err := autoCopyFiles(cmd, repo, root, result.WorktreePath)
+		if err != nil {
+			// Safety aborts are reported through the exit code
+			if isSafetyAbort(err) || errors.Is(err, autocopy.ErrCaseCollision) {
+				return fmt.Errorf("❌ Auto-copy aborted: %w", err)
+			}
 			fmt.Printf("⚠️  Auto-copy failed: %v\n", err)
+		} else {
+			report = copyReport
 		}
 	}
 
@@ -137,27 +280,285 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	// Change to the new directory (print for shell evaluation)
 	fmt.Printf("📂 cd %s\n", result.WorktreePath)
 
+	if jsonOut != nil {
+		return jsonOut.Write(report)
+	}
 	return nil
 }
 
-// autoCopyFiles copies configuration files to the new worktree
-func autoCopyFiles(srcRoot, worktreePath string) error {
+// useExistingWorktree offers to switch to the worktree branchName is already
+// checked out in and reports whether the user accepted
+func useExistingWorktree(repo git.Repository, branchName string) bool {
+	existing, err := repo.GetWorktreePath(branchName)
+	if err != nil {
+		return false
+	}
+
+	if !confirm(fmt.Sprintf("💡 '%s' is already checked out at %s. Move there instead?", branchName, existing)) {
+		return false
+	}
+
+	if editor != "" {
+		if err := openInEditor(existing, editor); err != nil {
+			fmt.Printf("⚠️  Failed to open in editor: %v\n", err)
+		}
+	}
+	fmt.Printf("📂 cd %s\n", existing)
+	return true
+}
+
+// confirmBranchReset asks before --force-branch moves an existing branch to
+// base, discarding its current tip. Branches that do not exist yet need no
+// confirmation.
+func confirmBranchReset(repo git.Repository, branchName, base string) bool {
+	exists, err := repo.BranchExists(branchName)
+	if err != nil || !exists {
+		return true
+	}
+	tip, err := repo.ResolveCommit(branchName)
+	if err != nil {
+		// Let the creator report the failure
+		return true
+	}
+
+	return confirm(fmt.Sprintf("⚠️  This resets '%s' to %s, discarding its current tip %s. Continue?", branchName, base, shortCommit(tip)))
+}
+
+// shortCommit abbreviates a commit hash for display
+func shortCommit(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// previewAutoCopy prints the files auto-copy would write into the worktree
+// at worktreePath, which does not exist yet, and reports them as copied
+func previewAutoCopy(cmd *cobra.Command, repo git.Repository, srcRoot, worktreePath string) (*autocopy.CopyReport, error) {
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(srcRoot)
+	if err != nil {
+		return nil, err
+	}
+	if autoCopyConfig.Version == 0 && len(autoCopyConfig.Items) == 0 && len(autoCopyConfig.Files) == 0 {
+		return autocopy.NewCopyReport(), nil
+	}
+
+	copyOptions := createCopyOptions(cmd, hatcherConfig)
+	copyOptions.DryRun = true
+	return autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions).Report(srcRoot, worktreePath)
+}
+
+// autoCopyFiles copies configuration files to the new worktree and reports
+// what was copied
+func autoCopyFiles(cmd *cobra.Command, repo git.Repository, srcRoot, worktreePath string) (*autocopy.CopyReport, error) {
 	if verbose {
 		fmt.Println("📋 Auto-copying configuration files...")
 	}
 
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(srcRoot)
+	if err != nil {
+		return nil, err
+	}
+	return copyIntoWorktree(cmd, repo, hatcherConfig, autoCopyConfig, srcRoot, worktreePath, false)
+}
+
+// copyIntoWorktree runs the configured auto-copy from srcRoot into the new
+// worktree at worktreePath, updates its ignore file and records the copy
+// manifest. Batch copies run concurrently, so instead of asking and printing
+// they need --yes for large copies and return every problem as an error.
+func copyIntoWorktree(cmd *cobra.Command, repo git.Repository, hatcherConfig *config.Config, autoCopyConfig *autocopy.AutoCopyConfig, srcRoot, worktreePath string, batch bool) (*autocopy.CopyReport, error) {
+	// Skip if no configuration found
+	if autoCopyConfig.Version == 0 && len(autoCopyConfig.Items) == 0 && len(autoCopyConfig.Files) == 0 {
+		if verbose && !batch {
+			fmt.Println("ℹ️  No auto-copy configuration found, skipping file copying")
+		}
+		return autocopy.NewCopyReport(), nil
+	}
+
+	// Preflight: estimate the copy and confirm unusually large ones
+	copyOptions := createCopyOptions(cmd, hatcherConfig)
+	if copyOnlyNew {
+		if err := skipExistingFiles(repo, autoCopyConfig, &copyOptions, srcRoot, worktreePath); err != nil {
+			return nil, err
+		}
+	}
+	manifestPath := customManifestPath(copyManifestPath, hatcherConfig)
+	estimate, err := autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions).Estimate(srcRoot, worktreePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate copy: %w", err)
+	}
+	if estimate.Files > 0 && !batch {
+		fmt.Printf("📊 About to copy %s\n", estimate)
+	}
+
+	threshold := resolveMaxConfirmFiles(hatcherConfig.AutoCopy.MaxConfirmFiles)
+	if estimate.Files > threshold && !createYes {
+		if batch {
+			return nil, fmt.Errorf("copying %d files exceeds the confirmation threshold of %d (use --yes)", estimate.Files, threshold)
+		}
+		if !confirm(fmt.Sprintf("⚠️  This exceeds the confirmation threshold of %d files. Copy anyway?", threshold)) {
+			fmt.Println("⏭️  Skipped auto-copy")
+			return autocopy.NewCopyReport(), nil
+		}
+	}
+
+	mode := resolveCopyMode(hatcherConfig.AutoCopy.UseParallel)
+	if mode == copyModeSequential && estimate.Files >= parallelHintFiles && !batch {
+		showParallelHint(repo)
+	}
+
+	// Create auto-copier and copy files
+	copyOptions.UseParallel = mode == copyModeParallel
+	copyOptions.NoGitignoreUpdate = true // Updated below
+	report, err := autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions).Report(srcRoot, worktreePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy files: %w", err)
+	}
+	copiedFiles := report.CopiedFiles
+	textOutput := createOutput != outputJSON && !batch
+
+	if len(copiedFiles) > 0 {
+		if textOutput {
+			fmt.Printf("📋 Auto-copied %d files/directories:\n", len(copiedFiles))
+			for _, file := range copiedFiles {
+				fmt.Printf("  ✅ %s\n", file)
+			}
+		}
+
+		// Update ignore file if not disabled
+		if !noGitignoreUpdate {
+			ignoreName := ".gitignore"
+			if autoCopyConfig.IgnoreTarget == autocopy.IgnoreTargetExclude {
+				ignoreName = "info/exclude"
+			}
+			entries := ignoreEntries(copiedFiles, manifestPath)
+			if err := autocopy.UpdateIgnoreFile(worktreePath, autoCopyConfig.IgnoreTarget, entries); err != nil {
+				if batch {
+					return nil, fmt.Errorf("failed to update %s: %w", ignoreName, err)
+				}
+				fmt.Printf("⚠️  Failed to update %s: %v\n", ignoreName, err)
+			} else {
+				report.GitignoreUpdated = true
+				if textOutput {
+					fmt.Printf("  ✅ Updated %s with %d entries\n", ignoreName, len(entries))
+				}
+			}
+		}
+
+		if textOutput {
+			elapsed := time.Duration(report.DurationMs) * time.Millisecond
+			fmt.Printf("  ⏱️  Copied in %s (%s)\n", elapsed, mode)
+			if report.VerifyDurationMs > 0 {
+				fmt.Printf("  🔍 Verified large files in %s\n", time.Duration(report.VerifyDurationMs)*time.Millisecond)
+			}
+		}
+
+		// Record what was copied so `hch sync --changed-only` can skip it
+		if err := recordManifest(repo, autoCopyConfig, copyOptions, srcRoot, worktreePath, manifestPath); err != nil {
+			if batch {
+				return nil, fmt.Errorf("failed to record copy manifest: %w", err)
+			}
+			fmt.Printf("⚠️  Failed to record copy manifest: %v\n", err)
+		}
+	} else {
+		if verbose && !batch {
+			fmt.Println("ℹ️  No files matched auto-copy configuration")
+		}
+	}
+
+	return report, nil
+}
+
+// createCopyOptions returns the configured copy options with the create
+// flags applied
+func createCopyOptions(cmd *cobra.Command, hatcherConfig *config.Config) autocopy.AutoCopierOptions {
+	copyOptions := copyOptionsFromConfig(hatcherConfig)
+	// The preset was validated by runCreate; explicit flags refine it
+	autocopy.ApplyIntegrityPreset(copyIntegrity, &copyOptions, nil)
+	copyOptions.StripPrefix = stripPrefix
+	copyOptions.AddPrefix = addPrefix
+	copyOptions.Backup = copyOptions.Backup || copyBackup
+	copyOptions.ForceRelink = force
+	if maxTotalFiles != 0 {
+		copyOptions.MaxTotalFiles = maxTotalFiles
+	}
+	if maxTotalBytes != 0 {
+		copyOptions.MaxTotalBytes = maxTotalBytes
+	}
+	if cmd.Flags().Changed("copy-gitignored") {
+		copyOptions.RespectGitignore = !copyGitignored
+	}
+	applyRetryFlags(cmd, &copyOptions, deferVerifyMin, copyMaxRetries, copyRetryBackoff)
+	copyOptions.UseParallel = resolveCopyMode(hatcherConfig.AutoCopy.UseParallel) == copyModeParallel
+	if manifestPath := customManifestPath(copyManifestPath, hatcherConfig); manifestPath != "" {
+		copyOptions.SkipPaths = []string{manifestPath}
+	}
+	return copyOptions
+}
+
+// skipExistingFiles adds the files auto-copy would write that already exist
+// in the worktree, e.g. because the branch commits them, to the skip paths
+func skipExistingFiles(repo git.Repository, autoCopyConfig *autocopy.AutoCopyConfig, copyOptions *autocopy.AutoCopierOptions, srcRoot, worktreePath string) error {
+	existing, err := autocopy.NewAutoCopier(repo, autoCopyConfig, *copyOptions).ExistingDestinations(srcRoot, worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to check existing files: %w", err)
+	}
+
+	if verbose {
+		for _, file := range existing {
+			fmt.Printf("⏭️  Keeping existing %s\n", file)
+		}
+	}
+	copyOptions.SkipPaths = append(copyOptions.SkipPaths, existing...)
+	return nil
+}
+
+// resolveCopyMode returns the copy mode selected by --parallel,
+// --sequential or --integrity fast, falling back to the configured
+// useParallel setting
+func resolveCopyMode(configured bool) string {
+	if copySequential {
+		return copyModeSequential
+	}
+	if copyParallel || copyIntegrity == autocopy.IntegrityFast || configured {
+		return copyModeParallel
+	}
+	return copyModeSequential
+}
+
+// showParallelHint suggests --parallel for a large sequential copy, once per
+// git directory
+func showParallelHint(repo git.Repository) {
+	gitDir, err := repo.GitDir("")
+	if err != nil {
+		return
+	}
+
+	marker := filepath.Join(gitDir, parallelHintFile)
+	if _, err := os.Stat(marker); err == nil {
+		return
+	}
+
+	fmt.Println("💡 Tip: --parallel may be faster when copying many files")
+	os.WriteFile(marker, nil, 0644)
+}
+
+// loadAutoCopyConfig loads the hatcher configuration for srcRoot and converts
+// its auto-copy section for the copier
+func loadAutoCopyConfig(srcRoot string) (*config.Config, *autocopy.AutoCopyConfig, error) {
 	// Use the new config manager to load configuration
 	manager := config.NewManager()
 	hatcherConfig, err := manager.LoadConfig(srcRoot)
 	if err != nil {
-		return fmt.Errorf("failed to load hatcher configuration: %w", err)
+		return nil, nil, fmt.Errorf("failed to load hatcher configuration: %w", err)
 	}
 
 	// Convert hatcher config to autocopy config
 	autoCopyConfig := &autocopy.AutoCopyConfig{
-		Version: hatcherConfig.AutoCopy.Version,
-		Items:   make([]autocopy.AutoCopyItem, len(hatcherConfig.AutoCopy.Items)),
-		Files:   hatcherConfig.AutoCopy.Files,
+		Version:      hatcherConfig.AutoCopy.Version,
+		Items:        make([]autocopy.AutoCopyItem, len(hatcherConfig.AutoCopy.Items)),
+		Files:        hatcherConfig.AutoCopy.Files,
+		IgnoreTarget: hatcherConfig.AutoCopy.IgnoreTarget,
 	}
 
 	// Convert items
@@ -169,6 +570,8 @@ func autoCopyFiles(srcRoot, worktreePath string) error {
 			AutoDetect: item.AutoDetect,
 			Exclude:    item.Exclude,
 			Include:    item.Include,
+			Priority:   item.Priority,
+			OnConflict: item.OnConflict,
 		}
 
 		// Only set Directory if AutoDetect is false
@@ -181,45 +584,80 @@ func autoCopyFiles(srcRoot, worktreePath string) error {
 
 	// Validate configuration
 	if err := autocopy.ValidateAutoCopyConfig(autoCopyConfig); err != nil {
-		return fmt.Errorf("invalid auto-copy configuration: %w", err)
+		return nil, nil, fmt.Errorf("invalid auto-copy configuration: %w", err)
 	}
 
-	// Skip if no configuration found
-	if autoCopyConfig.Version == 0 && len(autoCopyConfig.Items) == 0 && len(autoCopyConfig.Files) == 0 {
-		if verbose {
-			fmt.Println("ℹ️  No auto-copy configuration found, skipping file copying")
-		}
-		return nil
+	return hatcherConfig, autoCopyConfig, nil
+}
+
+// copyOptionsFromConfig returns the copy options set in the configuration
+func copyOptionsFromConfig(hatcherConfig *config.Config) autocopy.AutoCopierOptions {
+	options := autocopy.AutoCopierOptions{
+		MaxTotalFiles:       hatcherConfig.AutoCopy.MaxTotalFiles,
+		MaxTotalBytes:       hatcherConfig.AutoCopy.MaxTotalBytes,
+		RespectGitignore:    hatcherConfig.AutoCopy.RespectGitignore,
+		RespectExportIgnore: hatcherConfig.AutoCopy.RespectExportIgnore,
+		AddProvenanceHeader: hatcherConfig.AutoCopy.AddProvenanceHeader,
+		PreserveXattrs:      hatcherConfig.AutoCopy.PreserveXattrs,
+		PreserveSymlinks:    hatcherConfig.AutoCopy.PreserveSymlinks,
+		SkipTracked:         hatcherConfig.AutoCopy.SkipTracked,
+		DeferVerifyMinSize:  hatcherConfig.AutoCopy.DeferVerifyMinSize,
+		MaxRetries:          hatcherConfig.AutoCopy.MaxRetries,
+	}
+	// The backoff was validated when the configuration was loaded
+	if backoff, err := time.ParseDuration(hatcherConfig.AutoCopy.RetryBackoff); err == nil {
+		options.RetryBackoff = backoff
 	}
+	if traceCopy {
+		// Decisions are debug messages, which only verbose output shows
+		logger.GetLogger().SetVerbose(true)
+		options.Trace = traceCopyDecision
+	}
+	return options
+}
 
-	// Create auto-copier and copy files
-	copier := autocopy.NewLegacyAutoCopier()
-	copiedFiles, err := copier.CopyFiles(srcRoot, worktreePath, autoCopyConfig)
-	if err != nil {
-		return fmt.Errorf("failed to copy files: %w", err)
+// applyRetryFlags overrides the deferred verification threshold and the
+// retry settings with the flags given to cmd
+func applyRetryFlags(cmd *cobra.Command, options *autocopy.AutoCopierOptions, deferMin int64, maxRetries int, backoff time.Duration) {
+	if cmd.Flags().Changed("defer-verify-min-size") {
+		options.DeferVerifyMinSize = deferMin
 	}
+	if cmd.Flags().Changed("max-retries") {
+		options.MaxRetries = maxRetries
+	}
+	if cmd.Flags().Changed("retry-backoff") {
+		options.RetryBackoff = backoff
+	}
+}
 
-	if len(copiedFiles) > 0 {
-		fmt.Printf("📋 Auto-copied %d files/directories:\n", len(copiedFiles))
-		for _, file := range copiedFiles {
-			fmt.Printf("  ✅ %s\n", file)
-		}
+// traceCopyDecision logs the decision made for a file with --trace-copy
+func traceCopyDecision(entry autocopy.TraceEntry) {
+	logger.Debug("copy %s: %s (%s)", entry.Path, entry.Decision, entry.Reason)
+}
 
-		// Update .gitignore if not disabled
-		if !noGitignoreUpdate {
-			if err := copier.UpdateGitignore(worktreePath, copiedFiles); err != nil {
-				fmt.Printf("⚠️  Failed to update .gitignore: %v\n", err)
-			} else {
-				fmt.Printf("  ✅ Updated .gitignore with %d entries\n", len(copiedFiles))
-			}
-		}
-	} else {
-		if verbose {
-			fmt.Println("ℹ️  No files matched auto-copy configuration")
-		}
+// resolveMaxConfirmFiles returns the confirmation threshold, preferring the
+// --max-confirm-files flag over the configured value
+func resolveMaxConfirmFiles(configured int) int {
+	if maxConfirmFiles > 0 {
+		return maxConfirmFiles
 	}
+	if configured > 0 {
+		return configured
+	}
+	return autocopy.DefaultMaxConfirmFiles
+}
 
-	return nil
+// confirm asks the user a yes/no question on stdin, defaulting to no
+func confirm(message string) bool {
+	fmt.Printf("%s (y/N): ", message)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	if scanner.Scan() {
+		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		return response == "y" || response == "yes"
+	}
+
+	return false
 }
 
 // openInEditor opens the worktree in the specified editor
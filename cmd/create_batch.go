@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/config"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// batchResult is the outcome of creating one worktree of a batch
+type batchResult struct {
+	branch string
+	path   string
+	tracks bool // Created to track origin/<branch>
+	copied int
+	err    error
+}
+
+// runCreateFromFile creates a worktree for every branch listed in path, up
+// to --jobs at a time. Failures are reported and do not stop the others.
+func runCreateFromFile(cmd *cobra.Command, path string) error {
+	branches, err := worktree.ReadBranchFile(path)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	if len(branches) == 0 {
+		fmt.Printf("ℹ️  No branches listed in %s\n", path)
+		return nil
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+	srcRoot, err := repo.GetRoot()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to get repository root: %w", err)
+	}
+
+	// The configuration is shared by all worktrees; without one nothing is copied
+	var hatcherConfig *config.Config
+	var autoCopyConfig *autocopy.AutoCopyConfig
+	if !noCopy && !dryRun {
+		if hatcherConfig, autoCopyConfig, err = loadAutoCopyConfig(srcRoot); err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+	}
+
+	creator := worktree.NewCreator(repo)
+	creator.SetLayout(loadWorktreeLayout(repo))
+
+	if dryRun {
+		fmt.Println("🔍 Dry run mode - no changes will be made")
+	}
+	fmt.Printf("📋 Creating %d worktrees from %s\n", len(branches), path)
+
+	jobs := createJobs
+	if jobs < 1 {
+		jobs = 1
+	}
+	slots := make(chan struct{}, jobs)
+	results := make([]batchResult, len(branches))
+
+	var printMu sync.Mutex
+	var wg sync.WaitGroup
+	for i, branch := range branches {
+		wg.Add(1)
+		go func(i int, branch string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result := createBatchWorktree(cmd, repo, creator, srcRoot, branch, hatcherConfig, autoCopyConfig)
+			results[i] = result
+
+			printMu.Lock()
+			defer printMu.Unlock()
+			printBatchResult(result)
+		}(i, branch)
+	}
+	wg.Wait()
+
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+		}
+	}
+
+	verb := "created"
+	if dryRun {
+		verb = "would be created"
+	}
+	fmt.Printf("📊 %d %s, %d failed\n", len(results)-failed, verb, failed)
+	if failed > 0 {
+		return fmt.Errorf("❌ Failed to create %d of %d worktrees", failed, len(results))
+	}
+	return nil
+}
+
+// createBatchWorktree creates the worktree of branch and copies the
+// configured files into it like a single create, without prompting
+func createBatchWorktree(cmd *cobra.Command, repo git.Repository, creator *worktree.Creator, srcRoot, branch string, hatcherConfig *config.Config, autoCopyConfig *autocopy.AutoCopyConfig) batchResult {
+	result := batchResult{branch: branch}
+
+	created, err := creator.Create(worktree.CreateOptions{
+		BranchName:        branch,
+		Force:             force,
+		NoCopy:            noCopy,
+		NoGitignoreUpdate: noGitignoreUpdate,
+		DryRun:            dryRun,
+		TrackRemote:       trackRemote,
+	})
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.path = created.WorktreePath
+	result.tracks = created.TracksRemote
+
+	// Without a configuration (--no-copy or --dry-run) nothing is copied
+	if autoCopyConfig == nil {
+		return result
+	}
+
+	report, err := copyIntoWorktree(cmd, repo, hatcherConfig, autoCopyConfig, srcRoot, result.path, true)
+	if err != nil {
+		result.err = fmt.Errorf("worktree created, but %w", err)
+		return result
+	}
+	result.copied = len(report.CopiedFiles)
+
+	return result
+}
+
+// printBatchResult prints the outcome of one worktree of a batch
+func printBatchResult(result batchResult) {
+	branch := result.branch
+	if result.tracks {
+		branch += fmt.Sprintf(" tracking origin/%s", result.branch)
+	}
+
+	switch {
+	case result.err != nil:
+		fmt.Printf("❌ %s: %v\n", result.branch, result.err)
+	case dryRun:
+		fmt.Printf("📁 Would create %s at %s\n", branch, result.path)
+	case result.copied > 0:
+		fmt.Printf("✅ Created %s at %s (%d files/directories copied)\n", branch, result.path, result.copied)
+	default:
+		fmt.Printf("✅ Created %s at %s\n", branch, result.path)
+	}
+}
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/keisukeshimizu/hatcher/internal/config"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -266,3 +272,340 @@ func TestCreateCommandIntegration(t *testing.T) {
 		assert.FileExists(t, gitDir) // Should be a file pointing to the main .git
 	})
 }
+
+func TestResolveCopyMode(t *testing.T) {
+	defer func() {
+		copyParallel, copySequential = false, false
+		createCmd.Flags().Lookup("parallel").Changed = false
+		createCmd.Flags().Lookup("sequential").Changed = false
+	}()
+
+	tests := []struct {
+		name       string
+		parallel   bool
+		sequential bool
+		configured bool
+		expected   string
+	}{
+		{"default", false, false, false, copyModeSequential},
+		{"parallel flag", true, false, false, copyModeParallel},
+		{"sequential flag", false, true, false, copyModeSequential},
+		{"configured parallel", false, false, true, copyModeParallel},
+		{"sequential flag overrides config", false, true, true, copyModeSequential},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			copyParallel, copySequential = tt.parallel, tt.sequential
+			assert.Equal(t, tt.expected, resolveCopyMode(tt.configured))
+		})
+	}
+
+	t.Run("flags are mutually exclusive", func(t *testing.T) {
+		require.NoError(t, createCmd.Flags().Set("parallel", "true"))
+		require.NoError(t, createCmd.Flags().Set("sequential", "true"))
+		err := createCmd.ValidateFlagGroups()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parallel")
+	})
+}
+
+func TestRetryOptions(t *testing.T) {
+	hatcherConfig := &config.Config{AutoCopy: config.AutoCopyConfig{
+		DeferVerifyMinSize: 1 << 20,
+		MaxRetries:         2,
+		RetryBackoff:       "250ms",
+	}}
+
+	t.Run("from config", func(t *testing.T) {
+		options := copyOptionsFromConfig(hatcherConfig)
+		assert.Equal(t, int64(1<<20), options.DeferVerifyMinSize)
+		assert.Equal(t, 2, options.MaxRetries)
+		assert.Equal(t, 250*time.Millisecond, options.RetryBackoff)
+	})
+
+	t.Run("flags override config", func(t *testing.T) {
+		defer func() {
+			for _, name := range []string{"defer-verify-min-size", "max-retries", "retry-backoff"} {
+				createCmd.Flags().Lookup(name).Changed = false
+			}
+			deferVerifyMin, copyMaxRetries, copyRetryBackoff = 0, 0, 0
+		}()
+		require.NoError(t, createCmd.Flags().Set("max-retries", "0"))
+		require.NoError(t, createCmd.Flags().Set("retry-backoff", "1s"))
+
+		options := copyOptionsFromConfig(hatcherConfig)
+		applyRetryFlags(createCmd, &options, deferVerifyMin, copyMaxRetries, copyRetryBackoff)
+		assert.Equal(t, int64(1<<20), options.DeferVerifyMinSize)
+		assert.Equal(t, 0, options.MaxRetries)
+		assert.Equal(t, time.Second, options.RetryBackoff)
+	})
+}
+
+func TestShowParallelHint(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "hint-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	showParallelHint(repo)
+	assert.FileExists(t, filepath.Join(testRepo.RepoDir, ".git", parallelHintFile))
+}
+
+func TestCreateFromFile(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "batch-project")
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	branchFile := filepath.Join(t.TempDir(), "branches.txt")
+	require.NoError(t, os.WriteFile(branchFile, []byte("# PRs\nfeature/one\nbad..branch\nfeature/two\n"), 0644))
+
+	originalNoCopy, originalDryRun, originalJobs := noCopy, dryRun, createJobs
+	defer func() { noCopy, dryRun, createJobs = originalNoCopy, originalDryRun, originalJobs }()
+	noCopy, dryRun, createJobs = true, false, 2
+
+	err := runCreateFromFile(createCmd, branchFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 of 3")
+
+	// The failing branch does not stop the others
+	parentDir := filepath.Dir(testRepo.RepoDir)
+	assert.DirExists(t, filepath.Join(parentDir, "batch-project-feature-one"))
+	assert.DirExists(t, filepath.Join(parentDir, "batch-project-feature-two"))
+}
+
+func TestCreateFromFileCopies(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "batch-copy-project")
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+		{"path": ".cursorrules", "directory": false}
+	]}}`)
+	testRepo.CreateFile(".cursorrules", "# Cursor rules")
+
+	branchFile := filepath.Join(t.TempDir(), "branches.txt")
+	require.NoError(t, os.WriteFile(branchFile, []byte("feature/one\nfeature/two\n"), 0644))
+
+	originalNoCopy, originalDryRun, originalJobs := noCopy, dryRun, createJobs
+	defer func() { noCopy, dryRun, createJobs = originalNoCopy, originalDryRun, originalJobs }()
+	noCopy, dryRun, createJobs = false, false, 2
+
+	stdout, _ := testutil.CaptureOutput(t, func() {
+		require.NoError(t, runCreateFromFile(createCmd, branchFile))
+	})
+	assert.Contains(t, stdout, "(1 files/directories copied)")
+	assert.NotContains(t, stdout, "About to copy")
+
+	parentDir := filepath.Dir(testRepo.RepoDir)
+	for _, dir := range []string{"batch-copy-project-feature-one", "batch-copy-project-feature-two"} {
+		worktreePath := filepath.Join(parentDir, dir)
+		assert.FileExists(t, filepath.Join(worktreePath, ".cursorrules"))
+
+		gitignore, err := os.ReadFile(filepath.Join(worktreePath, ".gitignore"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(gitignore), ".cursorrules\n"))
+	}
+}
+
+func TestCreateFromFileTrackRemote(t *testing.T) {
+	remote := testutil.NewTestGitRepository(t, "batch-remote")
+	remoteBase := remote.GetCurrentBranch()
+	for _, branch := range []string{"feature/remote-one", "feature/remote-two"} {
+		remote.CreateBranch(branch)
+		remote.SwitchToBranch(remoteBase)
+	}
+
+	testRepo := testutil.NewTestGitRepository(t, "batch-track-project")
+	for _, args := range [][]string{{"remote", "add", "origin", remote.RepoDir}, {"fetch", "--quiet", "origin"}} {
+		output, err := exec.Command("git", append([]string{"-C", testRepo.RepoDir}, args...)...).CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	branchFile := filepath.Join(t.TempDir(), "branches.txt")
+	require.NoError(t, os.WriteFile(branchFile, []byte("feature/remote-one\nfeature/remote-two\n"), 0644))
+
+	originalNoCopy, originalDryRun, originalJobs, originalTrack := noCopy, dryRun, createJobs, trackRemote
+	defer func() {
+		noCopy, dryRun, createJobs, trackRemote = originalNoCopy, originalDryRun, originalJobs, originalTrack
+	}()
+	noCopy, dryRun, createJobs, trackRemote = true, false, 2, true
+
+	stdout, _ := testutil.CaptureOutput(t, func() {
+		require.NoError(t, runCreateFromFile(createCmd, branchFile))
+	})
+	assert.Contains(t, stdout, "feature/remote-one tracking origin/feature/remote-one")
+
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	for _, branch := range []string{"feature/remote-one", "feature/remote-two"} {
+		upstream, err := repo.GetUpstream(branch)
+		require.NoError(t, err)
+		assert.Equal(t, "origin/"+branch, upstream)
+	}
+}
+
+func TestCreateJSONOutput(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "json-project")
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+		{"path": ".cursorrules", "directory": false}
+	]}}`)
+	testRepo.CreateFile(".cursorrules", "# Cursor rules")
+
+	originalOutput, originalYes, originalNoCopy, originalDryRun := createOutput, createYes, noCopy, dryRun
+	defer func() {
+		createOutput, createYes, noCopy, dryRun = originalOutput, originalYes, originalNoCopy, originalDryRun
+	}()
+	createOutput, createYes, noCopy, dryRun = outputJSON, true, false, false
+
+	stdout, stderr := testutil.CaptureOutput(t, func() {
+		require.NoError(t, runCreate(createCmd, []string{"feature/json"}))
+	})
+
+	// stdout holds nothing but the report
+	var report map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(stdout), &report), stdout)
+	assert.Equal(t, []interface{}{".cursorrules"}, report["copiedFiles"])
+	assert.Equal(t, []interface{}{}, report["skipped"])
+	assert.Equal(t, float64(len("# Cursor rules")), report["bytesCopied"])
+	assert.Contains(t, report, "durationMs")
+	assert.Equal(t, true, report["gitignoreUpdated"])
+	assert.NotContains(t, stdout, "📋")
+
+	// Progress is still shown, on stderr
+	assert.Contains(t, stderr, "📂 cd")
+	assert.NotContains(t, stderr, "Auto-copied")
+}
+
+func TestCreateCopyOnlyNew(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "only-new-project")
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	// The branch commits its own .cursorrules
+	testRepo.CreateFile(".cursorrules", "committed rules")
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+		{"path": ".cursorrules", "directory": false},
+		{"path": "notes.txt", "directory": false}
+	]}}`)
+	testRepo.CommitAll("Add committed config")
+	mainBranch := testRepo.GetCurrentBranch()
+	testRepo.CreateBranch("feature/only-new")
+	testRepo.SwitchToBranch(mainBranch)
+
+	// Local, uncommitted versions in the main worktree
+	testRepo.CreateFile(".cursorrules", "local rules")
+	testRepo.CreateFile("notes.txt", "local notes")
+
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	created, err := worktree.NewCreator(repo).Create(worktree.CreateOptions{
+		BranchName: "feature/only-new",
+		NoCopy:     true,
+	})
+	require.NoError(t, err)
+
+	originalOnlyNew, originalYes := copyOnlyNew, createYes
+	defer func() { copyOnlyNew, createYes = originalOnlyNew, originalYes }()
+	copyOnlyNew, createYes = true, true
+
+	report, err := autoCopyFiles(createCmd, repo, testRepo.RepoDir, created.WorktreePath)
+	require.NoError(t, err)
+	assert.Contains(t, report.Skipped, ".cursorrules")
+
+	content, err := os.ReadFile(filepath.Join(created.WorktreePath, ".cursorrules"))
+	require.NoError(t, err)
+	assert.Equal(t, "committed rules", string(content))
+
+	content, err = os.ReadFile(filepath.Join(created.WorktreePath, "notes.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "local notes", string(content))
+
+	// Only the copied file is ignored; the committed one stays tracked
+	gitignore, err := os.ReadFile(filepath.Join(created.WorktreePath, ".gitignore"))
+	require.NoError(t, err)
+	assert.Contains(t, string(gitignore), "notes.txt")
+	assert.NotContains(t, string(gitignore), ".cursorrules")
+}
+
+func TestUseExistingWorktree(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "existing-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	worktreePath := filepath.Join(testRepo.TempDir, "existing-project-feature-open")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/open", true))
+
+	t.Run("accepted", func(t *testing.T) {
+		answerPrompt(t, "y\n")
+		assert.True(t, useExistingWorktree(repo, "feature/open"))
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		answerPrompt(t, "n\n")
+		assert.False(t, useExistingWorktree(repo, "feature/open"))
+	})
+
+	t.Run("branch without worktree", func(t *testing.T) {
+		answerPrompt(t, "y\n")
+		assert.False(t, useExistingWorktree(repo, "feature/none"))
+	})
+}
+
+func TestConfirmBranchReset(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "reset-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	base := testRepo.GetCurrentBranch()
+	testRepo.CreateBranch("feature/reset")
+	testRepo.SwitchToBranch(base)
+
+	t.Run("accepted", func(t *testing.T) {
+		answerPrompt(t, "y\n")
+		assert.True(t, confirmBranchReset(repo, "feature/reset", base))
+	})
+
+	t.Run("declined", func(t *testing.T) {
+		answerPrompt(t, "\n")
+		assert.False(t, confirmBranchReset(repo, "feature/reset", base))
+	})
+
+	t.Run("missing branch needs no confirmation", func(t *testing.T) {
+		answerPrompt(t, "n\n")
+		assert.True(t, confirmBranchReset(repo, "feature/none", base))
+	})
+}
+
+// answerPrompt feeds input to the next confirmation prompt
+func answerPrompt(t *testing.T, input string) {
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = writer.WriteString(input)
+	require.NoError(t, err)
+	writer.Close()
+
+	original := os.Stdin
+	os.Stdin = reader
+	t.Cleanup(func() {
+		os.Stdin = original
+		reader.Close()
+	})
+}
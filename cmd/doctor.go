@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/keisukeshimizu/hatcher/internal/config"
 	"github.com/keisukeshimizu/hatcher/internal/doctor"
 	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/spf13/cobra"
@@ -16,16 +17,45 @@ var doctorCmd = &cobra.Command{
 	Long: `Diagnose system configuration and validate Hatcher setup.
 
 Checks Git configuration, editor availability, configuration files, and system requirements.
+The copy-state check sums up the copy manifests of all worktrees and reports
+worktrees whose auto-copied files no longer match the main worktree.
+The config-validity check loads the configuration and fails on files that do
+not parse and on settings 'hch config validate' would reject.
+The disk-space check warns when the filesystem new worktrees are created on
+has less than twice the size of the auto-copied files free, and fails below
+their size.
 
 Examples:
   hch doctor                    # Run all diagnostic checks
   hch doctor --format json     # Output results in JSON format
-  hch doctor --simple          # Use simple output format`,
+  hch doctor --simple          # Use simple output format
+  hch doctor --no-progress     # Print results only once all checks finished
+  hch doctor --quiet --strict  # Print nothing, fail CI on warnings too
+  hch doctor --check git       # Run only the Git installation check
+  hch doctor --check worktrees --check editors   # Run several checks
+
+Available checks: git, git-version, repository, worktrees, configuration,
+config-validity, permissions, copy-state, disk-space, editors.
+The exit code is 1 when a selected check fails and 0 otherwise. With --strict,
+warnings exit with 2. --quiet prints nothing and only sets the exit code.
+
+JSON output includes a healthScore from 0 to 100: each check earns its weight
+when it passes, half its weight on a warning and nothing on a failure, and the
+score is the earned share of all weights. Weights default to 1 and can be set
+per check with doctor.weights in the configuration.
+
+The git-version check warns when Git is older than doctor.minGitVersion
+(default 2.17.0, which has every worktree command hatcher uses) and fails
+when Git cannot create worktrees at all.`,
 	Aliases: []string{"check", "validate", "diagnose"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
 		outputFormat, _ := cmd.Flags().GetString("format")
 		useSimple, _ := cmd.Flags().GetBool("simple")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		noProgress, _ := cmd.Flags().GetBool("no-progress")
+		strict, _ := cmd.Flags().GetBool("strict")
+		selectedChecks, _ := cmd.Flags().GetStringSlice("check")
 
 		// Initialize Git repository (optional for doctor)
 		var repo git.Repository
@@ -40,38 +70,54 @@ Examples:
 
 		// Create checker
 		checker := doctor.NewChecker(repo)
+		if err := applyDoctorConfig(checker, repo); err != nil {
+			return err
+		}
 
-		// Run diagnostic checks
-		result, err := checker.CheckSystem()
+		// Table and simple output are printed check by check as results come
+		// in; JSON is only printed once complete
+		format := outputFormat
+		if format != "json" && format != "simple" {
+			format = "table"
+			if useSimple {
+				format = "simple"
+			}
+		}
+		incremental := format != "json" && !quiet && !noProgress
+
+		var result *doctor.DiagnosticResult
+		if incremental {
+			printer := doctor.NewProgressPrinter(os.Stdout, format, isTerminal(os.Stdout))
+			result, err = checker.RunChecks(selectedChecks, printer)
+		} else {
+			result, err = checker.RunChecks(selectedChecks, nil)
+		}
 		if err != nil {
 			return fmt.Errorf("diagnostic checks failed: %w", err)
 		}
 
 		// Output results in requested format
-		switch outputFormat {
-		case "json":
+		switch {
+		case quiet:
+			// Only the exit code reports the result
+		case format == "json":
 			fmt.Print(result.FormatAsJSON())
-		case "simple":
+		case format == "simple" && incremental:
+			fmt.Print(result.FormatSimpleSummary())
+		case format == "simple":
 			fmt.Print(result.FormatAsSimple())
+		case incremental:
+			fmt.Print(result.FormatTableSummary())
 		default:
-			if useSimple {
-				fmt.Print(result.FormatAsSimple())
-			} else {
-				fmt.Print(result.FormatAsTable())
-			}
+			fmt.Print(result.FormatAsTable())
 		}
 
-		// Exit with appropriate code based on overall status
-		overallStatus := result.GetOverallStatus()
-		switch overallStatus {
-		case doctor.CheckStatusFail:
-			os.Exit(1)
-		case doctor.CheckStatusWarn:
-			os.Exit(2)
-		default:
-			os.Exit(0)
+		if code := doctorExitCode(result, strict); code != 0 {
+			// The results already explain the status
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return &exitStatusError{code: code}
 		}
-
 		return nil
 	},
 }
@@ -82,4 +128,59 @@ func init() {
 	// Add flags
 	doctorCmd.Flags().StringP("format", "f", "table", "Output format (table, json, simple)")
 	doctorCmd.Flags().Bool("simple", false, "Use simple output format")
+	doctorCmd.Flags().BoolP("quiet", "q", false, "Print nothing; only set the exit code")
+	doctorCmd.Flags().Bool("no-progress", false, "Print results only when all checks have finished, without progress")
+	doctorCmd.Flags().Bool("strict", false, "Exit with 2 when checks only warn")
+	doctorCmd.Flags().StringSlice("check", nil, "Run only the named check (repeatable)")
+}
+
+// doctorExitCode returns the exit code for result: ExitCodeError on failures
+// and, when strict, ExitCodeWarning on warnings
+func doctorExitCode(result *doctor.DiagnosticResult, strict bool) int {
+	switch result.GetOverallStatus() {
+	case doctor.CheckStatusFail:
+		return ExitCodeError
+	case doctor.CheckStatusWarn:
+		if strict {
+			return ExitCodeWarning
+		}
+	}
+	return 0
+}
+
+// applyDoctorConfig sets the configured health score weights and minimum Git
+// version on checker. Configuration problems are reported by the
+// configuration check instead.
+func applyDoctorConfig(checker *doctor.Checker, repo git.Repository) error {
+	projectPath := ""
+	if repo != nil {
+		projectPath, _ = repo.GetRoot()
+	}
+
+	hatcherConfig, err := config.NewManager().LoadConfig(projectPath)
+	if err != nil {
+		return nil
+	}
+
+	checker.SetLayout(hatcherConfig.Worktree.Layout())
+	if err := checker.SetWeights(hatcherConfig.Doctor.Weights); err != nil {
+		return fmt.Errorf("invalid doctor.weights: %w", err)
+	}
+	if hatcherConfig.Doctor.MinGitVersion != "" {
+		version, err := git.ParseVersion(hatcherConfig.Doctor.MinGitVersion)
+		if err != nil {
+			return fmt.Errorf("invalid doctor.minGitVersion: %w", err)
+		}
+		checker.SetMinGitVersion(version)
+	}
+	return nil
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
 }
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/doctor"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDoctorExitCode(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []doctor.CheckStatus
+		strict bool
+		want   int
+	}{
+		{"all pass", []doctor.CheckStatus{doctor.CheckStatusPass, doctor.CheckStatusPass}, false, 0},
+		{"all pass strict", []doctor.CheckStatus{doctor.CheckStatusPass}, true, 0},
+		{"warning", []doctor.CheckStatus{doctor.CheckStatusPass, doctor.CheckStatusWarn}, false, 0},
+		{"warning strict", []doctor.CheckStatus{doctor.CheckStatusPass, doctor.CheckStatusWarn}, true, ExitCodeWarning},
+		{"failure", []doctor.CheckStatus{doctor.CheckStatusPass, doctor.CheckStatusFail}, false, ExitCodeError},
+		{"failure and warning strict", []doctor.CheckStatus{doctor.CheckStatusWarn, doctor.CheckStatusFail}, true, ExitCodeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := &doctor.DiagnosticResult{}
+			for _, status := range tt.checks {
+				result.Checks = append(result.Checks, doctor.CheckResult{Status: status})
+				switch status {
+				case doctor.CheckStatusPass:
+					result.Summary.Passed++
+				case doctor.CheckStatusWarn:
+					result.Summary.Warned++
+				case doctor.CheckStatusFail:
+					result.Summary.Failed++
+				}
+			}
+
+			assert.Equal(t, tt.want, doctorExitCode(result, tt.strict))
+		})
+	}
+}
+
+func TestDoctorQuiet(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "doctor-quiet-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	defer func() {
+		doctorCmd.Flags().Set("quiet", "false")
+		doctorCmd.Flags().Lookup("check").Value.(pflag.SliceValue).Replace(nil)
+	}()
+
+	t.Run("passing checks", func(t *testing.T) {
+		testRepo.CreateFile(".hatcher-auto-copy.json", `{"version": 2, "items": [{"path": ".env"}]}`)
+
+		var err error
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			err = cliHelper.ExecuteCommand(rootCmd, "doctor", "--quiet", "--check", "config-validity")
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, stdout)
+	})
+
+	t.Run("failing checks", func(t *testing.T) {
+		testRepo.CreateFile(".hatcher-auto-copy.json", `{"version": 2, "items": [`)
+
+		var err error
+		stdout, stderr := testutil.CaptureOutput(t, func() {
+			err = cliHelper.ExecuteCommand(rootCmd, "doctor", "--quiet", "--check", "config-validity")
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, ExitCodeError, ExitCode(err))
+		assert.Empty(t, stdout)
+		assert.Empty(t, stderr)
+	})
+}
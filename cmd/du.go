@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// duCmd represents the du command
+var duCmd = &cobra.Command{
+	Use:   "du",
+	Short: "Show disk usage per worktree",
+	Long: `Summarize the disk usage of each worktree and the space saved by files
+shared between them (e.g. hardlinks).
+
+SIZE is the total size of a worktree's files; UNIQUE is the part not already
+counted for a worktree listed above it. Git metadata is not included.
+
+Examples:
+  hch du                  # Show Hatcher-managed worktrees
+  hch du --all            # Include all Git worktrees
+  hch du --format json    # Output in JSON format`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		showAll, _ := cmd.Flags().GetBool("all")
+		outputFormat, _ := cmd.Flags().GetString("format")
+
+		// Initialize Git repository
+		repo, err := git.NewRepository()
+		if err != nil {
+			return fmt.Errorf("❌ Not in a Git repository: %w", err)
+		}
+
+		calculator := worktree.NewUsageCalculator(repo)
+		calculator.SetLayout(loadWorktreeLayout(repo))
+		report, err := calculator.Calculate(worktree.UsageOptions{
+			ShowAll: showAll,
+		})
+		if err != nil {
+			return fmt.Errorf("❌ Failed to measure disk usage: %w", err)
+		}
+
+		switch outputFormat {
+		case "json":
+			fmt.Print(report.FormatAsJSON())
+		default:
+			fmt.Print(report.FormatAsTable())
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(duCmd)
+
+	duCmd.Flags().Bool("all", false, "Include all Git worktrees, not just Hatcher-managed ones")
+	duCmd.Flags().StringP("format", "f", "table", "Output format (table, json)")
+}
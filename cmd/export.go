@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var exportOutput string
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export hatcher worktree state as JSON",
+	Long: `Export all Hatcher-managed worktrees (branch, base ref, tags and notes) as JSON.
+
+The exported file can be used with 'hch import' to recreate the same set of
+worktrees on another machine.
+
+Examples:
+  hch export                    # Print state to stdout
+  hch export -o state.json      # Write state to a file`,
+	Args: cobra.NoArgs,
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "write state to file instead of stdout")
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	// Initialize Git repository
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	manager := worktree.NewStateManager(repo)
+	manager.SetLayout(loadWorktreeLayout(repo))
+	state, err := manager.Export()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to export worktree state: %w", err)
+	}
+
+	data, err := state.FormatAsJSON()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to export worktree state: %w", err)
+	}
+
+	if exportOutput == "" {
+		fmt.Print(data)
+		return nil
+	}
+
+	if err := os.WriteFile(exportOutput, []byte(data), 0644); err != nil {
+		return fmt.Errorf("❌ Failed to write state file: %w", err)
+	}
+
+	fmt.Printf("✅ Exported %d worktree(s) to %s\n", len(state.Worktrees), exportOutput)
+	return nil
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// exportCopyCmd represents the export-copy command
+var exportCopyCmd = &cobra.Command{
+	Use:   "export-copy <branch-name>",
+	Short: "Bundle a worktree's auto-copy files into a tar.gz archive",
+	Long: `Bundle the files the auto-copy configuration selects in a worktree into a
+single gzip-compressed tar archive, for moving them to another machine.
+
+The files are taken from the worktree of the branch, so local changes made
+there are included. Paths in the archive are relative to the worktree, and
+a manifest of their checksums comes first; extract it on the other machine
+with 'hch restore <worktree> config.tar.gz'.
+
+Examples:
+  hch export-copy feature/user-auth              # Write config.tar.gz
+  hch export-copy main -o ~/transfer/myapp.tar.gz`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExportCopy,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCopyCmd)
+
+	exportCopyCmd.Flags().StringP("output", "o", "config.tar.gz", "write the bundle to this file")
+}
+
+func runExportCopy(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	output, _ := cmd.Flags().GetString("output")
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+	srcRoot, err := repo.GetRoot()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to get repository root: %w", err)
+	}
+
+	finder := worktree.NewFinder(repo)
+	finder.SetLayout(loadWorktreeLayout(repo))
+	worktreePath, exists, err := finder.FindWorktree(branchName)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to find worktree: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("❌ Worktree for branch '%s' not found", branchName)
+	}
+
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(srcRoot)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	copyOptions := copyOptionsFromConfig(hatcherConfig)
+	if manifestPath := customManifestPath("", hatcherConfig); manifestPath != "" {
+		copyOptions.SkipPaths = []string{manifestPath}
+	}
+	copier := autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions)
+
+	// Write next to the output and rename, so a failed export leaves no
+	// truncated bundle behind
+	tmpPath := output + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to create bundle: %w", err)
+	}
+	files, err := copier.WriteBundle(worktreePath, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, output)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("❌ Failed to write bundle: %w", err)
+	}
+
+	fmt.Printf("📦 Bundled %d files from %s into %s\n", len(files), branchName, output)
+	for _, file := range files {
+		fmt.Printf("  - %s\n", file)
+	}
+
+	return nil
+}
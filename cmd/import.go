@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import <state.json>",
+	Short: "Recreate worktrees from an exported state file",
+	Long: `Recreate worktrees described by a file written with 'hch export'.
+
+Existing worktrees are left untouched. For each entry the local branch is used
+if it exists, then the remote branch, then the exported base ref. Entries whose
+refs cannot be found are skipped and reported.
+
+Examples:
+  hch import state.json             # Recreate worktrees
+  hch import state.json --dry-run   # Show what would be created`,
+	Args: cobra.ExactArgs(1),
+	RunE: runImport,
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	state, err := worktree.LoadState(args[0])
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	// Initialize Git repository
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	manager := worktree.NewStateManager(repo)
+	manager.SetLayout(loadWorktreeLayout(repo))
+	result, err := manager.Import(state, worktree.ImportOptions{
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return fmt.Errorf("❌ Failed to import worktree state: %w", err)
+	}
+
+	if dryRun {
+		fmt.Println("🔍 Dry run mode - no changes will be made")
+	}
+
+	for _, entry := range result.Created {
+		if dryRun {
+			fmt.Printf("📁 Would create %s from %s at %s\n", entry.Branch, entry.Ref, entry.Path)
+		} else {
+			fmt.Printf("✅ Created %s from %s at %s\n", entry.Branch, entry.Ref, entry.Path)
+		}
+	}
+	for _, entry := range result.Skipped {
+		fmt.Printf("⏭️  Skipped %s: %s\n", entry.Branch, entry.Reason)
+	}
+
+	fmt.Printf("📊 %d created, %d skipped\n", len(result.Created), len(result.Skipped))
+	return nil
+}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
+	"github.com/keisukeshimizu/hatcher/internal/config"
 	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/internal/worktree"
 	"github.com/spf13/cobra"
@@ -19,9 +22,16 @@ By default, only shows Hatcher-managed worktrees. Use --all to show all Git work
 Examples:
   hch list                          # Show Hatcher-managed worktrees
   hch list --all                    # Show all Git worktrees
+  hch list --exclude-main           # Hide the main repository
   hch list --format json           # Output in JSON format
+  hch list --format jsonl --status # One JSON object per line, as soon as each is ready
   hch list --filter "feature/*"    # Filter by branch pattern
-  hch list --paths                  # Show full paths`,
+  hch list --paths                  # Show full paths
+  hch list --format paths-only | xargs -I{} du -sh {}  # Bare paths for scripting
+  hch list --tag review             # Show worktrees tagged "review"
+  hch list --notes                  # Show worktree notes
+  hch list --sort mtime             # Recently modified worktrees first
+  hch list --stale 720h             # Worktrees untouched for 30 days`,
 	Aliases: []string{"ls", "show"},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get flags
@@ -30,6 +40,20 @@ Examples:
 		showStatus, _ := cmd.Flags().GetBool("status")
 		outputFormat, _ := cmd.Flags().GetString("format")
 		filterPattern, _ := cmd.Flags().GetString("filter")
+		tag, _ := cmd.Flags().GetString("tag")
+		showNotes, _ := cmd.Flags().GetBool("notes")
+		excludeMain, _ := cmd.Flags().GetBool("exclude-main")
+		sortOrder, _ := cmd.Flags().GetString("sort")
+		staleFor, _ := cmd.Flags().GetDuration("stale")
+		if err := worktree.ValidateListSort(sortOrder); err != nil {
+			return fmt.Errorf("❌ Invalid --sort: %w", err)
+		}
+		if staleFor < 0 {
+			return fmt.Errorf("❌ Invalid --stale: %s is negative", staleFor)
+		}
+		if outputFormat == "jsonl" && cmd.Flags().Changed("sort") {
+			return fmt.Errorf("❌ --sort cannot be used with --format jsonl, which lists worktrees in git's order")
+		}
 
 		// Initialize Git repository
 		repo, err := git.NewRepositoryFromPath(".")
@@ -37,14 +61,40 @@ Examples:
 			return fmt.Errorf("failed to initialize Git repository: %w", err)
 		}
 
+		// list.excludeMain is the default unless the flag is given
+		if !cmd.Flags().Changed("exclude-main") {
+			excludeMain = loadListConfig(repo).ExcludeMain
+		}
+
 		// Create lister
 		lister := worktree.NewLister(repo)
+		lister.SetLayout(loadWorktreeLayout(repo))
 
 		// Prepare options
 		options := worktree.ListOptions{
-			ShowAll:    showAll,
-			ShowPaths:  showPaths,
-			ShowStatus: showStatus,
+			ShowAll:     showAll,
+			ExcludeMain: excludeMain,
+			ShowPaths:   showPaths,
+			ShowStatus:  showStatus,
+			ShowNotes:   showNotes,
+			Tag:         tag,
+			Sort:        sortOrder,
+			StaleFor:    staleFor,
+		}
+
+		// JSON lines are written as the worktrees are read, in git's order
+		if outputFormat == "jsonl" {
+			encoder := json.NewEncoder(os.Stdout)
+			err := lister.Stream(options, func(wt worktree.WorktreeInfo) error {
+				if filterPattern != "" && !worktree.MatchBranchPattern(wt.Branch, filterPattern) {
+					return nil
+				}
+				return encoder.Encode(wt)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to list worktrees: %w", err)
+			}
+			return nil
 		}
 
 		// List worktrees
@@ -66,6 +116,8 @@ Examples:
 			fmt.Print(result.FormatAsJSON())
 		case "simple":
 			fmt.Print(result.FormatAsSimple())
+		case "paths-only":
+			fmt.Print(result.FormatAsPaths())
 		case "table":
 			fallthrough
 		default:
@@ -83,6 +135,23 @@ func init() {
 	listCmd.Flags().Bool("all", false, "Show all Git worktrees, not just Hatcher-managed ones")
 	listCmd.Flags().Bool("paths", false, "Show full paths in output")
 	listCmd.Flags().Bool("status", false, "Show status information (clean/dirty)")
-	listCmd.Flags().StringP("format", "f", "table", "Output format (table, json, simple)")
+	listCmd.Flags().StringP("format", "f", "table", "Output format (table, json, jsonl, simple, paths-only)")
 	listCmd.Flags().String("filter", "", "Filter worktrees by branch pattern (e.g., 'feature/*')")
+	listCmd.Flags().String("tag", "", "Only show worktrees with the given tag")
+	listCmd.Flags().Bool("notes", false, "Show worktree notes")
+	listCmd.Flags().Bool("exclude-main", false, "Hide the main repository (default from list.excludeMain)")
+	listCmd.Flags().String("sort", worktree.SortBranch, "Sort worktrees by branch, path or mtime (most recently modified first)")
+	listCmd.Flags().Duration("stale", 0, "Only show worktrees whose files were not modified for this long (e.g. 720h)")
+}
+
+// loadListConfig returns the list configuration. An unreadable configuration
+// yields the zero value, listing the main repository.
+func loadListConfig(repo git.Repository) config.ListConfig {
+	projectPath, _ := repo.GetRoot()
+
+	hatcherConfig, err := config.NewManager().LoadConfig(projectPath)
+	if err != nil {
+		return config.ListConfig{}
+	}
+	return hatcherConfig.List
 }
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCommandExcludeMain(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "list-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+	defer func() {
+		listCmd.Flags().Set("exclude-main", "false")
+		listCmd.Flags().Set("format", "table")
+		listCmd.Flags().Lookup("exclude-main").Changed = false
+	}()
+
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	worktreePath := filepath.Join(testRepo.TempDir, "list-project-feature-list")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/list", true))
+
+	listPaths := func(args ...string) string {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, append([]string{"list", "--format", "paths-only"}, args...)...))
+		})
+		return stdout
+	}
+
+	t.Run("lists the main repository by default", func(t *testing.T) {
+		output := listPaths()
+		assert.Contains(t, output, testRepo.RepoDir+"\n")
+		assert.Contains(t, output, worktreePath)
+	})
+
+	t.Run("config default hides the main repository", func(t *testing.T) {
+		testRepo.CreateFile(".hatcher/config.json", `{"list": {"excludeMain": true}}`)
+		defer testRepo.CreateFile(".hatcher/config.json", `{}`)
+
+		output := listPaths()
+		assert.NotContains(t, output, testRepo.RepoDir+"\n")
+		assert.Contains(t, output, worktreePath)
+
+		// The flag overrides the default
+		output = listPaths("--exclude-main=false")
+		assert.Contains(t, output, testRepo.RepoDir+"\n")
+	})
+
+	t.Run("JSON lines", func(t *testing.T) {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "list", "--format", "jsonl", "--status", "--filter", "feature/*"))
+		})
+		defer listCmd.Flags().Set("status", "false")
+		defer listCmd.Flags().Set("filter", "")
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, 1)
+		var wt worktree.WorktreeInfo
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &wt))
+		assert.Equal(t, "feature/list", wt.Branch)
+		assert.Equal(t, worktreePath, wt.Path)
+		assert.Equal(t, worktree.StatusClean, wt.Status)
+	})
+
+	t.Run("flag hides the main repository", func(t *testing.T) {
+		output := listPaths("--exclude-main")
+		assert.NotContains(t, output, testRepo.RepoDir+"\n")
+		assert.Contains(t, output, worktreePath)
+	})
+}
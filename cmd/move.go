@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/keisukeshimizu/hatcher/internal/config"
 	editorpkg "github.com/keisukeshimizu/hatcher/internal/editor"
 	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/internal/worktree"
@@ -13,6 +14,7 @@ var (
 	switchEditor bool
 	yes          bool
 	newWindow    bool
+	reuseWindow  bool
 )
 
 // moveCmd represents the move command
@@ -27,7 +29,8 @@ Examples:
   hatcher move feature/user-auth    # Open worktree in new editor window
   hatcher move -s main             # Switch current editor to main worktree
   hatcher move -y new-feature      # Create and open if doesn't exist
-  hatcher move --editor cursor ui  # Open in specific editor`,
+  hatcher move --editor cursor ui  # Open in specific editor
+  hatcher move --reuse-window ui   # Open in the current editor window`,
 	Aliases: []string{"mv", "switch", "open"},
 	Args:    cobra.ExactArgs(1),
 	RunE:    runMove,
@@ -39,7 +42,9 @@ func init() {
 	// Flags for move command
 	moveCmd.Flags().BoolVarP(&switchEditor, "switch", "s", false, "close current editor and switch to new worktree")
 	moveCmd.Flags().BoolVarP(&yes, "yes", "y", false, "automatically create worktree if it doesn't exist")
-	moveCmd.Flags().BoolVar(&newWindow, "new-window", true, "open in new window (default)")
+	moveCmd.Flags().BoolVar(&newWindow, "new-window", false, "open in a new window (overrides editor.windowReuse)")
+	moveCmd.Flags().BoolVar(&reuseWindow, "reuse-window", false, "open in an existing window (overrides editor.windowReuse)")
+	moveCmd.MarkFlagsMutuallyExclusive("new-window", "reuse-window")
 	moveCmd.Flags().StringVar(&editor, "editor", "", "specify editor to use (cursor, code)")
 }
 
@@ -56,11 +61,18 @@ func runMove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("❌ Not in a Git repository: %w", err)
 	}
 
+	editorConfig := loadEditorConfig(repo)
+
 	// Initialize editor detector
 	detector := editorpkg.NewDetector()
+	if err := detector.SetOrder(editorConfig.Order); err != nil {
+		return fmt.Errorf("❌ invalid editor.order: %w", err)
+	}
 
 	// Create mover
 	mover := worktree.NewMover(repo, detector)
+	mover.SetWindowReuse(editorConfig.WindowReuse)
+	mover.SetLayout(loadWorktreeLayout(repo))
 
 	// Prepare move options
 	options := worktree.MoveOptions{
@@ -68,6 +80,7 @@ func runMove(cmd *cobra.Command, args []string) error {
 		SwitchMode:    switchEditor,
 		AutoCreate:    yes,
 		EditorCommand: editor,
+		Window:        windowMode(),
 	}
 
 	// Execute move operation
@@ -83,7 +96,13 @@ func runMove(cmd *cobra.Command, args []string) error {
 		fmt.Printf("✅ Found worktree: %s\n", result.WorktreePath)
 	}
 
-	if switchEditor {
+	for _, failed := range result.FailedEditors {
+		fmt.Printf("⚠️  Failed to open %s, trying the next editor\n", failed)
+	}
+
+	if result.Focused {
+		fmt.Printf("👀 Already open in %s, focused the existing window\n", result.EditorUsed)
+	} else if switchEditor {
 		fmt.Printf("🔄 Switched to %s\n", result.EditorUsed)
 	} else {
 		fmt.Printf("🚀 Opened in %s\n", result.EditorUsed)
@@ -93,3 +112,29 @@ func runMove(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// loadEditorConfig returns the editor configuration. An unreadable
+// configuration yields the zero value, keeping the default priority and new
+// windows.
+func loadEditorConfig(repo git.Repository) config.EditorConfig {
+	projectPath, _ := repo.GetRoot()
+
+	hatcherConfig, err := config.NewManager().LoadConfig(projectPath)
+	if err != nil {
+		return config.EditorConfig{}
+	}
+	return hatcherConfig.Editor
+}
+
+// windowMode returns the window mode requested with --new-window or
+// --reuse-window
+func windowMode() worktree.WindowMode {
+	switch {
+	case newWindow:
+		return worktree.WindowNew
+	case reuseWindow:
+		return worktree.WindowReuse
+	default:
+		return worktree.WindowDefault
+	}
+}
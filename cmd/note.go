@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var clearNote bool
+
+// noteCmd represents the note command
+var noteCmd = &cobra.Command{
+	Use:   "note <branch-name> [text...]",
+	Short: "Show or set a free-text note on a worktree",
+	Long: `Attach a short free-text note to a worktree, e.g. what it is waiting on.
+
+Notes are stored in .hatcher/worktrees.json in the main repository alongside
+tags. Use 'hch list --notes' to show them as a column.
+
+Examples:
+  hch note feature/login "waiting on review"   # Set a note
+  hch note feature/login                       # Show the current note
+  hch note feature/login --clear               # Remove the note`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runNote,
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+
+	noteCmd.Flags().BoolVar(&clearNote, "clear", false, "remove the note")
+}
+
+func runNote(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	text := strings.TrimSpace(strings.Join(args[1:], " "))
+
+	if clearNote && text != "" {
+		return fmt.Errorf("❌ Cannot set and clear a note at the same time")
+	}
+
+	// Initialize Git repository
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	// Make sure the worktree exists before annotating it
+	finder := worktree.NewFinder(repo)
+	finder.SetLayout(loadWorktreeLayout(repo))
+	if _, exists, err := finder.FindWorktree(branchName); err != nil {
+		return fmt.Errorf("❌ Failed to find worktree: %w", err)
+	} else if !exists {
+		return fmt.Errorf("❌ Worktree for branch '%s' not found", branchName)
+	}
+
+	store, err := worktree.NewMetadataStore(repo)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to open worktree metadata: %w", err)
+	}
+
+	// Without text or --clear, just show the current note
+	if text == "" && !clearNote {
+		meta, err := store.Get(branchName)
+		if err != nil {
+			return fmt.Errorf("❌ Failed to read note: %w", err)
+		}
+		if meta.Note == "" {
+			fmt.Printf("📝 %s has no note\n", branchName)
+		} else {
+			fmt.Printf("📝 %s: %s\n", branchName, meta.Note)
+		}
+		return nil
+	}
+
+	if err := store.SetNote(branchName, text); err != nil {
+		return fmt.Errorf("❌ Failed to update note: %w", err)
+	}
+
+	if clearNote {
+		fmt.Printf("📝 Cleared note for %s\n", branchName)
+	} else {
+		fmt.Printf("📝 %s: %s\n", branchName, text)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Output formats selected with --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// validateOutputFormat checks a --output value
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputText, outputJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (use text or json)", format)
+	}
+}
+
+// jsonOutput keeps stdout for a single JSON document: until Write or Close,
+// human-readable output printed to stdout goes to stderr instead
+type jsonOutput struct {
+	stdout *os.File
+}
+
+// startJSONOutput redirects stdout to stderr
+func startJSONOutput() *jsonOutput {
+	out := &jsonOutput{stdout: os.Stdout}
+	os.Stdout = os.Stderr
+	return out
+}
+
+// Write restores stdout and writes value to it as indented JSON
+func (o *jsonOutput) Write(value interface{}) error {
+	o.Close()
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("❌ Failed to write JSON output: %w", err)
+	}
+	return nil
+}
+
+// Close restores stdout without writing anything
+func (o *jsonOutput) Close() {
+	os.Stdout = o.stdout
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what the auto-copy configuration would copy",
+	Long: `Resolve the auto-copy configuration into the files it copies, without
+copying anything.
+
+Every source file is listed with its destination, size and decision:
+create, overwrite, backup (overwrite keeping a backup), unchanged, or
+overridden when an item copied later writes the same destination. Without
+--dest the plan is made for an empty, hypothetical worktree.
+
+Examples:
+  hch plan                          # Plan a copy into a new worktree
+  hch plan --dest ../myapp-feature  # Plan a copy into an existing worktree
+  hch plan --json                   # Output in JSON format`,
+	Args: cobra.NoArgs,
+	RunE: runPlan,
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().String("dest", "", "plan the copy into this directory instead of an empty worktree")
+	planCmd.Flags().Bool("backup", false, "plan as if --backup were given")
+	planCmd.Flags().Bool("json", false, "output in JSON format")
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	destDir, _ := cmd.Flags().GetString("dest")
+	planBackup, _ := cmd.Flags().GetBool("backup")
+	jsonOutput, _ := cmd.Flags().GetBool("json")
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+	srcRoot, err := repo.GetRoot()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to get repository root: %w", err)
+	}
+
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(srcRoot)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	copyOptions := copyOptionsFromConfig(hatcherConfig)
+	copyOptions.Backup = planBackup
+	if manifestPath := customManifestPath("", hatcherConfig); manifestPath != "" {
+		copyOptions.SkipPaths = []string{manifestPath}
+	}
+
+	// An empty directory stands in for a new worktree
+	if destDir != "" {
+		if destDir, err = filepath.Abs(destDir); err != nil {
+			return fmt.Errorf("❌ Invalid --dest: %w", err)
+		}
+	} else {
+		tempDir, err := os.MkdirTemp("", "hatcher-plan-")
+		if err != nil {
+			return fmt.Errorf("❌ Failed to create a temporary directory: %w", err)
+		}
+		defer os.RemoveAll(tempDir)
+		destDir = tempDir
+	}
+
+	plan, err := autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions).Plan(srcRoot, destDir)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to plan the copy: %w", err)
+	}
+
+	if jsonOutput {
+		fmt.Print(plan.FormatAsJSON())
+	} else {
+		fmt.Print(plan.FormatAsTable())
+	}
+	return nil
+}
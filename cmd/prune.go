@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// pruneCmd represents the prune command
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove stale entries of deleted worktrees",
+	Long: `Remove the entries Git keeps for worktrees whose directories were deleted
+without 'hch remove', so they no longer show up in 'hch list'.
+
+Examples:
+  hch prune               # Remove stale worktree entries
+  hch prune --dry-run     # Show the entries that would be removed`,
+	Args: cobra.NoArgs,
+	RunE: runPrune,
+}
+
+func init() {
+	rootCmd.AddCommand(pruneCmd)
+}
+
+func runPrune(cmd *cobra.Command, args []string) error {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	if dryRun {
+		candidates, err := repo.PruneCandidates()
+		if err != nil {
+			return fmt.Errorf("❌ %w", err)
+		}
+		if len(candidates) == 0 {
+			fmt.Println("✅ No stale worktree entries")
+			return nil
+		}
+		fmt.Printf("🔍 Dry run mode - %d stale worktree entries would be removed:\n", len(candidates))
+		for _, candidate := range candidates {
+			fmt.Printf("  - %s\n", candidate)
+		}
+		return nil
+	}
+
+	before, err := repo.ListWorktreeEntries()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to list worktrees: %w", err)
+	}
+	if err := repo.PruneWorktrees(); err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	after, err := repo.ListWorktreeEntries()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to list worktrees: %w", err)
+	}
+
+	removed := len(before) - len(after)
+	if removed == 0 {
+		fmt.Println("✅ No stale worktree entries")
+		return nil
+	}
+	fmt.Printf("🧹 Removed %d stale worktree entries\n", removed)
+	return nil
+}
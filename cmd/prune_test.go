@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruneCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "prune-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+	defer func() { dryRun = false }()
+
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	worktreePath := filepath.Join(testRepo.TempDir, "prune-project-deleted")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/deleted", true))
+	require.NoError(t, os.RemoveAll(worktreePath))
+
+	t.Run("dry run keeps the entry", func(t *testing.T) {
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "prune", "--dry-run"))
+		assert.Contains(t, testRepo.ListWorktrees()[0], worktreePath)
+	})
+
+	t.Run("removes the entry of a deleted worktree", func(t *testing.T) {
+		dryRun = false
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "prune"))
+		assert.NotContains(t, testRepo.ListWorktrees()[0], worktreePath)
+	})
+}
@@ -51,6 +51,7 @@ Examples:
 
 		// Create remover
 		remover := worktree.NewRemover(repo)
+		remover.SetLayout(loadWorktreeLayout(repo))
 
 		// Prepare options
 		options := worktree.RemoveOptions{
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename <branch-name> <new-branch-name>",
+	Short: "Rename a branch and move its worktree to match",
+	Long: `Rename a local branch and move its worktree to the directory hatcher
+would create for the new name, keeping its tags, note and editor.
+
+The main repository is never renamed, and the rename is refused when the
+new worktree directory already exists.
+
+Examples:
+  hch rename feature/login feature/user-auth`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRename,
+}
+
+func init() {
+	rootCmd.AddCommand(renameCmd)
+}
+
+func runRename(cmd *cobra.Command, args []string) error {
+	oldBranch, newBranch := args[0], args[1]
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	renamer := worktree.NewRenamer(repo)
+	renamer.SetLayout(loadWorktreeLayout(repo))
+	result, err := renamer.Rename(oldBranch, newBranch)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to rename worktree: %w", err)
+	}
+
+	// Tags, notes and pinned editors are keyed by branch
+	if store, err := worktree.NewMetadataStore(repo); err == nil {
+		if err := store.Rename(oldBranch, newBranch); err != nil {
+			fmt.Printf("⚠️  Failed to move worktree metadata: %v\n", err)
+		}
+	}
+
+	fmt.Printf("✅ Renamed %s to %s\n", result.OldBranch, result.NewBranch)
+	fmt.Printf("📁 %s\n", result.OldPath)
+	fmt.Printf("  → %s\n", result.NewPath)
+
+	return nil
+}
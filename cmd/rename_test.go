@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenameCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "rename-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	t.Run("moves the worktree and its metadata", func(t *testing.T) {
+		oldPath := filepath.Join(testRepo.TempDir, "rename-project-feature-login")
+		require.NoError(t, repo.CreateWorktree(oldPath, "feature/login", true))
+		store, err := worktree.NewMetadataStore(repo)
+		require.NoError(t, err)
+		require.NoError(t, store.SetNote("feature/login", "needs review"))
+
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "rename", "feature/login", "feature/user-auth"))
+
+		assert.NoDirExists(t, oldPath)
+		assert.DirExists(t, filepath.Join(testRepo.TempDir, "rename-project-feature-user-auth"))
+		meta, err := store.Get("feature/user-auth")
+		require.NoError(t, err)
+		assert.Equal(t, "needs review", meta.Note)
+	})
+
+	t.Run("moves the worktree into the configured base directory", func(t *testing.T) {
+		require.NoError(t, os.MkdirAll(filepath.Join(testRepo.RepoDir, ".hatcher"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(testRepo.RepoDir, ".hatcher", "config.json"), []byte(`{"worktree": {"baseDir": "worktrees"}}`), 0644))
+		defer os.RemoveAll(filepath.Join(testRepo.RepoDir, ".hatcher"))
+
+		oldPath := filepath.Join(testRepo.TempDir, "rename-project-feature-base")
+		require.NoError(t, repo.CreateWorktree(oldPath, "feature/base", true))
+
+		require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "rename", "feature/base", "feature/moved"))
+
+		assert.NoDirExists(t, oldPath)
+		assert.DirExists(t, filepath.Join(testRepo.TempDir, "worktrees", "rename-project-feature-moved"))
+	})
+
+	t.Run("refuses the main repository", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "rename", testRepo.GetCurrentBranch(), "feature/other")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "main repository")
+	})
+}
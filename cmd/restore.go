@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// restoreCmd represents the restore command
+var restoreCmd = &cobra.Command{
+	Use:   "restore <worktree> <bundle>",
+	Short: "Extract a copy bundle made by export-copy into a worktree",
+	Long: `Extract a bundle written by 'hch export-copy' into a worktree, which is
+given as a directory or as the branch checked out in it.
+
+Every file is verified against the checksums in the bundle before anything
+is written, so the source repository is not needed. Files that already have
+the bundled content are left alone; files that differ are reported and the
+restore is refused unless --force is given.
+
+Examples:
+  hch restore ../myapp-feature config.tar.gz      # Restore into a directory
+  hch restore feature/user-auth config.tar.gz     # Restore into a branch's worktree
+  hch restore --force feature/user-auth config.tar.gz
+  hch restore --dry-run ../myapp-feature config.tar.gz`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+
+	restoreCmd.Flags().Bool("force", false, "overwrite files that differ from the bundled ones")
+	restoreCmd.Flags().Bool("no-gitignore-update", false, "skip .gitignore update")
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	skipIgnoreUpdate, _ := cmd.Flags().GetBool("no-gitignore-update")
+
+	destDir, err := resolveRestoreTarget(args[0])
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	bundle, err := os.Open(args[1])
+	if err != nil {
+		return fmt.Errorf("❌ Failed to open bundle: %w", err)
+	}
+	defer bundle.Close()
+
+	report, err := autocopy.RestoreBundle(bundle, destDir, autocopy.RestoreOptions{Force: force, DryRun: dryRun})
+	if errors.Is(err, autocopy.ErrRestoreConflicts) {
+		fmt.Printf("⚠️  Files in %s differ from the bundle:\n", destDir)
+		for _, file := range report.Conflicts {
+			fmt.Printf("  - %s\n", file)
+		}
+		fmt.Println("💡 Use --force to overwrite them")
+		return fmt.Errorf("❌ Restore cancelled: %d modified files", len(report.Conflicts))
+	}
+	if err != nil {
+		return fmt.Errorf("❌ Failed to restore bundle: %w", err)
+	}
+
+	if dryRun {
+		fmt.Printf("🔍 Dry run mode - files that would be restored to %s:\n", destDir)
+		for _, file := range report.Restored {
+			fmt.Printf("  - %s\n", file)
+		}
+		return nil
+	}
+
+	fmt.Printf("📦 Restored %d files into %s:\n", len(report.Restored), destDir)
+	for _, file := range report.Restored {
+		fmt.Printf("  ✅ %s\n", file)
+	}
+	if len(report.Conflicts) > 0 {
+		fmt.Printf("  ♻️  Overwrote %d modified files\n", len(report.Conflicts))
+	}
+	if len(report.Unchanged) > 0 {
+		fmt.Printf("  ℹ️  %d files were already up to date\n", len(report.Unchanged))
+	}
+
+	if !skipIgnoreUpdate {
+		if err := autocopy.UpdateIgnoreFile(destDir, "", report.Restored); err != nil {
+			fmt.Printf("⚠️  Failed to update .gitignore: %v\n", err)
+		}
+	}
+
+	return nil
+}
+
+// resolveRestoreTarget returns the directory target names: an existing
+// directory, or else the worktree of the branch target
+func resolveRestoreTarget(target string) (string, error) {
+	if info, err := os.Stat(target); err == nil {
+		if !info.IsDir() {
+			return "", fmt.Errorf("worktree is not a directory: %s", target)
+		}
+		return filepath.Abs(target)
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return "", fmt.Errorf("worktree not found: %s", target)
+	}
+	finder := worktree.NewFinder(repo)
+	finder.SetLayout(loadWorktreeLayout(repo))
+	worktreePath, exists, err := finder.FindWorktree(target)
+	if err != nil {
+		return "", fmt.Errorf("failed to find worktree: %w", err)
+	}
+	if !exists {
+		return "", fmt.Errorf("worktree not found: %s", target)
+	}
+	return worktreePath, nil
+}
@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/config"
+	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/internal/logger"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -15,6 +20,8 @@ var (
 	dryRun    bool
 	noColor   bool
 	configDir string
+	envName   string
+	traceCopy bool
 	// Version is set by build flags
 	Version = "dev"
 )
@@ -55,11 +62,45 @@ Examples:
 	},
 }
 
+// Exit codes returned by the hatcher binary
+const (
+	ExitCodeError       = 1 // General failure
+	ExitCodeWarning     = 2 // hch doctor --strict found warnings
+	ExitCodeSafetyAbort = 3 // Aborted by a safety limit such as maxTotalFiles or maxTotalBytes
+)
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
 	return rootCmd.Execute()
 }
 
+// exitStatusError ends a command with an exit code and no error message, for
+// commands whose exit status is their result
+type exitStatusError struct {
+	code int
+}
+
+func (e *exitStatusError) Error() string {
+	return fmt.Sprintf("exit status %d", e.code)
+}
+
+// ExitCode maps an error returned by Execute to the process exit code
+func ExitCode(err error) int {
+	var status *exitStatusError
+	if errors.As(err, &status) {
+		return status.code
+	}
+	if isSafetyAbort(err) {
+		return ExitCodeSafetyAbort
+	}
+	return ExitCodeError
+}
+
+// isSafetyAbort reports whether err stopped a copy at a safety limit
+func isSafetyAbort(err error) bool {
+	return errors.Is(err, autocopy.ErrTooManyFiles) || errors.Is(err, autocopy.ErrTransferTooLarge)
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 
@@ -69,6 +110,8 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&dryRun, "dry-run", "", false, "show what would be done without executing")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
 	rootCmd.PersistentFlags().StringVar(&configDir, "config-dir", "", "config directory path")
+	rootCmd.PersistentFlags().BoolVar(&traceCopy, "trace-copy", false, "log the decision made for every file auto-copy considers (turns on verbose output)")
+	rootCmd.PersistentFlags().StringVar(&envName, "env", "", "apply the overlay of this configured environment (overrides $"+config.EnvironmentVariable+")")
 
 	// Bind flags to viper
 	viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
@@ -96,8 +139,47 @@ func initConfig() {
 
 	viper.AutomaticEnv() // read in environment variables that match
 
+	// Every configuration load picks the environment up from the variable
+	if envName != "" {
+		os.Setenv(config.EnvironmentVariable, envName)
+	}
+
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil && verbose {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	applyRuntimeConfig()
+}
+
+// applyRuntimeConfig applies git settings from the hatcher
+// configuration, including the project's when run inside a repository
+func applyRuntimeConfig() {
+	projectPath := ""
+	if repo, err := git.NewRepository(); err == nil {
+		projectPath, _ = repo.GetRoot()
+	}
+
+	hatcherConfig, err := config.NewManager().LoadConfig(projectPath)
+	if err != nil {
+		if verbose {
+			fmt.Fprintln(os.Stderr, "Failed to load git and worktree settings:", err)
+		}
+		return
+	}
+
+	git.SetMaxConcurrent(hatcherConfig.Git.MaxConcurrent)
+}
+
+// loadWorktreeLayout returns where the configuration places worktrees and
+// how it names their directories. An unreadable configuration yields the
+// default layout, next to the repository.
+func loadWorktreeLayout(repo git.Repository) worktree.Layout {
+	projectPath, _ := repo.GetRoot()
+
+	hatcherConfig, err := config.NewManager().LoadConfig(projectPath)
+	if err != nil {
+		return worktree.Layout{}
+	}
+	return hatcherConfig.Worktree.Layout()
 }
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// selftestCmd represents the selftest command
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check that hatcher works end to end in this repository",
+	Long: `Run hatcher's worktree pipeline against the current repository without
+touching your worktrees: check the Git version and permissions, create a
+throwaway worktree in a temporary directory, plan an auto-copy into it as a
+dry run, check the worktree is discovered, and remove it again.
+
+The throwaway branch and worktree are removed even when a step fails.
+
+Examples:
+  hch selftest`,
+	Args: cobra.NoArgs,
+	RunE: runSelftest,
+}
+
+func init() {
+	rootCmd.AddCommand(selftestCmd)
+}
+
+// selftest holds the state of a self-test run so cleanup can undo exactly
+// what was created
+type selftest struct {
+	repo         git.Repository
+	root         string
+	tempDir      string
+	branch       string
+	worktreePath string
+	created      bool
+}
+
+// selftestStep is a single check of the self-test. run returns a short
+// result description, or a warning that does not stop the test.
+type selftestStep struct {
+	name string
+	run  func() (result string, warning bool, err error)
+}
+
+func runSelftest(cmd *cobra.Command, args []string) (err error) {
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+	root, err := repo.GetRoot()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to get repository root: %w", err)
+	}
+
+	test := &selftest{repo: repo, root: root}
+	fmt.Printf("🧪 Running hatcher self-test in %s\n", root)
+
+	defer func() {
+		if cleanupErr := test.cleanup(); cleanupErr != nil {
+			fmt.Printf("❌ Cleanup: %v\n", cleanupErr)
+			if err == nil {
+				err = fmt.Errorf("❌ Self-test cleanup failed: %w", cleanupErr)
+			}
+			return
+		}
+		fmt.Println("✅ Cleanup: throwaway worktree and branch removed")
+	}()
+
+	steps := []selftestStep{
+		{"Git version", test.checkGitVersion},
+		{"Worktree directory", test.checkWorktreeDir},
+		{"Create worktree", test.createWorktree},
+		{"Auto-copy dry run", test.planCopy},
+		{"Discovery", test.discoverWorktree},
+	}
+	for _, step := range steps {
+		result, warning, err := step.run()
+		if err != nil {
+			fmt.Printf("❌ %s: %v\n", step.name, err)
+			return fmt.Errorf("❌ Self-test failed at %s: %w", strings.ToLower(step.name), err)
+		}
+		if warning {
+			fmt.Printf("⚠️  %s: %s\n", step.name, result)
+		} else {
+			fmt.Printf("✅ %s: %s\n", step.name, result)
+		}
+	}
+
+	fmt.Println("🎉 Self-test passed")
+	return nil
+}
+
+// checkGitVersion reports the installed Git and the worktree features it
+// lacks
+func (s *selftest) checkGitVersion() (string, bool, error) {
+	version, err := s.repo.GitVersion()
+	if err != nil {
+		return "", false, err
+	}
+
+	unsupported := git.UnsupportedFeatures(version)
+	if len(unsupported) == 0 {
+		return fmt.Sprintf("Git %s", version), false, nil
+	}
+	names := make([]string, len(unsupported))
+	for i, feature := range unsupported {
+		names[i] = feature.Name
+	}
+	return fmt.Sprintf("Git %s is older than %s; unavailable: %s", version, git.MinimumVersion, strings.Join(names, ", ")), true, nil
+}
+
+// checkWorktreeDir checks that files can be created where hatcher puts new
+// worktrees
+func (s *selftest) checkWorktreeDir() (string, bool, error) {
+	dir := filepath.Dir(loadWorktreeLayout(s.repo).WorktreePath(s.root, s.repo.GetProjectName(), "selftest"))
+
+	file, err := os.CreateTemp(dir, ".hatcher-selftest-*")
+	if err != nil {
+		return "", false, fmt.Errorf("cannot create files in %s: %w", dir, err)
+	}
+	file.Close()
+	if err := os.Remove(file.Name()); err != nil {
+		return "", false, err
+	}
+	return fmt.Sprintf("%s is writable", dir), false, nil
+}
+
+// createWorktree creates a worktree for a new throwaway branch in a
+// temporary directory
+func (s *selftest) createWorktree() (string, bool, error) {
+	tempDir, err := os.MkdirTemp("", "hatcher-selftest-")
+	if err != nil {
+		return "", false, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	s.tempDir = tempDir
+
+	// The directory name is unique, so the branch cannot exist yet
+	s.branch = filepath.Base(tempDir)
+	s.worktreePath = filepath.Join(tempDir, "worktree")
+	if err := s.repo.CreateWorktree(s.worktreePath, s.branch, true); err != nil {
+		return "", false, err
+	}
+	s.created = true
+
+	return fmt.Sprintf("%s at %s", s.branch, s.worktreePath), false, nil
+}
+
+// planCopy plans the auto-copy into the throwaway worktree without copying
+func (s *selftest) planCopy() (string, bool, error) {
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(s.root)
+	if err != nil {
+		return "", false, err
+	}
+
+	copier := autocopy.NewAutoCopier(s.repo, autoCopyConfig, copyOptionsFromConfig(hatcherConfig))
+	estimate, err := copier.Estimate(s.root, s.worktreePath)
+	if err != nil {
+		return "", false, err
+	}
+	return fmt.Sprintf("would copy %s", estimate), false, nil
+}
+
+// discoverWorktree checks that hatcher finds the throwaway worktree by its
+// branch
+func (s *selftest) discoverWorktree() (string, bool, error) {
+	finder := worktree.NewFinder(s.repo)
+	finder.SetLayout(loadWorktreeLayout(s.repo))
+	path, found, err := finder.FindWorktree(s.branch)
+	if err != nil {
+		return "", false, err
+	}
+	if !found {
+		return "", false, fmt.Errorf("worktree for %s not found", s.branch)
+	}
+
+	foundInfo, err := os.Stat(path)
+	if err != nil {
+		return "", false, err
+	}
+	createdInfo, err := os.Stat(s.worktreePath)
+	if err != nil {
+		return "", false, err
+	}
+	if !os.SameFile(foundInfo, createdInfo) {
+		return "", false, fmt.Errorf("found %s instead of %s", path, s.worktreePath)
+	}
+	return fmt.Sprintf("found %s", s.branch), false, nil
+}
+
+// cleanup removes the throwaway worktree, its branch and the temporary
+// directory, whichever were created
+func (s *selftest) cleanup() error {
+	var errs []error
+	if s.created {
+		if err := s.repo.RemoveWorktree(s.worktreePath, true); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if s.branch != "" {
+		if exists, err := s.repo.BranchExists(s.branch); err == nil && exists {
+			if err := s.repo.RemoveBranch(s.branch, true); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if s.tempDir != "" {
+		if err := os.RemoveAll(s.tempDir); err != nil {
+			errs = append(errs, err)
+		}
+		// Drop the administrative files of a worktree git failed to remove
+		if err := s.repo.PruneWorktrees(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelftestCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "selftest-project")
+	cliHelper := testutil.NewCLITestHelper(t)
+
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.SetEnv("TMPDIR", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	// The throwaway branch is named after its temporary directory
+	branchPattern := regexp.MustCompile(`hatcher-selftest-\S+`)
+	assertCleanedUp := func(t *testing.T, stdout string) {
+		branch := branchPattern.FindString(stdout)
+		require.NotEmpty(t, branch, stdout)
+		assert.False(t, testRepo.BranchExists(branch))
+		assert.Len(t, testRepo.ListWorktrees(), 1)
+		assert.NoDirExists(t, filepath.Join(os.Getenv("TMPDIR"), branch))
+	}
+
+	t.Run("passes and cleans up", func(t *testing.T) {
+		testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+			{"path": ".cursorrules", "directory": false}
+		]}}`)
+		testRepo.CreateFile(".cursorrules", "# Cursor rules")
+
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "selftest"))
+		})
+
+		assert.Contains(t, stdout, "✅ Create worktree")
+		assert.Contains(t, stdout, "would copy 1 files")
+		assert.Contains(t, stdout, "✅ Discovery")
+		assert.Contains(t, stdout, "✅ Cleanup")
+		assert.Contains(t, stdout, "Self-test passed")
+		assertCleanedUp(t, stdout)
+	})
+
+	t.Run("cleans up after a failed step", func(t *testing.T) {
+		testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [{"path": "../outside"}]}}`)
+
+		var err error
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			err = cliHelper.ExecuteCommand(rootCmd, "selftest")
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "auto-copy dry run")
+		assert.Contains(t, stdout, "✅ Create worktree")
+		assert.Contains(t, stdout, "✅ Cleanup")
+		assertCleanedUp(t, stdout)
+	})
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	editorpkg "github.com/keisukeshimizu/hatcher/internal/editor"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var clearEditor bool
+
+// setEditorCmd represents the set-editor command
+var setEditorCmd = &cobra.Command{
+	Use:   "set-editor <branch-name> [editor]",
+	Short: "Pin the editor a worktree opens in",
+	Long: `Pin the editor a worktree always opens in, e.g. VS Code for a docs
+worktree while code worktrees use Cursor.
+
+The pin is stored in .hatcher/worktrees.json in the main repository and takes
+precedence over editor.order in the configuration. 'hch move --editor' still
+overrides it.
+
+Examples:
+  hch set-editor docs/guide code        # Open docs/guide in VS Code
+  hch set-editor docs/guide             # Show the pinned editor
+  hch set-editor docs/guide --clear     # Follow the configuration again`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSetEditor,
+}
+
+func init() {
+	rootCmd.AddCommand(setEditorCmd)
+
+	setEditorCmd.Flags().BoolVar(&clearEditor, "clear", false, "remove the pinned editor")
+}
+
+func runSetEditor(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	editorCommand := ""
+	if len(args) > 1 {
+		editorCommand = strings.TrimSpace(args[1])
+	}
+
+	if clearEditor && editorCommand != "" {
+		return fmt.Errorf("❌ Cannot set and clear the editor at the same time")
+	}
+
+	// Only known, installed editors can be pinned
+	if editorCommand != "" {
+		detector := editorpkg.NewDetector()
+		ed := detector.GetEditorByName(editorCommand)
+		if ed == nil {
+			return fmt.Errorf("❌ Unknown editor %q (available: %s)", editorCommand, strings.Join(detector.KnownCommands(), ", "))
+		}
+		if !ed.IsInstalled() {
+			return fmt.Errorf("❌ Editor '%s' is not installed", editorCommand)
+		}
+	}
+
+	// Initialize Git repository
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	// Make sure the worktree exists before annotating it
+	finder := worktree.NewFinder(repo)
+	finder.SetLayout(loadWorktreeLayout(repo))
+	if _, exists, err := finder.FindWorktree(branchName); err != nil {
+		return fmt.Errorf("❌ Failed to find worktree: %w", err)
+	} else if !exists {
+		return fmt.Errorf("❌ Worktree for branch '%s' not found", branchName)
+	}
+
+	store, err := worktree.NewMetadataStore(repo)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to open worktree metadata: %w", err)
+	}
+
+	// Without an editor or --clear, just show the pinned editor
+	if editorCommand == "" && !clearEditor {
+		meta, err := store.Get(branchName)
+		if err != nil {
+			return fmt.Errorf("❌ Failed to read worktree metadata: %w", err)
+		}
+		if meta.Editor == "" {
+			fmt.Printf("🎯 %s follows the configured editor order\n", branchName)
+		} else {
+			fmt.Printf("🎯 %s opens in %s\n", branchName, meta.Editor)
+		}
+		return nil
+	}
+
+	if err := store.SetEditor(branchName, editorCommand); err != nil {
+		return fmt.Errorf("❌ Failed to update worktree metadata: %w", err)
+	}
+
+	if clearEditor {
+		fmt.Printf("🎯 Cleared pinned editor for %s\n", branchName)
+	} else {
+		fmt.Printf("🎯 %s now opens in %s\n", branchName, editorCommand)
+	}
+
+	return nil
+}
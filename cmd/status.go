@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/config"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status <branch-name>",
+	Short: "Summarize the worktree of a branch",
+	Long: `Show the path, checked out commit, commits ahead of and behind the
+default branch, uncommitted changes and the auto-copied files present in the
+worktree of a branch.
+
+Examples:
+  hch status feature/user-auth
+  hch status feature/user-auth --format json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorktreeBranches(false),
+	RunE:              runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringP("format", "f", outputText, "Output format (text, json)")
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if err := validateOutputFormat(format); err != nil {
+		return fmt.Errorf("❌ Invalid --format: %w", err)
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	reporter := worktree.NewStatusReporter(repo)
+	reporter.SetLayout(loadWorktreeLayout(repo))
+	report, err := reporter.Status(args[0], autoCopyPaths(repo))
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+
+	if format == outputJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("❌ Failed to write JSON output: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printStatusReport(report)
+	return nil
+}
+
+// autoCopyPaths returns the paths the auto-copy configuration copies into
+// worktrees. Without a usable configuration there are none.
+func autoCopyPaths(repo git.Repository) []string {
+	projectPath, _ := repo.GetRoot()
+	hatcherConfig, err := config.NewManager().LoadConfig(projectPath)
+	if err != nil {
+		return nil
+	}
+
+	paths := append([]string(nil), hatcherConfig.AutoCopy.Files...)
+	for _, item := range hatcherConfig.AutoCopy.Items {
+		paths = append(paths, strings.TrimSuffix(item.Path, "/"))
+	}
+	return paths
+}
+
+// printStatusReport prints report for humans
+func printStatusReport(report *worktree.StatusReport) {
+	fmt.Printf("🌿 %s\n", report.Branch)
+	fmt.Printf("📁 %s\n", report.Path)
+	fmt.Printf("🔖 HEAD %s\n", report.Head)
+
+	if report.Base != "" {
+		fmt.Printf("🔀 %d ahead, %d behind %s\n", report.Ahead, report.Behind, report.Base)
+	}
+
+	if report.Dirty {
+		fmt.Println("⚠️  Uncommitted changes")
+	} else {
+		fmt.Println("✅ Clean")
+	}
+
+	if len(report.AutoCopy) > 0 {
+		var missing []string
+		for _, item := range report.AutoCopy {
+			if !item.Present {
+				missing = append(missing, item.Path)
+			}
+		}
+		present := len(report.AutoCopy) - len(missing)
+		if len(missing) == 0 {
+			fmt.Printf("📋 Auto-copied files: %d/%d present\n", present, len(report.AutoCopy))
+		} else {
+			fmt.Printf("📋 Auto-copied files: %d/%d present (missing: %s)\n", present, len(report.AutoCopy), strings.Join(missing, ", "))
+		}
+	}
+}
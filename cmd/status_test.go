@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCommand(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "status-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".hatcher-auto-copy.json", `{"version": 2, "items": [{"path": ".cursorrules"}, {"path": ".ai/"}]}`)
+	testRepo.CommitAll("Add auto-copy config")
+
+	worktreePath := filepath.Join(testRepo.TempDir, "status-project-feature-status")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/status", true))
+	require.NoError(t, os.MkdirAll(filepath.Join(worktreePath, ".ai"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(testRepo.RepoDir, ".git", "info", "exclude"), []byte(".ai/\n"), 0644))
+
+	cliHelper := testutil.NewCLITestHelper(t)
+	mockEnv := testutil.NewMockEnvironment(t)
+	defer mockEnv.Cleanup()
+	mockEnv.SetEnv("HOME", t.TempDir())
+	mockEnv.ChangeDir(testRepo.RepoDir)
+
+	defer statusCmd.Flags().Set("format", outputText)
+
+	t.Run("clean worktree", func(t *testing.T) {
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "status", "feature/status"))
+		})
+
+		assert.Contains(t, stdout, "🌿 feature/status")
+		assert.Contains(t, stdout, worktreePath)
+		assert.Contains(t, stdout, "0 ahead, 0 behind")
+		assert.Contains(t, stdout, "✅ Clean")
+		assert.Contains(t, stdout, "1/2 present (missing: .cursorrules)")
+	})
+
+	t.Run("dirty worktree as JSON", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "README.md"), []byte("changed"), 0644))
+		defer os.WriteFile(filepath.Join(worktreePath, "README.md"), []byte("# Test Project"), 0644)
+
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			require.NoError(t, cliHelper.ExecuteCommand(rootCmd, "status", "feature/status", "--format", "json"))
+		})
+
+		var report worktree.StatusReport
+		require.NoError(t, json.Unmarshal([]byte(stdout), &report), stdout)
+		assert.Equal(t, "feature/status", report.Branch)
+		assert.True(t, report.Dirty)
+		assert.True(t, report.IsHatcherManaged)
+		assert.Len(t, report.AutoCopy, 2)
+	})
+
+	t.Run("branch without a worktree", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "status", "feature/missing", "--format", "text")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worktree not found for branch 'feature/missing'")
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		err := cliHelper.ExecuteCommand(rootCmd, "status", "feature/status", "--format", "yaml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Invalid --format")
+	})
+}
@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/config"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync [branch-name]",
+	Short: "Copy configured files from the main worktree into worktrees again",
+	Long: `Copy the auto-copy files from the main worktree into existing worktrees.
+
+Without a branch name every Hatcher-managed worktree is synced. Each worktree
+keeps a manifest of the copied files in its git directory; with --changed-only
+only files whose source changed since the last sync are copied, and manifest
+entries for files deleted from the source are dropped. With
+--propagate-deletions the copies of those files are deleted too, after
+confirmation; copies modified in the worktree are kept unless --force is given.
+
+The manifest location can be moved into the worktree with
+--copy-manifest-path or autoCopy.manifestPath; it is never overwritten by
+copies.
+
+With --backup, files that are overwritten with different content are kept
+as <name>.hatcher.bak; --prune-backups deletes those backups first.
+
+Examples:
+  hch sync                          # Sync all managed worktrees
+  hch sync feature/user-auth        # Sync a single worktree
+  hch sync --changed-only           # Copy only files changed since the last sync
+  hch sync --propagate-deletions    # Also delete copies of removed files
+  hch sync --backup                 # Keep overwritten files as backups
+  hch sync --integrity mirror       # Verified copies that mirror the source
+  hch sync --max-bytes 104857600    # Abort a worktree's sync above 100 MB`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSync,
+}
+
+func init() {
+	rootCmd.AddCommand(syncCmd)
+
+	syncCmd.Flags().Bool("changed-only", false, "copy only files that changed since the last sync")
+	syncCmd.Flags().Bool("propagate-deletions", false, "delete copies of files removed from the source")
+	syncCmd.Flags().Bool("force", false, "with --propagate-deletions, also delete copies modified in the worktree; with preserveSymlinks, replace files with links and links with files")
+	syncCmd.Flags().BoolP("yes", "y", false, "delete without confirmation")
+	syncCmd.Flags().Bool("backup", false, "keep files overwritten with different content as <name>"+autocopy.BackupSuffix)
+	syncCmd.Flags().Bool("prune-backups", false, "delete backups left by earlier syncs before syncing")
+	syncCmd.Flags().String("integrity", "", "copy option preset: fast, safe (atomic writes, sha256 verification, backups) or mirror (safe plus source timestamps and --propagate-deletions)")
+	syncCmd.Flags().Int64("max-bytes", 0, "abort syncing a worktree when the matched files add up to more bytes than this (default from config, or unlimited)")
+	syncCmd.Flags().Int64("defer-verify-min-size", 0, "with verified copies, verify files of at least this many bytes in a separate phase (default from config, or inline)")
+	syncCmd.Flags().Int("max-retries", 0, "retry copies failing with a transient error this many times (default from config, or 0)")
+	syncCmd.Flags().Duration("retry-backoff", 0, "delay before the first retry, doubled for each further one (default from config, or 100ms)")
+	syncCmd.Flags().String("copy-manifest-path", "", "read and write the copy manifest at this path in each worktree (default from config, or the worktree's git directory)")
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	changedOnly, _ := cmd.Flags().GetBool("changed-only")
+	propagateDeletions, _ := cmd.Flags().GetBool("propagate-deletions")
+	syncForce, _ := cmd.Flags().GetBool("force")
+	syncYes, _ := cmd.Flags().GetBool("yes")
+	syncBackup, _ := cmd.Flags().GetBool("backup")
+	pruneBackups, _ := cmd.Flags().GetBool("prune-backups")
+	manifestFlag, _ := cmd.Flags().GetString("copy-manifest-path")
+	integrity, _ := cmd.Flags().GetString("integrity")
+	maxBytes, _ := cmd.Flags().GetInt64("max-bytes")
+	deferMin, _ := cmd.Flags().GetInt64("defer-verify-min-size")
+	maxRetries, _ := cmd.Flags().GetInt("max-retries")
+	retryBackoff, _ := cmd.Flags().GetDuration("retry-backoff")
+	if err := autocopy.ValidateManifestPath(manifestFlag); err != nil {
+		return fmt.Errorf("❌ Invalid --copy-manifest-path: %w", err)
+	}
+
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	worktrees, err := repo.ListWorktreeEntries()
+	if err != nil {
+		return fmt.Errorf("❌ Failed to list worktrees: %w", err)
+	}
+	if len(worktrees) == 0 {
+		return fmt.Errorf("❌ No worktrees found")
+	}
+
+	// Git always lists the main worktree first; it is the source of the copies
+	srcRoot := worktrees[0].Path
+	targets, err := syncTargets(repo, srcRoot, args)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println("ℹ️  No worktrees to sync")
+		return nil
+	}
+
+	hatcherConfig, autoCopyConfig, err := loadAutoCopyConfig(srcRoot)
+	if err != nil {
+		return fmt.Errorf("❌ %w", err)
+	}
+	copyOptions := copyOptionsFromConfig(hatcherConfig)
+	var presetOptions autocopy.SyncOptions
+	if err := autocopy.ApplyIntegrityPreset(integrity, &copyOptions, &presetOptions); err != nil {
+		return fmt.Errorf("❌ Invalid --integrity: %w", err)
+	}
+	copyOptions.Backup = copyOptions.Backup || syncBackup
+	if maxBytes != 0 {
+		copyOptions.MaxTotalBytes = maxBytes
+	}
+	applyRetryFlags(cmd, &copyOptions, deferMin, maxRetries, retryBackoff)
+	customManifest := customManifestPath(manifestFlag, hatcherConfig)
+	if customManifest != "" {
+		copyOptions.SkipPaths = []string{customManifest}
+	}
+	copier := autocopy.NewAutoCopier(repo, autoCopyConfig, copyOptions)
+
+	for _, target := range targets {
+		gitDir, err := repo.GitDir(target.Path)
+		if err != nil {
+			return fmt.Errorf("❌ Failed to sync %s: %w", target.Branch, err)
+		}
+		manifestPath := autocopy.ResolveManifestPath(target.Path, gitDir, customManifest)
+
+		if pruneBackups {
+			pruned, err := autocopy.PruneBackups(target.Path, manifestPath)
+			if err != nil {
+				return fmt.Errorf("❌ Failed to prune backups in %s: %w", target.Branch, err)
+			}
+			if len(pruned) > 0 {
+				fmt.Printf("🧹 %s: pruned %d backups\n", target.Branch, len(pruned))
+			}
+		}
+
+		options := autocopy.SyncOptions{
+			ChangedOnly:        changedOnly,
+			PropagateDeletions: propagateDeletions || presetOptions.PropagateDeletions,
+			Force:              syncForce,
+		}
+		if !syncYes {
+			branch := target.Branch
+			options.ConfirmDeletions = func(files []string) bool {
+				fmt.Printf("🗑️  Files removed from the source, still copied in %s:\n", branch)
+				for _, file := range files {
+					fmt.Printf("  - %s\n", file)
+				}
+				return confirm(fmt.Sprintf("Delete %d files from %s?", len(files), branch))
+			}
+		}
+
+		report, err := copier.Sync(srcRoot, target.Path, manifestPath, options)
+		if err != nil {
+			return fmt.Errorf("❌ Failed to sync %s: %w", target.Branch, err)
+		}
+
+		fmt.Printf("🔄 %s: %s\n", target.Branch, report)
+		if verbose {
+			for _, file := range report.Files {
+				fmt.Printf("  ✅ %s\n", file)
+			}
+			for _, file := range report.Deleted {
+				fmt.Printf("  🗑️  %s\n", file)
+			}
+		}
+		for _, file := range report.Kept {
+			fmt.Printf("  ⚠️  Kept %s: modified in the worktree (use --force to delete)\n", file)
+		}
+	}
+
+	return nil
+}
+
+// syncTargets returns the worktree of the given branch, or every managed
+// worktree other than the source at srcRoot
+func syncTargets(repo git.Repository, srcRoot string, args []string) ([]worktree.WorktreeInfo, error) {
+	lister := worktree.NewLister(repo)
+	lister.SetLayout(loadWorktreeLayout(repo))
+	result, err := lister.ListWorktrees(worktree.ListOptions{ShowAll: len(args) > 0})
+	if err != nil {
+		return nil, fmt.Errorf("❌ Failed to list worktrees: %w", err)
+	}
+
+	var targets []worktree.WorktreeInfo
+	for _, wt := range result.Worktrees {
+		if wt.Path == srcRoot {
+			continue
+		}
+		if len(args) > 0 && wt.Branch != args[0] {
+			continue
+		}
+		targets = append(targets, wt)
+	}
+
+	if len(args) > 0 && len(targets) == 0 {
+		return nil, fmt.Errorf("❌ No worktree found for branch %s", args[0])
+	}
+
+	return targets, nil
+}
+
+// recordManifest writes the copy manifest of a freshly created worktree, at
+// customManifest inside it when set
+func recordManifest(repo git.Repository, autoCopyConfig *autocopy.AutoCopyConfig, options autocopy.AutoCopierOptions, srcRoot, worktreePath, customManifest string) error {
+	gitDir, err := repo.GitDir(worktreePath)
+	if err != nil {
+		return err
+	}
+
+	copier := autocopy.NewAutoCopier(repo, autoCopyConfig, options)
+	return copier.RecordManifest(srcRoot, worktreePath, autocopy.ResolveManifestPath(worktreePath, gitDir, customManifest))
+}
+
+// customManifestPath returns the manifest location given with
+// --copy-manifest-path, or autoCopy.manifestPath from the configuration. An
+// empty result keeps the manifest in the worktree's git directory.
+func customManifestPath(flagValue string, hatcherConfig *config.Config) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if hatcherConfig == nil {
+		return ""
+	}
+	return hatcherConfig.AutoCopy.ManifestPath
+}
+
+// ignoreEntries returns the copied files to ignore, plus a manifest kept
+// inside the worktree so it is never committed
+func ignoreEntries(copied []string, customManifest string) []string {
+	if customManifest == "" {
+		return copied
+	}
+	return append(append([]string(nil), copied...), filepath.ToSlash(filepath.Clean(customManifest)))
+}
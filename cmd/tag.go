@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
+	"github.com/spf13/cobra"
+)
+
+var removeTags bool
+
+// tagCmd represents the tag command
+var tagCmd = &cobra.Command{
+	Use:   "tag <branch-name> <tag>...",
+	Short: "Add or remove tags on a worktree",
+	Long: `Annotate a worktree with free-form tags such as ticket numbers or review state.
+
+Tags are stored in .hatcher/worktrees.json in the main repository and shown by
+'hch list'. Use 'hch list --tag <tag>' to show only worktrees with a given tag.
+
+Examples:
+  hch tag feature/login JIRA-123 review   # Add tags
+  hch tag feature/login --remove review   # Remove a tag`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runTag,
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+
+	tagCmd.Flags().BoolVarP(&removeTags, "remove", "r", false, "remove the given tags instead of adding them")
+}
+
+func runTag(cmd *cobra.Command, args []string) error {
+	branchName := args[0]
+	tags := args[1:]
+
+	// Initialize Git repository
+	repo, err := git.NewRepository()
+	if err != nil {
+		return fmt.Errorf("❌ Not in a Git repository: %w", err)
+	}
+
+	// Make sure the worktree exists before annotating it
+	finder := worktree.NewFinder(repo)
+	finder.SetLayout(loadWorktreeLayout(repo))
+	if _, exists, err := finder.FindWorktree(branchName); err != nil {
+		return fmt.Errorf("❌ Failed to find worktree: %w", err)
+	} else if !exists {
+		return fmt.Errorf("❌ Worktree for branch '%s' not found", branchName)
+	}
+
+	store, err := worktree.NewMetadataStore(repo)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to open worktree metadata: %w", err)
+	}
+
+	if removeTags {
+		err = store.RemoveTags(branchName, tags...)
+	} else {
+		err = store.AddTags(branchName, tags...)
+	}
+	if err != nil {
+		return fmt.Errorf("❌ Failed to update tags: %w", err)
+	}
+
+	meta, err := store.Get(branchName)
+	if err != nil {
+		return fmt.Errorf("❌ Failed to read tags: %w", err)
+	}
+
+	if len(meta.Tags) == 0 {
+		fmt.Printf("🏷️  %s has no tags\n", branchName)
+	} else {
+		fmt.Printf("🏷️  %s: %s\n", branchName, strings.Join(meta.Tags, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// destFile is the file a copy writes to. Atomic writes go to a temporary file
+// next to the destination that Commit renames into place, so a failed or
+// interrupted copy never leaves a partial file behind.
+type destFile struct {
+	*os.File
+	path      string
+	atomic    bool
+	committed bool
+}
+
+// createDestFile creates the destination file at path
+func createDestFile(path string, atomic bool) (*destFile, error) {
+	if !atomic {
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		return &destFile{File: file, path: path}, nil
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".hatcher-tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	return &destFile{File: file, path: path, atomic: true}, nil
+}
+
+// Commit closes the file and moves an atomic write into place
+func (d *destFile) Commit() error {
+	d.committed = true
+	if err := d.File.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", d.path, err)
+	}
+	if d.atomic {
+		if err := os.Rename(d.File.Name(), d.path); err != nil {
+			os.Remove(d.File.Name())
+			return fmt.Errorf("failed to replace %s: %w", d.path, err)
+		}
+	}
+	return nil
+}
+
+// Discard closes a file that was not committed and removes the temporary file
+// of an atomic write
+func (d *destFile) Discard() {
+	if d.committed {
+		return
+	}
+	d.File.Close()
+	if d.atomic {
+		os.Remove(d.File.Name())
+	}
+}
+
+// preserveModTime sets the modification time of destPath to that of
+// sourcePath
+func preserveModTime(sourcePath, destPath string) error {
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", sourcePath, err)
+	}
+	if err := os.Chtimes(destPath, info.ModTime(), info.ModTime()); err != nil {
+		return fmt.Errorf("failed to set timestamps of %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// verifyCopy compares the sha256 checksums of sourcePath and its copy
+func verifyCopy(sourcePath, destPath string) error {
+	sourceChecksum, err := fileChecksum(sourcePath)
+	if err != nil {
+		return err
+	}
+	destChecksum, err := fileChecksum(destPath)
+	if err != nil {
+		return err
+	}
+	if sourceChecksum != destChecksum {
+		return fmt.Errorf("integrity verification failed for %s: checksums don't match", destPath)
+	}
+	return nil
+}
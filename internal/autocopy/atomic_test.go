@@ -0,0 +1,96 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestFile(t *testing.T) {
+	t.Run("atomic write is invisible until committed", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "CLAUDE.md")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+		file, err := createDestFile(path, true)
+		require.NoError(t, err)
+		_, err = file.WriteString("new")
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+
+		require.NoError(t, file.Commit())
+		content, err = os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(content))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("discarded atomic write leaves the destination untouched", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "CLAUDE.md")
+		require.NoError(t, os.WriteFile(path, []byte("old"), 0644))
+
+		file, err := createDestFile(path, true)
+		require.NoError(t, err)
+		_, err = file.WriteString("partial")
+		require.NoError(t, err)
+		file.Discard()
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
+
+func TestCopyIntegrityOptions(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "integrity-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	past := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(filepath.Join(testRepo.RepoDir, "CLAUDE.md"), past, past))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true}},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("mirror parallel=%t", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"), []byte("local"), 0644))
+
+			options := AutoCopierOptions{UseParallel: parallel}
+			require.NoError(t, ApplyIntegrityPreset(IntegrityMirror, &options, nil))
+			_, err := NewAutoCopier(repo, config, options).Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+
+			content, err := os.ReadFile(filepath.Join(destDir, "CLAUDE.md"))
+			require.NoError(t, err)
+			assert.Equal(t, "rules", string(content))
+			assert.FileExists(t, BackupPath(filepath.Join(destDir, "CLAUDE.md")))
+
+			info, err := os.Stat(filepath.Join(destDir, "CLAUDE.md"))
+			require.NoError(t, err)
+			assert.True(t, info.ModTime().Equal(past), "got %s", info.ModTime())
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package autocopy
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// BackupSuffix is appended to the name of a file saved before it was
+// overwritten by a copy
+const BackupSuffix = ".hatcher.bak"
+
+// BackupPath returns where the backup of the file at path is stored
+func BackupPath(path string) string {
+	return path + BackupSuffix
+}
+
+// backupFile renames the existing file at destPath to its backup path when
+// copying sourcePath over it would change its content. An earlier backup of
+// the same file is replaced. Missing files and non-regular files are left
+// alone.
+func backupFile(sourcePath, destPath string, provenance bool) error {
+	info, err := os.Lstat(destPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat %s: %w", destPath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil
+	}
+
+	changed, err := contentChanged(sourcePath, destPath, info, provenance)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := os.Rename(destPath, BackupPath(destPath)); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// contentChanged reports whether copying sourcePath would write different
+// content than the file at destPath has. With provenance, the header the
+// destination got when it was copied is left out of the comparison, since it
+// records the date of that copy.
+func contentChanged(sourcePath, destPath string, destInfo os.FileInfo, provenance bool) (bool, error) {
+	if _, ok := provenanceSyntax[strings.ToLower(filepath.Ext(sourcePath))]; provenance && ok {
+		content, err := os.ReadFile(sourcePath)
+		if err != nil {
+			return false, fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+		}
+		existing, err := os.ReadFile(destPath)
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", destPath, err)
+		}
+		return !bytes.Equal(content, withoutProvenanceHeader(existing, sourcePath)), nil
+	}
+
+	sourceInfo, err := os.Stat(sourcePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", sourcePath, err)
+	}
+	if sourceInfo.Size() != destInfo.Size() {
+		return true, nil
+	}
+
+	sourceChecksum, err := fileChecksum(sourcePath)
+	if err != nil {
+		return false, err
+	}
+	destChecksum, err := fileChecksum(destPath)
+	if err != nil {
+		return false, err
+	}
+	return sourceChecksum != destChecksum, nil
+}
+
+// PruneBackups deletes the backups of the files listed in the manifest at
+// manifestPath from destDir and returns them relative to destDir
+func PruneBackups(destDir, manifestPath string) ([]string, error) {
+	manifest, err := LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for rel := range manifest.Files {
+		backup := BackupPath(rel)
+		err := os.Remove(filepath.Join(destDir, filepath.FromSlash(backup)))
+		if err == nil {
+			pruned = append(pruned, backup)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to delete backup %s: %w", backup, err)
+		}
+	}
+
+	sort.Strings(pruned)
+	return pruned, nil
+}
@@ -0,0 +1,194 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupFile(t *testing.T) {
+	write := func(t *testing.T, path, content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	t.Run("changed content is backed up", func(t *testing.T) {
+		dir := t.TempDir()
+		source, dest := filepath.Join(dir, "source.txt"), filepath.Join(dir, "dest.txt")
+		write(t, source, "new")
+		write(t, dest, "old")
+
+		require.NoError(t, backupFile(source, dest, false))
+		assert.NoFileExists(t, dest)
+		content, err := os.ReadFile(BackupPath(dest))
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+	})
+
+	t.Run("same size but different content is backed up", func(t *testing.T) {
+		dir := t.TempDir()
+		source, dest := filepath.Join(dir, "source.txt"), filepath.Join(dir, "dest.txt")
+		write(t, source, "abc")
+		write(t, dest, "xyz")
+
+		require.NoError(t, backupFile(source, dest, false))
+		assert.FileExists(t, BackupPath(dest))
+	})
+
+	t.Run("identical content is not backed up", func(t *testing.T) {
+		dir := t.TempDir()
+		source, dest := filepath.Join(dir, "source.txt"), filepath.Join(dir, "dest.txt")
+		write(t, source, "same")
+		write(t, dest, "same")
+
+		require.NoError(t, backupFile(source, dest, false))
+		assert.FileExists(t, dest)
+		assert.NoFileExists(t, BackupPath(dest))
+	})
+
+	t.Run("identical annotated content is not backed up", func(t *testing.T) {
+		dir := t.TempDir()
+		source, dest := filepath.Join(dir, "rules.md"), filepath.Join(dir, "copy.md")
+		write(t, source, "# Rules\n")
+		header, ok := provenanceHeader(source, time.Now())
+		require.True(t, ok)
+		write(t, dest, header+"# Rules\n")
+
+		require.NoError(t, backupFile(source, dest, true))
+		assert.NoFileExists(t, BackupPath(dest))
+	})
+
+	t.Run("annotated content copied on an earlier day is not backed up", func(t *testing.T) {
+		dir := t.TempDir()
+		source, dest := filepath.Join(dir, "tool.sh"), filepath.Join(dir, "copy.sh")
+		write(t, source, "#!/bin/sh\necho hi\n")
+		header, ok := provenanceHeader(source, time.Now().AddDate(0, 0, -3))
+		require.True(t, ok)
+		write(t, dest, "#!/bin/sh\n"+header+"echo hi\n")
+
+		require.NoError(t, backupFile(source, dest, true))
+		assert.NoFileExists(t, BackupPath(dest))
+	})
+
+	t.Run("changed annotated content is backed up", func(t *testing.T) {
+		dir := t.TempDir()
+		source, dest := filepath.Join(dir, "rules.md"), filepath.Join(dir, "copy.md")
+		write(t, source, "# New rules\n")
+		header, ok := provenanceHeader(source, time.Now().AddDate(0, 0, -3))
+		require.True(t, ok)
+		write(t, dest, header+"# Rules\n")
+
+		require.NoError(t, backupFile(source, dest, true))
+		assert.FileExists(t, BackupPath(dest))
+	})
+
+	t.Run("missing destination", func(t *testing.T) {
+		dir := t.TempDir()
+		source := filepath.Join(dir, "source.txt")
+		write(t, source, "new")
+
+		require.NoError(t, backupFile(source, filepath.Join(dir, "missing.txt"), false))
+		assert.NoFileExists(t, BackupPath(filepath.Join(dir, "missing.txt")))
+	})
+}
+
+func TestCopyWithBackup(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "backup-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "new rules")
+	testRepo.CreateFile(".ai/prompts.txt", "prompts")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"), []byte("local rules"), 0644))
+			require.NoError(t, os.MkdirAll(filepath.Join(destDir, ".ai"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, ".ai", "prompts.txt"), []byte("prompts"), 0644))
+
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel, Backup: true})
+			_, err := copier.Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+
+			backup, err := os.ReadFile(BackupPath(filepath.Join(destDir, "CLAUDE.md")))
+			require.NoError(t, err)
+			assert.Equal(t, "local rules", string(backup))
+
+			content, err := os.ReadFile(filepath.Join(destDir, "CLAUDE.md"))
+			require.NoError(t, err)
+			assert.Equal(t, "new rules", string(content))
+
+			// Unchanged files are overwritten without a backup
+			assert.NoFileExists(t, BackupPath(filepath.Join(destDir, ".ai", "prompts.txt")))
+		})
+	}
+}
+
+func TestCopyWithBackupAndProvenance(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "backup-provenance-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "# Rules\n")
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true}},
+	}
+	source := filepath.Join(testRepo.RepoDir, "CLAUDE.md")
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			// The destination was copied, with provenance, on an earlier day
+			destDir := t.TempDir()
+			dest := filepath.Join(destDir, "CLAUDE.md")
+			earlier, ok := provenanceHeader(source, time.Now().AddDate(0, 0, -1))
+			require.True(t, ok)
+			require.NoError(t, os.WriteFile(dest, []byte(earlier+"# Rules\n"), 0644))
+
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel, Backup: true, AddProvenanceHeader: true})
+			_, err := copier.Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+
+			assert.NoFileExists(t, BackupPath(dest))
+			content, err := os.ReadFile(dest)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "# Rules\n")
+		})
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	destDir := t.TempDir()
+	manifestPath := filepath.Join(t.TempDir(), ManifestFile)
+
+	manifest := &Manifest{Files: map[string]ManifestEntry{
+		"CLAUDE.md":       {Source: "CLAUDE.md"},
+		".ai/prompts.txt": {Source: ".ai/prompts.txt"},
+	}}
+	require.NoError(t, manifest.Save(manifestPath))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(destDir, ".ai"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"+BackupSuffix), []byte("old"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(destDir, "unrelated"+BackupSuffix), []byte("keep"), 0644))
+
+	pruned, err := PruneBackups(destDir, manifestPath)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CLAUDE.md" + BackupSuffix}, pruned)
+	assert.NoFileExists(t, filepath.Join(destDir, "CLAUDE.md"+BackupSuffix))
+	assert.FileExists(t, filepath.Join(destDir, "unrelated"+BackupSuffix))
+}
@@ -0,0 +1,337 @@
+package autocopy
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BundleManifestFile is the first entry of a copy bundle, a Manifest of the
+// bundled files keyed by their path in the bundle
+const BundleManifestFile = ".hatcher-bundle.json"
+
+// ErrRestoreConflicts is returned when restoring a bundle would overwrite
+// files that differ from the bundled ones
+var ErrRestoreConflicts = errors.New("restore would overwrite modified files")
+
+// RestoreOptions controls RestoreBundle
+type RestoreOptions struct {
+	Force  bool // Overwrite files that differ from the bundled ones
+	DryRun bool // Verify the bundle and report without writing anything
+}
+
+// RestoreReport summarizes a restore
+type RestoreReport struct {
+	Restored  []string // Files written, including overwritten conflicts
+	Unchanged []string // Files that already had the bundled content
+	Conflicts []string // Existing files that differ from the bundled ones
+}
+
+// bundleFile is a file selected for a bundle
+type bundleFile struct {
+	name string // Path in the bundle, relative to the worktree
+	path string // Source path
+	info os.FileInfo
+}
+
+// WriteBundle writes the files a copy from sourceDir would write to w as a
+// gzip-compressed tar archive, under their paths relative to a worktree, so
+// the copy set can be moved to another machine. The archive starts with a
+// manifest of the files' checksums that RestoreBundle verifies. It returns
+// the archived files, sorted.
+func (ac *AutoCopier) WriteBundle(sourceDir string, w io.Writer) ([]string, error) {
+	// Tasks are resolved against an empty directory standing in for a worktree
+	destDir, err := os.MkdirTemp("", "hatcher-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+	defer os.RemoveAll(destDir)
+
+	tasks, err := ac.Tasks(sourceDir, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// A destination written by several items holds the last one's file
+	last := make(map[string]CopyTask)
+	for _, task := range tasks {
+		if !task.IsDir {
+			last[relativeSlash(destDir, task.DestPath)] = task
+		}
+	}
+	names := make([]string, 0, len(last))
+	for name := range last {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	manifest := &Manifest{SyncedAt: time.Now(), Files: make(map[string]ManifestEntry)}
+	var files []bundleFile
+	for _, name := range names {
+		task := last[name]
+		info, err := os.Stat(task.SourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", task.SourcePath, err)
+		}
+		if !info.Mode().IsRegular() {
+			// Special files are skipped, as they are by a copy
+			warnSpecialFile(task.SourcePath, info.Mode())
+			continue
+		}
+
+		checksum, err := fileChecksum(task.SourcePath)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files[name] = ManifestEntry{Source: name, Size: info.Size(), ModTime: info.ModTime(), Checksum: checksum}
+		files = append(files, bundleFile{name: name, path: task.SourcePath, info: info})
+	}
+
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	if err := writeBundleManifest(archive, manifest); err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, file := range files {
+		if err := writeBundleFile(archive, file); err != nil {
+			return nil, err
+		}
+		written = append(written, file.name)
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish bundle: %w", err)
+	}
+
+	return written, nil
+}
+
+// writeBundleManifest adds the manifest to archive
+func writeBundleManifest(archive *tar.Writer, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode bundle manifest: %w", err)
+	}
+
+	header := &tar.Header{
+		Name:    BundleManifestFile,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: manifest.SyncedAt,
+	}
+	if err := archive.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+	if _, err := archive.Write(data); err != nil {
+		return fmt.Errorf("failed to write bundle manifest: %w", err)
+	}
+	return nil
+}
+
+// writeBundleFile adds file to archive
+func writeBundleFile(archive *tar.Writer, file bundleFile) error {
+	header, err := tar.FileInfoHeader(file.info, "")
+	if err != nil {
+		return fmt.Errorf("failed to archive %s: %w", file.path, err)
+	}
+	header.Name = file.name
+	if err := archive.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", file.path, err)
+	}
+
+	source, err := os.Open(file.path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.path, err)
+	}
+	defer source.Close()
+	if _, err := io.Copy(archive, source); err != nil {
+		return fmt.Errorf("failed to archive %s: %w", file.path, err)
+	}
+
+	return nil
+}
+
+// RestoreBundle extracts a bundle written by WriteBundle into destDir. The
+// whole bundle is verified against its manifest before anything is written.
+// Existing files with the bundled content are left alone; files that differ
+// are reported as conflicts and, unless options.Force is set, nothing is
+// written and ErrRestoreConflicts is returned.
+func RestoreBundle(r io.Reader, destDir string, options RestoreOptions) (*RestoreReport, error) {
+	staging, err := os.MkdirTemp("", "hatcher-restore-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a temporary directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	manifest, modes, err := stageBundle(r, staging)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(manifest.Files))
+	for name := range manifest.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := &RestoreReport{}
+	var pending []string
+	for _, name := range names {
+		destPath := filepath.Join(destDir, filepath.FromSlash(name))
+		if _, err := os.Lstat(destPath); os.IsNotExist(err) {
+			pending = append(pending, name)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", destPath, err)
+		}
+
+		checksum, err := fileChecksum(destPath)
+		if err == nil && checksum == manifest.Files[name].Checksum {
+			report.Unchanged = append(report.Unchanged, name)
+			continue
+		}
+		report.Conflicts = append(report.Conflicts, name)
+		pending = append(pending, name)
+	}
+
+	if len(report.Conflicts) > 0 && !options.Force {
+		return report, fmt.Errorf("%w: %s", ErrRestoreConflicts, strings.Join(report.Conflicts, ", "))
+	}
+	if options.DryRun {
+		report.Restored = pending
+		return report, nil
+	}
+
+	for _, name := range pending {
+		entry := manifest.Files[name]
+		destPath := filepath.Join(destDir, filepath.FromSlash(name))
+		if err := installStagedFile(filepath.Join(staging, filepath.FromSlash(name)), destPath, modes[name], entry.ModTime); err != nil {
+			return report, err
+		}
+		report.Restored = append(report.Restored, name)
+	}
+
+	return report, nil
+}
+
+// stageBundle extracts the bundle in r into staging, verifying every file
+// against the manifest, and returns the manifest and the files' modes
+func stageBundle(r io.Reader, staging string) (*Manifest, map[string]os.FileMode, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	archive := tar.NewReader(gz)
+
+	header, err := archive.Next()
+	if err != nil || header.Name != BundleManifestFile {
+		return nil, nil, fmt.Errorf("invalid bundle: missing %s", BundleManifestFile)
+	}
+	manifest := &Manifest{}
+	if err := json.NewDecoder(archive).Decode(manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid bundle manifest: %w", err)
+	}
+
+	modes := make(map[string]os.FileMode)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if header.Typeflag == tar.TypeDir {
+			continue
+		}
+
+		entry, ok := manifest.Files[header.Name]
+		if !ok || header.Typeflag != tar.TypeReg {
+			return nil, nil, fmt.Errorf("invalid bundle: unexpected entry %s", header.Name)
+		}
+		if _, inside := relativeInside(staging, filepath.Join(staging, filepath.FromSlash(header.Name))); !inside {
+			return nil, nil, fmt.Errorf("invalid bundle: path outside the worktree: %s", header.Name)
+		}
+
+		checksum, err := stageBundleFile(archive, filepath.Join(staging, filepath.FromSlash(header.Name)))
+		if err != nil {
+			return nil, nil, err
+		}
+		if checksum != entry.Checksum {
+			return nil, nil, fmt.Errorf("invalid bundle: checksum mismatch for %s", header.Name)
+		}
+		modes[header.Name] = os.FileMode(header.Mode).Perm()
+	}
+
+	for name := range manifest.Files {
+		if _, ok := modes[name]; !ok {
+			return nil, nil, fmt.Errorf("invalid bundle: %s is missing", name)
+		}
+	}
+
+	return manifest, modes, nil
+}
+
+// stageBundleFile writes the current archive entry to path and returns the
+// checksum of its content
+func stageBundleFile(content io.Reader, path string) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(file, hash), content); err != nil {
+		return "", fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// installStagedFile moves a verified file into place atomically
+func installStagedFile(stagedPath, destPath string, mode os.FileMode, modTime time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", destPath, err)
+	}
+
+	source, err := os.Open(stagedPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", stagedPath, err)
+	}
+	defer source.Close()
+
+	dest, err := createDestFile(destPath, true)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", destPath, err)
+	}
+	defer dest.Discard()
+
+	if _, err := io.Copy(dest, source); err != nil {
+		return fmt.Errorf("failed to write %s: %w", destPath, err)
+	}
+	if err := dest.Chmod(mode); err != nil {
+		return fmt.Errorf("failed to set mode of %s: %w", destPath, err)
+	}
+	if err := dest.Commit(); err != nil {
+		return err
+	}
+
+	return os.Chtimes(destPath, modTime, modTime)
+}
@@ -0,0 +1,192 @@
+package autocopy
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tarEntry is a file of a hand-made bundle
+type tarEntry struct {
+	name    string
+	content string
+}
+
+// makeBundle writes a bundle with the given entries, in order
+func makeBundle(t *testing.T, entries ...tarEntry) *bytes.Buffer {
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(gz)
+	for _, entry := range entries {
+		require.NoError(t, archive.WriteHeader(&tar.Header{Name: entry.name, Mode: 0644, Size: int64(len(entry.content))}))
+		_, err := archive.Write([]byte(entry.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, archive.Close())
+	require.NoError(t, gz.Close())
+	return &buffer
+}
+
+func TestAutoCopier_WriteBundle(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "bundle-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".ai/prompts/one.md", "one")
+	testRepo.CreateFile(".ai/two.md", "two")
+	testRepo.CreateFile("notes.txt", "not configured")
+	require.NoError(t, os.Chmod(filepath.Join(testRepo.RepoDir, ".ai", "two.md"), 0755))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+	copier := NewAutoCopier(repo, config, AutoCopierOptions{})
+
+	var bundle bytes.Buffer
+	files, err := copier.WriteBundle(testRepo.RepoDir, &bundle)
+	require.NoError(t, err)
+	assert.Equal(t, []string{".ai/prompts/one.md", ".ai/two.md", "CLAUDE.md"}, files)
+
+	restore := func(t *testing.T, destDir string, options RestoreOptions) (*RestoreReport, error) {
+		return RestoreBundle(bytes.NewReader(bundle.Bytes()), destDir, options)
+	}
+
+	t.Run("manifest comes first", func(t *testing.T) {
+		gz, err := gzip.NewReader(bytes.NewReader(bundle.Bytes()))
+		require.NoError(t, err)
+		header, err := tar.NewReader(gz).Next()
+		require.NoError(t, err)
+		assert.Equal(t, BundleManifestFile, header.Name)
+	})
+
+	t.Run("round trip matches a copy", func(t *testing.T) {
+		restored := t.TempDir()
+		report, err := restore(t, restored, RestoreOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, files, report.Restored)
+
+		copied := t.TempDir()
+		_, err = copier.Copy(testRepo.RepoDir, copied)
+		require.NoError(t, err)
+
+		for _, file := range files {
+			want, err := os.ReadFile(filepath.Join(copied, filepath.FromSlash(file)))
+			require.NoError(t, err)
+			got, err := os.ReadFile(filepath.Join(restored, filepath.FromSlash(file)))
+			require.NoError(t, err)
+			assert.Equal(t, string(want), string(got), file)
+		}
+		assert.NoFileExists(t, filepath.Join(restored, "notes.txt"))
+		assert.NoFileExists(t, filepath.Join(restored, BundleManifestFile))
+
+		info, err := os.Stat(filepath.Join(restored, ".ai", "two.md"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	})
+
+	t.Run("unchanged files are left alone", func(t *testing.T) {
+		destDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"), []byte("rules"), 0644))
+
+		report, err := restore(t, destDir, RestoreOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CLAUDE.md"}, report.Unchanged)
+		assert.Equal(t, []string{".ai/prompts/one.md", ".ai/two.md"}, report.Restored)
+	})
+
+	t.Run("conflicts are refused", func(t *testing.T) {
+		destDir := t.TempDir()
+		modified := filepath.Join(destDir, "CLAUDE.md")
+		require.NoError(t, os.WriteFile(modified, []byte("local rules"), 0644))
+
+		report, err := restore(t, destDir, RestoreOptions{})
+		require.ErrorIs(t, err, ErrRestoreConflicts)
+		assert.Equal(t, []string{"CLAUDE.md"}, report.Conflicts)
+
+		// Nothing was written
+		content, err := os.ReadFile(modified)
+		require.NoError(t, err)
+		assert.Equal(t, "local rules", string(content))
+		assert.NoDirExists(t, filepath.Join(destDir, ".ai"))
+	})
+
+	t.Run("force overwrites conflicts", func(t *testing.T) {
+		destDir := t.TempDir()
+		modified := filepath.Join(destDir, "CLAUDE.md")
+		require.NoError(t, os.WriteFile(modified, []byte("local rules"), 0644))
+
+		report, err := restore(t, destDir, RestoreOptions{Force: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"CLAUDE.md"}, report.Conflicts)
+		assert.Equal(t, files, report.Restored)
+
+		content, err := os.ReadFile(modified)
+		require.NoError(t, err)
+		assert.Equal(t, "rules", string(content))
+	})
+
+	t.Run("dry run writes nothing", func(t *testing.T) {
+		destDir := t.TempDir()
+
+		report, err := restore(t, destDir, RestoreOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, files, report.Restored)
+		assert.NoFileExists(t, filepath.Join(destDir, "CLAUDE.md"))
+	})
+
+	t.Run("no configuration", func(t *testing.T) {
+		_, err := NewAutoCopier(repo, nil, AutoCopierOptions{}).WriteBundle(testRepo.RepoDir, io.Discard)
+		assert.Error(t, err)
+	})
+}
+
+func TestRestoreBundle_InvalidBundles(t *testing.T) {
+	// sha256 of "rules"
+	const rulesChecksum = "6c621d1a05138a7888d37d9269a9da8e2e11e4aced2f6cfd24b05ab1b9e61bb0"
+	manifest := func(name, checksum string) tarEntry {
+		return tarEntry{BundleManifestFile, `{"files": {"` + name + `": {"source": "` + name + `", "size": 5, "checksum": "` + checksum + `"}}}`}
+	}
+
+	tests := []struct {
+		name    string
+		bundle  *bytes.Buffer
+		message string
+	}{
+		{"not a bundle", bytes.NewBufferString("plain text"), "failed to read bundle"},
+		{"missing manifest", makeBundle(t, tarEntry{"CLAUDE.md", "rules"}), "missing " + BundleManifestFile},
+		{"checksum mismatch", makeBundle(t, manifest("CLAUDE.md", rulesChecksum), tarEntry{"CLAUDE.md", "tampered"}), "checksum mismatch"},
+		{"file not in the manifest", makeBundle(t, manifest("CLAUDE.md", rulesChecksum), tarEntry{"extra.md", "rules"}), "unexpected entry"},
+		{"file missing from the bundle", makeBundle(t, manifest("CLAUDE.md", rulesChecksum)), "CLAUDE.md is missing"},
+		{"path outside the worktree", makeBundle(t, manifest("../escape.md", rulesChecksum), tarEntry{"../escape.md", "rules"}), "outside the worktree"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destDir := filepath.Join(t.TempDir(), "worktree")
+			require.NoError(t, os.Mkdir(destDir, 0755))
+
+			_, err := RestoreBundle(tt.bundle, destDir, RestoreOptions{})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+
+			entries, err := os.ReadDir(destDir)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+			assert.NoFileExists(t, filepath.Join(filepath.Dir(destDir), "escape.md"))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// caseInsensitiveFS reports whether the filesystem holding dir treats names
+// differing only by case as the same file. It is a variable so tests can
+// simulate case-insensitive filesystems.
+var caseInsensitiveFS = probeCaseInsensitive
+
+// probeCaseInsensitive creates a mixed-case temporary file in dir, or its
+// nearest existing parent, and checks whether its upper-case name resolves
+func probeCaseInsensitive(dir string) bool {
+	for {
+		if _, err := os.Stat(dir); err == nil || !os.IsNotExist(err) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	file, err := os.CreateTemp(dir, ".hatcher-case-probe-")
+	if err != nil {
+		return false
+	}
+	file.Close()
+	defer os.Remove(file.Name())
+
+	upper := filepath.Join(dir, strings.ToUpper(filepath.Base(file.Name())))
+	_, err = os.Stat(upper)
+	return err == nil
+}
+
+// caseCollisions groups the file tasks whose destinations differ only by case
+// and returns the source paths, relative to sourceDir, of each group
+func caseCollisions(sourceDir string, tasks []CopyTask) [][]string {
+	dests := make(map[string]map[string]string) // folded dest -> dest -> source
+	for _, task := range tasks {
+		if task.IsDir {
+			continue
+		}
+		folded := strings.ToLower(task.DestPath)
+		if dests[folded] == nil {
+			dests[folded] = make(map[string]string)
+		}
+		source := task.SourcePath
+		if rel, err := filepath.Rel(sourceDir, task.SourcePath); err == nil {
+			source = filepath.ToSlash(rel)
+		}
+		dests[folded][task.DestPath] = source
+	}
+
+	var collisions [][]string
+	for _, group := range dests {
+		// Several items writing the exact same destination is an intended
+		// override; only distinct spellings collide
+		if len(group) < 2 {
+			continue
+		}
+		var sources []string
+		for _, source := range group {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		collisions = append(collisions, sources)
+	}
+	sort.Slice(collisions, func(i, j int) bool { return collisions[i][0] < collisions[j][0] })
+
+	return collisions
+}
+
+// checkCaseCollisions returns ErrCaseCollision when tasks would write files
+// differing only by case into destDir on a case-insensitive filesystem, where
+// one would silently overwrite the other
+func checkCaseCollisions(sourceDir, destDir string, tasks []CopyTask) error {
+	collisions := caseCollisions(sourceDir, tasks)
+	if len(collisions) == 0 || !caseInsensitiveFS(destDir) {
+		return nil
+	}
+
+	groups := make([]string, len(collisions))
+	for i, sources := range collisions {
+		groups[i] = strings.Join(sources, ", ")
+	}
+	return fmt.Errorf("%w: %s", ErrCaseCollision, strings.Join(groups, "; "))
+}
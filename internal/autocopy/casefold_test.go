@@ -0,0 +1,82 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbeCaseInsensitive(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "probe.txt"), nil, 0644))
+	_, err := os.Stat(filepath.Join(dir, "PROBE.TXT"))
+
+	assert.Equal(t, err == nil, probeCaseInsensitive(dir))
+	// Missing directories are probed at their nearest existing parent
+	assert.Equal(t, err == nil, probeCaseInsensitive(filepath.Join(dir, "missing", "dir")))
+}
+
+func TestCaseCollisions(t *testing.T) {
+	tasks := []CopyTask{
+		{SourcePath: "/src/ReadMe.md", DestPath: "/dst/ReadMe.md"},
+		{SourcePath: "/src/README.md", DestPath: "/dst/README.md"},
+		{SourcePath: "/src/docs", DestPath: "/dst/docs", IsDir: true},
+		{SourcePath: "/src/Docs", DestPath: "/dst/Docs", IsDir: true},
+		{SourcePath: "/src/base/CLAUDE.md", DestPath: "/dst/CLAUDE.md"},
+		{SourcePath: "/src/CLAUDE.md", DestPath: "/dst/CLAUDE.md"},
+	}
+
+	// Directories merge and identical destinations are overrides
+	assert.Equal(t, [][]string{{"README.md", "ReadMe.md"}}, caseCollisions("/src", tasks))
+}
+
+func TestCopyCaseCollision(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "case-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("ReadMe.md", "mixed")
+	testRepo.CreateFile("README.md", "upper")
+	if probeCaseInsensitive(testRepo.RepoDir) {
+		t.Skip("source filesystem cannot hold names differing only by case")
+	}
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "ReadMe.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: "README.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+
+	original := caseInsensitiveFS
+	defer func() { caseInsensitiveFS = original }()
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("case-insensitive destination parallel=%t", parallel), func(t *testing.T) {
+			caseInsensitiveFS = func(string) bool { return true }
+			destDir := t.TempDir()
+
+			_, err := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel}).Copy(testRepo.RepoDir, destDir)
+			require.ErrorIs(t, err, ErrCaseCollision)
+			assert.Contains(t, err.Error(), "README.md, ReadMe.md")
+			assert.NoFileExists(t, filepath.Join(destDir, "README.md"))
+		})
+	}
+
+	t.Run("case-sensitive destination", func(t *testing.T) {
+		caseInsensitiveFS = func(string) bool { return false }
+		destDir := t.TempDir()
+
+		_, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Copy(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(destDir, "ReadMe.md"))
+		assert.FileExists(t, filepath.Join(destDir, "README.md"))
+	})
+}
@@ -0,0 +1,167 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+// ignoredPaths returns the subset of paths that git ignores in repo, keyed by
+// the form the paths were given in. Relative paths are resolved against the
+// current directory. All paths are checked in a single batch.
+func ignoredPaths(repo git.Repository, paths []string) (map[string]bool, error) {
+	ignored := make(map[string]bool)
+	if len(paths) == 0 {
+		return ignored, nil
+	}
+
+	byAbsolute := make(map[string]string, len(paths))
+	absPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path %s: %w", path, err)
+		}
+		byAbsolute[absPath] = path
+		absPaths = append(absPaths, absPath)
+	}
+
+	matches, err := repo.FilterIgnored(absPaths)
+	if err != nil {
+		return nil, err
+	}
+	for _, match := range matches {
+		ignored[byAbsolute[match]] = true
+	}
+
+	return ignored, nil
+}
+
+// exportIgnoredPaths returns the subset of paths marked export-ignore in the
+// repo's gitattributes, keyed by the form the paths were given in. Git does
+// not inherit the attribute, so a path also counts when one of its parent
+// directories inside the repository is marked.
+func exportIgnoredPaths(repo git.Repository, paths []string) (map[string]bool, error) {
+	exported := make(map[string]bool)
+	if len(paths) == 0 {
+		return exported, nil
+	}
+
+	root, err := repo.GetRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	// Check every path and its parents below root in a single batch
+	absPaths := make([]string, len(paths))
+	seen := make(map[string]bool)
+	var candidates []string
+	for i, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve path %s: %w", path, err)
+		}
+		absPaths[i] = absPath
+		for dir := absPath; !seen[dir]; dir = filepath.Dir(dir) {
+			if _, inside := relativeInside(root, dir); !inside {
+				break
+			}
+			seen[dir] = true
+			candidates = append(candidates, dir)
+		}
+	}
+
+	values, err := repo.CheckAttr("export-ignore", candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, path := range paths {
+		for dir := absPaths[i]; seen[dir]; dir = filepath.Dir(dir) {
+			if values[dir] == "set" {
+				exported[path] = true
+				break
+			}
+		}
+	}
+
+	return exported, nil
+}
+
+// repoSkips selects which of the repository's own exclusions a copy honours
+type repoSkips struct {
+	gitignore    bool // Paths git ignores
+	exportIgnore bool // Paths marked export-ignore in gitattributes
+}
+
+// enabled reports whether any exclusion is honoured
+func (s repoSkips) enabled() bool {
+	return s.gitignore || s.exportIgnore
+}
+
+// repoSkips returns the exclusions selected by the options
+func (o AutoCopierOptions) repoSkips() repoSkips {
+	return repoSkips{gitignore: o.RespectGitignore, exportIgnore: o.RespectExportIgnore}
+}
+
+// repoSkips returns the exclusions selected by the options
+func (o ParallelCopyOptions) repoSkips() repoSkips {
+	return repoSkips{gitignore: o.RespectGitignore, exportIgnore: o.RespectExportIgnore}
+}
+
+// skippedPaths returns the subset of paths excluded by s, keyed by the form
+// the paths were given in
+func (s repoSkips) skippedPaths(repo git.Repository, paths []string) (map[string]bool, error) {
+	skipped := make(map[string]bool)
+	if s.gitignore {
+		ignored, err := ignoredPaths(repo, paths)
+		if err != nil {
+			return nil, err
+		}
+		for path := range ignored {
+			skipped[path] = true
+		}
+	}
+	if s.exportIgnore {
+		exported, err := exportIgnoredPaths(repo, paths)
+		if err != nil {
+			return nil, err
+		}
+		for path := range exported {
+			skipped[path] = true
+		}
+	}
+	return skipped, nil
+}
+
+// skippedInTree walks root and returns the paths below it excluded by
+// skips, checked in a single batch
+func skippedInTree(repo git.Repository, root string, skips repoSkips) (map[string]bool, error) {
+	var paths []string
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != root {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk directory %s: %w", root, err)
+	}
+
+	return skips.skippedPaths(repo, paths)
+}
+
+// repositoryAt opens the repository containing dir for ignore and
+// attribute checks
+func repositoryAt(dir string) (git.Repository, error) {
+	repo, err := git.NewRepositoryFromPath(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository at %s: %w", dir, err)
+	}
+	return repo, nil
+}
@@ -0,0 +1,160 @@
+package autocopy
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIgnoredPaths(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "check-ignore-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".gitignore", "*.log\n")
+	testRepo.CreateFile("keep.txt", "keep")
+	testRepo.CreateFile("debug.log", "debug")
+
+	keep := filepath.Join(testRepo.RepoDir, "keep.txt")
+	debug := filepath.Join(testRepo.RepoDir, "debug.log")
+	ignored, err := ignoredPaths(repo, []string{keep, debug})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{debug: true}, ignored)
+}
+
+func TestRespectGitignore(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "respect-gitignore-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".gitignore", "*.log\ncache/\n")
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+	testRepo.CreateFile(".ai/debug.log", "debug")
+	testRepo.CreateFile(".ai/cache/entry.json", "{}")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	assertCopied := func(t *testing.T, destDir string, respect bool) {
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "prompt.md"))
+		if respect {
+			assert.NoFileExists(t, filepath.Join(destDir, ".ai", "debug.log"))
+			assert.NoDirExists(t, filepath.Join(destDir, ".ai", "cache"))
+		} else {
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "debug.log"))
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "cache", "entry.json"))
+		}
+	}
+
+	for _, respect := range []bool{false, true} {
+		t.Run(fmt.Sprintf("legacy copier (respect=%t)", respect), func(t *testing.T) {
+			destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("legacy-%t", respect))
+			copier := NewLegacyAutoCopierWithOptions(AutoCopierOptions{RespectGitignore: respect})
+			_, err := copier.CopyFiles(testRepo.RepoDir, destDir, config)
+			require.NoError(t, err)
+			assertCopied(t, destDir, respect)
+		})
+
+		for _, parallel := range []bool{false, true} {
+			t.Run(fmt.Sprintf("auto copier (respect=%t, parallel=%t)", respect, parallel), func(t *testing.T) {
+				destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("auto-%t-%t", respect, parallel))
+				copier := NewAutoCopier(repo, config, AutoCopierOptions{
+					UseParallel:       parallel,
+					RespectGitignore:  respect,
+					NoGitignoreUpdate: true,
+				})
+				require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+				assertCopied(t, destDir, respect)
+			})
+		}
+	}
+
+	t.Run("estimate excludes ignored files", func(t *testing.T) {
+		estimate, err := NewAutoCopier(repo, config, AutoCopierOptions{RespectGitignore: true}).
+			Estimate(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "estimate"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, estimate.Files)
+	})
+}
+
+func TestExportIgnoredPaths(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "export-ignore-paths-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".gitattributes", "secret.txt export-ignore\ncache export-ignore\n")
+	testRepo.CreateFile("keep.txt", "keep")
+	testRepo.CreateFile("secret.txt", "secret")
+	testRepo.CreateFile("cache/deep/entry.json", "{}")
+
+	keep := filepath.Join(testRepo.RepoDir, "keep.txt")
+	secret := filepath.Join(testRepo.RepoDir, "secret.txt")
+	entry := filepath.Join(testRepo.RepoDir, "cache", "deep", "entry.json")
+	exported, err := exportIgnoredPaths(repo, []string{keep, secret, entry})
+	require.NoError(t, err)
+
+	// The attribute is not inherited by git, but applies below marked directories
+	assert.Equal(t, map[string]bool{secret: true, entry: true}, exported)
+}
+
+func TestRespectExportIgnore(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "respect-export-ignore-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".gitattributes", ".ai/internal.md export-ignore\n.ai/cache export-ignore\n")
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+	testRepo.CreateFile(".ai/internal.md", "internal")
+	testRepo.CreateFile(".ai/cache/entry.json", "{}")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	assertCopied := func(t *testing.T, destDir string, respect bool) {
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "prompt.md"))
+		if respect {
+			assert.NoFileExists(t, filepath.Join(destDir, ".ai", "internal.md"))
+			assert.NoDirExists(t, filepath.Join(destDir, ".ai", "cache"))
+		} else {
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "internal.md"))
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "cache", "entry.json"))
+		}
+	}
+
+	for _, respect := range []bool{false, true} {
+		t.Run(fmt.Sprintf("legacy copier (respect=%t)", respect), func(t *testing.T) {
+			destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("legacy-%t", respect))
+			copier := NewLegacyAutoCopierWithOptions(AutoCopierOptions{RespectExportIgnore: respect})
+			_, err := copier.CopyFiles(testRepo.RepoDir, destDir, config)
+			require.NoError(t, err)
+			assertCopied(t, destDir, respect)
+		})
+
+		for _, parallel := range []bool{false, true} {
+			t.Run(fmt.Sprintf("auto copier (respect=%t, parallel=%t)", respect, parallel), func(t *testing.T) {
+				destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("auto-%t-%t", respect, parallel))
+				copier := NewAutoCopier(repo, config, AutoCopierOptions{
+					UseParallel:         parallel,
+					RespectExportIgnore: respect,
+					NoGitignoreUpdate:   true,
+				})
+				require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+				assertCopied(t, destDir, respect)
+			})
+		}
+	}
+}
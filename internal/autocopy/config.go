@@ -2,16 +2,64 @@ package autocopy
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 )
 
+// DefaultMaxConfirmFiles is the number of files above which copying asks for
+// confirmation unless a different threshold is configured
+const DefaultMaxConfirmFiles = 1000
+
+// DefaultMaxTotalFiles is the hard limit on files discovered for a single copy
+const DefaultMaxTotalFiles = 10000
+
+// ErrTooManyFiles is returned when discovery finds more files than allowed.
+// It signals a safety abort rather than a copy failure.
+var ErrTooManyFiles = errors.New("too many files to copy")
+
+// ErrTransferTooLarge is returned when the files discovered for a copy add
+// up to more bytes than allowed. Like ErrTooManyFiles, it signals a safety
+// abort rather than a copy failure.
+var ErrTransferTooLarge = errors.New("copy exceeds the transfer size limit")
+
+// ErrDestinationNotWritable is returned before copying when files cannot be
+// created in the destination directory
+var ErrDestinationNotWritable = errors.New("destination is not writable")
+
+// ErrDestinationReadOnly is returned before copying when the destination is
+// on a read-only filesystem, such as a mounted snapshot. It wraps
+// ErrDestinationNotWritable.
+var ErrDestinationReadOnly = fmt.Errorf("%w: read-only filesystem", ErrDestinationNotWritable)
+
+// ErrCaseCollision is returned when copied files differ only by case and the
+// destination filesystem is case-insensitive
+var ErrCaseCollision = errors.New("files differ only by case on a case-insensitive filesystem")
+
+// ErrSymlinkOutsideRoot is reported for a symlink resolving outside the
+// source root when symlinks are not preserved; the link is not copied
+var ErrSymlinkOutsideRoot = errors.New("symlink points outside the source root")
+
+// ErrTypeConflict is reported when preserving symlinks would replace a file
+// with a link or a link with a file without ForceRelink
+var ErrTypeConflict = errors.New("source and destination types differ")
+
+// ErrUnsupportedChecksum is returned for a ChecksumType other than md5,
+// sha1, sha256 or crc32
+var ErrUnsupportedChecksum = errors.New("unsupported checksum type")
+
+// ErrUnsupportedProgressFormat is returned for a ProgressFormat other than
+// human or jsonl
+var ErrUnsupportedProgressFormat = errors.New("unsupported progress format")
+
 // AutoCopyConfig represents the configuration for automatic file copying
 type AutoCopyConfig struct {
-	Version int            `json:"version"`
-	Items   []AutoCopyItem `json:"items"`
-	Files   []string       `json:"files,omitempty"` // Legacy format support
+	Version      int            `json:"version"`
+	Items        []AutoCopyItem `json:"items"`
+	Files        []string       `json:"files,omitempty"`        // Legacy format support
+	IgnoreTarget string         `json:"ignoreTarget,omitempty"` // "gitignore" (default) or "exclude"
 }
 
 // AutoCopyItem represents a single item to be copied
@@ -24,6 +72,8 @@ type AutoCopyItem struct {
 	UseGlob    bool     `json:"useGlob"`
 	Exclude    []string `json:"exclude,omitempty"`
 	Include    []string `json:"include,omitempty"`
+	Priority   int      `json:"priority,omitempty"`   // Lower priorities are copied first
+	OnConflict string   `json:"onConflict,omitempty"` // Policy for differing destination files; the copier's default when empty
 }
 
 // IsDirectory returns true if the item should be treated as a directory
@@ -42,6 +92,17 @@ func (item *AutoCopyItem) IsDirectory() bool {
 	return strings.HasSuffix(item.Path, "/")
 }
 
+// itemsByPriority returns items ordered by ascending priority. Items with the
+// same priority keep their configured order.
+func itemsByPriority(items []AutoCopyItem) []AutoCopyItem {
+	sorted := make([]AutoCopyItem, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+	return sorted
+}
+
 // IsGlobPattern returns true if the path contains glob pattern characters
 func (item *AutoCopyItem) IsGlobPattern() bool {
 	path := item.Path
@@ -76,6 +137,10 @@ func ValidateAutoCopyConfig(config *AutoCopyConfig) error {
 		return fmt.Errorf("config cannot be nil")
 	}
 
+	if err := ValidateIgnoreTarget(config.IgnoreTarget); err != nil {
+		return err
+	}
+
 	// Validate legacy format
 	if config.Version == 0 && len(config.Files) > 0 {
 		for _, file := range config.Files {
@@ -124,5 +189,9 @@ func validateAutoCopyItem(item AutoCopyItem, index int) error {
 		return fmt.Errorf("item %d: cannot use both directory and autoDetect options", index)
 	}
 
+	if err := ValidateConflictPolicy(item.OnConflict); err != nil {
+		return fmt.Errorf("item %d: %w", index, err)
+	}
+
 	return nil
 }
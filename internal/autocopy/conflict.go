@@ -0,0 +1,102 @@
+package autocopy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Policies for copying over a destination file whose content differs
+const (
+	ConflictOverwrite = "overwrite" // Replace the file
+	ConflictSkip      = "skip"      // Keep the file and leave it uncopied
+	ConflictBackup    = "backup"    // Replace the file, keeping it as <name>.hatcher.bak
+	ConflictError     = "error"     // Fail the copy of the file
+	ConflictNewer     = "newer"     // Replace the file only if the source was modified after it
+)
+
+// ErrCopyConflict is returned for a destination file whose item has the
+// "error" conflict policy
+var ErrCopyConflict = errors.New("destination file exists with different content")
+
+// ConflictPolicies returns the values an item's onConflict accepts
+func ConflictPolicies() []string {
+	return []string{ConflictOverwrite, ConflictSkip, ConflictBackup, ConflictError, ConflictNewer}
+}
+
+// ValidateConflictPolicy checks an item's onConflict value. An empty policy
+// selects the copier's default.
+func ValidateConflictPolicy(policy string) error {
+	switch policy {
+	case "", ConflictOverwrite, ConflictSkip, ConflictBackup, ConflictError, ConflictNewer:
+		return nil
+	default:
+		return fmt.Errorf("unknown onConflict policy %q (use overwrite, skip, backup, error or newer)", policy)
+	}
+}
+
+// conflictPolicy returns the policy for files of an item with the given
+// onConflict value: the item's own, the copier's OnConflict option, or the
+// default derived from its Backup option
+func conflictPolicy(onConflict, copierPolicy string, backup bool) string {
+	switch {
+	case onConflict != "":
+		return onConflict
+	case copierPolicy != "":
+		return copierPolicy
+	case backup:
+		return ConflictBackup
+	default:
+		return ConflictOverwrite
+	}
+}
+
+// resolveConflict applies policy to the file at destPath before sourcePath
+// is copied over it and reports whether the copy goes ahead. Missing files,
+// non-regular files and files with the same content are not conflicts; the
+// newer policy only compares modification times.
+func resolveConflict(policy, sourcePath, destPath string, provenance bool) (bool, error) {
+	switch policy {
+	case ConflictBackup:
+		return true, backupFile(sourcePath, destPath, provenance)
+	case ConflictSkip, ConflictError, ConflictNewer:
+	default:
+		return true, nil
+	}
+
+	info, err := os.Lstat(destPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to stat %s: %w", destPath, err)
+	}
+	if !info.Mode().IsRegular() {
+		return true, nil
+	}
+	if policy == ConflictNewer {
+		return sourceNewer(sourcePath, info)
+	}
+
+	changed, err := contentChanged(sourcePath, destPath, info, provenance)
+	if err != nil {
+		return false, err
+	}
+	if !changed {
+		return true, nil
+	}
+	if policy == ConflictSkip {
+		return false, nil
+	}
+	return false, fmt.Errorf("%w: %s", ErrCopyConflict, destPath)
+}
+
+// sourceNewer reports whether the file at sourcePath was modified strictly
+// after the destination file described by destInfo
+func sourceNewer(sourcePath string, destInfo os.FileInfo) (bool, error) {
+	sourceInfo, err := os.Stat(sourcePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat %s: %w", sourcePath, err)
+	}
+	return sourceInfo.ModTime().After(destInfo.ModTime()), nil
+}
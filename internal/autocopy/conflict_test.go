@@ -0,0 +1,290 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConflictPolicy(t *testing.T) {
+	assert.Equal(t, ConflictOverwrite, conflictPolicy("", "", false))
+	assert.Equal(t, ConflictBackup, conflictPolicy("", "", true))
+	assert.Equal(t, ConflictSkip, conflictPolicy(ConflictSkip, "", true))
+	assert.Equal(t, ConflictOverwrite, conflictPolicy(ConflictOverwrite, "", true))
+
+	// The copier's policy applies to items without their own and wins over
+	// Backup
+	assert.Equal(t, ConflictError, conflictPolicy("", ConflictError, true))
+	assert.Equal(t, ConflictSkip, conflictPolicy(ConflictSkip, ConflictError, false))
+
+	assert.NoError(t, ValidateConflictPolicy(""))
+	assert.NoError(t, ValidateConflictPolicy(ConflictError))
+	assert.Error(t, ValidateConflictPolicy("merge"))
+}
+
+func TestItemConflictPolicies(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "conflict-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "shared rules")
+	testRepo.CreateFile(".env.local", "TOKEN=main")
+	testRepo.CreateFile(".cursorrules", "shared cursor rules")
+	testRepo.CreateFile("notes.md", "shared notes")
+
+	item := func(path, onConflict string) AutoCopyItem {
+		return AutoCopyItem{Path: path, Directory: testutil.BoolPtr(false), RootOnly: true, OnConflict: onConflict}
+	}
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			item("CLAUDE.md", ConflictOverwrite),
+			item(".env.local", ConflictSkip),
+			item(".cursorrules", ""),
+			item("notes.md", ConflictBackup),
+		},
+	}
+
+	// Every destination exists with local changes
+	prepareDest := func(t *testing.T) string {
+		destDir := t.TempDir()
+		for _, name := range []string{"CLAUDE.md", ".env.local", ".cursorrules", "notes.md"} {
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, name), []byte("local "+name), 0644))
+		}
+		return destDir
+	}
+	readDest := func(t *testing.T, destDir, name string) string {
+		content, err := os.ReadFile(filepath.Join(destDir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			destDir := prepareDest(t)
+
+			// The copier's default is backup, which only .cursorrules uses
+			copied, err := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel, Backup: true}).Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+
+			assert.Equal(t, "shared rules", readDest(t, destDir, "CLAUDE.md"))
+			assert.NoFileExists(t, BackupPath(filepath.Join(destDir, "CLAUDE.md")))
+
+			assert.Equal(t, "local .env.local", readDest(t, destDir, ".env.local"))
+			assert.NotContains(t, copied, ".env.local")
+
+			assert.Equal(t, "shared cursor rules", readDest(t, destDir, ".cursorrules"))
+			assert.Equal(t, "local .cursorrules", readDest(t, destDir, BackupPath(".cursorrules")))
+
+			assert.Equal(t, "shared notes", readDest(t, destDir, "notes.md"))
+			assert.Equal(t, "local notes.md", readDest(t, destDir, BackupPath("notes.md")))
+
+			assert.ElementsMatch(t, []string{"CLAUDE.md", ".cursorrules", "notes.md"}, copied)
+		})
+	}
+
+	t.Run("error policy fails the copy", func(t *testing.T) {
+		strict := &AutoCopyConfig{Version: 2, Items: []AutoCopyItem{item(".env.local", ConflictError)}}
+
+		destDir := prepareDest(t)
+		_, err := NewAutoCopier(repo, strict, AutoCopierOptions{}).Copy(testRepo.RepoDir, destDir)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrCopyConflict)
+		assert.Equal(t, "local .env.local", readDest(t, destDir, ".env.local"))
+
+		// Identical files are not conflicts
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, ".env.local"), []byte("TOKEN=main"), 0644))
+		_, err = NewAutoCopier(repo, strict, AutoCopierOptions{}).Copy(testRepo.RepoDir, destDir)
+		assert.NoError(t, err)
+	})
+
+	t.Run("plan shows item policies", func(t *testing.T) {
+		plan, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Plan(testRepo.RepoDir, prepareDest(t))
+		require.NoError(t, err)
+
+		decisions := make(map[string]string)
+		for _, entry := range plan.Entries {
+			decisions[entry.Dest] = entry.Decision
+		}
+		assert.Equal(t, map[string]string{
+			"CLAUDE.md":    PlanOverwrite,
+			".env.local":   PlanSkip,
+			".cursorrules": PlanOverwrite,
+			"notes.md":     PlanBackup,
+		}, decisions)
+		assert.Equal(t, 3, plan.Files)
+	})
+}
+
+func TestCopierConflictOption(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "conflict-option-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".env.local", "TOKEN=main")
+	testRepo.CreateFile("CLAUDE.md", "shared rules")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".env.local", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+
+	// .env.local exists with local changes, CLAUDE.md is missing
+	prepareDest := func(t *testing.T) string {
+		destDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, ".env.local"), []byte("TOKEN=local"), 0644))
+		return destDir
+	}
+	readDest := func(t *testing.T, destDir, name string) string {
+		content, err := os.ReadFile(filepath.Join(destDir, name))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			copyWith := func(t *testing.T, destDir, policy string) ([]string, error) {
+				options := AutoCopierOptions{UseParallel: parallel, NoGitignoreUpdate: true, OnConflict: policy}
+				return NewAutoCopier(repo, config, options).Copy(testRepo.RepoDir, destDir)
+			}
+
+			t.Run("overwrite", func(t *testing.T) {
+				destDir := prepareDest(t)
+				copied, err := copyWith(t, destDir, ConflictOverwrite)
+				require.NoError(t, err)
+				assert.ElementsMatch(t, []string{".env.local", "CLAUDE.md"}, copied)
+				assert.Equal(t, "TOKEN=main", readDest(t, destDir, ".env.local"))
+			})
+
+			t.Run("skip", func(t *testing.T) {
+				destDir := prepareDest(t)
+				copied, err := copyWith(t, destDir, ConflictSkip)
+				require.NoError(t, err)
+				assert.Equal(t, []string{"CLAUDE.md"}, copied)
+				assert.Equal(t, "TOKEN=local", readDest(t, destDir, ".env.local"))
+				assert.Equal(t, "shared rules", readDest(t, destDir, "CLAUDE.md"))
+			})
+
+			t.Run("error", func(t *testing.T) {
+				destDir := prepareDest(t)
+				_, err := copyWith(t, destDir, ConflictError)
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrCopyConflict)
+				assert.Contains(t, err.Error(), filepath.Join(destDir, ".env.local"))
+				assert.Equal(t, "TOKEN=local", readDest(t, destDir, ".env.local"))
+			})
+
+			t.Run("item policy wins", func(t *testing.T) {
+				override := &AutoCopyConfig{Version: 2, Items: []AutoCopyItem{
+					{Path: ".env.local", Directory: testutil.BoolPtr(false), RootOnly: true, OnConflict: ConflictOverwrite},
+				}}
+				destDir := prepareDest(t)
+				options := AutoCopierOptions{UseParallel: parallel, NoGitignoreUpdate: true, OnConflict: ConflictError}
+				_, err := NewAutoCopier(repo, override, options).Copy(testRepo.RepoDir, destDir)
+				require.NoError(t, err)
+				assert.Equal(t, "TOKEN=main", readDest(t, destDir, ".env.local"))
+			})
+
+			t.Run("unknown policy", func(t *testing.T) {
+				_, err := copyWith(t, prepareDest(t), "fail-fast")
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "unknown onConflict policy")
+			})
+		})
+	}
+}
+
+func TestNewerConflictPolicy(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "newer-conflict-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".env.local", "TOKEN=main")
+	sourcePath := filepath.Join(testRepo.RepoDir, ".env.local")
+	sourceTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(sourcePath, sourceTime, sourceTime))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: ".env.local", Directory: testutil.BoolPtr(false), RootOnly: true}},
+	}
+
+	// prepareDest writes a locally edited destination modified at destTime
+	prepareDest := func(t *testing.T, destTime time.Time) string {
+		destDir := t.TempDir()
+		destPath := filepath.Join(destDir, ".env.local")
+		require.NoError(t, os.WriteFile(destPath, []byte("TOKEN=local"), 0644))
+		require.NoError(t, os.Chtimes(destPath, destTime, destTime))
+		return destDir
+	}
+	readDest := func(t *testing.T, destDir string) string {
+		content, err := os.ReadFile(filepath.Join(destDir, ".env.local"))
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	tests := []struct {
+		name     string
+		destTime time.Time
+		copied   bool
+	}{
+		{"newer source", sourceTime.Add(-time.Minute), true},
+		{"older source", sourceTime.Add(time.Minute), false},
+		{"equal mtime", sourceTime, false},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("parallel=%t/%s", parallel, tt.name), func(t *testing.T) {
+				destDir := prepareDest(t, tt.destTime)
+				options := AutoCopierOptions{UseParallel: parallel, NoGitignoreUpdate: true, OnConflict: ConflictNewer}
+
+				report, err := NewAutoCopier(repo, config, options).Report(testRepo.RepoDir, destDir)
+				require.NoError(t, err)
+
+				if tt.copied {
+					assert.Equal(t, "TOKEN=main", readDest(t, destDir))
+					assert.Equal(t, []string{".env.local"}, report.CopiedFiles)
+					assert.Empty(t, report.SkippedOlder)
+				} else {
+					assert.Equal(t, "TOKEN=local", readDest(t, destDir))
+					assert.Empty(t, report.CopiedFiles)
+					assert.Equal(t, []string{".env.local"}, report.SkippedOlder)
+				}
+				assert.Empty(t, report.Skipped)
+			})
+		}
+	}
+
+	t.Run("missing destination is copied", func(t *testing.T) {
+		destDir := t.TempDir()
+		copied, err := NewAutoCopier(repo, config, AutoCopierOptions{OnConflict: ConflictNewer}).Copy(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{".env.local"}, copied)
+		assert.Equal(t, "TOKEN=main", readDest(t, destDir))
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		for _, tt := range tests {
+			plan, err := NewAutoCopier(repo, config, AutoCopierOptions{OnConflict: ConflictNewer}).Plan(testRepo.RepoDir, prepareDest(t, tt.destTime))
+			require.NoError(t, err)
+			require.Len(t, plan.Entries, 1)
+
+			expected := PlanSkip
+			if tt.copied {
+				expected = PlanOverwrite
+			}
+			assert.Equal(t, expected, plan.Entries[0].Decision, tt.name)
+		}
+	})
+}
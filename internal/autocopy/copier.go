@@ -1,24 +1,52 @@
 package autocopy
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
+	"time"
 
+	"github.com/keisukeshimizu/hatcher/internal/filelock"
 	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/logger"
 )
 
 // AutoCopierOptions contains options for the AutoCopier
 type AutoCopierOptions struct {
-	NoGitignoreUpdate bool // Skip updating .gitignore
-	UseParallel       bool // Use parallel processing
-	MaxWorkers        int  // Maximum number of worker goroutines
-	BufferSize        int  // Buffer size for file copying
-	ShowProgress      bool // Show progress updates
-	VerifyIntegrity   bool // Verify file integrity after copying
+	NoGitignoreUpdate   bool                 // Skip updating .gitignore
+	UseParallel         bool                 // Use parallel processing
+	MaxWorkers          int                  // Maximum number of worker goroutines
+	BufferSize          int                  // Buffer size for file copying
+	ShowProgress        bool                 // Show progress updates
+	ProgressCallback    func(ProgressUpdate) // Receives progress updates with ShowProgress; nil prints them
+	ProgressFormat      string               // How updates are printed without a ProgressCallback: human (default) or jsonl
+	ProgressInterval    int                  // Files a parallel copy completes between progress updates (0 uses DefaultProgressInterval)
+	VerifyIntegrity     bool                 // Verify file integrity after copying
+	GitModeSemantics    bool                 // Normalize file modes to 0644/0755 like git stores them
+	MaxTotalFiles       int                  // Abort above this many files (0 uses the default, negative disables)
+	MaxTotalBytes       int64                // Abort when the files add up to more bytes (0 or negative is unlimited)
+	RespectGitignore    bool                 // Skip files ignored by git during recursive copies
+	RespectExportIgnore bool                 // Skip files marked export-ignore in gitattributes during recursive copies
+	PreserveXattrs      bool                 // Copy extended attributes on Linux and macOS
+	Backup              bool                 // Keep overwritten files whose content changes as <name>.hatcher.bak
+	OnConflict          string               // Policy for differing destination files of items without their own (overrides Backup)
+	AddProvenanceHeader bool                 // Prepend a "copied by hatcher" comment to recognized text files
+	StripPrefix         string               // Remove this leading directory from every destination path
+	AddPrefix           string               // Place every destination path below this directory
+	SkipPaths           []string             // Destination files, relative to the destination root, that are never written
+	AtomicWrites        bool                 // Write sequential copies through a temporary file renamed into place (parallel copies always are)
+	PreserveTimestamps  bool                 // Give copies the modification time of their source
+	PreserveSymlinks    bool                 // Recreate symlinks instead of copying their targets' content
+	ForceRelink         bool                 // With PreserveSymlinks, replace files with links and links with files
+	SkipTracked         bool                 // Never overwrite files tracked in the destination worktree
+	DryRun              bool                 // Print the planned copies instead of copying
+	DeferVerifyMinSize  int64                // Verify parallel copies of files this large in a separate phase (0 verifies inline)
+	MaxRetries          int                  // Re-attempts of parallel copies failing with a transient error (0 disables)
+	RetryBackoff        time.Duration        // Delay before the first retry, doubled for each further one (0 uses DefaultRetryBackoff)
+	Trace               func(TraceEntry)     // Receives the decision made for every file considered
 }
 
 // AutoCopier handles automatic file copying operations
@@ -26,6 +54,13 @@ type AutoCopier struct {
 	repo    git.Repository
 	config  *AutoCopyConfig
 	options AutoCopierOptions
+	source  string // Source root symlinks must stay inside
+	dest    destMapper
+	filter  pathFilter // Exclude and include patterns of the item being copied
+
+	onConflict string // Conflict policy of the item being copied
+
+	verification VerificationStats // Deferred verification phase of the last parallel copy
 }
 
 // NewAutoCopier creates a new AutoCopier instance
@@ -51,8 +86,24 @@ func NewLegacyAutoCopier() *LegacyAutoCopier {
 	return &LegacyAutoCopier{}
 }
 
+// NewLegacyAutoCopierWithOptions creates a legacy copier honoring copy options
+func NewLegacyAutoCopierWithOptions(options AutoCopierOptions) *LegacyAutoCopier {
+	return &LegacyAutoCopier{
+		options: options,
+	}
+}
+
 // LegacyAutoCopier provides backward compatibility
-type LegacyAutoCopier struct{}
+type LegacyAutoCopier struct {
+	options AutoCopierOptions
+	source  string // Source root symlinks must stay inside
+	dest    destMapper
+	filter  pathFilter // Exclude and include patterns of the item being copied
+
+	onConflict      string           // Conflict policy of the item being copied
+	conflictSkipped []string         // Files kept by the skip policy, relative to the destination root
+	onCopied        func(size int64) // Called after each file is written
+}
 
 // CopyFiles provides legacy interface for file copying
 func (lac *LegacyAutoCopier) CopyFiles(sourceDir, destDir string, config *AutoCopyConfig) ([]string, error) {
@@ -60,6 +111,27 @@ func (lac *LegacyAutoCopier) CopyFiles(sourceDir, destDir string, config *AutoCo
 		return []string{}, nil
 	}
 
+	if err := checkWritable(destDir); err != nil {
+		return nil, err
+	}
+	if err := ValidateConflictPolicy(lac.options.OnConflict); err != nil {
+		return nil, err
+	}
+
+	dest, err := newDestMapper(destDir, lac.options.StripPrefix, lac.options.AddPrefix)
+	if err != nil {
+		return nil, err
+	}
+	ignore, err := loadHatcherIgnore(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	lac.source = sourceDir
+	lac.dest = dest
+	lac.filter = pathFilter{root: sourceDir, ignore: ignore, trace: lac.options.Trace}
+	lac.onConflict = ""
+	lac.conflictSkipped = nil
+
 	var copiedFiles []string
 
 	// Handle legacy format
@@ -73,11 +145,15 @@ func (lac *LegacyAutoCopier) CopyFiles(sourceDir, destDir string, config *AutoCo
 				copiedFiles = append(copiedFiles, file)
 			}
 		}
-		return copiedFiles, nil
+		return lac.dest.mapCopied(copiedFiles), nil
 	}
 
 	// Handle new format
-	for _, item := range config.Items {
+	for _, item := range itemsByPriority(config.Items) {
+		lac.filter = newPathFilter(sourceDir, item)
+		lac.filter.ignore = ignore
+		lac.filter.trace = lac.options.Trace
+		lac.onConflict = item.OnConflict
 		if item.IsGlobPattern() || (item.Recursive && !item.RootOnly) {
 			// Use glob pattern processing for recursive searches
 			pattern := item.Path
@@ -99,7 +175,8 @@ func (lac *LegacyAutoCopier) CopyFiles(sourceDir, destDir string, config *AutoCo
 		}
 	}
 
-	return copiedFiles, nil
+	// Files kept by the skip policy were not copied
+	return withoutSkipped(lac.dest.mapCopied(copiedFiles), lac.conflictSkipped), nil
 }
 
 // ProcessGlobPatternWithOptions provides glob processing with item options
@@ -146,6 +223,18 @@ func (lac *LegacyAutoCopier) ProcessGlobPattern(pattern, sourceDir, destDir stri
 			continue
 		}
 
+		if isSpecialFile(info.Mode()) {
+			warnSpecialFile(match, info.Mode())
+			continue
+		}
+
+		if !info.IsDir() && lac.filter.skipFile(match) {
+			continue
+		}
+		if info.IsDir() && lac.filter.ignoredDir(match) {
+			continue
+		}
+
 		if info.IsDir() {
 			err = lac.copyDirectory(match, destPath, true)
 		} else {
@@ -163,6 +252,7 @@ func (lac *LegacyAutoCopier) ProcessGlobPattern(pattern, sourceDir, destDir stri
 // findRecursiveFiles finds files recursively using filepath.Walk
 func (lac *LegacyAutoCopier) findRecursiveFiles(filename, sourceDir, destDir string) ([]string, error) {
 	var copiedFiles []string
+	var matches []string
 
 	err := filepath.Walk(sourceDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -175,27 +265,53 @@ func (lac *LegacyAutoCopier) findRecursiveFiles(filename, sourceDir, destDir str
 		}
 
 		// Check if filename matches
-		if filepath.Base(path) == filename {
-			// Get relative path from source directory
-			relPath, err := filepath.Rel(sourceDir, path)
-			if err != nil {
-				return err
+		if filepath.Base(path) == filename && !lac.filter.skipFile(path) {
+			if isSpecialFile(info.Mode()) {
+				warnSpecialFile(path, info.Mode())
+				return nil
 			}
+			matches = append(matches, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return copiedFiles, err
+	}
 
-			destPath := filepath.Join(destDir, relPath)
+	var ignored map[string]bool
+	if skips := lac.options.repoSkips(); skips.enabled() {
+		repo, err := repositoryAt(sourceDir)
+		if err != nil {
+			return copiedFiles, err
+		}
+		if ignored, err = skips.skippedPaths(repo, matches); err != nil {
+			return copiedFiles, err
+		}
+	}
 
-			// Copy the file
-			if err := lac.copyFile(path, destPath); err != nil {
-				return err
-			}
+	for _, path := range matches {
+		if ignored[path] {
+			continue
+		}
 
-			copiedFiles = append(copiedFiles, relPath)
+		// Get relative path from source directory
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return copiedFiles, err
 		}
 
-		return nil
-	})
+		destPath := filepath.Join(destDir, relPath)
 
-	return copiedFiles, err
+		// Copy the file
+		if err := lac.copyFile(path, destPath); err != nil {
+			return copiedFiles, err
+		}
+
+		copiedFiles = append(copiedFiles, relPath)
+	}
+
+	return copiedFiles, nil
 }
 
 // findRecursiveFilesWithRootOnly finds files recursively with rootOnly option
@@ -205,7 +321,11 @@ func (lac *LegacyAutoCopier) findRecursiveFilesWithRootOnly(filename, sourceDir,
 	if rootOnly {
 		// Only check root level
 		rootPath := filepath.Join(sourceDir, filename)
-		if info, err := os.Stat(rootPath); err == nil && !info.IsDir() {
+		if info, err := os.Stat(rootPath); err == nil && !info.IsDir() && !lac.filter.skipFile(rootPath) {
+			if isSpecialFile(info.Mode()) {
+				warnSpecialFile(rootPath, info.Mode())
+				return copiedFiles, nil
+			}
 			destPath := filepath.Join(destDir, filename)
 			if err := lac.copyFile(rootPath, destPath); err != nil {
 				return nil, err
@@ -221,42 +341,7 @@ func (lac *LegacyAutoCopier) findRecursiveFilesWithRootOnly(filename, sourceDir,
 
 // UpdateGitignore provides legacy interface for gitignore updates
 func (lac *LegacyAutoCopier) UpdateGitignore(repoDir string, files []string) error {
-	if len(files) == 0 {
-		return nil
-	}
-
-	gitignorePath := filepath.Join(repoDir, ".gitignore")
-
-	// Read existing .gitignore content
-	var existingContent string
-	if data, err := os.ReadFile(gitignorePath); err == nil {
-		existingContent = string(data)
-	}
-
-	// Check if we already have our section
-	if strings.Contains(existingContent, "# Auto-copied files (added by hatcher)") {
-		return nil // Already updated
-	}
-
-	// Prepare new content to append
-	var newContent strings.Builder
-	if existingContent != "" && !strings.HasSuffix(existingContent, "\n") {
-		newContent.WriteString("\n")
-	}
-	newContent.WriteString("\n# Auto-copied files (added by hatcher)\n")
-	for _, file := range files {
-		newContent.WriteString(file + "\n")
-	}
-
-	// Append to .gitignore
-	file, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to open .gitignore: %w", err)
-	}
-	defer file.Close()
-
-	_, err = file.WriteString(newContent.String())
-	return err
+	return UpdateIgnoreFile(repoDir, IgnoreTargetGitignore, files)
 }
 
 // copySinglePath copies a single file or directory path
@@ -268,14 +353,24 @@ func (lac *LegacyAutoCopier) copySinglePath(sourceDir, destDir, path string) (bo
 	info, err := os.Stat(sourcePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return false, nil // Skip non-existent files
+			// Skip non-existent files
+			tracer(lac.options.Trace).record(sourceDir, sourcePath, TraceSkippedMissing, "source does not exist")
+			return false, nil
 		}
 		return false, err
 	}
 
+	if isSpecialFile(info.Mode()) {
+		warnSpecialFile(sourcePath, info.Mode())
+		return false, nil
+	}
+
 	if info.IsDir() {
 		return true, lac.copyDirectory(sourcePath, destPath, false)
 	} else {
+		if lac.filter.skipFile(sourcePath) {
+			return false, nil
+		}
 		return true, lac.copyFile(sourcePath, destPath)
 	}
 }
@@ -289,18 +384,30 @@ func (lac *LegacyAutoCopier) copySingleItem(sourceDir, destDir string, item Auto
 	info, err := os.Stat(sourcePath)
 	if err != nil {
 		if os.IsNotExist(err) && item.AutoDetect {
-			return []string{}, nil // Skip non-existent files when auto-detecting
+			// Skip non-existent files when auto-detecting
+			tracer(lac.options.Trace).record(sourceDir, sourcePath, TraceSkippedMissing, "auto-detected path does not exist")
+			return []string{}, nil
 		}
 		if os.IsNotExist(err) {
-			return []string{}, nil // Skip non-existent files
+			// Skip non-existent files
+			tracer(lac.options.Trace).record(sourceDir, sourcePath, TraceSkippedMissing, "source does not exist")
+			return []string{}, nil
 		}
 		return nil, err
 	}
 
+	if isSpecialFile(info.Mode()) {
+		warnSpecialFile(sourcePath, info.Mode())
+		return []string{}, nil
+	}
+
 	if info.IsDir() {
 		if item.Directory != nil && !*item.Directory {
 			return nil, fmt.Errorf("expected file but found directory: %s", sourcePath)
 		}
+		if lac.filter.ignoredDir(sourcePath) {
+			return []string{}, nil
+		}
 		// For directories, always copy contents unless explicitly set to false
 		recursive := item.Recursive
 		if item.Directory != nil && *item.Directory {
@@ -315,6 +422,9 @@ func (lac *LegacyAutoCopier) copySingleItem(sourceDir, destDir string, item Auto
 		if item.Directory != nil && *item.Directory {
 			return nil, fmt.Errorf("expected directory but found file: %s", sourcePath)
 		}
+		if lac.filter.skipFile(sourcePath) {
+			return []string{}, nil
+		}
 		err = lac.copyFile(sourcePath, destPath)
 		if err != nil {
 			return nil, err
@@ -325,12 +435,47 @@ func (lac *LegacyAutoCopier) copySingleItem(sourceDir, destDir string, item Auto
 
 // copyFile copies a single file
 func (lac *LegacyAutoCopier) copyFile(sourcePath, destPath string) error {
+	destPath = lac.dest.mapPath(destPath)
+	if skippedPath(lac.dest.root, destPath, lac.options.SkipPaths) {
+		return nil
+	}
+	trace := tracer(lac.options.Trace)
+	if lac.options.SkipTracked {
+		if tracked, err := skipTrackedDestination(nil, lac.dest.root, destPath); tracked || err != nil {
+			if tracked {
+				trace.record(lac.source, sourcePath, TraceSkippedFiltered, "tracked in the destination worktree")
+			}
+			return err
+		}
+	}
+
+	if linked, written, err := handleSymlink(lac.source, sourcePath, destPath, lac.options.PreserveSymlinks, lac.options.ForceRelink); errors.Is(err, ErrSymlinkOutsideRoot) {
+		logger.Warning("Skipping %v", err)
+		return nil
+	} else if linked || err != nil {
+		traceSymlink(trace, lac.source, sourcePath, written, err)
+		return err
+	}
+
 	// Create destination directory if it doesn't exist
 	destDir := filepath.Dir(destPath)
 	if err := os.MkdirAll(destDir, 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory %s: %w", destDir, err)
 	}
 
+	policy := conflictPolicy(lac.onConflict, lac.options.OnConflict, lac.options.Backup)
+	decision := trace.copyDecision(policy, sourcePath, destPath, lac.options.AddProvenanceHeader)
+	if proceed, err := resolveConflict(policy, sourcePath, destPath, lac.options.AddProvenanceHeader); !proceed || err != nil {
+		if decision.Decision == TraceConflict {
+			trace.recordEntry(lac.source, sourcePath, decision)
+		}
+		if err != nil {
+			return err
+		}
+		lac.conflictSkipped = append(lac.conflictSkipped, relativeSlash(lac.dest.root, destPath))
+		return nil
+	}
+
 	// Open source file
 	sourceFile, err := os.Open(sourcePath)
 	if err != nil {
@@ -339,22 +484,56 @@ func (lac *LegacyAutoCopier) copyFile(sourcePath, destPath string) error {
 	defer sourceFile.Close()
 
 	// Create destination file
-	destFile, err := os.Create(destPath)
+	destFile, err := createDestFile(destPath, lac.options.AtomicWrites)
 	if err != nil {
 		return fmt.Errorf("failed to create destination file %s: %w", destPath, err)
 	}
-	defer destFile.Close()
+	defer destFile.Discard()
 
 	// Copy content
-	_, err = io.Copy(destFile, sourceFile)
-	if err != nil {
-		return fmt.Errorf("failed to copy file content: %w", err)
+	copied := false
+	if lac.options.AddProvenanceHeader {
+		if copied, err = copyWithProvenance(destFile, sourceFile, sourcePath); err != nil {
+			return err
+		}
+	}
+	if !copied {
+		if _, err := io.Copy(destFile, sourceFile); err != nil {
+			return fmt.Errorf("failed to copy file content: %w", err)
+		}
+	}
+	if err := destFile.Commit(); err != nil {
+		return err
+	}
+
+	// Annotated files differ from their source by design
+	if lac.options.VerifyIntegrity && !copied {
+		if err := verifyCopy(sourcePath, destPath); err != nil {
+			return err
+		}
 	}
 
 	// Copy permissions
 	sourceInfo, err := os.Stat(sourcePath)
 	if err == nil {
-		os.Chmod(destPath, sourceInfo.Mode())
+		os.Chmod(destPath, copiedFileMode(sourceInfo.Mode(), lac.options.GitModeSemantics))
+	}
+
+	if lac.options.PreserveTimestamps {
+		if err := preserveModTime(sourcePath, destPath); err != nil {
+			return err
+		}
+	}
+
+	if lac.options.PreserveXattrs {
+		if err := copyXattrs(sourcePath, destPath); err != nil {
+			return err
+		}
+	}
+
+	trace.recordEntry(lac.source, sourcePath, decision)
+	if lac.onCopied != nil && sourceInfo != nil {
+		lac.onCopied(sourceInfo.Size())
 	}
 
 	return nil
@@ -363,7 +542,7 @@ func (lac *LegacyAutoCopier) copyFile(sourcePath, destPath string) error {
 // copyDirectory copies a directory and optionally its contents
 func (lac *LegacyAutoCopier) copyDirectory(sourcePath, destPath string, recursive bool) error {
 	// Create destination directory
-	if err := os.MkdirAll(destPath, 0755); err != nil {
+	if err := os.MkdirAll(lac.dest.mapPath(destPath), 0755); err != nil {
 		return fmt.Errorf("failed to create destination directory %s: %w", destPath, err)
 	}
 
@@ -371,6 +550,17 @@ func (lac *LegacyAutoCopier) copyDirectory(sourcePath, destPath string, recursiv
 		return nil // Only create the directory structure, not contents
 	}
 
+	var ignored map[string]bool
+	if skips := lac.options.repoSkips(); skips.enabled() {
+		repo, err := repositoryAt(sourcePath)
+		if err != nil {
+			return err
+		}
+		if ignored, err = skippedInTree(repo, sourcePath, skips); err != nil {
+			return err
+		}
+	}
+
 	// Copy directory contents recursively
 	return filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -382,6 +572,13 @@ func (lac *LegacyAutoCopier) copyDirectory(sourcePath, destPath string, recursiv
 			return nil
 		}
 
+		if ignored[path] {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
 		// Get relative path from source
 		relPath, err := filepath.Rel(sourcePath, path)
 		if err != nil {
@@ -390,11 +587,21 @@ func (lac *LegacyAutoCopier) copyDirectory(sourcePath, destPath string, recursiv
 
 		destItemPath := filepath.Join(destPath, relPath)
 
+		if isSpecialFile(info.Mode()) {
+			warnSpecialFile(path, info.Mode())
+			return nil
+		}
+
 		if info.IsDir() {
-			return os.MkdirAll(destItemPath, info.Mode())
-		} else {
-			return lac.copyFile(path, destItemPath)
+			if lac.filter.skipDir(path) {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(lac.dest.mapPath(destItemPath), info.Mode())
 		}
+		if lac.filter.skipFile(path) {
+			return nil
+		}
+		return lac.copyFile(path, destItemPath)
 	})
 }
 
@@ -404,120 +611,209 @@ func (ac *AutoCopier) Run(sourceDir, destDir string) error {
 		return fmt.Errorf("no configuration loaded")
 	}
 
-	// Use parallel copier if enabled
-	if ac.options.UseParallel {
-		return ac.runParallel(sourceDir, destDir)
+	copiedFiles, err := ac.Copy(sourceDir, destDir)
+	if err != nil {
+		return err
 	}
 
-	// Use sequential copier (original implementation)
-	return ac.runSequential(sourceDir, destDir)
+	// Update ignore file if we copied any files
+	if len(copiedFiles) > 0 && !ac.options.NoGitignoreUpdate && !ac.options.DryRun {
+		if err := UpdateIgnoreFile(destDir, ac.config.IgnoreTarget, copiedFiles); err != nil {
+			return fmt.Errorf("failed to update ignore file: %w", err)
+		}
+	}
+
+	return nil
 }
 
-// runParallel executes the auto-copy operation using parallel processing
-func (ac *AutoCopier) runParallel(sourceDir, destDir string) error {
-	parallelOptions := ParallelCopyOptions{
-		MaxWorkers:      ac.options.MaxWorkers,
-		BufferSize:      ac.options.BufferSize,
-		ShowProgress:    ac.options.ShowProgress,
-		VerifyIntegrity: ac.options.VerifyIntegrity,
-		ContinueOnError: true, // Continue on individual file errors
+// Copy copies the configured files without touching ignore files and returns
+// the copied entries. UseParallel selects the parallel copier; with DryRun
+// the planned files are printed and returned instead.
+func (ac *AutoCopier) Copy(sourceDir, destDir string) ([]string, error) {
+	if ac.config == nil {
+		return nil, fmt.Errorf("no configuration loaded")
 	}
 
-	// Set up progress callback if needed
-	if ac.options.ShowProgress {
-		parallelOptions.ProgressCallback = func(update ProgressUpdate) {
-			switch update.Type {
-			case ProgressTypeStart:
-				fmt.Printf("🚀 %s\n", update.Message)
-			case ProgressTypeProgress:
-				fmt.Printf("📋 %s (%.1f%%)\n", update.Message, update.Percentage)
-			case ProgressTypeComplete:
-				fmt.Printf("✅ %s in %v\n", update.Message, update.ElapsedTime)
-			}
-		}
+	if ac.options.DryRun {
+		return ac.dryRun(sourceDir, destDir)
 	}
 
-	// Track copied files for .gitignore update
-	var copiedFiles []string
-	var copiedFilesMutex sync.Mutex
+	if err := checkWritable(destDir); err != nil {
+		return nil, err
+	}
 
-	parallelOptions.ErrorCallback = func(err CopyError) {
-		fmt.Printf("⚠️  Failed to copy %s: %v\n", err.SourcePath, err.Error)
+	var copied []string
+	var err error
+	if ac.options.UseParallel {
+		copied, err = ac.copyParallel(sourceDir, destDir)
+	} else {
+		// Use sequential copier (original implementation)
+		copied, err = ac.copySequential(sourceDir, destDir)
 	}
 
-	// Create parallel copier
-	copier := NewParallelCopier(ac.repo, ac.config, parallelOptions)
+	// Skipped files that exist anyway, e.g. committed ones, were not copied
+	copied = withoutSkipped(copied, ac.options.SkipPaths)
+	if ac.options.SkipTracked && err == nil {
+		copied, err = withoutTracked(ac.repo, destDir, copied)
+	}
+	return copied, err
+}
 
-	// Execute parallel copy
-	if err := copier.Run(sourceDir, destDir); err != nil {
-		return fmt.Errorf("parallel copy failed: %w", err)
+// ExistingDestinations returns the files a copy from sourceDir would write
+// that already exist in destDir, as slash paths relative to destDir suitable
+// for SkipPaths
+func (ac *AutoCopier) ExistingDestinations(sourceDir, destDir string) ([]string, error) {
+	tasks, err := ac.Tasks(sourceDir, destDir)
+	if err != nil {
+		return nil, err
 	}
 
-	// Collect copied files for .gitignore update
-	// This is a simplified approach - in a real implementation,
-	// you'd want to track this during the copy operation
-	for _, item := range ac.config.Items {
-		files, err := ac.findCopiedFiles(destDir, item)
-		if err != nil {
-			continue // Continue on error
+	var existing []string
+	for _, task := range tasks {
+		if task.IsDir {
+			continue
+		}
+		if _, err := os.Lstat(task.DestPath); err == nil {
+			existing = append(existing, relativeSlash(destDir, task.DestPath))
 		}
-		copiedFilesMutex.Lock()
-		copiedFiles = append(copiedFiles, files...)
-		copiedFilesMutex.Unlock()
 	}
+	return existing, nil
+}
 
-	// Update .gitignore if we copied any files
-	if len(copiedFiles) > 0 && !ac.options.NoGitignoreUpdate {
-		if err := ac.repo.UpdateGitignore(copiedFiles); err != nil {
-			return fmt.Errorf("failed to update .gitignore: %w", err)
-		}
+// Estimate reports how many files and bytes Run would copy without copying.
+// Items whose source is missing are ignored, as they are when copying.
+func (ac *AutoCopier) Estimate(sourceDir, destDir string) (*CopyEstimate, error) {
+	if ac.config == nil {
+		return nil, fmt.Errorf("no configuration loaded")
+	}
+
+	copier, err := NewParallelCopier(ac.repo, ac.config, ParallelCopyOptions{
+		ContinueOnError:     true,
+		MaxTotalFiles:       ac.options.MaxTotalFiles,
+		MaxTotalBytes:       ac.options.MaxTotalBytes,
+		RespectGitignore:    ac.options.RespectGitignore,
+		RespectExportIgnore: ac.options.RespectExportIgnore,
+		StripPrefix:         ac.options.StripPrefix,
+		AddPrefix:           ac.options.AddPrefix,
+		SkipPaths:           ac.options.SkipPaths,
+	})
+	if err != nil {
+		return nil, err
 	}
+	return copier.Estimate(sourceDir, destDir)
+}
 
-	return nil
+// parallelOptions returns the ParallelCopier options matching ac's options
+func (ac *AutoCopier) parallelOptions() ParallelCopyOptions {
+	return ParallelCopyOptions{
+		MaxWorkers:          ac.options.MaxWorkers,
+		BufferSize:          ac.options.BufferSize,
+		ShowProgress:        ac.options.ShowProgress,
+		ProgressInterval:    ac.options.ProgressInterval,
+		VerifyIntegrity:     ac.options.VerifyIntegrity,
+		GitModeSemantics:    ac.options.GitModeSemantics,
+		MaxTotalFiles:       ac.options.MaxTotalFiles,
+		MaxTotalBytes:       ac.options.MaxTotalBytes,
+		RespectGitignore:    ac.options.RespectGitignore,
+		RespectExportIgnore: ac.options.RespectExportIgnore,
+		AddProvenanceHeader: ac.options.AddProvenanceHeader,
+		PreserveXattrs:      ac.options.PreserveXattrs,
+		Backup:              ac.options.Backup,
+		OnConflict:          ac.options.OnConflict,
+		StripPrefix:         ac.options.StripPrefix,
+		AddPrefix:           ac.options.AddPrefix,
+		SkipPaths:           ac.options.SkipPaths,
+		PreserveTimestamps:  ac.options.PreserveTimestamps,
+		PreserveSymlinks:    ac.options.PreserveSymlinks,
+		ForceRelink:         ac.options.ForceRelink,
+		SkipTracked:         ac.options.SkipTracked,
+		DeferVerifyMinSize:  ac.options.DeferVerifyMinSize,
+		MaxRetries:          ac.options.MaxRetries,
+		RetryBackoff:        ac.options.RetryBackoff,
+		ContinueOnError:     true, // Continue on individual file errors
+	}
 }
 
-// runSequential executes the auto-copy operation sequentially (original implementation)
-func (ac *AutoCopier) runSequential(sourceDir, destDir string) error {
-	// Use legacy copier for sequential processing
-	legacyCopier := NewLegacyAutoCopier()
-	copiedFiles, err := legacyCopier.CopyFiles(sourceDir, destDir, ac.config)
+// copyParallel copies the configured files using parallel processing
+func (ac *AutoCopier) copyParallel(sourceDir, destDir string) ([]string, error) {
+	dest, err := newDestMapper(destDir, ac.options.StripPrefix, ac.options.AddPrefix)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	ac.dest = dest
+
+	parallelOptions := ac.parallelOptions()
+
+	// Set up progress callback if needed
+	if ac.options.ShowProgress {
+		callback, err := ac.progressCallback()
+		if err != nil {
+			return nil, err
+		}
+		parallelOptions.ProgressCallback = callback
 	}
+	parallelOptions.Trace = ac.options.Trace
 
-	// Update .gitignore if we copied any files
-	if len(copiedFiles) > 0 && !ac.options.NoGitignoreUpdate {
-		if err := legacyCopier.UpdateGitignore(destDir, copiedFiles); err != nil {
-			return fmt.Errorf("failed to update .gitignore: %w", err)
+	parallelOptions.ErrorCallback = func(err CopyError) {
+		if err.Retries > 0 {
+			fmt.Printf("⚠️  Failed to copy %s after %d retries: %v\n", err.SourcePath, err.Retries, err.Error)
+			return
 		}
+		fmt.Printf("⚠️  Failed to copy %s: %v\n", err.SourcePath, err.Error)
 	}
 
-	return nil
-}
+	// Create parallel copier
+	copier, err := NewParallelCopier(ac.repo, ac.config, parallelOptions)
+	if err != nil {
+		return nil, err
+	}
 
-// findCopiedFiles finds files that were copied for a given item
-func (ac *AutoCopier) findCopiedFiles(destDir string, item AutoCopyItem) ([]string, error) {
-	var files []string
-	destPath := filepath.Join(destDir, item.Path)
+	// Execute parallel copy
+	err = copier.Run(sourceDir, destDir)
+	ac.verification = copier.Verification()
+	if err != nil {
+		return nil, fmt.Errorf("parallel copy failed: %w", err)
+	}
 
-	// Check if destination exists
-	info, err := os.Stat(destPath)
+	// Destinations are already mapped, so the files need no prefix handling
+	return copier.CopiedFiles(), nil
+}
+
+// copySequential copies the configured files sequentially (original implementation)
+func (ac *AutoCopier) copySequential(sourceDir, destDir string) ([]string, error) {
+	// Planning enforces the file limit before copying anything and counts the
+	// files progress is reported against
+	tasks, err := discoverTasks(ac.repo, ac.config, ac.parallelOptions(), sourceDir, destDir)
 	if err != nil {
-		if os.IsNotExist(err) {
-			return files, nil // No files copied
-		}
-		return files, err
+		return nil, err
 	}
 
-	if info.IsDir() {
-		// For directories, add the directory itself
-		files = append(files, item.Path)
-	} else {
-		// For files, add the file
-		files = append(files, item.Path)
+	// Use legacy copier for sequential processing
+	legacyCopier := NewLegacyAutoCopierWithOptions(ac.options)
+	if !ac.options.ShowProgress {
+		return legacyCopier.CopyFiles(sourceDir, destDir, ac.config)
 	}
 
-	return files, nil
+	callback, err := ac.progressCallback()
+	if err != nil {
+		return nil, err
+	}
+	progress := newSequentialProgress(tasks, callback)
+	legacyCopier.onCopied = progress.fileCopied
+	progress.start()
+	copied, err := legacyCopier.CopyFiles(sourceDir, destDir, ac.config)
+	if err == nil {
+		progress.complete()
+	}
+	return copied, err
+}
+
+// progressCallback returns the receiver of progress updates
+func (ac *AutoCopier) progressCallback() (func(ProgressUpdate), error) {
+	if ac.options.ProgressCallback != nil {
+		return ac.options.ProgressCallback, nil
+	}
+	return progressPrinter(ac.options.ProgressFormat)
 }
 
 // CopyFiles copies files according to the configuration
@@ -526,6 +822,26 @@ func (c *AutoCopier) CopyFiles(srcRoot, dstRoot string, config *AutoCopyConfig)
 		return nil, fmt.Errorf("config cannot be nil")
 	}
 
+	if err := checkWritable(dstRoot); err != nil {
+		return nil, err
+	}
+	if err := ValidateConflictPolicy(c.options.OnConflict); err != nil {
+		return nil, err
+	}
+
+	dest, err := newDestMapper(dstRoot, c.options.StripPrefix, c.options.AddPrefix)
+	if err != nil {
+		return nil, err
+	}
+	ignore, err := loadHatcherIgnore(srcRoot)
+	if err != nil {
+		return nil, err
+	}
+	c.source = srcRoot
+	c.dest = dest
+	c.filter = pathFilter{root: srcRoot, ignore: ignore, trace: c.options.Trace}
+	c.onConflict = ""
+
 	var copiedFiles []string
 
 	// Handle legacy format
@@ -539,19 +855,23 @@ func (c *AutoCopier) CopyFiles(srcRoot, dstRoot string, config *AutoCopyConfig)
 				copiedFiles = append(copiedFiles, file)
 			}
 		}
-		return copiedFiles, nil
+		return c.dest.mapCopied(copiedFiles), nil
 	}
 
 	// Handle new format
-	for _, item := range config.Items {
+	for _, item := range itemsByPriority(config.Items) {
+		c.filter = newPathFilter(srcRoot, item)
+		c.filter.ignore = ignore
+		c.filter.trace = c.options.Trace
+		c.onConflict = item.OnConflict
 		copied, err := c.copyItem(srcRoot, dstRoot, item)
 		if err != nil {
-			return copiedFiles, err
+			return c.dest.mapCopied(copiedFiles), err
 		}
 		copiedFiles = append(copiedFiles, copied...)
 	}
 
-	return copiedFiles, nil
+	return c.dest.mapCopied(copiedFiles), nil
 }
 
 // copyLegacyFile copies a file using legacy format rules
@@ -563,7 +883,9 @@ func (c *AutoCopier) copyLegacyFile(srcRoot, dstRoot, file string) (bool, error)
 	srcInfo, err := os.Stat(srcPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return false, nil // File doesn't exist, skip silently
+			// File doesn't exist, skip silently
+			tracer(c.options.Trace).record(srcRoot, srcPath, TraceSkippedMissing, "source does not exist")
+			return false, nil
 		}
 		return false, err
 	}
@@ -658,11 +980,18 @@ func (c *AutoCopier) copySingleItem(srcRoot, dstRoot string, item AutoCopyItem,
 	srcInfo, err := os.Stat(srcPath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, nil // File doesn't exist, skip silently
+			// File doesn't exist, skip silently
+			tracer(c.options.Trace).record(srcRoot, srcPath, TraceSkippedMissing, "source does not exist")
+			return nil, nil
 		}
 		return nil, err
 	}
 
+	if isSpecialFile(srcInfo.Mode()) {
+		warnSpecialFile(srcPath, srcInfo.Mode())
+		return nil, nil
+	}
+
 	// Determine if it's a directory
 	isDir := srcInfo.IsDir()
 	if item.AutoDetect {
@@ -680,6 +1009,9 @@ func (c *AutoCopier) copySingleItem(srcRoot, dstRoot string, item AutoCopyItem,
 	}
 
 	if isDir {
+		if c.filter.ignoredDir(srcPath) {
+			return nil, nil
+		}
 		copied, err := c.copyDirectory(srcPath, dstPath, item.Recursive)
 		if err != nil {
 			return nil, err
@@ -732,6 +1064,11 @@ func (c *AutoCopier) ProcessGlobPattern(pattern, srcRoot, dstRoot string) ([]str
 			continue
 		}
 
+		if isSpecialFile(info.Mode()) {
+			warnSpecialFile(srcPath, info.Mode())
+			continue
+		}
+
 		if info.IsDir() {
 			copied, err := c.copyDirectory(srcPath, dstPath, false)
 			if err != nil {
@@ -756,12 +1093,43 @@ func (c *AutoCopier) ProcessGlobPattern(pattern, srcRoot, dstRoot string) ([]str
 
 // copyFile copies a single file
 func (c *AutoCopier) copyFile(srcPath, dstPath string) (bool, error) {
+	dstPath = c.dest.mapPath(dstPath)
+	if skippedPath(c.dest.root, dstPath, c.options.SkipPaths) || c.filter.skipFile(srcPath) {
+		return false, nil
+	}
+	trace := tracer(c.options.Trace)
+	if c.options.SkipTracked {
+		if tracked, err := skipTrackedDestination(c.repo, c.dest.root, dstPath); tracked || err != nil {
+			if tracked {
+				trace.record(c.source, srcPath, TraceSkippedFiltered, "tracked in the destination worktree")
+			}
+			return false, err
+		}
+	}
+
+	if linked, written, err := handleSymlink(c.source, srcPath, dstPath, c.options.PreserveSymlinks, c.options.ForceRelink); errors.Is(err, ErrSymlinkOutsideRoot) {
+		logger.Warning("Skipping %v", err)
+		return false, nil
+	} else if linked || err != nil {
+		traceSymlink(trace, c.source, srcPath, written, err)
+		return written, err
+	}
+
 	// Create destination directory if it doesn't exist
 	dstDir := filepath.Dir(dstPath)
 	if err := os.MkdirAll(dstDir, 0755); err != nil {
 		return false, fmt.Errorf("failed to create destination directory %s: %w", dstDir, err)
 	}
 
+	policy := conflictPolicy(c.onConflict, c.options.OnConflict, c.options.Backup)
+	decision := trace.copyDecision(policy, srcPath, dstPath, c.options.AddProvenanceHeader)
+	if proceed, err := resolveConflict(policy, srcPath, dstPath, c.options.AddProvenanceHeader); !proceed || err != nil {
+		if decision.Decision == TraceConflict {
+			trace.recordEntry(c.source, srcPath, decision)
+		}
+		return false, err
+	}
+
 	// Open source file
 	srcFile, err := os.Open(srcPath)
 	if err != nil {
@@ -770,31 +1138,61 @@ func (c *AutoCopier) copyFile(srcPath, dstPath string) (bool, error) {
 	defer srcFile.Close()
 
 	// Create destination file
-	dstFile, err := os.Create(dstPath)
+	dstFile, err := createDestFile(dstPath, c.options.AtomicWrites)
 	if err != nil {
 		return false, fmt.Errorf("failed to create destination file %s: %w", dstPath, err)
 	}
-	defer dstFile.Close()
+	defer dstFile.Discard()
 
 	// Copy content
-	_, err = io.Copy(dstFile, srcFile)
-	if err != nil {
-		return false, fmt.Errorf("failed to copy file content: %w", err)
+	copied := false
+	if c.options.AddProvenanceHeader {
+		if copied, err = copyWithProvenance(dstFile, srcFile, srcPath); err != nil {
+			return false, err
+		}
+	}
+	if !copied {
+		if _, err := io.Copy(dstFile, srcFile); err != nil {
+			return false, fmt.Errorf("failed to copy file content: %w", err)
+		}
+	}
+	if err := dstFile.Commit(); err != nil {
+		return false, err
+	}
+
+	// Annotated files differ from their source by design
+	if c.options.VerifyIntegrity && !copied {
+		if err := verifyCopy(srcPath, dstPath); err != nil {
+			return false, err
+		}
 	}
 
 	// Copy permissions
 	srcInfo, err := os.Stat(srcPath)
 	if err == nil {
-		os.Chmod(dstPath, srcInfo.Mode())
+		os.Chmod(dstPath, copiedFileMode(srcInfo.Mode(), c.options.GitModeSemantics))
 	}
 
+	if c.options.PreserveTimestamps {
+		if err := preserveModTime(srcPath, dstPath); err != nil {
+			return false, err
+		}
+	}
+
+	if c.options.PreserveXattrs {
+		if err := copyXattrs(srcPath, dstPath); err != nil {
+			return false, err
+		}
+	}
+
+	trace.recordEntry(c.source, srcPath, decision)
 	return true, nil
 }
 
 // copyDirectory copies a directory and optionally its contents
 func (c *AutoCopier) copyDirectory(srcPath, dstPath string, recursive bool) (bool, error) {
 	// Create destination directory
-	if err := os.MkdirAll(dstPath, 0755); err != nil {
+	if err := os.MkdirAll(c.dest.mapPath(dstPath), 0755); err != nil {
 		return false, fmt.Errorf("failed to create destination directory %s: %w", dstPath, err)
 	}
 
@@ -814,11 +1212,36 @@ func (c *AutoCopier) copyDirectoryRecursive(srcPath, dstPath string) (bool, erro
 		return false, fmt.Errorf("failed to read directory %s: %w", srcPath, err)
 	}
 
+	// Check all entries of this level at once; ignored directories are
+	// pruned so their contents are never visited
+	var ignored map[string]bool
+	if skips := c.options.repoSkips(); skips.enabled() {
+		paths := make([]string, len(entries))
+		for i, entry := range entries {
+			paths[i] = filepath.Join(srcPath, entry.Name())
+		}
+		if ignored, err = skips.skippedPaths(c.repo, paths); err != nil {
+			return false, err
+		}
+	}
+
 	for _, entry := range entries {
 		srcEntryPath := filepath.Join(srcPath, entry.Name())
 		dstEntryPath := filepath.Join(dstPath, entry.Name())
 
+		if ignored[srcEntryPath] {
+			continue
+		}
+
+		if isSpecialFile(entry.Type()) {
+			warnSpecialFile(srcEntryPath, entry.Type())
+			continue
+		}
+
 		if entry.IsDir() {
+			if c.filter.skipDir(srcEntryPath) {
+				continue
+			}
 			_, err := c.copyDirectory(srcEntryPath, dstEntryPath, true)
 			if err != nil {
 				return false, err
@@ -842,26 +1265,94 @@ func (c *AutoCopier) UpdateGitignore(repoRoot string, files []string) error {
 
 	gitignorePath := filepath.Join(repoRoot, ".gitignore")
 
-	// Read existing .gitignore
-	var existing []byte
-	if _, err := os.Stat(gitignorePath); err == nil {
-		existing, _ = os.ReadFile(gitignorePath)
+	// Hold the lock across the read and write so concurrent updates are not lost
+	return filelock.With(gitignorePath, func() error {
+		// Read existing .gitignore
+		var existing []byte
+		if _, err := os.Stat(gitignorePath); err == nil {
+			existing, _ = os.ReadFile(gitignorePath)
+		}
+
+		// Prepare new content
+		content := string(existing)
+		if len(content) > 0 && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+
+		// Add separator comment
+		content += "\n# Auto-copied files (added by hatcher)\n"
+
+		// Add files
+		for _, file := range files {
+			content += file + "\n"
+		}
+
+		// Write back to .gitignore
+		return os.WriteFile(gitignorePath, []byte(content), 0644)
+	})
+}
+
+// withoutSkipped removes the skip paths from copied entries
+func withoutSkipped(copied, skip []string) []string {
+	if len(skip) == 0 {
+		return copied
 	}
 
-	// Prepare new content
-	content := string(existing)
-	if len(content) > 0 && !strings.HasSuffix(content, "\n") {
-		content += "\n"
+	kept := copied[:0]
+	for _, entry := range copied {
+		if !containsPath(skip, entry) {
+			kept = append(kept, entry)
+		}
 	}
+	return kept
+}
+
+// containsPath reports whether paths contains rel, comparing cleaned slash
+// paths
+func containsPath(paths []string, rel string) bool {
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	for _, path := range paths {
+		if rel == filepath.ToSlash(filepath.Clean(path)) {
+			return true
+		}
+	}
+	return false
+}
 
-	// Add separator comment
-	content += "\n# Auto-copied files (added by hatcher)\n"
+// skippedPath reports whether destPath below root is one of the skip paths
+func skippedPath(root, destPath string, skip []string) bool {
+	if len(skip) == 0 {
+		return false
+	}
 
-	// Add files
-	for _, file := range files {
-		content += file + "\n"
+	rel, err := filepath.Rel(root, destPath)
+	if err != nil {
+		return false
 	}
+	return containsPath(skip, rel)
+}
+
+// isSpecialFile reports whether mode describes a FIFO, socket, device or other
+// non-regular file whose contents cannot be streamed safely. Symlinks are not
+// considered special since they are resolved by the copy itself.
+func isSpecialFile(mode os.FileMode) bool {
+	return !mode.IsRegular() && !mode.IsDir() && mode&os.ModeSymlink == 0
+}
 
-	// Write back to .gitignore
-	return os.WriteFile(gitignorePath, []byte(content), 0644)
+// warnSpecialFile reports a skipped non-regular file
+func warnSpecialFile(path string, mode os.FileMode) {
+	logger.Warning("Skipping non-regular file %s (%s)", path, mode.Type())
+}
+
+// copiedFileMode returns the permissions to apply to a copied file. With git
+// mode semantics only the executable bit is kept, yielding 0755 or 0644 like
+// the modes git records, independent of the local umask.
+func copiedFileMode(mode os.FileMode, gitModeSemantics bool) os.FileMode {
+	if !gitModeSemantics {
+		return mode.Perm()
+	}
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
 }
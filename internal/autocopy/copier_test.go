@@ -1,10 +1,14 @@
 package autocopy
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -372,3 +376,163 @@ func TestAutoCopier_UpdateGitignore(t *testing.T) {
 		}
 	})
 }
+
+func TestAutoCopier_Copy(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "copy-mode-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".ai/prompts.md", "prompts")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	// The parallel copier reports every file it wrote
+	expected := map[bool][]string{
+		false: {"CLAUDE.md", ".ai/"},
+		true:  {"CLAUDE.md", ".ai/prompts.md"},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel})
+
+			copied, err := copier.Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, expected[parallel], copied)
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "prompts.md"))
+
+			// Copy leaves ignore files to the caller
+			assert.NoFileExists(t, filepath.Join(destDir, ".gitignore"))
+		})
+	}
+}
+
+func TestAutoCopier_SequentialProgress(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "sequential-progress-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".ai/prompts.md", "prompts")
+	testRepo.CreateFile(".ai/agents/review.md", "review")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	t.Run("reports start, each file and completion", func(t *testing.T) {
+		var updates []ProgressUpdate
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{
+			ShowProgress:     true,
+			ProgressCallback: func(update ProgressUpdate) { updates = append(updates, update) },
+		})
+
+		_, err := copier.Copy(testRepo.RepoDir, t.TempDir())
+		require.NoError(t, err)
+
+		require.Len(t, updates, 5)
+		assert.Equal(t, ProgressTypeStart, updates[0].Type)
+		assert.Equal(t, 3, updates[0].Total)
+		for i, update := range updates[1:4] {
+			assert.Equal(t, ProgressTypeProgress, update.Type)
+			assert.Equal(t, i+1, update.Current)
+			assert.Equal(t, 3, update.Total)
+		}
+		assert.Equal(t, 100.0, updates[3].Percentage)
+
+		complete := updates[4]
+		assert.Equal(t, ProgressTypeComplete, complete.Type)
+		assert.Equal(t, 3, complete.Current)
+		assert.Equal(t, int64(len("rules")+len("prompts")+len("review")), complete.BytesCopied)
+		assert.Equal(t, complete.TotalBytes, complete.BytesCopied)
+	})
+
+	t.Run("silent without ShowProgress", func(t *testing.T) {
+		called := false
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{
+			ProgressCallback: func(ProgressUpdate) { called = true },
+		})
+
+		_, err := copier.Copy(testRepo.RepoDir, t.TempDir())
+		require.NoError(t, err)
+		assert.False(t, called)
+	})
+}
+
+func TestAutoCopier_ProgressJSONL(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "progress-jsonl-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	const numFiles = 5
+	for i := 0; i < numFiles; i++ {
+		testRepo.CreateFile(fmt.Sprintf(".ai/file%d.md", i), fmt.Sprintf("content %d", i))
+	}
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	t.Run("prints every update as a JSON line", func(t *testing.T) {
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{
+			UseParallel:      true,
+			ShowProgress:     true,
+			ProgressFormat:   ProgressFormatJSONL,
+			ProgressInterval: 1,
+		})
+
+		var copyErr error
+		stdout, _ := testutil.CaptureOutput(t, func() {
+			_, copyErr = copier.Copy(testRepo.RepoDir, t.TempDir())
+		})
+		require.NoError(t, copyErr)
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		var updates []ProgressUpdate
+		for _, line := range lines {
+			var update ProgressUpdate
+			require.NoError(t, json.Unmarshal([]byte(line), &update), line)
+			updates = append(updates, update)
+		}
+
+		require.GreaterOrEqual(t, len(updates), numFiles+2)
+		assert.Equal(t, ProgressTypeStart, updates[0].Type)
+		complete := updates[len(updates)-1]
+		assert.Equal(t, ProgressTypeComplete, complete.Type)
+		assert.Equal(t, complete.Total, complete.Current)
+
+		// One update per completed task with an interval of 1
+		progress := 0
+		for _, update := range updates {
+			if update.Type == ProgressTypeProgress {
+				progress++
+			}
+		}
+		assert.Equal(t, complete.Total, progress)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{
+			UseParallel:    true,
+			ShowProgress:   true,
+			ProgressFormat: "xml",
+		})
+
+		_, err := copier.Copy(testRepo.RepoDir, t.TempDir())
+		assert.ErrorIs(t, err, ErrUnsupportedProgressFormat)
+	})
+}
@@ -0,0 +1,105 @@
+package autocopy
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// destMapper rewrites destination paths below a worktree root by stripping
+// a leading prefix and adding another one
+type destMapper struct {
+	root  string
+	strip string
+	add   string
+}
+
+// newDestMapper creates a mapper for destinations below root. The prefixes
+// are validated like configured paths so they cannot leave the worktree.
+func newDestMapper(root, stripPrefix, addPrefix string) (destMapper, error) {
+	strip, err := cleanPrefix(stripPrefix)
+	if err != nil {
+		return destMapper{}, fmt.Errorf("invalid strip prefix %q: %w", stripPrefix, err)
+	}
+
+	add, err := cleanPrefix(addPrefix)
+	if err != nil {
+		return destMapper{}, fmt.Errorf("invalid add prefix %q: %w", addPrefix, err)
+	}
+
+	return destMapper{root: root, strip: strip, add: add}, nil
+}
+
+// cleanPrefix normalizes a prefix to a slash-separated path without leading
+// or trailing slashes
+func cleanPrefix(prefix string) (string, error) {
+	cleaned := strings.Trim(filepath.ToSlash(prefix), "/")
+	if cleaned == "" {
+		return "", nil
+	}
+	if err := validatePath(cleaned); err != nil {
+		return "", err
+	}
+	return cleaned, nil
+}
+
+// mapRelative applies the prefixes to a path relative to the root. A path
+// equal to the stripped prefix maps to "", the root itself.
+func (m destMapper) mapRelative(relPath string) string {
+	rel := filepath.ToSlash(filepath.Clean(relPath))
+	if rel == "." {
+		rel = ""
+	}
+
+	if m.strip != "" {
+		if rel == m.strip {
+			rel = ""
+		} else if strings.HasPrefix(rel, m.strip+"/") {
+			rel = strings.TrimPrefix(rel, m.strip+"/")
+		}
+	}
+
+	if m.add != "" {
+		rel = path.Join(m.add, rel)
+	}
+
+	return filepath.FromSlash(rel)
+}
+
+// mapPath applies the prefixes to a destination path below the root. Paths
+// outside the root are returned unchanged.
+func (m destMapper) mapPath(destPath string) string {
+	if m.strip == "" && m.add == "" {
+		return destPath
+	}
+
+	rel, err := filepath.Rel(m.root, destPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return destPath
+	}
+
+	return filepath.Join(m.root, m.mapRelative(rel))
+}
+
+// mapCopied applies the prefixes to the reported copied paths, dropping
+// entries that map onto the root itself
+func (m destMapper) mapCopied(copied []string) []string {
+	if m.strip == "" && m.add == "" {
+		return copied
+	}
+
+	mapped := make([]string, 0, len(copied))
+	for _, relPath := range copied {
+		trailingSlash := strings.HasSuffix(relPath, "/")
+		rel := filepath.ToSlash(m.mapRelative(relPath))
+		if rel == "" {
+			continue
+		}
+		if trailingSlash {
+			rel += "/"
+		}
+		mapped = append(mapped, rel)
+	}
+	return mapped
+}
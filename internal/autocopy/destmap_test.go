@@ -0,0 +1,120 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDestMapper(t *testing.T) {
+	root := filepath.Join(string(filepath.Separator), "worktree")
+
+	tests := []struct {
+		name     string
+		strip    string
+		add      string
+		relPath  string
+		expected string
+	}{
+		{"no prefixes", "", "", "config/ai/rules.md", "config/ai/rules.md"},
+		{"strip prefix", "config/ai/", "", "config/ai/rules.md", "rules.md"},
+		{"strip leaves other paths alone", "config/ai/", "", "config/other.md", "config/other.md"},
+		{"strip does not match partial names", "config/ai", "", "config/aid/x.md", "config/aid/x.md"},
+		{"add prefix", "", ".ai", "rules.md", ".ai/rules.md"},
+		{"strip and add", "config/ai/", ".ai/", "config/ai/prompts/a.md", ".ai/prompts/a.md"},
+		{"stripped directory maps to the added prefix", "config/ai", ".ai", "config/ai", ".ai"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper, err := newDestMapper(root, tt.strip, tt.add)
+			require.NoError(t, err)
+
+			mapped := mapper.mapPath(filepath.Join(root, filepath.FromSlash(tt.relPath)))
+			assert.Equal(t, filepath.Join(root, filepath.FromSlash(tt.expected)), mapped)
+		})
+	}
+
+	t.Run("copied entries", func(t *testing.T) {
+		mapper, err := newDestMapper(root, "config/ai/", "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"rules.md", "prompts/"}, mapper.mapCopied([]string{"config/ai/", "config/ai/rules.md", "config/ai/prompts/"}))
+	})
+
+	t.Run("prefixes cannot leave the worktree", func(t *testing.T) {
+		_, err := newDestMapper(root, "", "../outside")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid add prefix")
+
+		_, err = newDestMapper(root, "a/../..", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid strip prefix")
+	})
+}
+
+func TestStripAndAddPrefix(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "prefix-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("config/ai/rules.md", "rules")
+	testRepo.CreateFile("config/ai/prompts/review.md", "review")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "config/ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+	options := AutoCopierOptions{StripPrefix: "config/ai/", AddPrefix: ".ai"}
+
+	assertRemapped := func(t *testing.T, destDir string) {
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "rules.md"))
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "prompts", "review.md"))
+		assert.NoDirExists(t, filepath.Join(destDir, "config"))
+	}
+
+	t.Run("legacy copier", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "legacy")
+		copied, err := NewLegacyAutoCopierWithOptions(options).CopyFiles(testRepo.RepoDir, destDir, config)
+		require.NoError(t, err)
+		assertRemapped(t, destDir)
+		assert.Equal(t, []string{".ai/"}, copied)
+	})
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("auto copier (parallel=%t)", parallel), func(t *testing.T) {
+			destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("auto-%t", parallel))
+			require.NoError(t, os.MkdirAll(destDir, 0755))
+
+			runOptions := options
+			runOptions.UseParallel = parallel
+			require.NoError(t, NewAutoCopier(repo, config, runOptions).Run(testRepo.RepoDir, destDir))
+			assertRemapped(t, destDir)
+
+			gitignore, err := os.ReadFile(filepath.Join(destDir, ".gitignore"))
+			require.NoError(t, err)
+			if parallel {
+				// The parallel copier reports every file it wrote
+				assert.Contains(t, string(gitignore), ".ai/rules.md\n")
+				assert.Contains(t, string(gitignore), ".ai/prompts/review.md\n")
+			} else {
+				assert.Contains(t, string(gitignore), ".ai/\n")
+			}
+			assert.NotContains(t, string(gitignore), "config/ai")
+		})
+	}
+
+	t.Run("invalid prefix fails the copy", func(t *testing.T) {
+		_, err := NewLegacyAutoCopierWithOptions(AutoCopierOptions{AddPrefix: "../escape"}).
+			CopyFiles(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "invalid"), config)
+		require.Error(t, err)
+		assert.NoDirExists(t, filepath.Join(testRepo.TempDir, "escape"))
+	})
+}
@@ -0,0 +1,46 @@
+package autocopy
+
+import (
+	"fmt"
+)
+
+// Tasks resolves the copy tasks a copy from sourceDir to destDir would
+// execute, in copy order, without copying anything. destDir does not need to
+// exist.
+func (ac *AutoCopier) Tasks(sourceDir, destDir string) ([]CopyTask, error) {
+	if ac.config == nil {
+		return nil, fmt.Errorf("no configuration loaded")
+	}
+
+	return discoverTasks(ac.repo, ac.config, ac.parallelOptions(), sourceDir, destDir)
+}
+
+// dryRun prints every file a copy would write and returns their paths
+// relative to destDir, without touching the filesystem
+func (ac *AutoCopier) dryRun(sourceDir, destDir string) ([]string, error) {
+	tasks, err := ac.Tasks(sourceDir, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// A destination written by several items is copied once, by the last
+	last := make(map[string]int)
+	for i, task := range tasks {
+		last[task.DestPath] = i
+	}
+
+	files := []string{}
+	var totalBytes int64
+	for i, task := range tasks {
+		if task.IsDir || last[task.DestPath] != i {
+			continue
+		}
+		dest := relativeSlash(destDir, task.DestPath)
+		fmt.Printf("  📄 %s → %s\n", relativeSlash(sourceDir, task.SourcePath), dest)
+		files = append(files, dest)
+		totalBytes += task.Size
+	}
+	fmt.Printf("📊 Would copy %d files (%s)\n", len(files), formatSize(totalBytes))
+
+	return files, nil
+}
@@ -0,0 +1,75 @@
+package autocopy
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoCopierDryRun(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "dry-run-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("notes.txt", "notes")
+	testRepo.CreateFile("todo.txt", "todo")
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+	testRepo.CreateFile("README.md", "readme")
+
+	t.Run("empty config", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "empty-dest")
+		copier := NewAutoCopier(repo, &AutoCopyConfig{Version: 2}, AutoCopierOptions{DryRun: true})
+
+		tasks, err := copier.Tasks(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.Empty(t, tasks)
+
+		files, err := copier.Copy(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+		assert.NoDirExists(t, destDir)
+	})
+
+	t.Run("glob items", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "glob-dest")
+		config := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: "*.txt", Directory: testutil.BoolPtr(false), UseGlob: true},
+				{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+			},
+		}
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{DryRun: true})
+
+		tasks, err := copier.Tasks(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		var sources []string
+		for _, task := range tasks {
+			if !task.IsDir {
+				sources = append(sources, relativeSlash(testRepo.RepoDir, task.SourcePath))
+			}
+		}
+		assert.ElementsMatch(t, []string{"notes.txt", "todo.txt", ".ai/prompt.md"}, sources)
+
+		files, err := copier.Copy(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"notes.txt", "todo.txt", ".ai/prompt.md"}, files)
+		assert.NoDirExists(t, destDir)
+	})
+
+	t.Run("run writes nothing", func(t *testing.T) {
+		destDir := t.TempDir()
+		config := &AutoCopyConfig{
+			Version: 2,
+			Items:   []AutoCopyItem{{Path: "README.md", Directory: testutil.BoolPtr(false)}},
+		}
+
+		require.NoError(t, NewAutoCopier(repo, config, AutoCopierOptions{DryRun: true}).Run(testRepo.RepoDir, destDir))
+		assert.NoFileExists(t, filepath.Join(destDir, "README.md"))
+		assert.NoFileExists(t, filepath.Join(destDir, ".gitignore"))
+	})
+}
@@ -0,0 +1,82 @@
+//go:build !windows
+
+package autocopy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyGitModeSemantics(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "file-mode-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// Explicit chmod so the source modes do not depend on the umask
+	testRepo.CreateFile("config.env", "KEY=value")
+	testRepo.CreateFile("run.sh", "#!/bin/sh")
+	require.NoError(t, os.Chmod(filepath.Join(testRepo.RepoDir, "config.env"), 0666))
+	require.NoError(t, os.Chmod(filepath.Join(testRepo.RepoDir, "run.sh"), 0775))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "config.env", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: "run.sh", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+
+	modeOf := func(t *testing.T, path string) os.FileMode {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		return info.Mode().Perm()
+	}
+
+	tests := []struct {
+		name     string
+		parallel bool
+	}{
+		{name: "sequential", parallel: false},
+		{name: "parallel", parallel: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name+" with git mode semantics", func(t *testing.T) {
+			destDir := filepath.Join(testRepo.TempDir, tt.name+"-git-mode")
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{
+				UseParallel:       tt.parallel,
+				GitModeSemantics:  true,
+				NoGitignoreUpdate: true,
+			})
+			require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+			assert.Equal(t, os.FileMode(0644), modeOf(t, filepath.Join(destDir, "config.env")))
+			assert.Equal(t, os.FileMode(0755), modeOf(t, filepath.Join(destDir, "run.sh")))
+		})
+
+		t.Run(tt.name+" preserves source mode by default", func(t *testing.T) {
+			destDir := filepath.Join(testRepo.TempDir, tt.name+"-source-mode")
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{
+				UseParallel:       tt.parallel,
+				NoGitignoreUpdate: true,
+			})
+			require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+			assert.Equal(t, os.FileMode(0666), modeOf(t, filepath.Join(destDir, "config.env")))
+			assert.Equal(t, os.FileMode(0775), modeOf(t, filepath.Join(destDir, "run.sh")))
+		})
+	}
+}
+
+func TestCopiedFileMode(t *testing.T) {
+	assert.Equal(t, os.FileMode(0644), copiedFileMode(0666, true))
+	assert.Equal(t, os.FileMode(0644), copiedFileMode(0600, true))
+	assert.Equal(t, os.FileMode(0755), copiedFileMode(0700, true))
+	assert.Equal(t, os.FileMode(0600), copiedFileMode(0600, false))
+}
@@ -0,0 +1,188 @@
+package autocopy
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// pathFilter decides which paths below an item are copied, from the item's
+// Exclude and Include patterns. Patterns are matched against paths relative
+// to the source root:
+//
+//   - "*", "?" and "[...]" match within a path segment, "**" matches any
+//     number of segments
+//   - a pattern ending in "/" only matches directories, and with them
+//     everything below
+//   - a pattern without any other "/" matches the name of the path or of any
+//     of its parent directories, e.g. "*.log" or "node_modules/"
+//
+// Excludes win over includes; when includes are set, only files matching
+// one of them are copied. Paths ignored by the source's .hatcherignore are
+// skipped before the item's patterns apply.
+type pathFilter struct {
+	root    string
+	exclude []string
+	include []string
+	ignore  hatcherIgnore
+	trace   tracer
+}
+
+// newPathFilter returns the filter of item for paths below root
+func newPathFilter(root string, item AutoCopyItem) pathFilter {
+	return pathFilter{root: root, exclude: item.Exclude, include: item.Include}
+}
+
+// skipDir reports whether the directory at path is excluded with everything
+// below it
+func (f pathFilter) skipDir(dirPath string) bool {
+	if len(f.exclude) == 0 && len(f.ignore.rules) == 0 {
+		return false
+	}
+	rel, ok := f.relative(dirPath)
+	if !ok {
+		return false
+	}
+
+	reason := ""
+	switch {
+	case f.ignore.prunesDir(rel):
+		reason = "ignored by " + HatcherIgnoreFile
+	case matchesAny(f.exclude, rel, true):
+		reason = "excluded by " + matchingPattern(f.exclude, rel, true)
+	default:
+		return false
+	}
+	f.trace.record(f.root, dirPath, TraceSkippedFiltered, reason)
+	return true
+}
+
+// ignoredDir reports whether .hatcherignore excludes the directory at path
+// with everything below it; the item's own patterns do not apply to it
+func (f pathFilter) ignoredDir(dirPath string) bool {
+	if len(f.ignore.rules) == 0 {
+		return false
+	}
+	rel, ok := f.relative(dirPath)
+	if !ok || !f.ignore.prunesDir(rel) {
+		return false
+	}
+	f.trace.record(f.root, dirPath, TraceSkippedFiltered, "ignored by "+HatcherIgnoreFile)
+	return true
+}
+
+// skipFile reports whether the file at path is not copied
+func (f pathFilter) skipFile(filePath string) bool {
+	if len(f.exclude) == 0 && len(f.include) == 0 && len(f.ignore.rules) == 0 {
+		return false
+	}
+	rel, ok := f.relative(filePath)
+	if !ok {
+		return false
+	}
+
+	reason := ""
+	switch {
+	case f.ignore.ignored(rel, false):
+		reason = "ignored by " + HatcherIgnoreFile
+	case matchesAny(f.exclude, rel, false):
+		reason = "excluded by " + matchingPattern(f.exclude, rel, false)
+	case len(f.include) > 0 && !matchesAny(f.include, rel, false):
+		reason = "not matched by any include pattern"
+	default:
+		return false
+	}
+	f.trace.record(f.root, filePath, TraceSkippedFiltered, reason)
+	return true
+}
+
+// relative returns p relative to the filter's root as a slash path
+func (f pathFilter) relative(p string) (string, bool) {
+	return relativeInside(f.root, p)
+}
+
+// matchesAny reports whether rel, or one of its parent directories, matches
+// any of patterns
+func matchesAny(patterns []string, rel string, isDir bool) bool {
+	for _, pattern := range patterns {
+		// Parents are directories, so directory-only patterns apply to them
+		for candidate, candidateIsDir := rel, isDir; candidate != "."; candidate, candidateIsDir = path.Dir(candidate), true {
+			if matchPattern(pattern, candidate, candidateIsDir) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchingPattern returns the first of patterns that matchesAny finds for
+// rel
+func matchingPattern(patterns []string, rel string, isDir bool) string {
+	for _, pattern := range patterns {
+		if matchesAny([]string{pattern}, rel, isDir) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// matchPattern reports whether the slash path rel matches pattern
+func matchPattern(pattern, rel string, isDir bool) bool {
+	if strings.HasSuffix(pattern, "/") {
+		if !isDir {
+			return false
+		}
+		pattern = strings.TrimSuffix(pattern, "/")
+	}
+
+	// Patterns without a separator match names at any depth
+	if !strings.Contains(pattern, "/") {
+		matched, _ := path.Match(pattern, path.Base(rel))
+		return matched
+	}
+
+	pattern = strings.TrimPrefix(pattern, "/")
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(rel, "/"))
+}
+
+// matchSegments matches path segments against pattern segments, where "**"
+// matches zero or more segments
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(rest, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(segments) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(pattern[0], segments[0]); !matched {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// ValidateFilterPattern checks the syntax of an exclude or include pattern
+func ValidateFilterPattern(pattern string) error {
+	if strings.TrimSuffix(pattern, "/") == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	for _, segment := range strings.Split(filepath.ToSlash(pattern), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
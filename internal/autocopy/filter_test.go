@@ -0,0 +1,129 @@
+package autocopy
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		rel     string
+		isDir   bool
+		want    bool
+	}{
+		{"*.log", "debug.log", false, true},
+		{"*.log", "logs/debug.log", false, true},
+		{"*.log", "debug.txt", false, false},
+		{"**/*.log", "debug.log", false, true},
+		{"**/*.log", "a/b/c/debug.log", false, true},
+		{".ai/**", ".ai/cache/index", false, true},
+		{".ai/cache/", ".ai/cache", true, true},
+		{".ai/cache/", ".ai/cache", false, false},
+		{".ai/cache/", "other/.ai/cache", true, false},
+		{"node_modules/", "web/node_modules", true, true},
+		{"node_modules/", "node_modules", false, false},
+		{"file?.txt", "file1.txt", false, true},
+		{"file?.txt", "file10.txt", false, false},
+		{"[ab].md", "a.md", false, true},
+		{"[ab].md", "c.md", false, false},
+		{"docs/*.md", "docs/guide.md", false, true},
+		{"docs/*.md", "docs/sub/guide.md", false, false},
+		{"docs/**/*.md", "docs/sub/guide.md", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s %s", tt.pattern, tt.rel), func(t *testing.T) {
+			assert.Equal(t, tt.want, matchPattern(tt.pattern, tt.rel, tt.isDir))
+		})
+	}
+}
+
+func TestPathFilter(t *testing.T) {
+	root := filepath.Join("/src")
+	file := func(rel string) string { return filepath.Join(root, filepath.FromSlash(rel)) }
+
+	t.Run("empty lists copy everything", func(t *testing.T) {
+		filter := newPathFilter(root, AutoCopyItem{Path: ".ai/"})
+		assert.False(t, filter.skipDir(file(".ai/cache")))
+		assert.False(t, filter.skipFile(file(".ai/cache/index")))
+	})
+
+	t.Run("excluded directories are skipped with their contents", func(t *testing.T) {
+		filter := newPathFilter(root, AutoCopyItem{Path: ".ai/", Exclude: []string{".ai/cache/"}})
+		assert.True(t, filter.skipDir(file(".ai/cache")))
+		assert.True(t, filter.skipFile(file(".ai/cache/index")))
+		assert.False(t, filter.skipFile(file(".ai/prompt.md")))
+	})
+
+	t.Run("includes restrict files", func(t *testing.T) {
+		filter := newPathFilter(root, AutoCopyItem{Path: ".ai/", Include: []string{"*.md"}})
+		assert.False(t, filter.skipFile(file(".ai/prompt.md")))
+		assert.True(t, filter.skipFile(file(".ai/notes.txt")))
+		// Directories are still walked to find included files
+		assert.False(t, filter.skipDir(file(".ai/docs")))
+	})
+
+	t.Run("exclude wins over include", func(t *testing.T) {
+		filter := newPathFilter(root, AutoCopyItem{
+			Path:    ".ai/",
+			Exclude: []string{"**/draft.md"},
+			Include: []string{"*.md"},
+		})
+		assert.True(t, filter.skipFile(file(".ai/draft.md")))
+		assert.False(t, filter.skipFile(file(".ai/prompt.md")))
+	})
+}
+
+func TestValidateFilterPattern(t *testing.T) {
+	for _, pattern := range []string{"*.log", "**/*.log", ".ai/cache/", "[ab].md", "file?.txt"} {
+		assert.NoError(t, ValidateFilterPattern(pattern), pattern)
+	}
+	for _, pattern := range []string{"", "/", "[", "docs/[a-"} {
+		assert.Error(t, ValidateFilterPattern(pattern), pattern)
+	}
+}
+
+func TestCopyExcludeInclude(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "filter-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+	testRepo.CreateFile(".ai/notes.txt", "notes")
+	testRepo.CreateFile(".ai/draft.md", "draft")
+	testRepo.CreateFile(".ai/cache/index.md", "cache")
+	testRepo.CreateFile(".ai/cache/deep/blob.bin", "blob")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{{
+			Path:      ".ai/",
+			Directory: testutil.BoolPtr(true),
+			Recursive: true,
+			RootOnly:  true,
+			Exclude:   []string{".ai/cache/", "draft.md"},
+			Include:   []string{"*.md"},
+		}},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+
+			_, err := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel}).Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "prompt.md"))
+			assert.NoFileExists(t, filepath.Join(destDir, ".ai", "notes.txt"))
+			assert.NoFileExists(t, filepath.Join(destDir, ".ai", "draft.md"))
+			assert.NoDirExists(t, filepath.Join(destDir, ".ai", "cache"))
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package autocopy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HatcherIgnoreFile lists paths, relative to the source root, that are never
+// auto-copied, whatever the configured items match
+const HatcherIgnoreFile = ".hatcherignore"
+
+// ignoreRule is a single .hatcherignore pattern
+type ignoreRule struct {
+	pattern string
+	negate  bool // "!" re-includes paths an earlier pattern ignored
+}
+
+// hatcherIgnore holds the patterns of a .hatcherignore file in file order.
+// Patterns use the syntax of item excludes, so a pattern ending in "/"
+// ignores a directory with everything below it. The last pattern matching a
+// path, or one of its parent directories, decides whether it is ignored.
+type hatcherIgnore struct {
+	rules []ignoreRule
+}
+
+// loadHatcherIgnore reads the .hatcherignore file in root. A missing file
+// ignores nothing.
+func loadHatcherIgnore(root string) (hatcherIgnore, error) {
+	file, err := os.Open(filepath.Join(root, HatcherIgnoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return hatcherIgnore{}, nil
+		}
+		return hatcherIgnore{}, fmt.Errorf("failed to read %s: %w", HatcherIgnoreFile, err)
+	}
+	defer file.Close()
+
+	ignore, err := parseHatcherIgnore(file)
+	if err != nil {
+		return hatcherIgnore{}, fmt.Errorf("invalid %s: %w", HatcherIgnoreFile, err)
+	}
+	return ignore, nil
+}
+
+// parseHatcherIgnore parses .hatcherignore content. Blank lines and lines
+// starting with "#" are skipped.
+func parseHatcherIgnore(r io.Reader) (hatcherIgnore, error) {
+	var ignore hatcherIgnore
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		rule := ignoreRule{pattern: pattern}
+		if strings.HasPrefix(pattern, "!") {
+			rule = ignoreRule{pattern: strings.TrimPrefix(pattern, "!"), negate: true}
+		}
+		if err := ValidateFilterPattern(rule.pattern); err != nil {
+			return hatcherIgnore{}, fmt.Errorf("line %d: %w", line, err)
+		}
+		ignore.rules = append(ignore.rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return hatcherIgnore{}, err
+	}
+	return ignore, nil
+}
+
+// ignored reports whether the slash path rel, relative to the source root,
+// is ignored
+func (h hatcherIgnore) ignored(rel string, isDir bool) bool {
+	ignored := false
+	for _, rule := range h.rules {
+		if matchesAny([]string{rule.pattern}, rel, isDir) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// prunesDir reports whether the directory rel is ignored with everything
+// below it, so walks can skip it. A negation that could re-include a path
+// below rel keeps the directory.
+func (h hatcherIgnore) prunesDir(rel string) bool {
+	if !h.ignored(rel, true) {
+		return false
+	}
+	for _, rule := range h.rules {
+		if rule.negate {
+			return false
+		}
+	}
+	return true
+}
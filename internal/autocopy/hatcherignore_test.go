@@ -0,0 +1,104 @@
+package autocopy
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHatcherIgnore(t *testing.T) {
+	t.Run("skips comments and blank lines", func(t *testing.T) {
+		ignore, err := parseHatcherIgnore(strings.NewReader("# secrets\n\n*.pem\n  !public.pem  \n"))
+		require.NoError(t, err)
+		assert.Equal(t, []ignoreRule{
+			{pattern: "*.pem"},
+			{pattern: "public.pem", negate: true},
+		}, ignore.rules)
+	})
+
+	t.Run("reports invalid patterns with their line", func(t *testing.T) {
+		_, err := parseHatcherIgnore(strings.NewReader("*.pem\n[z-a\n"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+
+	t.Run("last matching pattern wins", func(t *testing.T) {
+		ignore, err := parseHatcherIgnore(strings.NewReader("build/\n!build/keep.txt\n*.log\n"))
+		require.NoError(t, err)
+
+		assert.True(t, ignore.ignored("build/out.bin", false))
+		assert.False(t, ignore.ignored("build/keep.txt", false))
+		assert.True(t, ignore.ignored("nested/debug.log", false))
+		assert.False(t, ignore.ignored("src/main.go", false))
+
+		// The negation may re-include files below build/
+		assert.False(t, ignore.prunesDir("build"))
+	})
+
+	t.Run("directories without negations are pruned", func(t *testing.T) {
+		ignore, err := parseHatcherIgnore(strings.NewReader("node_modules/\n"))
+		require.NoError(t, err)
+		assert.True(t, ignore.prunesDir("web/node_modules"))
+		assert.False(t, ignore.prunesDir("web"))
+	})
+}
+
+func TestHatcherIgnoreCopy(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "hatcherignore-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("config/app.env", "APP=1")
+	testRepo.CreateFile("config/secrets.env", "TOKEN=secret")
+	testRepo.CreateFile(".ai/prompts.md", "prompts")
+	testRepo.CreateFile(".ai/cache/state.bin", "state")
+	testRepo.CreateFile(".ai/cache/keep.md", "keep")
+	testRepo.CreateFile(HatcherIgnoreFile, "# Never copied\nsecrets.env\n.ai/cache/\n!.ai/cache/keep.md\n")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "config/*.env", Directory: testutil.BoolPtr(false), UseGlob: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+			_, err := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel}).Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+
+			assert.FileExists(t, filepath.Join(destDir, "config", "app.env"))
+			assert.NoFileExists(t, filepath.Join(destDir, "config", "secrets.env"))
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "prompts.md"))
+			assert.NoFileExists(t, filepath.Join(destDir, ".ai", "cache", "state.bin"))
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "cache", "keep.md"))
+		})
+	}
+
+	t.Run("item excludes still apply", func(t *testing.T) {
+		excluding := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true, Exclude: []string{"keep.md"}},
+			},
+		}
+		tasks, err := NewAutoCopier(repo, excluding, AutoCopierOptions{}).Tasks(testRepo.RepoDir, t.TempDir())
+		require.NoError(t, err)
+
+		var files []string
+		for _, task := range tasks {
+			if !task.IsDir {
+				files = append(files, relativeSlash(testRepo.RepoDir, task.SourcePath))
+			}
+		}
+		assert.Equal(t, []string{".ai/prompts.md"}, files)
+	})
+}
@@ -0,0 +1,126 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/filelock"
+)
+
+// Ignore targets for auto-copied entries
+const (
+	// IgnoreTargetGitignore writes entries to the worktree's .gitignore (committed)
+	IgnoreTargetGitignore = "gitignore"
+	// IgnoreTargetExclude writes entries to the repository's info/exclude (local only)
+	IgnoreTargetExclude = "exclude"
+)
+
+// ignoreSectionHeader marks the block of entries added by hatcher
+const ignoreSectionHeader = "# Auto-copied files (added by hatcher)"
+
+// ValidateIgnoreTarget checks that target is a supported ignore target.
+// An empty target selects the default (.gitignore).
+func ValidateIgnoreTarget(target string) error {
+	switch target {
+	case "", IgnoreTargetGitignore, IgnoreTargetExclude:
+		return nil
+	default:
+		return fmt.Errorf("unsupported ignoreTarget %q (expected %q or %q)", target, IgnoreTargetGitignore, IgnoreTargetExclude)
+	}
+}
+
+// ResolveIgnoreFile returns the path of the ignore file for the worktree at dir
+func ResolveIgnoreFile(dir, target string) (string, error) {
+	if err := ValidateIgnoreTarget(target); err != nil {
+		return "", err
+	}
+
+	if target != IgnoreTargetExclude {
+		return filepath.Join(dir, ".gitignore"), nil
+	}
+
+	// Let git resolve the path: in a linked worktree .git is a file and
+	// info/exclude lives in the common git directory
+	repo, err := repositoryAt(dir)
+	if err != nil {
+		return "", err
+	}
+	return repo.GitPath(dir, "info/exclude")
+}
+
+// UpdateIgnoreFile adds files to the ignore file selected by target for the
+// worktree at dir. Entries that are already present are not added again.
+// The file is locked while it is updated, so concurrent updates from other
+// goroutines or processes are applied one after another.
+func UpdateIgnoreFile(dir, target string, files []string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	ignorePath, err := ResolveIgnoreFile(dir, target)
+	if err != nil {
+		return err
+	}
+
+	// info/ does not always exist in fresh repositories
+	if err := os.MkdirAll(filepath.Dir(ignorePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", ignorePath, err)
+	}
+
+	return filelock.With(ignorePath, func() error {
+		return appendIgnoreEntries(ignorePath, files)
+	})
+}
+
+// appendIgnoreEntries appends the files missing from the ignore file at
+// ignorePath below the hatcher section header
+func appendIgnoreEntries(ignorePath string, files []string) error {
+	// Read existing content
+	var existing string
+	if data, err := os.ReadFile(ignorePath); err == nil {
+		existing = string(data)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", ignorePath, err)
+	}
+
+	present := make(map[string]bool)
+	for _, line := range strings.Split(existing, "\n") {
+		present[strings.TrimSpace(line)] = true
+	}
+
+	var newContent strings.Builder
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		newContent.WriteString("\n")
+	}
+	if !present[ignoreSectionHeader] {
+		newContent.WriteString("\n" + ignoreSectionHeader + "\n")
+	}
+
+	added := 0
+	for _, file := range files {
+		if present[file] {
+			continue
+		}
+		present[file] = true
+		newContent.WriteString(file + "\n")
+		added++
+	}
+
+	if added == 0 {
+		return nil // Already up to date
+	}
+
+	file, err := os.OpenFile(ignorePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", ignorePath, err)
+	}
+	defer file.Close()
+
+	if _, err := file.WriteString(newContent.String()); err != nil {
+		return fmt.Errorf("failed to write %s: %w", ignorePath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateIgnoreFile(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "ignore-target-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// isIgnored asks git whether path is ignored in the worktree at dir
+	isIgnored := func(dir, path string) bool {
+		cmd := exec.Command("git", "check-ignore", "-q", path)
+		cmd.Dir = dir
+		return cmd.Run() == nil
+	}
+
+	t.Run("gitignore target", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, UpdateIgnoreFile(dir, IgnoreTargetGitignore, []string{".ai/", "CLAUDE.md"}))
+		require.NoError(t, UpdateIgnoreFile(dir, IgnoreTargetGitignore, []string{"CLAUDE.md", ".env"}))
+
+		content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+		require.NoError(t, err)
+		assert.Equal(t, "\n"+ignoreSectionHeader+"\n.ai/\nCLAUDE.md\n.env\n", string(content))
+	})
+
+	t.Run("concurrent updates keep every entry", func(t *testing.T) {
+		dir := t.TempDir()
+
+		const writers = 20
+		var wg sync.WaitGroup
+		errs := make(chan error, writers)
+		for i := 0; i < writers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				errs <- UpdateIgnoreFile(dir, IgnoreTargetGitignore, []string{fmt.Sprintf("file-%d", i), "shared"})
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		assert.Len(t, lines, writers+2)
+		assert.Equal(t, 1, strings.Count(string(content), ignoreSectionHeader))
+		assert.Equal(t, 1, strings.Count(string(content), "shared\n"))
+		for i := 0; i < writers; i++ {
+			assert.Contains(t, lines, fmt.Sprintf("file-%d", i))
+		}
+		assert.NoFileExists(t, filepath.Join(dir, ".gitignore.lock"))
+	})
+
+	t.Run("exclude target in main worktree", func(t *testing.T) {
+		require.NoError(t, UpdateIgnoreFile(testRepo.RepoDir, IgnoreTargetExclude, []string{"main-local.txt"}))
+
+		content, err := os.ReadFile(filepath.Join(testRepo.RepoDir, ".git", "info", "exclude"))
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "main-local.txt")
+		assert.NoFileExists(t, filepath.Join(testRepo.RepoDir, ".gitignore"))
+		assert.True(t, isIgnored(testRepo.RepoDir, "main-local.txt"))
+	})
+
+	t.Run("exclude target in linked worktree", func(t *testing.T) {
+		worktreePath := filepath.Join(testRepo.TempDir, "ignore-target-test-feature-exclude")
+		require.NoError(t, repo.CreateWorktree(worktreePath, "feature/exclude", true))
+
+		excludePath, err := ResolveIgnoreFile(worktreePath, IgnoreTargetExclude)
+		require.NoError(t, err)
+		assert.True(t, filepath.IsAbs(excludePath))
+
+		require.NoError(t, UpdateIgnoreFile(worktreePath, IgnoreTargetExclude, []string{"worktree-local.txt"}))
+
+		assert.NoFileExists(t, filepath.Join(worktreePath, ".gitignore"))
+		assert.True(t, isIgnored(worktreePath, "worktree-local.txt"))
+	})
+
+	t.Run("auto-copier writes to configured target", func(t *testing.T) {
+		testRepo.CreateFile("local.env", "SECRET=1")
+		worktreePath := filepath.Join(testRepo.TempDir, "ignore-target-test-feature-copier")
+		require.NoError(t, repo.CreateWorktree(worktreePath, "feature/copier", true))
+
+		config := &AutoCopyConfig{
+			Version:      2,
+			IgnoreTarget: IgnoreTargetExclude,
+			Items:        []AutoCopyItem{{Path: "local.env", Directory: testutil.BoolPtr(false), RootOnly: true}},
+		}
+
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{})
+		require.NoError(t, copier.Run(testRepo.RepoDir, worktreePath))
+
+		assert.FileExists(t, filepath.Join(worktreePath, "local.env"))
+		assert.NoFileExists(t, filepath.Join(worktreePath, ".gitignore"))
+		assert.True(t, isIgnored(worktreePath, "local.env"))
+	})
+
+	t.Run("unsupported target", func(t *testing.T) {
+		err := UpdateIgnoreFile(t.TempDir(), "global", []string{"file"})
+		assert.Error(t, err)
+
+		err = ValidateAutoCopyConfig(&AutoCopyConfig{Version: 2, IgnoreTarget: "global"})
+		assert.Error(t, err)
+	})
+}
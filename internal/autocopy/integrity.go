@@ -0,0 +1,39 @@
+package autocopy
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Integrity presets bundling copy options, selected with --integrity
+const (
+	IntegrityFast   = "fast"   // Parallel copies with as many workers as CPUs, no verification
+	IntegritySafe   = "safe"   // Atomic writes, sha256 verification and backups
+	IntegrityMirror = "mirror" // Safe, plus source timestamps and deletion propagation
+)
+
+// ApplyIntegrityPreset sets the options bundled in preset. Deletion
+// propagation of the mirror preset only applies to syncs, so syncOptions may
+// be nil when copying into a new worktree. An empty preset changes nothing.
+func ApplyIntegrityPreset(preset string, options *AutoCopierOptions, syncOptions *SyncOptions) error {
+	switch preset {
+	case "":
+	case IntegrityFast:
+		options.UseParallel = true
+		options.MaxWorkers = runtime.NumCPU()
+		options.VerifyIntegrity = false
+	case IntegritySafe, IntegrityMirror:
+		options.AtomicWrites = true
+		options.VerifyIntegrity = true
+		options.Backup = true
+		if preset == IntegrityMirror {
+			options.PreserveTimestamps = true
+			if syncOptions != nil {
+				syncOptions.PropagateDeletions = true
+			}
+		}
+	default:
+		return fmt.Errorf("unknown integrity preset %q (expected fast, safe or mirror)", preset)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package autocopy
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyIntegrityPreset(t *testing.T) {
+	t.Run("fast", func(t *testing.T) {
+		options := AutoCopierOptions{VerifyIntegrity: true}
+		syncOptions := SyncOptions{}
+		require.NoError(t, ApplyIntegrityPreset(IntegrityFast, &options, &syncOptions))
+
+		assert.Equal(t, AutoCopierOptions{UseParallel: true, MaxWorkers: runtime.NumCPU()}, options)
+		assert.False(t, syncOptions.PropagateDeletions)
+	})
+
+	t.Run("safe", func(t *testing.T) {
+		options := AutoCopierOptions{}
+		syncOptions := SyncOptions{}
+		require.NoError(t, ApplyIntegrityPreset(IntegritySafe, &options, &syncOptions))
+
+		assert.Equal(t, AutoCopierOptions{AtomicWrites: true, VerifyIntegrity: true, Backup: true}, options)
+		assert.False(t, syncOptions.PropagateDeletions)
+	})
+
+	t.Run("mirror", func(t *testing.T) {
+		options := AutoCopierOptions{}
+		syncOptions := SyncOptions{}
+		require.NoError(t, ApplyIntegrityPreset(IntegrityMirror, &options, &syncOptions))
+
+		assert.Equal(t, AutoCopierOptions{AtomicWrites: true, VerifyIntegrity: true, Backup: true, PreserveTimestamps: true}, options)
+		assert.True(t, syncOptions.PropagateDeletions)
+
+		// Copies into new worktrees have nothing to propagate
+		require.NoError(t, ApplyIntegrityPreset(IntegrityMirror, &options, nil))
+	})
+
+	t.Run("no preset", func(t *testing.T) {
+		options := AutoCopierOptions{Backup: true}
+		require.NoError(t, ApplyIntegrityPreset("", &options, nil))
+		assert.Equal(t, AutoCopierOptions{Backup: true}, options)
+	})
+
+	t.Run("unknown preset", func(t *testing.T) {
+		err := ApplyIntegrityPreset("paranoid", &AutoCopierOptions{}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "paranoid")
+	})
+}
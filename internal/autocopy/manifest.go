@@ -0,0 +1,218 @@
+package autocopy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManifestFile is the name of the copy manifest inside a worktree's git
+// directory, where it stays out of the working tree
+const ManifestFile = "hatcher-copy-manifest.json"
+
+// ManifestEntry records the source of a copied file at the time it was copied
+type ManifestEntry struct {
+	Source   string    `json:"source"` // Source path relative to the source root
+	Size     int64     `json:"size"`
+	ModTime  time.Time `json:"modTime"`
+	Checksum string    `json:"checksum"` // sha256 of the source content
+	// sha256 of the copy as written; differs from Checksum for annotated
+	// copies and is empty in manifests written before it was recorded
+	CopyChecksum string `json:"copyChecksum,omitempty"`
+}
+
+// copyChecksum returns the checksum the unmodified copy has
+func (e ManifestEntry) copyChecksum() string {
+	if e.CopyChecksum != "" {
+		return e.CopyChecksum
+	}
+	return e.Checksum
+}
+
+// Manifest lists the files copied into a worktree
+type Manifest struct {
+	SyncedAt time.Time                `json:"syncedAt"`
+	Files    map[string]ManifestEntry `json:"files"` // Keyed by destination path relative to the worktree
+}
+
+// ManifestPath returns the manifest location for a worktree's git directory
+func ManifestPath(gitDir string) string {
+	return filepath.Join(gitDir, ManifestFile)
+}
+
+// ValidateManifestPath checks a custom manifest location, which must be a
+// relative path inside the worktree and outside its .git
+func ValidateManifestPath(path string) error {
+	if path == "" {
+		return nil
+	}
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") {
+		return fmt.Errorf("manifest path must be relative to the worktree: %s", path)
+	}
+
+	clean := filepath.ToSlash(filepath.Clean(path))
+	if clean == "." || clean == ".." || strings.HasPrefix(clean, "../") {
+		return fmt.Errorf("manifest path must stay inside the worktree: %s", path)
+	}
+	if clean == ".git" || strings.HasPrefix(clean, ".git/") {
+		return fmt.Errorf("manifest path must not be inside .git: %s", path)
+	}
+	return nil
+}
+
+// ResolveManifestPath returns the manifest location of a worktree: custom
+// relative to worktreePath, or the default inside the worktree's gitDir when
+// custom is empty
+func ResolveManifestPath(worktreePath, gitDir, custom string) string {
+	if custom == "" {
+		return ManifestPath(gitDir)
+	}
+	return filepath.Join(worktreePath, filepath.FromSlash(custom))
+}
+
+// relativeInside returns path relative to root as a slash path, if it lies
+// inside root
+func relativeInside(root, path string) (string, bool) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return "", false
+	}
+	rel = filepath.ToSlash(rel)
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
+	}
+	return rel, true
+}
+
+// LoadManifest reads the manifest at path. A missing file yields an empty
+// manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	manifest := &Manifest{Files: make(map[string]ManifestEntry)}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return manifest, nil
+		}
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]ManifestEntry)
+	}
+
+	return manifest, nil
+}
+
+// Save writes the manifest to path, replacing it atomically
+func (m *Manifest) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ManifestStats summarizes a manifest against the files it describes
+type ManifestStats struct {
+	Files int   // Copied files recorded in the manifest
+	Bytes int64 // Total size of their sources when copied
+	// Destination paths whose source changed or was removed, or whose copy
+	// is missing
+	Stale []string
+}
+
+// Stats compares the manifest with the sources in sourceDir and the copies
+// in destDir. Sources with the recorded size and mtime are taken as
+// unchanged; otherwise their checksum decides.
+func (m *Manifest) Stats(sourceDir, destDir string) ManifestStats {
+	stats := ManifestStats{Files: len(m.Files)}
+
+	for dest, entry := range m.Files {
+		stats.Bytes += entry.Size
+		if !entryInSync(sourceDir, destDir, dest, entry) {
+			stats.Stale = append(stats.Stale, dest)
+		}
+	}
+	sort.Strings(stats.Stale)
+
+	return stats
+}
+
+// entryInSync reports whether the copy at dest still matches its source
+func entryInSync(sourceDir, destDir, dest string, entry ManifestEntry) bool {
+	if _, err := os.Stat(filepath.Join(destDir, filepath.FromSlash(dest))); err != nil {
+		return false
+	}
+
+	sourcePath := filepath.Join(sourceDir, filepath.FromSlash(entry.Source))
+	info, err := os.Stat(sourcePath)
+	if err != nil || info.Size() != entry.Size {
+		return false
+	}
+	if info.ModTime().Equal(entry.ModTime) {
+		return true
+	}
+
+	checksum, err := fileChecksum(sourcePath)
+	return err == nil && checksum == entry.Checksum
+}
+
+// newManifestEntry describes the source file of a copy task
+func newManifestEntry(sourceDir string, task CopyTask, info os.FileInfo) (ManifestEntry, error) {
+	checksum, err := fileChecksum(task.SourcePath)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	source, err := filepath.Rel(sourceDir, task.SourcePath)
+	if err != nil {
+		source = task.SourcePath
+	}
+
+	return ManifestEntry{
+		Source:   filepath.ToSlash(source),
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+		Checksum: checksum,
+	}, nil
+}
+
+// fileChecksum returns the hex sha256 of the file at path
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -1,12 +1,18 @@
 package autocopy
 
 import (
+	"crypto/md5"
+	"crypto/sha1"
 	"crypto/sha256"
+	"errors"
 	"fmt"
 	"hash"
+	"hash/crc32"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -42,6 +48,7 @@ type CopyError struct {
 	DestPath   string    `json:"destPath"`
 	Error      error     `json:"error"`
 	Timestamp  time.Time `json:"timestamp"`
+	Retries    int       `json:"retries"` // Attempts made after the first before giving up
 }
 
 // CopyTask represents a single copy operation
@@ -50,18 +57,50 @@ type CopyTask struct {
 	DestPath   string
 	IsDir      bool
 	Size       int64
+	Priority   int    // Priority of the item the task belongs to
+	OnConflict string // Conflict policy of the item the task belongs to
 }
 
 // ParallelCopyOptions contains options for parallel copying
 type ParallelCopyOptions struct {
-	MaxWorkers       int                  // Maximum number of worker goroutines
-	BufferSize       int                  // Buffer size for file copying
-	ShowProgress     bool                 // Whether to show progress updates
-	VerifyIntegrity  bool                 // Whether to verify file integrity after copying
-	ChecksumType     string               // Type of checksum to use (sha256, md5)
-	ContinueOnError  bool                 // Whether to continue on individual file errors
-	ProgressCallback func(ProgressUpdate) // Callback for progress updates
-	ErrorCallback    func(CopyError)      // Callback for errors
+	MaxWorkers          int                  // Maximum number of worker goroutines
+	BufferSize          int                  // Buffer size for file copying
+	ShowProgress        bool                 // Whether to show progress updates
+	VerifyIntegrity     bool                 // Whether to verify file integrity after copying
+	ChecksumType        string               // Type of checksum to use (sha256, sha1, md5, crc32)
+	ContinueOnError     bool                 // Whether to continue on individual file errors
+	MaxRetries          int                  // Re-attempts of a file failing with a transient error such as EIO (0 disables)
+	RetryBackoff        time.Duration        // Delay before the first retry, doubled for each further one (0 uses DefaultRetryBackoff)
+	ProgressCallback    func(ProgressUpdate) // Callback for progress updates
+	ProgressInterval    int                  // Send a progress update every this many files (0 uses DefaultProgressInterval)
+	ErrorCallback       func(CopyError)      // Callback for errors
+	GitModeSemantics    bool                 // Normalize file modes to 0644/0755 like git stores them
+	MaxTotalFiles       int                  // Abort discovery above this many files (0 uses the default, negative disables)
+	MaxTotalBytes       int64                // Abort discovery when the files add up to more bytes (0 or negative is unlimited)
+	RespectGitignore    bool                 // Skip files ignored by git inside recursively copied directories
+	RespectExportIgnore bool                 // Skip files marked export-ignore in gitattributes inside recursively copied directories
+	PreserveXattrs      bool                 // Copy extended attributes on Linux and macOS
+	Backup              bool                 // Keep overwritten files whose content changes as <name>.hatcher.bak
+	OnConflict          string               // Policy for differing destination files of items without their own (overrides Backup)
+	AddProvenanceHeader bool                 // Prepend a "copied by hatcher" comment to recognized text files
+	StripPrefix         string               // Remove this leading directory from every destination path
+	AddPrefix           string               // Place every destination path below this directory
+	SkipPaths           []string             // Destination files, relative to the destination root, that are never written
+	PreserveTimestamps  bool                 // Give copies the modification time of their source
+	PreserveSymlinks    bool                 // Recreate symlinks instead of copying their targets' content
+	ForceRelink         bool                 // With PreserveSymlinks, replace files with links and links with files
+	SkipTracked         bool                 // Never overwrite files tracked in the destination worktree
+	Trace               func(TraceEntry)     // Receives the decision made for every file considered
+	// With VerifyIntegrity, files of at least this many bytes are verified
+	// after all copies by a pool of NumCPU hashing workers instead of inline
+	// by the copy workers (0 verifies every file inline)
+	DeferVerifyMinSize int64
+}
+
+// VerificationStats describes the deferred verification phase of a Run
+type VerificationStats struct {
+	Files    int           `json:"files"`
+	Duration time.Duration `json:"duration"`
 }
 
 // ParallelCopier handles parallel file copying operations
@@ -71,6 +110,8 @@ type ParallelCopier struct {
 	options ParallelCopyOptions
 
 	// Internal state
+	sourceRoot     string // Source root symlinks must stay inside
+	destRoot       string // Destination worktree root
 	taskQueue      chan CopyTask
 	results        chan error
 	progress       chan ProgressUpdate
@@ -78,14 +119,26 @@ type ParallelCopier struct {
 	wg             sync.WaitGroup
 	totalTasks     int
 	completedTasks int
+	copiedFiles    []string            // Written files, relative to destRoot
+	pendingVerify  map[string]CopyTask // Copies left for the verification phase, by destination
+	verification   VerificationStats
+	fileCount      int
+	duplicateTasks int           // Tasks collapsed by the last discovery
+	ignore         hatcherIgnore // Patterns of the source's .hatcherignore
 	totalBytes     int64
 	copiedBytes    int64
 	startTime      time.Time
 	mutex          sync.RWMutex
+	buffers        sync.Pool // Copy buffers of BufferSize bytes shared by the workers
+
+	newHash func(checksumType string) (hash.Hash, error) // Hashes copies for verification; replaced in tests
+	process func(task CopyTask) (bool, error)            // Copies a single task; replaced in tests
 }
 
-// NewParallelCopier creates a new parallel copier
-func NewParallelCopier(repo git.Repository, config *AutoCopyConfig, options ParallelCopyOptions) *ParallelCopier {
+// NewParallelCopier creates a new parallel copier. It fails for an unknown
+// ChecksumType or OnConflict policy so a bad option is reported before
+// anything is copied.
+func NewParallelCopier(repo git.Repository, config *AutoCopyConfig, options ParallelCopyOptions) (*ParallelCopier, error) {
 	// Set default options
 	if options.MaxWorkers <= 0 {
 		options.MaxWorkers = 4
@@ -96,20 +149,50 @@ func NewParallelCopier(repo git.Repository, config *AutoCopyConfig, options Para
 	if options.ChecksumType == "" {
 		options.ChecksumType = "sha256"
 	}
+	if _, err := newChecksumHash(options.ChecksumType); err != nil {
+		return nil, err
+	}
+	if err := ValidateConflictPolicy(options.OnConflict); err != nil {
+		return nil, err
+	}
+	if options.MaxTotalFiles == 0 {
+		options.MaxTotalFiles = DefaultMaxTotalFiles
+	}
+	if options.ProgressInterval <= 0 {
+		options.ProgressInterval = DefaultProgressInterval
+	}
+	if options.MaxRetries < 0 {
+		options.MaxRetries = 0
+	}
+	if options.RetryBackoff <= 0 {
+		options.RetryBackoff = DefaultRetryBackoff
+	}
 
-	return &ParallelCopier{
-		repo:    repo,
-		config:  config,
-		options: options,
+	pc := &ParallelCopier{
+		repo:          repo,
+		config:        config,
+		options:       options,
+		pendingVerify: make(map[string]CopyTask),
+		newHash:       newChecksumHash,
 	}
+	pc.process = pc.processTask
+	return pc, nil
 }
 
 // Run executes the parallel copy operation
 func (pc *ParallelCopier) Run(sourceDir, destDir string) error {
 	pc.startTime = time.Now()
+	pc.sourceRoot = sourceDir
+	pc.destRoot = destDir
+	pc.copiedFiles = nil
+	pc.pendingVerify = make(map[string]CopyTask)
+	pc.verification = VerificationStats{}
+
+	if err := checkWritable(destDir); err != nil {
+		return err
+	}
 
 	// Initialize channels
-	pc.taskQueue = make(chan CopyTask, pc.options.MaxWorkers*2)
 	pc.results = make(chan error, pc.options.MaxWorkers)
 	pc.progress = make(chan ProgressUpdate, 100)
 	pc.errors = make(chan CopyError, 100)
@@ -133,6 +216,7 @@ func (pc *ParallelCopier) Run(sourceDir, destDir string) error {
 	if err != nil {
 		return fmt.Errorf("failed to discover copy tasks: %w", err)
 	}
+	tasks = overriddenTasks(tasks)
 
 	pc.totalTasks = len(tasks)
 	if pc.totalTasks == 0 {
@@ -153,6 +237,50 @@ func (pc *ParallelCopier) Run(sourceDir, destDir string) error {
 		})
 	}
 
+	// Each priority is copied completely before the next one starts. Later
+	// priorities build on earlier ones, so a failed phase stops the copy.
+	var copyErr error
+	for _, phase := range taskPhases(tasks) {
+		if copyErr = pc.runPhase(phase); copyErr != nil {
+			break
+		}
+	}
+	// Mismatched copies are removed either way, but only stop the copy
+	// like any other failed file without ContinueOnError
+	if verifyErr := pc.runVerification(); verifyErr != nil && copyErr == nil && !pc.options.ContinueOnError {
+		copyErr = verifyErr
+	}
+
+	// Send completion progress update before closing channels
+	if pc.options.ShowProgress {
+		pc.sendProgressUpdate(ProgressUpdate{
+			Type:        ProgressTypeComplete,
+			Message:     "Copy operation completed",
+			Current:     pc.completedTasks,
+			Total:       pc.totalTasks,
+			Percentage:  100.0,
+			BytesCopied: pc.copiedBytes,
+			TotalBytes:  pc.totalBytes,
+			ElapsedTime: time.Since(pc.startTime),
+		})
+	}
+
+	// Close channels
+	close(pc.progress)
+	close(pc.errors)
+
+	// Wait for handlers to finish
+	progressWg.Wait()
+	errorWg.Wait()
+
+	return copyErr
+}
+
+// runPhase copies tasks with the worker pool and waits for all of them. It
+// returns the error a worker stopped on.
+func (pc *ParallelCopier) runPhase(tasks []CopyTask) error {
+	pc.taskQueue = make(chan CopyTask, pc.options.MaxWorkers*2)
+
 	// Start workers
 	for i := 0; i < pc.options.MaxWorkers; i++ {
 		pc.wg.Add(1)
@@ -170,39 +298,158 @@ func (pc *ParallelCopier) Run(sourceDir, destDir string) error {
 	// Wait for all workers to complete
 	pc.wg.Wait()
 
-	// Send completion progress update before closing channels
+	select {
+	case err := <-pc.results:
+		return err
+	default:
+		return nil
+	}
+}
+
+// runVerification verifies the copies deferred by the copy workers with a
+// pool of NumCPU hashing workers. A copy that does not match its source is
+// reported and removed, and the first failure is returned.
+func (pc *ParallelCopier) runVerification() error {
+	pending := pc.pendingVerify
+	pc.pendingVerify = make(map[string]CopyTask)
+	if len(pending) == 0 {
+		return nil
+	}
+	start := time.Now()
+
+	queue := make(chan CopyTask)
+	var wg sync.WaitGroup
+	var failMu sync.Mutex
+	var firstErr error
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				if err := pc.verifyCopy(task.SourcePath, task.DestPath); err != nil {
+					os.Remove(task.DestPath)
+					pc.dropCopied(task.DestPath)
+					failMu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to verify %s: %w", task.DestPath, err)
+					}
+					failMu.Unlock()
+					pc.sendError(CopyError{
+						SourcePath: task.SourcePath,
+						DestPath:   task.DestPath,
+						Error:      err,
+						Timestamp:  time.Now(),
+					})
+				}
+			}
+		}()
+	}
+	for _, task := range pending {
+		queue <- task
+	}
+	close(queue)
+	wg.Wait()
+
+	pc.verification = VerificationStats{Files: len(pending), Duration: time.Since(start)}
 	if pc.options.ShowProgress {
 		pc.sendProgressUpdate(ProgressUpdate{
-			Type:        ProgressTypeComplete,
-			Message:     "Copy operation completed",
-			Current:     pc.completedTasks,
-			Total:       pc.totalTasks,
+			Type:        ProgressTypeProgress,
+			Message:     fmt.Sprintf("Verified %d files in %v", pc.verification.Files, pc.verification.Duration),
+			Current:     pc.verification.Files,
+			Total:       pc.verification.Files,
 			Percentage:  100.0,
-			BytesCopied: pc.copiedBytes,
-			TotalBytes:  pc.totalBytes,
-			ElapsedTime: time.Since(pc.startTime),
+			ElapsedTime: pc.verification.Duration,
 		})
 	}
+	return firstErr
+}
 
-	// Close channels
-	close(pc.progress)
-	close(pc.errors)
+// Verification returns the statistics of the last Run's deferred
+// verification phase
+func (pc *ParallelCopier) Verification() VerificationStats {
+	return pc.verification
+}
 
-	// Wait for handlers to finish
-	progressWg.Wait()
-	errorWg.Wait()
+// taskPhases splits tasks, which are discovered in priority order, into
+// groups of equal priority
+func taskPhases(tasks []CopyTask) [][]CopyTask {
+	var phases [][]CopyTask
+	start := 0
+	for i := 1; i <= len(tasks); i++ {
+		if i == len(tasks) || tasks[i].Priority != tasks[start].Priority {
+			phases = append(phases, tasks[start:i])
+			start = i
+		}
+	}
+	return phases
+}
 
-	return nil
+// CopyEstimate summarizes the work a copy operation would perform
+type CopyEstimate struct {
+	Files       int   `json:"files"`
+	Directories int   `json:"directories"`
+	TotalBytes  int64 `json:"totalBytes"`
+}
+
+// String formats the estimate for display, e.g. "12 files, ~3.4 MB"
+func (e *CopyEstimate) String() string {
+	return fmt.Sprintf("%d files, ~%.1f MB", e.Files, float64(e.TotalBytes)/(1024*1024))
+}
+
+// Estimate runs task discovery without copying anything and summarizes the
+// number of files and bytes a Run would copy
+func (pc *ParallelCopier) Estimate(sourceDir, destDir string) (*CopyEstimate, error) {
+	tasks, err := pc.discoverTasks(sourceDir, destDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover copy tasks: %w", err)
+	}
+
+	estimate := &CopyEstimate{}
+	for _, task := range overriddenTasks(tasks) {
+		if task.IsDir {
+			estimate.Directories++
+			continue
+		}
+		estimate.Files++
+		estimate.TotalBytes += task.Size
+	}
+
+	return estimate, nil
+}
+
+// discoverTasks discovers the copy tasks a ParallelCopier with options would
+// run for config, for copiers planning without one
+func discoverTasks(repo git.Repository, config *AutoCopyConfig, options ParallelCopyOptions, sourceDir, destDir string) ([]CopyTask, error) {
+	copier, err := NewParallelCopier(repo, config, options)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := copier.discoverTasks(sourceDir, destDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover copy tasks: %w", err)
+	}
+	return tasks, nil
 }
 
 // discoverTasks discovers all copy tasks based on the configuration
 func (pc *ParallelCopier) discoverTasks(sourceDir, destDir string) ([]CopyTask, error) {
+	dest, err := newDestMapper(destDir, pc.options.StripPrefix, pc.options.AddPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	if pc.ignore, err = loadHatcherIgnore(sourceDir); err != nil {
+		return nil, err
+	}
+
 	var tasks []CopyTask
+	pc.fileCount = 0
 
-	for _, item := range pc.config.Items {
+	for _, item := range itemsByPriority(pc.config.Items) {
 		itemTasks, err := pc.discoverItemTasks(sourceDir, destDir, item)
 		if err != nil {
-			if pc.options.ContinueOnError {
+			// Safety aborts are never skipped
+			if pc.options.ContinueOnError && !errors.Is(err, ErrTooManyFiles) {
 				pc.sendError(CopyError{
 					SourcePath: item.Path,
 					Error:      err,
@@ -212,10 +459,100 @@ func (pc *ParallelCopier) discoverTasks(sourceDir, destDir string) ([]CopyTask,
 			}
 			return nil, err
 		}
+		for i := range itemTasks {
+			itemTasks[i].Priority = item.Priority
+			itemTasks[i].OnConflict = item.OnConflict
+		}
 		tasks = append(tasks, itemTasks...)
 	}
 
-	return tasks, nil
+	// Prefixes apply to the final destinations, after the configured paths
+	// have been validated and discovered
+	mapped := tasks[:0]
+	for _, task := range tasks {
+		task.DestPath = dest.mapPath(task.DestPath)
+		if skippedPath(dest.root, task.DestPath, pc.options.SkipPaths) {
+			continue
+		}
+		mapped = append(mapped, task)
+	}
+
+	// Overlapping items, e.g. "**/.cursorrules" and ".cursorrules", resolve
+	// the same file more than once
+	mapped, pc.duplicateTasks = dedupTasks(mapped)
+
+	if err := checkCaseCollisions(sourceDir, dest.root, mapped); err != nil {
+		return nil, err
+	}
+	if err := pc.checkTotalBytes(mapped); err != nil {
+		return nil, err
+	}
+
+	return mapped, nil
+}
+
+// checkTotalBytes enforces MaxTotalBytes on the discovered tasks
+func (pc *ParallelCopier) checkTotalBytes(tasks []CopyTask) error {
+	if pc.options.MaxTotalBytes <= 0 {
+		return nil
+	}
+
+	var totalBytes int64
+	for _, task := range tasks {
+		totalBytes += task.Size
+	}
+	if totalBytes > pc.options.MaxTotalBytes {
+		return fmt.Errorf("%w: the matched files add up to %d bytes, more than %d; narrow the auto-copy paths or globs (e.g. exclude build output) or raise maxTotalBytes",
+			ErrTransferTooLarge, totalBytes, pc.options.MaxTotalBytes)
+	}
+	return nil
+}
+
+// DuplicateTasks returns how many duplicate tasks the last discovery
+// collapsed
+func (pc *ParallelCopier) DuplicateTasks() int {
+	return pc.duplicateTasks
+}
+
+// dedupTasks drops tasks repeating an earlier task's destination, keeping the
+// first, and returns how many were dropped. Destinations are compared by
+// their cleaned path. A later priority writing a destination from a different
+// source is an intended override and is kept; it is resolved by
+// overriddenTasks before copying.
+func dedupTasks(tasks []CopyTask) ([]CopyTask, int) {
+	seen := make(map[string]CopyTask, len(tasks))
+	kept := tasks[:0]
+	dropped := 0
+	for _, task := range tasks {
+		dest := filepath.Clean(task.DestPath)
+		if first, ok := seen[dest]; ok {
+			// Directories merge, whatever their source
+			if task.IsDir || first.IsDir || task.Priority == first.Priority || task.SourcePath == first.SourcePath {
+				dropped++
+				continue
+			}
+		}
+		seen[dest] = task
+		kept = append(kept, task)
+	}
+	return kept, dropped
+}
+
+// overriddenTasks drops the tasks a later priority writes again, so each
+// destination is copied once, by the last item that selects it
+func overriddenTasks(tasks []CopyTask) []CopyTask {
+	last := make(map[string]int, len(tasks))
+	for i, task := range tasks {
+		last[filepath.Clean(task.DestPath)] = i
+	}
+
+	var kept []CopyTask
+	for i, task := range tasks {
+		if last[filepath.Clean(task.DestPath)] == i {
+			kept = append(kept, task)
+		}
+	}
+	return kept
 }
 
 // discoverItemTasks discovers copy tasks for a single configuration item
@@ -239,7 +576,7 @@ func (pc *ParallelCopier) discoverItemTasks(sourceDir, destDir string, item Auto
 
 			itemTasks, err := pc.discoverSinglePath(sourceDir, destDir, relPath, item)
 			if err != nil {
-				if pc.options.ContinueOnError {
+				if pc.options.ContinueOnError && !errors.Is(err, ErrTooManyFiles) {
 					continue
 				}
 				return nil, err
@@ -263,21 +600,34 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 
 	sourcePath := filepath.Join(sourceDir, relativePath)
 	destPath := filepath.Join(destDir, relativePath)
+	filter := newPathFilter(sourceDir, item)
+	filter.ignore = pc.ignore
+	filter.trace = pc.options.Trace
 
 	// Check if source exists
 	info, err := os.Stat(sourcePath)
 	if err != nil {
 		if os.IsNotExist(err) && item.AutoDetect {
-			return tasks, nil // Skip non-existent files when auto-detecting
+			// Skip non-existent files when auto-detecting
+			tracer(pc.options.Trace).record(sourceDir, sourcePath, TraceSkippedMissing, "auto-detected path does not exist")
+			return tasks, nil
 		}
 		return nil, fmt.Errorf("failed to stat %s: %w", sourcePath, err)
 	}
 
+	if isSpecialFile(info.Mode()) {
+		warnSpecialFile(sourcePath, info.Mode())
+		return tasks, nil
+	}
+
 	if info.IsDir() {
 		// Handle directory
 		if item.Directory != nil && !*item.Directory {
 			return nil, fmt.Errorf("expected file but found directory: %s", sourcePath)
 		}
+		if filter.ignoredDir(sourcePath) {
+			return tasks, nil
+		}
 
 		// Add directory creation task
 		tasks = append(tasks, CopyTask{
@@ -287,8 +637,11 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 			Size:       0,
 		})
 
-		// Recursively add files if needed
+		// Recursively add files if needed. Files git would skip are only
+		// known after the walk, so they are counted against MaxTotalFiles
+		// once they have been dropped.
 		if item.Recursive {
+			skips := pc.options.repoSkips()
 			err := filepath.Walk(sourcePath, func(walkPath string, walkInfo os.FileInfo, walkErr error) error {
 				if walkErr != nil {
 					return walkErr
@@ -305,7 +658,15 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 
 				destWalkPath := filepath.Join(destPath, relWalkPath)
 
+				if isSpecialFile(walkInfo.Mode()) {
+					warnSpecialFile(walkPath, walkInfo.Mode())
+					return nil
+				}
+
 				if walkInfo.IsDir() {
+					if filter.skipDir(walkPath) {
+						return filepath.SkipDir
+					}
 					tasks = append(tasks, CopyTask{
 						SourcePath: walkPath,
 						DestPath:   destWalkPath,
@@ -313,6 +674,14 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 						Size:       0,
 					})
 				} else {
+					if filter.skipFile(walkPath) {
+						return nil
+					}
+					if !skips.enabled() {
+						if err := pc.countFile(); err != nil {
+							return err
+						}
+					}
 					tasks = append(tasks, CopyTask{
 						SourcePath: walkPath,
 						DestPath:   destWalkPath,
@@ -327,6 +696,20 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 			if err != nil {
 				return nil, fmt.Errorf("failed to walk directory %s: %w", sourcePath, err)
 			}
+
+			if skips.enabled() {
+				if tasks, err = pc.dropIgnoredTasks(skips, sourcePath, tasks); err != nil {
+					return nil, err
+				}
+				for _, task := range tasks {
+					if task.IsDir {
+						continue
+					}
+					if err := pc.countFile(); err != nil {
+						return nil, err
+					}
+				}
+			}
 		}
 	} else {
 		// Handle file
@@ -334,6 +717,14 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 			return nil, fmt.Errorf("expected directory but found file: %s", sourcePath)
 		}
 
+		if filter.skipFile(sourcePath) {
+			return tasks, nil
+		}
+
+		if err := pc.countFile(); err != nil {
+			return nil, err
+		}
+
 		tasks = append(tasks, CopyTask{
 			SourcePath: sourcePath,
 			DestPath:   destPath,
@@ -345,21 +736,60 @@ func (pc *ParallelCopier) discoverSinglePath(sourceDir, destDir, relativePath st
 	return tasks, nil
 }
 
+// dropIgnoredTasks removes tasks for paths below dir that skips excludes.
+// The task for dir itself is kept since it was configured explicitly.
+func (pc *ParallelCopier) dropIgnoredTasks(skips repoSkips, dir string, tasks []CopyTask) ([]CopyTask, error) {
+	paths := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		if task.SourcePath != dir {
+			paths = append(paths, task.SourcePath)
+		}
+	}
+
+	ignored, err := skips.skippedPaths(pc.repo, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := tasks[:0]
+	for _, task := range tasks {
+		if !ignored[task.SourcePath] {
+			kept = append(kept, task)
+		}
+	}
+	return kept, nil
+}
+
+// countFile records a discovered file and enforces MaxTotalFiles
+func (pc *ParallelCopier) countFile() error {
+	pc.fileCount++
+	if pc.options.MaxTotalFiles > 0 && pc.fileCount > pc.options.MaxTotalFiles {
+		return fmt.Errorf("%w: more than %d files matched; narrow the auto-copy paths or globs (e.g. exclude node_modules) or raise maxTotalFiles",
+			ErrTooManyFiles, pc.options.MaxTotalFiles)
+	}
+	return nil
+}
+
 // worker is a worker goroutine that processes copy tasks
 func (pc *ParallelCopier) worker() {
 	defer pc.wg.Done()
 
 	for task := range pc.taskQueue {
-		err := pc.processTask(task)
+		written, retries, err := pc.processWithRetry(task)
+		if written {
+			pc.recordCopied(task.DestPath)
+		}
 		if err != nil {
 			pc.sendError(CopyError{
 				SourcePath: task.SourcePath,
 				DestPath:   task.DestPath,
 				Error:      err,
 				Timestamp:  time.Now(),
+				Retries:    retries,
 			})
 
-			if !pc.options.ContinueOnError {
+			// Conflicts refused by the error policy always stop the copy
+			if !pc.options.ContinueOnError || errors.Is(err, ErrCopyConflict) {
 				pc.results <- err
 				return
 			}
@@ -376,7 +806,7 @@ func (pc *ParallelCopier) worker() {
 		pc.mutex.Unlock()
 
 		// Send progress update
-		if pc.options.ShowProgress && current%10 == 0 { // Update every 10 files
+		if pc.options.ShowProgress && current%pc.options.ProgressInterval == 0 {
 			elapsed := time.Since(pc.startTime)
 			percentage := float64(current) / float64(total) * 100
 
@@ -400,63 +830,187 @@ func (pc *ParallelCopier) worker() {
 	}
 }
 
-// processTask processes a single copy task
-func (pc *ParallelCopier) processTask(task CopyTask) error {
+// dropCopied removes a destination from the copied files
+func (pc *ParallelCopier) dropCopied(destPath string) {
+	rel := relativeSlash(pc.destRoot, destPath)
+
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	kept := pc.copiedFiles[:0]
+	for _, file := range pc.copiedFiles {
+		if file != rel {
+			kept = append(kept, file)
+		}
+	}
+	pc.copiedFiles = kept
+}
+
+// recordCopied adds a written destination to the copied files
+func (pc *ParallelCopier) recordCopied(destPath string) {
+	rel := relativeSlash(pc.destRoot, destPath)
+
+	pc.mutex.Lock()
+	defer pc.mutex.Unlock()
+	pc.copiedFiles = append(pc.copiedFiles, rel)
+}
+
+// CopiedFiles returns the files the last Run wrote, as sorted slash paths
+// relative to the destination. A file written by several items is listed
+// once.
+func (pc *ParallelCopier) CopiedFiles() []string {
+	pc.mutex.RLock()
+	defer pc.mutex.RUnlock()
+
+	files := make([]string, 0, len(pc.copiedFiles))
+	seen := make(map[string]bool, len(pc.copiedFiles))
+	for _, file := range pc.copiedFiles {
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+	return files
+}
+
+// processTask processes a single copy task and reports whether it wrote a
+// file
+func (pc *ParallelCopier) processTask(task CopyTask) (bool, error) {
 	if task.IsDir {
 		// Create directory
-		return os.MkdirAll(task.DestPath, 0755)
+		return false, os.MkdirAll(task.DestPath, 0755)
+	}
+
+	trace := tracer(pc.options.Trace)
+	if pc.options.SkipTracked {
+		if tracked, err := skipTrackedDestination(pc.repo, pc.destRoot, task.DestPath); tracked || err != nil {
+			if tracked {
+				trace.record(pc.sourceRoot, task.SourcePath, TraceSkippedFiltered, "tracked in the destination worktree")
+			}
+			return false, err
+		}
+	}
+
+	// Links outside the source root are reported as copy errors
+	if linked, written, err := handleSymlink(pc.sourceRoot, task.SourcePath, task.DestPath, pc.options.PreserveSymlinks, pc.options.ForceRelink); linked || err != nil {
+		traceSymlink(trace, pc.sourceRoot, task.SourcePath, written, err)
+		return written, err
+	}
+
+	policy := conflictPolicy(task.OnConflict, pc.options.OnConflict, pc.options.Backup)
+	decision := trace.copyDecision(policy, task.SourcePath, task.DestPath, pc.options.AddProvenanceHeader)
+	if proceed, err := resolveConflict(policy, task.SourcePath, task.DestPath, pc.options.AddProvenanceHeader); !proceed || err != nil {
+		if decision.Decision == TraceConflict {
+			trace.recordEntry(pc.sourceRoot, task.SourcePath, decision)
+		}
+		return false, err
 	}
 
 	// Copy file
-	return pc.copyFile(task.SourcePath, task.DestPath)
+	deferred, err := pc.copyFile(task.SourcePath, task.DestPath)
+	if err != nil {
+		return false, err
+	}
+	// A later item may overwrite a deferred copy, which then no longer
+	// matches the earlier source
+	pc.mutex.Lock()
+	if deferred {
+		pc.pendingVerify[task.DestPath] = task
+	} else {
+		delete(pc.pendingVerify, task.DestPath)
+	}
+	pc.mutex.Unlock()
+
+	if pc.options.PreserveTimestamps {
+		if err := preserveModTime(task.SourcePath, task.DestPath); err != nil {
+			return false, err
+		}
+	}
+
+	if pc.options.PreserveXattrs {
+		if err := copyXattrs(task.SourcePath, task.DestPath); err != nil {
+			return false, err
+		}
+	}
+	trace.recordEntry(pc.sourceRoot, task.SourcePath, decision)
+	return true, nil
 }
 
-// copyFile copies a single file with optional integrity verification
-func (pc *ParallelCopier) copyFile(sourcePath, destPath string) error {
+// copyFile copies a single file with optional integrity verification. It
+// reports whether verification was deferred to the verification phase.
+func (pc *ParallelCopier) copyFile(sourcePath, destPath string) (bool, error) {
 	// Ensure destination directory exists
 	destDir := filepath.Dir(destPath)
 	if err := os.MkdirAll(destDir, 0755); err != nil {
-		return fmt.Errorf("failed to create destination directory: %w", err)
+		return false, fmt.Errorf("failed to create destination directory: %w", err)
 	}
 
 	// Open source file
 	sourceFile, err := os.Open(sourcePath)
 	if err != nil {
-		return fmt.Errorf("failed to open source file: %w", err)
+		return false, fmt.Errorf("failed to open source file: %w", err)
 	}
 	defer sourceFile.Close()
 
-	// Create destination file
-	destFile, err := os.Create(destPath)
+	// Files are written to a temporary file renamed into place once written
+	// and verified, so a failed copy never leaves a partial file behind
+	destFile, err := createDestFile(destPath, true)
+	if err != nil {
+		return false, fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer destFile.Discard()
+
+	// Copy permissions
+	var size int64
+	if sourceInfo, err := sourceFile.Stat(); err == nil {
+		size = sourceInfo.Size()
+		if err := destFile.Chmod(copiedFileMode(sourceInfo.Mode(), pc.options.GitModeSemantics)); err != nil {
+			return false, fmt.Errorf("failed to set file mode: %w", err)
+		}
+	}
+
+	deferred, err := pc.writeFile(destFile.File, sourceFile, sourcePath, destPath, size)
 	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
+		return false, err
+	}
+	return deferred, destFile.Commit()
+}
+
+// writeFile writes the content of sourceFile, of the given size, to destFile.
+// It reports whether verification was deferred.
+func (pc *ParallelCopier) writeFile(destFile, sourceFile *os.File, sourcePath, destPath string, size int64) (bool, error) {
+	// Annotated files differ from their source by design, so they are not
+	// verified
+	if pc.options.AddProvenanceHeader {
+		if copied, err := copyWithProvenance(destFile, sourceFile, sourcePath); err != nil || copied {
+			return false, err
+		}
 	}
-	defer destFile.Close()
 
 	// Copy with optional integrity verification
-	if pc.options.VerifyIntegrity {
-		return pc.copyWithVerification(sourceFile, destFile, sourcePath, destPath)
+	deferred := pc.options.VerifyIntegrity && pc.options.DeferVerifyMinSize > 0 && size >= pc.options.DeferVerifyMinSize
+	if pc.options.VerifyIntegrity && !deferred {
+		return false, pc.copyWithVerification(sourceFile, destFile, sourcePath, destPath)
 	}
 
 	// Simple copy
-	_, err = io.CopyBuffer(destFile, sourceFile, make([]byte, pc.options.BufferSize))
+	_, err := pc.copyBuffered(destFile, sourceFile)
 	if err != nil {
-		return fmt.Errorf("failed to copy file: %w", err)
+		return false, fmt.Errorf("failed to copy file: %w", err)
 	}
 
-	return nil
+	return deferred, nil
 }
 
 // copyWithVerification copies a file and verifies its integrity
 func (pc *ParallelCopier) copyWithVerification(sourceFile, destFile *os.File, sourcePath, destPath string) error {
-	var sourceHash, destHash hash.Hash
-
-	switch pc.options.ChecksumType {
-	case "sha256":
-		sourceHash = sha256.New()
-		destHash = sha256.New()
-	default:
-		return fmt.Errorf("unsupported checksum type: %s", pc.options.ChecksumType)
+	sourceHash, err := pc.newHash(pc.options.ChecksumType)
+	if err != nil {
+		return err
+	}
+	destHash, err := pc.newHash(pc.options.ChecksumType)
+	if err != nil {
+		return err
 	}
 
 	// Create multi-writers for hashing during copy
@@ -464,7 +1018,7 @@ func (pc *ParallelCopier) copyWithVerification(sourceFile, destFile *os.File, so
 	destWriter := io.MultiWriter(destFile, destHash)
 
 	// Copy with hashing
-	_, err := io.CopyBuffer(destWriter, sourceReader, make([]byte, pc.options.BufferSize))
+	_, err = pc.copyBuffered(destWriter, sourceReader)
 	if err != nil {
 		return fmt.Errorf("failed to copy file: %w", err)
 	}
@@ -480,6 +1034,81 @@ func (pc *ParallelCopier) copyWithVerification(sourceFile, destFile *os.File, so
 	return nil
 }
 
+// verifyCopy compares the checksums of a written copy and its source
+func (pc *ParallelCopier) verifyCopy(sourcePath, destPath string) error {
+	sourceChecksum, err := pc.checksum(sourcePath)
+	if err != nil {
+		return err
+	}
+	destChecksum, err := pc.checksum(destPath)
+	if err != nil {
+		return err
+	}
+
+	if !equalBytes(sourceChecksum, destChecksum) {
+		return fmt.Errorf("integrity verification failed: checksums don't match")
+	}
+	return nil
+}
+
+// getBuffer borrows a copy buffer of BufferSize bytes; return it with
+// putBuffer
+func (pc *ParallelCopier) getBuffer() *[]byte {
+	if buf, ok := pc.buffers.Get().(*[]byte); ok && cap(*buf) >= pc.options.BufferSize {
+		*buf = (*buf)[:pc.options.BufferSize]
+		return buf
+	}
+	buf := make([]byte, pc.options.BufferSize)
+	return &buf
+}
+
+// putBuffer returns a buffer borrowed with getBuffer
+func (pc *ParallelCopier) putBuffer(buf *[]byte) {
+	pc.buffers.Put(buf)
+}
+
+// copyBuffered copies src to dst through a pooled buffer
+func (pc *ParallelCopier) copyBuffered(dst io.Writer, src io.Reader) (int64, error) {
+	buf := pc.getBuffer()
+	defer pc.putBuffer(buf)
+	return io.CopyBuffer(dst, src, *buf)
+}
+
+// checksum hashes the file at path with the configured ChecksumType
+func (pc *ParallelCopier) checksum(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	hash, err := pc.newHash(pc.options.ChecksumType)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pc.copyBuffered(hash, file); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return hash.Sum(nil), nil
+}
+
+// newChecksumHash returns a hasher for a ChecksumType. md5, sha1 and crc32
+// are cheaper than sha256 on large trees and still catch corrupted copies.
+func newChecksumHash(checksumType string) (hash.Hash, error) {
+	switch checksumType {
+	case "sha256":
+		return sha256.New(), nil
+	case "sha1":
+		return sha1.New(), nil
+	case "md5":
+		return md5.New(), nil
+	case "crc32":
+		return crc32.NewIEEE(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q (use sha256, sha1, md5 or crc32)", ErrUnsupportedChecksum, checksumType)
+	}
+}
+
 // sendProgressUpdate sends a progress update
 func (pc *ParallelCopier) sendProgressUpdate(update ProgressUpdate) {
 	if pc.options.ShowProgress && pc.progress != nil {
@@ -2,6 +2,7 @@ package autocopy
 
 import (
 	"fmt"
+	"hash"
 	"os"
 	"path/filepath"
 	"strings"
@@ -58,12 +59,13 @@ func TestParallelCopier_Run(t *testing.T) {
 		}
 
 		// Create parallel copier
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers:      4,
 			BufferSize:      1024,
 			ShowProgress:    false,
 			VerifyIntegrity: true,
 		})
+		require.NoError(t, err)
 
 		// Measure execution time
 		start := time.Now()
@@ -116,11 +118,12 @@ func TestParallelCopier_Run(t *testing.T) {
 			progressMutex.Unlock()
 		}
 
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers:       2,
 			ShowProgress:     true,
 			ProgressCallback: progressCallback,
 		})
+		require.NoError(t, err)
 
 		err = copier.Run(testRepo.RepoDir, destDir)
 		require.NoError(t, err)
@@ -166,11 +169,12 @@ func TestParallelCopier_Run(t *testing.T) {
 			},
 		}
 
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers:      2,
 			VerifyIntegrity: true,
 			ChecksumType:    "sha256",
 		})
+		require.NoError(t, err)
 
 		err = copier.Run(testRepo.RepoDir, destDir)
 		require.NoError(t, err)
@@ -211,11 +215,12 @@ func TestParallelCopier_Run(t *testing.T) {
 			errorMutex.Unlock()
 		}
 
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers:      2,
 			ContinueOnError: true,
 			ErrorCallback:   errorCallback,
 		})
+		require.NoError(t, err)
 
 		err = copier.Run(testRepo.RepoDir, destDir)
 		// Should not fail completely due to ContinueOnError
@@ -252,9 +257,10 @@ func TestParallelCopier_Run(t *testing.T) {
 		err := os.MkdirAll(seqDestDir, 0755)
 		require.NoError(t, err)
 
-		seqCopier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		seqCopier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers: 1, // Sequential
 		})
+		require.NoError(t, err)
 
 		seqStart := time.Now()
 		err = seqCopier.Run(testRepo.RepoDir, seqDestDir)
@@ -266,9 +272,10 @@ func TestParallelCopier_Run(t *testing.T) {
 		err = os.MkdirAll(parDestDir, 0755)
 		require.NoError(t, err)
 
-		parCopier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		parCopier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers: 4, // Parallel
 		})
+		require.NoError(t, err)
 
 		parStart := time.Now()
 		err = parCopier.Run(testRepo.RepoDir, parDestDir)
@@ -321,9 +328,10 @@ func TestParallelCopier_WorkerPool(t *testing.T) {
 			err := os.MkdirAll(subDestDir, 0755)
 			require.NoError(t, err)
 
-			copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+			copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 				MaxWorkers: workers,
 			})
+			require.NoError(t, err)
 
 			start := time.Now()
 			err = copier.Run(testRepo.RepoDir, subDestDir)
@@ -340,4 +348,685 @@ func TestParallelCopier_WorkerPool(t *testing.T) {
 	})
 }
 
+func TestParallelCopier_Estimate(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "estimate-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".ai/prompts.md", strings.Repeat("a", 1024))
+	testRepo.CreateFile(".ai/nested/context.md", strings.Repeat("b", 2048))
+	testRepo.CreateFile("CLAUDE.md", strings.Repeat("c", 512))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true},
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false)},
+			{Path: "missing.md", Directory: testutil.BoolPtr(false)},
+		},
+	}
+
+	destDir := filepath.Join(testRepo.TempDir, "estimate-dest")
+	estimate, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Estimate(testRepo.RepoDir, destDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, estimate.Files)
+	assert.Equal(t, 2, estimate.Directories)
+	assert.Equal(t, int64(1024+2048+512), estimate.TotalBytes)
+	assert.Equal(t, "3 files, ~0.0 MB", estimate.String())
+
+	// Nothing is copied by an estimate
+	assert.NoDirExists(t, destDir)
+}
+
+func TestMaxTotalFiles(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "max-files-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	for i := 0; i < 5; i++ {
+		testRepo.CreateFile(fmt.Sprintf("node_modules/pkg/file%d.js", i), "module.exports = {}")
+	}
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "node_modules/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	t.Run("estimate aborts above limit", func(t *testing.T) {
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{MaxTotalFiles: 3})
+		_, err := copier.Estimate(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "estimate-dest"))
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrTooManyFiles)
+		assert.Contains(t, err.Error(), "narrow the auto-copy paths")
+	})
+
+	for _, parallel := range []bool{false, true} {
+		destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("dest-parallel-%t", parallel))
+
+		t.Run(fmt.Sprintf("run aborts above limit (parallel=%t)", parallel), func(t *testing.T) {
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{
+				UseParallel:       parallel,
+				MaxTotalFiles:     3,
+				NoGitignoreUpdate: true,
+			})
+			err := copier.Run(testRepo.RepoDir, destDir)
+			assert.ErrorIs(t, err, ErrTooManyFiles)
+			assert.NoDirExists(t, filepath.Join(destDir, "node_modules"))
+		})
+	}
+
+	t.Run("limit at file count and disabled limit pass", func(t *testing.T) {
+		for _, limit := range []int{5, -1} {
+			estimate, err := NewAutoCopier(repo, config, AutoCopierOptions{MaxTotalFiles: limit}).
+				Estimate(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "unused"))
+			require.NoError(t, err)
+			assert.Equal(t, 5, estimate.Files)
+		}
+	})
+
+	t.Run("gitignored files do not count", func(t *testing.T) {
+		testRepo.CreateFile(".gitignore", "node_modules/\n")
+		testRepo.CreateFile(".ai/rules.md", "rules")
+		for i := 0; i < 20; i++ {
+			testRepo.CreateFile(fmt.Sprintf(".ai/node_modules/file%d.js", i), "module.exports = {}")
+		}
+		ignoring := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+			},
+		}
+
+		estimate, err := NewAutoCopier(repo, ignoring, AutoCopierOptions{MaxTotalFiles: 5, RespectGitignore: true}).
+			Estimate(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "unused"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, estimate.Files)
+
+		for _, parallel := range []bool{false, true} {
+			destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("ignored-dest-parallel-%t", parallel))
+			copier := NewAutoCopier(repo, ignoring, AutoCopierOptions{
+				UseParallel:       parallel,
+				MaxTotalFiles:     5,
+				RespectGitignore:  true,
+				NoGitignoreUpdate: true,
+			})
+			require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+			assert.FileExists(t, filepath.Join(destDir, ".ai", "rules.md"))
+			assert.NoFileExists(t, filepath.Join(destDir, ".ai", "node_modules", "file0.js"))
+		}
+	})
+}
+
+func TestMaxTotalBytes(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "max-bytes-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// Five files of 100 bytes
+	for i := 0; i < 5; i++ {
+		testRepo.CreateFile(fmt.Sprintf("build/out%d.bin", i), strings.Repeat("x", 100))
+	}
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "build/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	t.Run("estimate aborts above limit", func(t *testing.T) {
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{MaxTotalBytes: 499})
+		_, err := copier.Estimate(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "estimate-dest"))
+		require.ErrorIs(t, err, ErrTransferTooLarge)
+		assert.NotErrorIs(t, err, ErrTooManyFiles)
+		assert.Contains(t, err.Error(), "500 bytes")
+		assert.Contains(t, err.Error(), "narrow the auto-copy paths")
+	})
+
+	for _, parallel := range []bool{false, true} {
+		destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("dest-parallel-%t", parallel))
+
+		t.Run(fmt.Sprintf("run aborts above limit (parallel=%t)", parallel), func(t *testing.T) {
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{
+				UseParallel:       parallel,
+				MaxTotalBytes:     499,
+				NoGitignoreUpdate: true,
+			})
+			err := copier.Run(testRepo.RepoDir, destDir)
+			assert.ErrorIs(t, err, ErrTransferTooLarge)
+			assert.NoDirExists(t, filepath.Join(destDir, "build"))
+		})
+	}
+
+	t.Run("sync aborts above limit", func(t *testing.T) {
+		destDir := t.TempDir()
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{MaxTotalBytes: 499})
+		_, err := copier.Sync(testRepo.RepoDir, destDir, filepath.Join(t.TempDir(), ManifestFile), SyncOptions{})
+		assert.ErrorIs(t, err, ErrTransferTooLarge)
+		assert.NoDirExists(t, filepath.Join(destDir, "build"))
+	})
+
+	t.Run("limit at total size and unlimited pass", func(t *testing.T) {
+		for _, limit := range []int64{500, 0, -1} {
+			estimate, err := NewAutoCopier(repo, config, AutoCopierOptions{MaxTotalBytes: limit}).
+				Estimate(testRepo.RepoDir, filepath.Join(testRepo.TempDir, "unused"))
+			require.NoError(t, err)
+			assert.Equal(t, int64(500), estimate.TotalBytes)
+		}
+	})
+}
+
 // Helper function
+
+func TestCopyPriority(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "priority-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("override.md", "override")
+	testRepo.CreateFile("base/one.md", "one")
+	testRepo.CreateFile("base/two.md", "two")
+	testRepo.CreateFile("extra.md", "extra")
+
+	// Listed out of order on purpose: base must be copied first
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "override.md", Directory: testutil.BoolPtr(false), RootOnly: true, Priority: 10},
+			{Path: "base/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true, Priority: -5},
+			{Path: "extra.md", Directory: testutil.BoolPtr(false), RootOnly: true, Priority: 10},
+		},
+	}
+
+	t.Run("items sort stably by priority", func(t *testing.T) {
+		sorted := itemsByPriority(config.Items)
+		paths := make([]string, len(sorted))
+		for i, item := range sorted {
+			paths[i] = item.Path
+		}
+		assert.Equal(t, []string{"base/", "override.md", "extra.md"}, paths)
+		assert.Equal(t, "override.md", config.Items[0].Path, "configured order must not change")
+	})
+
+	t.Run("parallel tasks run in priority phases", func(t *testing.T) {
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{})
+		require.NoError(t, err)
+		tasks, err := copier.discoverTasks(testRepo.RepoDir, t.TempDir())
+		require.NoError(t, err)
+
+		phases := taskPhases(tasks)
+		require.Len(t, phases, 2)
+		for _, task := range phases[0] {
+			assert.Equal(t, -5, task.Priority)
+			assert.Contains(t, task.SourcePath, "base")
+		}
+		require.Len(t, phases[1], 2)
+		assert.Equal(t, "override.md", filepath.Base(phases[1][0].SourcePath))
+		assert.Equal(t, "extra.md", filepath.Base(phases[1][1].SourcePath))
+
+		assert.Empty(t, taskPhases(nil))
+	})
+
+	t.Run("sequential copy follows priority", func(t *testing.T) {
+		copied, err := NewLegacyAutoCopier().CopyFiles(testRepo.RepoDir, t.TempDir(), config)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"base/", "override.md", "extra.md"}, copied)
+	})
+
+	t.Run("parallel copy copies every phase", func(t *testing.T) {
+		destDir := t.TempDir()
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{MaxWorkers: 2})
+		require.NoError(t, err)
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		for _, path := range []string{"override.md", "extra.md", "base/one.md", "base/two.md"} {
+			assert.FileExists(t, filepath.Join(destDir, path))
+		}
+	})
+}
+
+func TestParallelCopier_DedupTasks(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "dedup-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".cursorrules", "root rules")
+	testRepo.CreateFile("web/.cursorrules", "web rules")
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "**/.cursorrules", Directory: testutil.BoolPtr(false), UseGlob: true},
+			{Path: ".cursorrules", Directory: testutil.BoolPtr(false)},
+			{Path: ".cursorrules", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+			{Path: ".ai/prompt.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+
+	destDir := filepath.Join(testRepo.TempDir, "dedup-dest")
+	copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{})
+	require.NoError(t, err)
+	tasks, err := copier.discoverTasks(testRepo.RepoDir, destDir)
+	require.NoError(t, err)
+
+	seen := make(map[string]int)
+	for _, task := range tasks {
+		seen[task.DestPath]++
+	}
+	for dest, count := range seen {
+		assert.Equal(t, 1, count, "%s should be copied once", dest)
+	}
+	assert.Contains(t, seen, filepath.Join(destDir, ".cursorrules"))
+	assert.Contains(t, seen, filepath.Join(destDir, "web", ".cursorrules"))
+	assert.Contains(t, seen, filepath.Join(destDir, ".ai", "prompt.md"))
+	assert.Equal(t, 2, copier.DuplicateTasks())
+
+	t.Run("estimate counts each file once", func(t *testing.T) {
+		estimate, err := copier.Estimate(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.Equal(t, 3, estimate.Files)
+		assert.Equal(t, int64(len("root rules")+len("web rules")+len("prompt")), estimate.TotalBytes)
+	})
+
+	t.Run("run copies each file once", func(t *testing.T) {
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+		assert.Equal(t, len(tasks), copier.totalTasks)
+
+		content, err := os.ReadFile(filepath.Join(destDir, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "root rules", string(content))
+	})
+
+	t.Run("equal priorities writing one destination keep the first", func(t *testing.T) {
+		testRepo.CreateFile("override/.cursorrules", "override rules")
+		sameDest := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: ".cursorrules", Directory: testutil.BoolPtr(false), RootOnly: true},
+				{Path: "override/.cursorrules", Directory: testutil.BoolPtr(false), RootOnly: true},
+			},
+		}
+		copier, err := NewParallelCopier(repo, sameDest, ParallelCopyOptions{StripPrefix: "override"})
+		require.NoError(t, err)
+
+		destDir := t.TempDir()
+		tasks, err := copier.discoverTasks(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		require.Len(t, tasks, 1)
+		assert.Equal(t, filepath.Join(testRepo.RepoDir, ".cursorrules"), tasks[0].SourcePath)
+		assert.Equal(t, 1, copier.DuplicateTasks())
+
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+		assert.Equal(t, 1, copier.totalTasks)
+		content, err := os.ReadFile(filepath.Join(destDir, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "root rules", string(content))
+	})
+
+	t.Run("a later priority overrides the destination once", func(t *testing.T) {
+		overriding := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: ".cursorrules", Directory: testutil.BoolPtr(false), RootOnly: true},
+				{Path: "override/.cursorrules", Directory: testutil.BoolPtr(false), RootOnly: true, Priority: 1},
+			},
+		}
+		copier, err := NewParallelCopier(repo, overriding, ParallelCopyOptions{StripPrefix: "override"})
+		require.NoError(t, err)
+
+		destDir := t.TempDir()
+		estimate, err := copier.Estimate(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		assert.Equal(t, 1, estimate.Files)
+		assert.Equal(t, int64(len("override rules")), estimate.TotalBytes)
+
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+		assert.Equal(t, 1, copier.totalTasks)
+		content, err := os.ReadFile(filepath.Join(destDir, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "override rules", string(content))
+	})
+}
+
+func TestParallelCopier_ChecksumTypes(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "checksum-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	content := strings.Repeat("checksum content ", 4096)
+	testRepo.CreateFile("data.txt", content)
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: "data.txt", Directory: testutil.BoolPtr(false)}},
+	}
+
+	tests := []struct {
+		checksumType string
+		wantErr      bool
+	}{
+		{checksumType: ""},
+		{checksumType: "sha256"},
+		{checksumType: "sha1"},
+		{checksumType: "md5"},
+		{checksumType: "crc32"},
+		{checksumType: "blake3", wantErr: true},
+		{checksumType: "SHA256", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("checksum %q", tt.checksumType), func(t *testing.T) {
+			var mu sync.Mutex
+			var copyErrors []CopyError
+			copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+				VerifyIntegrity: true,
+				ChecksumType:    tt.checksumType,
+				ErrorCallback: func(copyErr CopyError) {
+					mu.Lock()
+					defer mu.Unlock()
+					copyErrors = append(copyErrors, copyErr)
+				},
+			})
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrUnsupportedChecksum)
+				assert.Nil(t, copier)
+				return
+			}
+			require.NoError(t, err)
+
+			destDir := t.TempDir()
+			require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+			mu.Lock()
+			defer mu.Unlock()
+			assert.Empty(t, copyErrors)
+			copied, err := os.ReadFile(filepath.Join(destDir, "data.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, content, string(copied))
+		})
+	}
+}
+
+func TestParallelCopier_DeferredVerification(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "deferred-verify-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	large := strings.Repeat("large content ", 1024)
+	testRepo.CreateFile("large.bin", large)
+	testRepo.CreateFile("small.txt", "small")
+	testRepo.CreateFile("override/large.bin", "overridden")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "large.bin", Directory: testutil.BoolPtr(false)},
+			{Path: "small.txt", Directory: testutil.BoolPtr(false)},
+		},
+	}
+
+	newCopier := func(t *testing.T, config *AutoCopyConfig, copyErrors *[]CopyError) *ParallelCopier {
+		var mu sync.Mutex
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+			VerifyIntegrity:    true,
+			DeferVerifyMinSize: 1024,
+			ContinueOnError:    true,
+			StripPrefix:        "override", // Maps override/large.bin onto large.bin
+			ErrorCallback: func(copyErr CopyError) {
+				mu.Lock()
+				defer mu.Unlock()
+				*copyErrors = append(*copyErrors, copyErr)
+			},
+		})
+		require.NoError(t, err)
+		return copier
+	}
+
+	t.Run("large files are verified in a separate phase", func(t *testing.T) {
+		var copyErrors []CopyError
+		copier := newCopier(t, config, &copyErrors)
+		destDir := t.TempDir()
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		assert.Empty(t, copyErrors)
+		assert.Equal(t, []string{"large.bin", "small.txt"}, copier.CopiedFiles())
+		assert.Equal(t, 1, copier.Verification().Files)
+		assert.Positive(t, copier.Verification().Duration)
+
+		copied, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, large, string(copied))
+	})
+
+	t.Run("a later item replacing a deferred copy is not verified against it", func(t *testing.T) {
+		overriding := &AutoCopyConfig{
+			Version: 2,
+			Items:   append(config.Items, AutoCopyItem{Path: "override/large.bin", Directory: testutil.BoolPtr(false), Priority: 1}),
+		}
+		var copyErrors []CopyError
+		copier := newCopier(t, overriding, &copyErrors)
+		destDir := t.TempDir()
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		assert.Empty(t, copyErrors)
+		assert.Zero(t, copier.Verification().Files)
+		copied, err := os.ReadFile(filepath.Join(destDir, "large.bin"))
+		require.NoError(t, err)
+		assert.Equal(t, "overridden", string(copied))
+	})
+
+	t.Run("mismatching deferred copies fail the run", func(t *testing.T) {
+		largeOnly := &AutoCopyConfig{Version: 2, Items: config.Items[:1]}
+		for _, continueOnError := range []bool{false, true} {
+			var copyErrors []CopyError
+			copier := newCopier(t, largeOnly, &copyErrors)
+			copier.options.ContinueOnError = continueOnError
+			calls := 0
+			copier.newHash = func(checksumType string) (hash.Hash, error) {
+				h, err := newChecksumHash(checksumType)
+				calls++
+				if calls%2 == 0 {
+					return mismatchHash{h}, err
+				}
+				return h, err
+			}
+			destDir := t.TempDir()
+
+			err := copier.Run(testRepo.RepoDir, destDir)
+			if continueOnError {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "integrity verification failed")
+			}
+			require.Len(t, copyErrors, 1)
+			assert.Equal(t, filepath.Join(destDir, "large.bin"), copyErrors[0].DestPath)
+			assert.NoFileExists(t, filepath.Join(destDir, "large.bin"))
+			assert.Empty(t, copier.CopiedFiles())
+		}
+	})
+
+	t.Run("mismatching copies are reported", func(t *testing.T) {
+		copier := newCopier(t, config, new([]CopyError))
+		destPath := filepath.Join(t.TempDir(), "large.bin")
+		require.NoError(t, os.WriteFile(destPath, []byte("corrupted"), 0644))
+
+		err := copier.verifyCopy(filepath.Join(testRepo.RepoDir, "large.bin"), destPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "integrity verification failed")
+	})
+}
+
+// mismatchHash reports a checksum that never matches another hash's
+type mismatchHash struct {
+	hash.Hash
+}
+
+func (h mismatchHash) Sum(b []byte) []byte {
+	return append(h.Hash.Sum(b), 0xff)
+}
+
+func TestParallelCopier_FailedVerification(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "failed-verify-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false)}},
+	}
+
+	// Corrupts the checksum of every written copy
+	run := func(t *testing.T, destDir string) []CopyError {
+		var copyErrors []CopyError
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+			MaxWorkers:      1,
+			VerifyIntegrity: true,
+			ContinueOnError: true,
+			ErrorCallback:   func(copyErr CopyError) { copyErrors = append(copyErrors, copyErr) },
+		})
+		require.NoError(t, err)
+		calls := 0
+		copier.newHash = func(checksumType string) (hash.Hash, error) {
+			h, err := newChecksumHash(checksumType)
+			calls++
+			if calls%2 == 0 {
+				return mismatchHash{h}, err
+			}
+			return h, err
+		}
+
+		copier.Run(testRepo.RepoDir, destDir)
+		return copyErrors
+	}
+
+	assertNoTempFiles := func(t *testing.T, destDir string) {
+		entries, err := os.ReadDir(destDir)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			assert.NotContains(t, entry.Name(), ".hatcher-tmp-")
+		}
+	}
+
+	t.Run("leaves no destination file behind", func(t *testing.T) {
+		destDir := t.TempDir()
+		copyErrors := run(t, destDir)
+
+		require.Len(t, copyErrors, 1)
+		assert.Contains(t, copyErrors[0].Error.Error(), "integrity verification failed")
+		assert.NoFileExists(t, filepath.Join(destDir, "CLAUDE.md"))
+		assertNoTempFiles(t, destDir)
+	})
+
+	t.Run("keeps an existing destination file", func(t *testing.T) {
+		destDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"), []byte("previous rules"), 0644))
+		copyErrors := run(t, destDir)
+
+		require.Len(t, copyErrors, 1)
+		content, err := os.ReadFile(filepath.Join(destDir, "CLAUDE.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "previous rules", string(content))
+		assertNoTempFiles(t, destDir)
+	})
+}
+
+func TestParallelCopier_CopiedFiles(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "copied-files-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("notes.txt", "notes")
+	testRepo.CreateFile("todo.txt", "todo")
+	testRepo.CreateFile("config/ai/.ai/prompt.md", "prompt")
+	testRepo.CreateFile("config/ai/.ai/nested/context.md", "context")
+	testRepo.CreateFile("config/ai/CLAUDE.md", "claude")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "*.txt", Directory: testutil.BoolPtr(false), UseGlob: true},
+			{Path: "config/ai/.ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+			{Path: "config/ai/CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+
+	// existingFiles lists the regular files below dir as slash paths
+	existingFiles := func(t *testing.T, dir string) []string {
+		var files []string
+		require.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			files = append(files, relativeSlash(dir, path))
+			return nil
+		}))
+		return files
+	}
+
+	t.Run("accessor lists every written file", func(t *testing.T) {
+		destDir := t.TempDir()
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{MaxWorkers: 4, ContinueOnError: true})
+		require.NoError(t, err)
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		copied := copier.CopiedFiles()
+		assert.Equal(t, []string{
+			"config/ai/.ai/nested/context.md",
+			"config/ai/.ai/prompt.md",
+			"config/ai/CLAUDE.md",
+			"notes.txt",
+			"todo.txt",
+		}, copied)
+		assert.ElementsMatch(t, existingFiles(t, destDir), copied)
+	})
+
+	t.Run("copy reports mapped destinations", func(t *testing.T) {
+		destDir := t.TempDir()
+		copied, err := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: true, StripPrefix: "config/ai/"}).Copy(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+
+		assert.Contains(t, copied, ".ai/nested/context.md")
+		assert.ElementsMatch(t, existingFiles(t, destDir), copied)
+	})
+}
+
+func TestParallelCopier_Buffers(t *testing.T) {
+	copier, err := NewParallelCopier(nil, &AutoCopyConfig{}, ParallelCopyOptions{BufferSize: 1024})
+	require.NoError(t, err)
+
+	t.Run("borrowed buffers have the full size", func(t *testing.T) {
+		buf := copier.getBuffer()
+		assert.Len(t, *buf, 1024)
+
+		// A buffer returned shortened is restored to BufferSize
+		*buf = (*buf)[:10]
+		copier.putBuffer(buf)
+		assert.Len(t, *copier.getBuffer(), 1024)
+	})
+
+	t.Run("undersized buffers are replaced", func(t *testing.T) {
+		small := make([]byte, 16)
+		copier.putBuffer(&small)
+		assert.Len(t, *copier.getBuffer(), 1024)
+	})
+
+	t.Run("verification reads through pooled buffers", func(t *testing.T) {
+		dir := t.TempDir()
+		source := filepath.Join(dir, "source.bin")
+		dest := filepath.Join(dir, "dest.bin")
+		content := strings.Repeat("0123456789", 1000)
+		require.NoError(t, os.WriteFile(source, []byte(content), 0644))
+		require.NoError(t, os.WriteFile(dest, []byte(content), 0644))
+
+		assert.NoError(t, copier.verifyCopy(source, dest))
+		require.NoError(t, os.WriteFile(dest, []byte(content[1:]+"x"), 0644))
+		assert.Error(t, copier.verifyCopy(source, dest))
+	})
+}
@@ -1,7 +1,10 @@
 package autocopy
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -44,9 +47,10 @@ func BenchmarkParallelCopy(b *testing.B) {
 		err := os.MkdirAll(destDir, 0755)
 		require.NoError(b, err)
 
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers: 4,
 		})
+		require.NoError(b, err)
 
 		err = copier.Run(testRepo.RepoDir, destDir)
 		require.NoError(b, err)
@@ -98,9 +102,10 @@ func BenchmarkSequentialVsParallel(b *testing.B) {
 				err := os.MkdirAll(destDir, 0755)
 				require.NoError(b, err)
 
-				copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+				copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 					MaxWorkers: tc.workers,
 				})
+				require.NoError(b, err)
 
 				err = copier.Run(testRepo.RepoDir, destDir)
 				require.NoError(b, err)
@@ -148,10 +153,11 @@ func BenchmarkMemoryUsage(b *testing.B) {
 		err := os.MkdirAll(destDir, 0755)
 		require.NoError(b, err)
 
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers: 2,
 			BufferSize: 64 * 1024, // 64KB buffer
 		})
+		require.NoError(b, err)
 
 		err = copier.Run(testRepo.RepoDir, destDir)
 		require.NoError(b, err)
@@ -168,6 +174,97 @@ func BenchmarkMemoryUsage(b *testing.B) {
 	b.ReportMetric(float64(m2.TotalAlloc-m1.TotalAlloc)/float64(b.N), "total-bytes/op")
 }
 
+// BenchmarkCopyBuffers compares allocating a copy buffer per file with
+// borrowing one from the copier's pool
+func BenchmarkCopyBuffers(b *testing.B) {
+	copier, err := NewParallelCopier(nil, &AutoCopyConfig{}, ParallelCopyOptions{BufferSize: 256 * 1024})
+	require.NoError(b, err)
+	content := make([]byte, 1024*1024)
+
+	// The readers hide WriterTo, which would let io.CopyBuffer skip the buffer
+	testCases := []struct {
+		name string
+		copy func(dst io.Writer, src io.Reader) (int64, error)
+	}{
+		{"Fresh", func(dst io.Writer, src io.Reader) (int64, error) {
+			return io.CopyBuffer(dst, src, make([]byte, copier.options.BufferSize))
+		}},
+		{"Pooled", copier.copyBuffered},
+	}
+
+	for _, tc := range testCases {
+		b.Run(tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(content)))
+
+			for i := 0; i < b.N; i++ {
+				src := io.LimitReader(bytes.NewReader(content), int64(len(content)))
+				if _, err := tc.copy(sha256.New(), src); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkVerification compares inline verification by the copy workers
+// with a separate verification phase for large files
+func BenchmarkVerification(b *testing.B) {
+	testRepo := testutil.NewTestGitRepository(b, "verify-bench")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(b, err)
+
+	// Create large files
+	numFiles := 32
+	fileSize := 4 * 1024 * 1024 // 4MB each
+	content := make([]byte, fileSize)
+	for j := range content {
+		content[j] = byte(j % 251)
+	}
+	for i := 0; i < numFiles; i++ {
+		filePath := filepath.Join(testRepo.RepoDir, fmt.Sprintf("large%d.bin", i))
+		require.NoError(b, os.WriteFile(filePath, content, 0644))
+	}
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "large*.bin", Directory: boolPtr(false), UseGlob: true},
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		minSize int64
+	}{
+		{"Inline", 0},
+		{"Deferred", 1024 * 1024},
+	}
+
+	for _, tc := range testCases {
+		b.Run(tc.name, func(b *testing.B) {
+			var verifyTime time.Duration
+			b.SetBytes(int64(numFiles * fileSize))
+
+			for i := 0; i < b.N; i++ {
+				destDir := filepath.Join(b.TempDir(), "dest")
+				require.NoError(b, os.MkdirAll(destDir, 0755))
+
+				copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+					MaxWorkers:         4,
+					VerifyIntegrity:    true,
+					DeferVerifyMinSize: tc.minSize,
+				})
+				require.NoError(b, err)
+				require.NoError(b, copier.Run(testRepo.RepoDir, destDir))
+				verifyTime += copier.Verification().Duration
+			}
+
+			b.ReportMetric(float64(verifyTime.Nanoseconds())/float64(b.N), "verify-ns/op")
+		})
+	}
+}
+
 // TestPerformanceRegression tests for performance regressions
 func TestPerformanceRegression(t *testing.T) {
 	if testing.Short() {
@@ -208,9 +305,10 @@ func TestPerformanceRegression(t *testing.T) {
 		err := os.MkdirAll(subDestDir, 0755)
 		require.NoError(t, err)
 
-		copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 			MaxWorkers: workers,
 		})
+		require.NoError(t, err)
 
 		start := time.Now()
 		err = copier.Run(testRepo.RepoDir, subDestDir)
@@ -277,9 +375,13 @@ func TestConcurrentSafety(t *testing.T) {
 				return
 			}
 
-			copier := NewParallelCopier(repo, config, ParallelCopyOptions{
+			copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
 				MaxWorkers: 2,
 			})
+			if err != nil {
+				done <- err
+				return
+			}
 
 			err = copier.Run(testRepo.RepoDir, destDir)
 			done <- err
@@ -0,0 +1,166 @@
+package autocopy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+)
+
+// Decisions of a planned copy
+const (
+	PlanCreate     = "create"     // The destination does not exist yet
+	PlanOverwrite  = "overwrite"  // The destination exists with different content
+	PlanBackup     = "backup"     // Overwrite, keeping the old file as a backup
+	PlanSkip       = "skip"       // The destination differs and its item's policy keeps it
+	PlanConflict   = "conflict"   // The destination differs and its item's policy fails the copy
+	PlanUnchanged  = "unchanged"  // The destination already has the same content
+	PlanOverridden = "overridden" // A later item writes the same destination
+)
+
+// PlanEntry is a single file of a copy plan
+type PlanEntry struct {
+	Source   string `json:"source"` // Relative to the source root
+	Dest     string `json:"dest"`   // Relative to the destination root
+	Size     int64  `json:"size"`
+	Decision string `json:"decision"`
+}
+
+// CopyPlan lists every file a copy would write and what would happen to it
+type CopyPlan struct {
+	Entries    []PlanEntry `json:"entries"`
+	Files      int         `json:"files"`      // Entries that would be written
+	TotalBytes int64       `json:"totalBytes"` // Size of the entries that would be written
+}
+
+// Plan resolves the files a copy from sourceDir to destDir would write
+// without copying anything. destDir does not need to exist; every file is
+// then created.
+func (ac *AutoCopier) Plan(sourceDir, destDir string) (*CopyPlan, error) {
+	tasks, err := ac.Tasks(sourceDir, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tasks are in copy order, so the last task for a destination wins
+	last := make(map[string]int)
+	for i, task := range tasks {
+		last[task.DestPath] = i
+	}
+
+	plan := &CopyPlan{Entries: []PlanEntry{}}
+	for i, task := range tasks {
+		if task.IsDir {
+			continue
+		}
+
+		entry := PlanEntry{
+			Source: relativeSlash(sourceDir, task.SourcePath),
+			Dest:   relativeSlash(destDir, task.DestPath),
+			Size:   task.Size,
+		}
+		if last[task.DestPath] != i {
+			entry.Decision = PlanOverridden
+		} else if entry.Decision, err = ac.planDecision(task); err != nil {
+			return nil, err
+		}
+
+		if entry.Decision != PlanOverridden && entry.Decision != PlanUnchanged && entry.Decision != PlanSkip {
+			plan.Files++
+			plan.TotalBytes += entry.Size
+		}
+		plan.Entries = append(plan.Entries, entry)
+	}
+
+	return plan, nil
+}
+
+// planDecision returns what copying task would do to its destination
+func (ac *AutoCopier) planDecision(task CopyTask) (string, error) {
+	destInfo, err := os.Stat(task.DestPath)
+	if os.IsNotExist(err) {
+		return PlanCreate, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to stat %s: %w", task.DestPath, err)
+	}
+
+	changed, err := contentChanged(task.SourcePath, task.DestPath, destInfo, ac.options.AddProvenanceHeader)
+	if err != nil {
+		return "", err
+	}
+	if !changed {
+		return PlanUnchanged, nil
+	}
+	switch conflictPolicy(task.OnConflict, ac.options.OnConflict, ac.options.Backup) {
+	case ConflictBackup:
+		return PlanBackup, nil
+	case ConflictSkip:
+		return PlanSkip, nil
+	case ConflictError:
+		return PlanConflict, nil
+	case ConflictNewer:
+		newer, err := sourceNewer(task.SourcePath, destInfo)
+		if err != nil {
+			return "", err
+		}
+		if !newer {
+			return PlanSkip, nil
+		}
+		return PlanOverwrite, nil
+	default:
+		return PlanOverwrite, nil
+	}
+}
+
+// relativeSlash returns path relative to root as a slash path, or path itself
+// if it is not below root
+func relativeSlash(root, path string) string {
+	if rel, ok := relativeInside(root, path); ok {
+		return rel
+	}
+	return filepath.ToSlash(path)
+}
+
+// FormatAsTable formats the plan as a table
+func (p *CopyPlan) FormatAsTable() string {
+	if len(p.Entries) == 0 {
+		return "No files match the auto-copy configuration.\n"
+	}
+
+	var output bytes.Buffer
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "SOURCE\tDEST\tSIZE\tDECISION")
+	fmt.Fprintln(w, "------\t----\t----\t--------")
+	for _, entry := range p.Entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", entry.Source, entry.Dest, formatSize(entry.Size), entry.Decision)
+	}
+	w.Flush()
+
+	fmt.Fprintf(&output, "\nTotal: %d files to write, %s\n", p.Files, formatSize(p.TotalBytes))
+	return output.String()
+}
+
+// FormatAsJSON formats the plan as JSON
+func (p *CopyPlan) FormatAsJSON() string {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal JSON: %s"}`, err.Error())
+	}
+	return string(data) + "\n"
+}
+
+// formatSize formats a byte count, e.g. "512 B" or "3.4 KB"
+func formatSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	}
+}
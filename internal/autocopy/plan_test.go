@@ -0,0 +1,99 @@
+package autocopy
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoCopier_Plan(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "plan-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".ai/one.md", "one")
+	testRepo.CreateFile(".ai/two.md", "two")
+	testRepo.CreateFile("base/.ai/one.md", "base")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	t.Run("hypothetical destination", func(t *testing.T) {
+		destDir := filepath.Join(t.TempDir(), "missing")
+		plan, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Plan(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []PlanEntry{
+			{Source: "CLAUDE.md", Dest: "CLAUDE.md", Size: 5, Decision: PlanCreate},
+			{Source: ".ai/one.md", Dest: ".ai/one.md", Size: 3, Decision: PlanCreate},
+			{Source: ".ai/two.md", Dest: ".ai/two.md", Size: 3, Decision: PlanCreate},
+		}, plan.Entries)
+		assert.Equal(t, 3, plan.Files)
+		assert.Equal(t, int64(11), plan.TotalBytes)
+		assert.NoDirExists(t, destDir)
+	})
+
+	t.Run("existing destination", func(t *testing.T) {
+		destDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"), []byte("rules"), 0644))
+		require.NoError(t, os.MkdirAll(filepath.Join(destDir, ".ai"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, ".ai", "one.md"), []byte("local"), 0644))
+
+		plan, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Plan(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		decisions := make(map[string]string)
+		for _, entry := range plan.Entries {
+			decisions[entry.Dest] = entry.Decision
+		}
+		assert.Equal(t, map[string]string{"CLAUDE.md": PlanUnchanged, ".ai/one.md": PlanOverwrite, ".ai/two.md": PlanCreate}, decisions)
+		assert.Equal(t, 2, plan.Files)
+
+		plan, err = NewAutoCopier(repo, config, AutoCopierOptions{Backup: true}).Plan(testRepo.RepoDir, destDir)
+		require.NoError(t, err)
+		for _, entry := range plan.Entries {
+			if entry.Dest == ".ai/one.md" {
+				assert.Equal(t, PlanBackup, entry.Decision)
+			}
+		}
+	})
+
+	t.Run("later items override earlier ones", func(t *testing.T) {
+		overrides := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: ".ai/one.md", Directory: testutil.BoolPtr(false), RootOnly: true, Priority: 1},
+				{Path: "base/.ai/one.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			},
+		}
+		plan, err := NewAutoCopier(repo, overrides, AutoCopierOptions{StripPrefix: "base/"}).Plan(testRepo.RepoDir, t.TempDir())
+		require.NoError(t, err)
+
+		assert.Equal(t, []PlanEntry{
+			{Source: "base/.ai/one.md", Dest: ".ai/one.md", Size: 4, Decision: PlanOverridden},
+			{Source: ".ai/one.md", Dest: ".ai/one.md", Size: 3, Decision: PlanCreate},
+		}, plan.Entries)
+		assert.Equal(t, 1, plan.Files)
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		plan, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Plan(testRepo.RepoDir, t.TempDir())
+		require.NoError(t, err)
+
+		var decoded CopyPlan
+		require.NoError(t, json.Unmarshal([]byte(plan.FormatAsJSON()), &decoded))
+		assert.Equal(t, plan.Entries, decoded.Entries)
+		assert.Contains(t, plan.FormatAsTable(), "Total: 3 files to write")
+	})
+}
@@ -0,0 +1,124 @@
+package autocopy
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Progress formats of updates printed without a ProgressCallback
+const (
+	ProgressFormatHuman = "human" // Emoji lines (the default)
+	ProgressFormatJSONL = "jsonl" // One JSON-encoded ProgressUpdate per line
+)
+
+// DefaultProgressInterval is how many files a parallel copy completes
+// between progress updates without a ProgressInterval
+const DefaultProgressInterval = 10
+
+// progressPrinter returns the function printing updates in format
+func progressPrinter(format string) (func(ProgressUpdate), error) {
+	switch format {
+	case "", ProgressFormatHuman:
+		return printProgress, nil
+	case ProgressFormatJSONL:
+		return printProgressJSON, nil
+	default:
+		return nil, fmt.Errorf("%w: %q (use %s or %s)", ErrUnsupportedProgressFormat, format, ProgressFormatHuman, ProgressFormatJSONL)
+	}
+}
+
+// printProgressJSON prints a progress update as a single line of JSON
+func printProgressJSON(update ProgressUpdate) {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// printProgress prints a progress update, the default with ShowProgress
+func printProgress(update ProgressUpdate) {
+	switch update.Type {
+	case ProgressTypeStart:
+		fmt.Printf("🚀 %s\n", update.Message)
+	case ProgressTypeProgress:
+		fmt.Printf("📋 %s (%.1f%%)\n", update.Message, update.Percentage)
+	case ProgressTypeComplete:
+		fmt.Printf("✅ %s in %v\n", update.Message, update.ElapsedTime)
+	}
+}
+
+// sequentialProgress reports the progress of a sequential copy file by file
+// against the planned copy tasks
+type sequentialProgress struct {
+	callback    func(ProgressUpdate)
+	total       int
+	totalBytes  int64
+	current     int
+	copiedBytes int64
+	startTime   time.Time
+}
+
+// newSequentialProgress creates a reporter for copying the files of tasks
+func newSequentialProgress(tasks []CopyTask, callback func(ProgressUpdate)) *sequentialProgress {
+	p := &sequentialProgress{callback: callback}
+	for _, task := range tasks {
+		if !task.IsDir {
+			p.total++
+			p.totalBytes += task.Size
+		}
+	}
+	return p
+}
+
+// start reports that copying begins
+func (p *sequentialProgress) start() {
+	p.startTime = time.Now()
+	p.callback(ProgressUpdate{
+		Type:       ProgressTypeStart,
+		Message:    fmt.Sprintf("Starting sequential copy of %d files", p.total),
+		Total:      p.total,
+		TotalBytes: p.totalBytes,
+	})
+}
+
+// fileCopied reports a written file of size bytes
+func (p *sequentialProgress) fileCopied(size int64) {
+	p.current++
+	p.copiedBytes += size
+
+	// The plan can miss files the sequential copier writes anyway
+	total := p.total
+	if p.current > total {
+		total = p.current
+	}
+	elapsed := time.Since(p.startTime)
+	eta := time.Duration(float64(elapsed) * float64(total-p.current) / float64(p.current))
+
+	p.callback(ProgressUpdate{
+		Type:         ProgressTypeProgress,
+		Message:      fmt.Sprintf("Copied %d/%d files", p.current, total),
+		Current:      p.current,
+		Total:        total,
+		Percentage:   float64(p.current) / float64(total) * 100,
+		BytesCopied:  p.copiedBytes,
+		TotalBytes:   p.totalBytes,
+		ElapsedTime:  elapsed,
+		EstimatedETA: eta,
+	})
+}
+
+// complete reports that copying finished
+func (p *sequentialProgress) complete() {
+	p.callback(ProgressUpdate{
+		Type:        ProgressTypeComplete,
+		Message:     "Copy operation completed",
+		Current:     p.current,
+		Total:       p.total,
+		Percentage:  100.0,
+		BytesCopied: p.copiedBytes,
+		TotalBytes:  p.totalBytes,
+		ElapsedTime: time.Since(p.startTime),
+	})
+}
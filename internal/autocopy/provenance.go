@@ -0,0 +1,133 @@
+package autocopy
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// commentSyntax describes how a line comment is written in a file type
+type commentSyntax struct {
+	prefix string
+	suffix string
+}
+
+// provenanceSyntax maps extensions of text files that get a provenance
+// header to their comment syntax. Formats without comments, such as JSON,
+// are deliberately absent.
+var provenanceSyntax = map[string]commentSyntax{
+	".py":   {"# ", ""},
+	".sh":   {"# ", ""},
+	".bash": {"# ", ""},
+	".zsh":  {"# ", ""},
+	".rb":   {"# ", ""},
+	".yaml": {"# ", ""},
+	".yml":  {"# ", ""},
+	".toml": {"# ", ""},
+	".go":   {"// ", ""},
+	".js":   {"// ", ""},
+	".ts":   {"// ", ""},
+	".jsx":  {"// ", ""},
+	".tsx":  {"// ", ""},
+	".java": {"// ", ""},
+	".rs":   {"// ", ""},
+	".c":    {"// ", ""},
+	".h":    {"// ", ""},
+	".cpp":  {"// ", ""},
+	".md":   {"<!-- ", " -->"},
+	".mdc":  {"<!-- ", " -->"},
+	".html": {"<!-- ", " -->"},
+}
+
+// provenanceHeader returns the header line for a file copied from
+// sourcePath, and false when the file type has no supported comment syntax
+func provenanceHeader(sourcePath string, now time.Time) (string, bool) {
+	syntax, ok := provenanceSyntax[strings.ToLower(filepath.Ext(sourcePath))]
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("%sCopied by hatcher from %s on %s%s\n",
+		syntax.prefix, sourcePath, now.Format("2006-01-02"), syntax.suffix), true
+}
+
+// withProvenanceHeader returns content with header inserted at the first
+// position where it does not change the meaning of the file. It returns
+// false when no such position exists.
+func withProvenanceHeader(content []byte, header string) ([]byte, bool) {
+	// Binary content is never annotated
+	if !isTextFile("", content) {
+		return nil, false
+	}
+
+	// Front matter has to stay at the very top
+	if bytes.HasPrefix(content, []byte("---\n")) || bytes.HasPrefix(content, []byte("---\r\n")) {
+		return nil, false
+	}
+
+	// Keep a shebang on the first line
+	var insertAt int
+	if bytes.HasPrefix(content, []byte("#!")) {
+		newline := bytes.IndexByte(content, '\n')
+		if newline < 0 {
+			return nil, false
+		}
+		insertAt = newline + 1
+	}
+
+	result := make([]byte, 0, len(content)+len(header))
+	result = append(result, content[:insertAt]...)
+	result = append(result, header...)
+	result = append(result, content[insertAt:]...)
+	return result, true
+}
+
+// withoutProvenanceHeader returns content with the provenance header for a
+// file copied from sourcePath removed, whatever date it records. Content
+// without such a header is returned unchanged.
+func withoutProvenanceHeader(content []byte, sourcePath string) []byte {
+	syntax, ok := provenanceSyntax[strings.ToLower(filepath.Ext(sourcePath))]
+	if !ok {
+		return content
+	}
+
+	lead := []byte(syntax.prefix + "Copied by hatcher from " + sourcePath + " on ")
+	start := bytes.Index(content, lead)
+	if start < 0 || (start > 0 && content[start-1] != '\n') {
+		return content
+	}
+	end := bytes.IndexByte(content[start:], '\n')
+	if end < 0 || !bytes.HasSuffix(content[start:start+end], []byte(syntax.suffix)) {
+		return content
+	}
+
+	result := make([]byte, 0, len(content)-end-1)
+	result = append(result, content[:start]...)
+	return append(result, content[start+end+1:]...)
+}
+
+// copyWithProvenance copies src to dst with a provenance header for
+// recognized text types. It returns false, without reading src, when the
+// file type does not get a header so the caller can copy it as usual.
+func copyWithProvenance(dst io.Writer, src io.Reader, sourcePath string) (bool, error) {
+	header, ok := provenanceHeader(sourcePath, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	content, err := io.ReadAll(src)
+	if err != nil {
+		return true, fmt.Errorf("failed to read source file %s: %w", sourcePath, err)
+	}
+
+	if annotated, ok := withProvenanceHeader(content, header); ok {
+		content = annotated
+	}
+
+	if _, err := dst.Write(content); err != nil {
+		return true, fmt.Errorf("failed to copy file content: %w", err)
+	}
+	return true, nil
+}
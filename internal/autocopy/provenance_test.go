@@ -0,0 +1,117 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenanceHeader(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("markdown", func(t *testing.T) {
+		header, ok := provenanceHeader("/src/CLAUDE.md", now)
+		require.True(t, ok)
+		assert.Equal(t, "<!-- Copied by hatcher from /src/CLAUDE.md on 2024-05-01 -->\n", header)
+	})
+
+	t.Run("python", func(t *testing.T) {
+		header, ok := provenanceHeader("/src/tool.py", now)
+		require.True(t, ok)
+		assert.Equal(t, "# Copied by hatcher from /src/tool.py on 2024-05-01\n", header)
+	})
+
+	t.Run("json and unknown types get no header", func(t *testing.T) {
+		for _, path := range []string{"/src/settings.json", "/src/.cursorrules", "/src/image.png"} {
+			_, ok := provenanceHeader(path, now)
+			assert.False(t, ok, path)
+		}
+	})
+
+	t.Run("header goes after a shebang", func(t *testing.T) {
+		content, ok := withProvenanceHeader([]byte("#!/usr/bin/env python3\nprint('hi')\n"), "# header\n")
+		require.True(t, ok)
+		assert.Equal(t, "#!/usr/bin/env python3\n# header\nprint('hi')\n", string(content))
+	})
+
+	t.Run("front matter and binary content are left alone", func(t *testing.T) {
+		_, ok := withProvenanceHeader([]byte("---\ntitle: x\n---\n# Doc\n"), "<!-- header -->\n")
+		assert.False(t, ok)
+
+		_, ok = withProvenanceHeader([]byte("text\x00binary"), "# header\n")
+		assert.False(t, ok)
+	})
+}
+
+func TestAddProvenanceHeader(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "provenance-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".ai/guide.md", "# Guide\n")
+	testRepo.CreateFile(".ai/tool.py", "print('hi')\n")
+	testRepo.CreateFile(".ai/settings.json", "{\"a\": 1}\n")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	assertAnnotated := func(t *testing.T, destDir string) {
+		guide, err := os.ReadFile(filepath.Join(destDir, ".ai", "guide.md"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(guide), "<!-- Copied by hatcher from "), string(guide))
+		assert.True(t, strings.HasSuffix(string(guide), " -->\n# Guide\n"), string(guide))
+
+		tool, err := os.ReadFile(filepath.Join(destDir, ".ai", "tool.py"))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(tool), "# Copied by hatcher from "), string(tool))
+		assert.True(t, strings.HasSuffix(string(tool), "\nprint('hi')\n"), string(tool))
+
+		settings, err := os.ReadFile(filepath.Join(destDir, ".ai", "settings.json"))
+		require.NoError(t, err)
+		assert.Equal(t, "{\"a\": 1}\n", string(settings))
+	}
+
+	t.Run("legacy copier", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "legacy")
+		_, err := NewLegacyAutoCopierWithOptions(AutoCopierOptions{AddProvenanceHeader: true}).
+			CopyFiles(testRepo.RepoDir, destDir, config)
+		require.NoError(t, err)
+		assertAnnotated(t, destDir)
+	})
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("auto copier (parallel=%t)", parallel), func(t *testing.T) {
+			destDir := filepath.Join(testRepo.TempDir, fmt.Sprintf("auto-%t", parallel))
+			copier := NewAutoCopier(repo, config, AutoCopierOptions{
+				UseParallel:         parallel,
+				VerifyIntegrity:     true,
+				AddProvenanceHeader: true,
+				NoGitignoreUpdate:   true,
+			})
+			require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+			assertAnnotated(t, destDir)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "default")
+		_, err := NewLegacyAutoCopier().CopyFiles(testRepo.RepoDir, destDir, config)
+		require.NoError(t, err)
+
+		guide, err := os.ReadFile(filepath.Join(destDir, ".ai", "guide.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Guide\n", string(guide))
+	})
+}
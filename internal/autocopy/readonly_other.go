@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package autocopy
+
+// isReadOnlyFilesystem reports whether err was caused by a read-only mount,
+// which is not detected on this platform
+func isReadOnlyFilesystem(err error) bool {
+	return false
+}
+
+// mountPoint returns dir, as mount points are not detected on this platform
+func mountPoint(dir string) string {
+	return dir
+}
@@ -0,0 +1,52 @@
+//go:build linux || darwin
+
+package autocopy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// isReadOnlyFilesystem reports whether err was caused by a read-only mount
+func isReadOnlyFilesystem(err error) bool {
+	return errors.Is(err, syscall.EROFS)
+}
+
+// mountPoint returns the topmost ancestor of dir on the same device as dir,
+// which is where its filesystem is mounted
+func mountPoint(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	device, ok := deviceOf(dir)
+	if !ok {
+		return dir
+	}
+
+	for {
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		if parentDevice, ok := deviceOf(parent); !ok || parentDevice != device {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// deviceOf returns the device the file at path resides on
+func deviceOf(path string) (uint64, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, false
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(stat.Dev), true
+}
@@ -0,0 +1,51 @@
+//go:build linux || darwin
+
+package autocopy
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCheckError(t *testing.T) {
+	dir := t.TempDir()
+	destDir := filepath.Join(dir, "worktree")
+
+	t.Run("read-only filesystem names the mount", func(t *testing.T) {
+		cause := &os.PathError{Op: "open", Path: dir, Err: syscall.EROFS}
+
+		err := writeCheckError(destDir, dir, cause)
+		assert.ErrorIs(t, err, ErrDestinationReadOnly)
+		assert.ErrorIs(t, err, ErrDestinationNotWritable)
+		assert.Contains(t, err.Error(), destDir)
+		assert.Contains(t, err.Error(), "mounted at "+mountPoint(dir))
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		cause := &os.PathError{Op: "open", Path: dir, Err: syscall.EACCES}
+
+		err := writeCheckError(destDir, dir, cause)
+		assert.ErrorIs(t, err, ErrDestinationNotWritable)
+		assert.NotErrorIs(t, err, ErrDestinationReadOnly)
+	})
+}
+
+func TestMountPoint(t *testing.T) {
+	dir := t.TempDir()
+	mount := mountPoint(dir)
+
+	// The mount point contains dir and shares its device
+	rel, err := filepath.Rel(mount, dir)
+	require.NoError(t, err)
+	assert.NotContains(t, rel, "..")
+	device, _ := deviceOf(dir)
+	mountDevice, _ := deviceOf(mount)
+	assert.Equal(t, device, mountDevice)
+
+	assert.Equal(t, "/", mountPoint("/"))
+}
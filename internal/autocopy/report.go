@@ -0,0 +1,134 @@
+package autocopy
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/fileid"
+)
+
+// CopyReport summarizes a copy in a form suitable for JSON output
+type CopyReport struct {
+	CopiedFiles      []string `json:"copiedFiles"`      // Copied entries relative to the destination
+	Skipped          []string `json:"skipped"`          // Entries left out as skip paths or tracked files
+	SkippedOlder     []string `json:"skippedOlder"`     // Files kept by the newer policy as the source was not newer
+	BytesCopied      int64    `json:"bytesCopied"`      // Logical size of the copied files
+	PhysicalBytes    int64    `json:"physicalBytes"`    // Bytes newly written; files sharing their source's inode count as 0
+	SavedBytes       int64    `json:"savedBytes"`       // Bytes saved by sharing content with the source
+	DurationMs       int64    `json:"durationMs"`       // Time the copy took
+	VerifyDurationMs int64    `json:"verifyDurationMs"` // Time the deferred verification phase took, included in DurationMs
+	GitignoreUpdated bool     `json:"gitignoreUpdated"` // Whether the ignore file was updated afterwards
+}
+
+// NewCopyReport returns a report of a copy that copied nothing
+func NewCopyReport() *CopyReport {
+	return &CopyReport{CopiedFiles: []string{}, Skipped: []string{}, SkippedOlder: []string{}}
+}
+
+// Report copies like Copy and summarizes the result. Updating the ignore
+// file is left to the caller, which records it in GitignoreUpdated.
+func (ac *AutoCopier) Report(sourceDir, destDir string) (*CopyReport, error) {
+	// Plan without skip paths so skipped files can be told apart from files
+	// the configuration never selected
+	planOptions := ac.options
+	planOptions.SkipPaths = nil
+	planned, err := NewAutoCopier(ac.repo, ac.config, planOptions).Tasks(sourceDir, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	copied, err := ac.Copy(sourceDir, destDir)
+	if err != nil {
+		return nil, err
+	}
+
+	report := NewCopyReport()
+	report.CopiedFiles = append(report.CopiedFiles, copied...)
+	report.DurationMs = time.Since(start).Milliseconds()
+	report.VerifyDurationMs = ac.verification.Duration.Milliseconds()
+	for _, task := range planned {
+		rel := relativeSlash(destDir, task.DestPath)
+		if task.IsDir || coveredBy(copied, rel) {
+			continue
+		}
+		if ac.keptAsNewer(task) {
+			report.SkippedOlder = append(report.SkippedOlder, rel)
+		} else {
+			report.Skipped = append(report.Skipped, rel)
+		}
+	}
+	if !ac.options.DryRun {
+		report.BytesCopied, report.PhysicalBytes = copiedBytes(destDir, copied, sourceIDs(planned))
+		report.SavedBytes = report.BytesCopied - report.PhysicalBytes
+	}
+	return report, nil
+}
+
+// keptAsNewer reports whether the newer policy kept the destination of task
+// because its source was not modified after it
+func (ac *AutoCopier) keptAsNewer(task CopyTask) bool {
+	if conflictPolicy(task.OnConflict, ac.options.OnConflict, ac.options.Backup) != ConflictNewer {
+		return false
+	}
+	destInfo, err := os.Stat(task.DestPath)
+	if err != nil || !destInfo.Mode().IsRegular() {
+		return false
+	}
+	newer, err := sourceNewer(task.SourcePath, destInfo)
+	return err == nil && !newer
+}
+
+// coveredBy reports whether rel is one of the copied entries or lies below
+// a copied directory
+func coveredBy(copied []string, rel string) bool {
+	for _, entry := range copied {
+		entry = strings.TrimSuffix(filepath.ToSlash(filepath.Clean(entry)), "/")
+		if rel == entry || strings.HasPrefix(rel, entry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// sourceIDs returns the file IDs of the planned source files
+func sourceIDs(planned []CopyTask) map[fileid.ID]bool {
+	ids := make(map[fileid.ID]bool)
+	for _, task := range planned {
+		if task.IsDir {
+			continue
+		}
+		if info, err := os.Stat(task.SourcePath); err == nil {
+			if id, ok := fileid.Of(info); ok {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
+// copiedBytes returns the logical size of the regular files below the copied
+// entries in destDir, and the physical size of those not sharing an inode
+// with a source file
+func copiedBytes(destDir string, copied []string, shared map[fileid.ID]bool) (logical, physical int64) {
+	for _, entry := range copied {
+		filepath.WalkDir(filepath.Join(destDir, entry), func(path string, d fs.DirEntry, err error) error {
+			if err != nil || !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			logical += info.Size()
+			if id, ok := fileid.Of(info); !ok || !shared[id] {
+				physical += info.Size()
+			}
+			return nil
+		})
+	}
+	return logical, physical
+}
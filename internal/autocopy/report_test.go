@@ -0,0 +1,83 @@
+package autocopy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyReport(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "report-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".cursorrules", "committed rules")
+	testRepo.CommitAll("Add rules")
+	testRepo.CreateFile("notes.txt", "local notes")
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".cursorrules", Directory: testutil.BoolPtr(false)},
+			{Path: "notes.txt", Directory: testutil.BoolPtr(false)},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		worktreePath := filepath.Join(testRepo.TempDir, fmt.Sprintf("report-test-parallel-%t", parallel))
+		require.NoError(t, repo.CreateWorktree(worktreePath, fmt.Sprintf("parallel-%t", parallel), true))
+
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel, SkipTracked: true})
+		report, err := copier.Report(testRepo.RepoDir, worktreePath)
+		require.NoError(t, err)
+
+		assert.NotContains(t, report.CopiedFiles, ".cursorrules")
+		assert.Subset(t, report.CopiedFiles, []string{"notes.txt"})
+		assert.Equal(t, int64(len("local notes")+len("prompt")), report.BytesCopied)
+		assert.Equal(t, report.BytesCopied, report.PhysicalBytes)
+		assert.Zero(t, report.SavedBytes)
+		assert.False(t, report.GitignoreUpdated)
+		assert.Equal(t, []string{".cursorrules"}, report.Skipped)
+	}
+
+	t.Run("JSON shape", func(t *testing.T) {
+		data, err := json.Marshal(&CopyReport{})
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &fields))
+		for _, key := range []string{"copiedFiles", "skipped", "bytesCopied", "physicalBytes", "savedBytes", "durationMs", "gitignoreUpdated"} {
+			assert.Contains(t, fields, key)
+		}
+	})
+}
+
+func TestCopiedBytes(t *testing.T) {
+	tempDir := t.TempDir()
+	source := filepath.Join(tempDir, "source.txt")
+	require.NoError(t, os.WriteFile(source, []byte("shared"), 0644))
+	destDir := filepath.Join(tempDir, "dest")
+	require.NoError(t, os.MkdirAll(destDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(destDir, "written.txt"), []byte("written"), 0644))
+	if err := os.Link(source, filepath.Join(destDir, "linked.txt")); err != nil {
+		t.Skipf("hardlinks not supported: %v", err)
+	}
+
+	shared := sourceIDs([]CopyTask{{SourcePath: source, DestPath: filepath.Join(destDir, "linked.txt")}})
+	if len(shared) == 0 {
+		t.Skip("file IDs not supported on this platform")
+	}
+
+	logical, physical := copiedBytes(destDir, []string{"written.txt", "linked.txt"}, shared)
+	assert.Equal(t, int64(len("written")+len("shared")), logical)
+	assert.Equal(t, int64(len("written")), physical)
+}
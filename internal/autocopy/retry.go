@@ -0,0 +1,53 @@
+package autocopy
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"time"
+)
+
+// DefaultRetryBackoff is the delay before the first retry of a failed copy
+// without a RetryBackoff
+const DefaultRetryBackoff = 100 * time.Millisecond
+
+// transientErrnos are errors of network and overloaded filesystems that a
+// later attempt can succeed after
+var transientErrnos = []syscall.Errno{
+	syscall.EIO,
+	syscall.EAGAIN,
+	syscall.EINTR,
+	syscall.EBUSY,
+	syscall.ETIMEDOUT,
+	syscall.ESTALE,
+}
+
+// isTransientCopyError reports whether err may go away when the copy is
+// retried. Errors not known to be transient, such as a missing source or
+// refused permissions, are treated as permanent.
+func isTransientCopyError(err error) bool {
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return true
+	}
+	for _, errno := range transientErrnos {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
+
+// processWithRetry processes task, re-attempting it up to MaxRetries times
+// after transient errors, with a backoff starting at RetryBackoff that
+// doubles on every retry. It reports how many retries were made.
+func (pc *ParallelCopier) processWithRetry(task CopyTask) (bool, int, error) {
+	backoff := pc.options.RetryBackoff
+	for retries := 0; ; retries++ {
+		written, err := pc.process(task)
+		if err == nil || retries >= pc.options.MaxRetries || !isTransientCopyError(err) {
+			return written, retries, err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,124 @@
+package autocopy
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTransientCopyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"EIO", &os.PathError{Op: "write", Path: "file", Err: syscall.EIO}, true},
+		{"EAGAIN", fmt.Errorf("failed to copy file: %w", syscall.EAGAIN), true},
+		{"ESTALE", &os.PathError{Op: "open", Path: "file", Err: syscall.ESTALE}, true},
+		{"deadline", os.ErrDeadlineExceeded, true},
+		{"missing source", &os.PathError{Op: "open", Path: "file", Err: syscall.ENOENT}, false},
+		{"permission", fmt.Errorf("failed to open source file: %w", fs.ErrPermission), false},
+		{"conflict", ErrCopyConflict, false},
+		{"other", fmt.Errorf("integrity verification failed: checksums don't match"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransientCopyError(tt.err))
+		})
+	}
+}
+
+func TestParallelCopier_Retry(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "retry-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false)}},
+	}
+
+	// newCopier returns a copier whose task processor fails with failure on
+	// the first failures attempts of every file
+	newCopier := func(t *testing.T, maxRetries, failures int, failure error) (*ParallelCopier, *[]CopyError, *int) {
+		var mu sync.Mutex
+		var copyErrors []CopyError
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+			MaxRetries:      maxRetries,
+			RetryBackoff:    time.Millisecond,
+			ContinueOnError: true,
+			ErrorCallback: func(copyErr CopyError) {
+				mu.Lock()
+				defer mu.Unlock()
+				copyErrors = append(copyErrors, copyErr)
+			},
+		})
+		require.NoError(t, err)
+
+		attempts := 0
+		copier.process = func(task CopyTask) (bool, error) {
+			mu.Lock()
+			attempts++
+			attempt := attempts
+			mu.Unlock()
+			if attempt <= failures {
+				return false, &os.PathError{Op: "write", Path: task.DestPath, Err: failure}
+			}
+			return copier.processTask(task)
+		}
+		return copier, &copyErrors, &attempts
+	}
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		copier, copyErrors, attempts := newCopier(t, 3, 2, syscall.EIO)
+		destDir := t.TempDir()
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		assert.Empty(t, *copyErrors)
+		assert.Equal(t, 3, *attempts)
+		assert.Equal(t, []string{"CLAUDE.md"}, copier.CopiedFiles())
+		assert.FileExists(t, filepath.Join(destDir, "CLAUDE.md"))
+	})
+
+	t.Run("reports the retries made before giving up", func(t *testing.T) {
+		copier, copyErrors, attempts := newCopier(t, 2, 10, syscall.EAGAIN)
+		destDir := t.TempDir()
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		assert.Equal(t, 3, *attempts)
+		require.Len(t, *copyErrors, 1)
+		assert.Equal(t, 2, (*copyErrors)[0].Retries)
+		assert.ErrorIs(t, (*copyErrors)[0].Error, syscall.EAGAIN)
+		assert.NoFileExists(t, filepath.Join(destDir, "CLAUDE.md"))
+	})
+
+	t.Run("does not retry permanent errors", func(t *testing.T) {
+		copier, copyErrors, attempts := newCopier(t, 3, 1, syscall.ENOENT)
+		require.NoError(t, copier.Run(testRepo.RepoDir, t.TempDir()))
+
+		assert.Equal(t, 1, *attempts)
+		require.Len(t, *copyErrors, 1)
+		assert.Zero(t, (*copyErrors)[0].Retries)
+	})
+
+	t.Run("retries are off by default", func(t *testing.T) {
+		copier, copyErrors, attempts := newCopier(t, 0, 1, syscall.EIO)
+		require.NoError(t, copier.Run(testRepo.RepoDir, t.TempDir()))
+
+		assert.Equal(t, 1, *attempts)
+		require.Len(t, *copyErrors, 1)
+		assert.Zero(t, (*copyErrors)[0].Retries)
+	})
+}
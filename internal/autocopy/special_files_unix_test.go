@@ -0,0 +1,96 @@
+//go:build !windows
+
+package autocopy
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopySkipsSpecialFiles(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "special-files-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// Source tree containing a regular file and a FIFO
+	testRepo.CreateFile(".ai/prompts.md", "# prompts")
+	fifoPath := filepath.Join(testRepo.RepoDir, ".ai", "pipe")
+	require.NoError(t, syscall.Mkfifo(fifoPath, 0644))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true},
+		},
+	}
+
+	// runWithTimeout fails the test if copying blocks on the FIFO
+	runWithTimeout := func(t *testing.T, fn func() error) {
+		done := make(chan error, 1)
+		go func() { done <- fn() }()
+
+		select {
+		case err := <-done:
+			require.NoError(t, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("copy blocked on a non-regular file")
+		}
+	}
+
+	t.Run("sequential copy skips FIFO", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "sequential-dest")
+
+		sequentialConfig := &AutoCopyConfig{
+			Version: 2,
+			Items: []AutoCopyItem{
+				{Path: ".ai/", Directory: testutil.BoolPtr(true), RootOnly: true},
+			},
+		}
+
+		runWithTimeout(t, func() error {
+			_, err := NewLegacyAutoCopier().CopyFiles(testRepo.RepoDir, destDir, sequentialConfig)
+			return err
+		})
+
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "prompts.md"))
+		assert.NoFileExists(t, filepath.Join(destDir, ".ai", "pipe"))
+	})
+
+	t.Run("parallel copy skips FIFO", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "parallel-dest")
+
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{MaxWorkers: 2})
+		require.NoError(t, err)
+		runWithTimeout(t, func() error {
+			return copier.Run(testRepo.RepoDir, destDir)
+		})
+
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "prompts.md"))
+		assert.NoFileExists(t, filepath.Join(destDir, ".ai", "pipe"))
+	})
+
+	t.Run("FIFO configured directly is skipped", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "direct-dest")
+		directConfig := &AutoCopyConfig{
+			Version: 2,
+			Items:   []AutoCopyItem{{Path: ".ai/pipe", AutoDetect: true}},
+		}
+
+		var copied []string
+		runWithTimeout(t, func() error {
+			var err error
+			copied, err = NewLegacyAutoCopier().CopyFiles(testRepo.RepoDir, destDir, directConfig)
+			return err
+		})
+
+		assert.Empty(t, copied)
+		assert.NoFileExists(t, filepath.Join(destDir, ".ai", "pipe"))
+	})
+}
@@ -0,0 +1,139 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// handleSymlink deals with a source that is a symlink before its content is
+// copied. With preserve the link is recreated at destPath unless a link with
+// the same target is already there; otherwise a link resolving outside root
+// is refused with ErrSymlinkOutsideRoot so external files are never exposed.
+// With preserve, a link never replaces a file and a file never replaces a
+// link unless force is set; ErrTypeConflict is returned instead. It reports
+// whether sourcePath was a link that must not be copied by content, and
+// whether destPath was written.
+func handleSymlink(root, sourcePath, destPath string, preserve, force bool) (bool, bool, error) {
+	info, err := os.Lstat(sourcePath)
+	if err != nil {
+		return false, false, nil // The copy reports missing files itself
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		if preserve {
+			return false, false, replaceDestinationLink(sourcePath, destPath, force)
+		}
+		return false, false, nil
+	}
+
+	if preserve {
+		written, err := relink(sourcePath, destPath, force)
+		return true, written, err
+	}
+	if root != "" && symlinkEscapes(root, sourcePath) {
+		return true, false, fmt.Errorf("%w: %s", ErrSymlinkOutsideRoot, sourcePath)
+	}
+	return false, false, nil
+}
+
+// relink recreates the symlink at sourcePath at destPath when destPath is
+// not already a link to the same target, and reports whether it did
+func relink(sourcePath, destPath string, force bool) (bool, error) {
+	target, err := os.Readlink(sourcePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read symlink %s: %w", sourcePath, err)
+	}
+
+	if destInfo, err := os.Lstat(destPath); err == nil {
+		if destInfo.Mode()&os.ModeSymlink == 0 {
+			if !force {
+				return false, fmt.Errorf("%w: %s is a symlink but %s is not", ErrTypeConflict, sourcePath, destPath)
+			}
+		} else if current, err := os.Readlink(destPath); err == nil && current == target {
+			return false, nil
+		}
+	}
+	return true, createSymlink(target, destPath)
+}
+
+// replaceDestinationLink makes room for copying the regular file at
+// sourcePath to destPath when destPath is a symlink. Copying through the link
+// would overwrite its target, so the link is removed with force and refused
+// otherwise.
+func replaceDestinationLink(sourcePath, destPath string, force bool) error {
+	destInfo, err := os.Lstat(destPath)
+	if err != nil || destInfo.Mode()&os.ModeSymlink == 0 {
+		return nil
+	}
+	if !force {
+		return fmt.Errorf("%w: %s is a symlink but %s is not", ErrTypeConflict, destPath, sourcePath)
+	}
+	if err := os.Remove(destPath); err != nil {
+		return fmt.Errorf("failed to remove symlink %s: %w", destPath, err)
+	}
+	return nil
+}
+
+// traceSymlink records the decision handleSymlink made for a link at
+// sourcePath
+func traceSymlink(trace tracer, root, sourcePath string, written bool, err error) {
+	switch {
+	case err != nil:
+	case written:
+		trace.record(root, sourcePath, TraceCopied, "recreated symlink")
+	default:
+		trace.record(root, sourcePath, TraceSkippedUnchanged, "symlink already points to the same target")
+	}
+}
+
+// symlinkEscapes reports whether the symlink at path resolves outside root.
+// Dangling links are not considered escaping; copying them fails anyway.
+func symlinkEscapes(root, path string) bool {
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return true
+	}
+
+	target, err = filepath.Abs(target)
+	if err != nil {
+		return true
+	}
+	resolvedRoot, err = filepath.Abs(resolvedRoot)
+	if err != nil {
+		return true
+	}
+	_, inside := relativeInside(resolvedRoot, target)
+	return !inside
+}
+
+// createSymlink creates a symlink to target at destPath, replacing an
+// existing file or link there
+func createSymlink(target, destPath string) error {
+	destDir := filepath.Dir(destPath)
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory %s: %w", destDir, err)
+	}
+
+	// Create the link under a temporary name and rename it into place, since
+	// os.Symlink does not replace existing files
+	temp, err := os.CreateTemp(destDir, "."+filepath.Base(destPath)+".hatcher-tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", destPath, err)
+	}
+	tempPath := temp.Name()
+	temp.Close()
+	os.Remove(tempPath)
+
+	if err := os.Symlink(target, tempPath); err != nil {
+		return fmt.Errorf("failed to create symlink %s: %w", destPath, err)
+	}
+	if err := os.Rename(tempPath, destPath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("failed to replace %s: %w", destPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+//go:build !windows
+
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopySymlinks(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "symlink-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	external := filepath.Join(t.TempDir(), "secret.txt")
+	require.NoError(t, os.WriteFile(external, []byte("secret"), 0644))
+
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+	require.NoError(t, os.Symlink("prompt.md", filepath.Join(testRepo.RepoDir, ".ai", "link.md")))
+	require.NoError(t, os.Symlink(external, filepath.Join(testRepo.RepoDir, ".ai", "outside.txt")))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	copiers := map[string]func(options AutoCopierOptions, destDir string) error{
+		"legacy copier": func(options AutoCopierOptions, destDir string) error {
+			_, err := NewLegacyAutoCopierWithOptions(options).CopyFiles(testRepo.RepoDir, destDir, config)
+			return err
+		},
+		"copy files": func(options AutoCopierOptions, destDir string) error {
+			_, err := NewAutoCopier(repo, config, options).CopyFiles(testRepo.RepoDir, destDir, config)
+			return err
+		},
+		"parallel copier": func(options AutoCopierOptions, destDir string) error {
+			options.UseParallel = true
+			_, err := NewAutoCopier(repo, config, options).Copy(testRepo.RepoDir, destDir)
+			return err
+		},
+	}
+
+	for name, copy := range copiers {
+		t.Run(fmt.Sprintf("%s recreates links", name), func(t *testing.T) {
+			destDir := t.TempDir()
+			// An existing file is replaced by the link with ForceRelink
+			require.NoError(t, os.MkdirAll(filepath.Join(destDir, ".ai"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, ".ai", "link.md"), []byte("stale"), 0644))
+
+			require.NoError(t, copy(AutoCopierOptions{PreserveSymlinks: true, ForceRelink: true}, destDir))
+
+			target, err := os.Readlink(filepath.Join(destDir, ".ai", "link.md"))
+			require.NoError(t, err)
+			assert.Equal(t, "prompt.md", target)
+
+			target, err = os.Readlink(filepath.Join(destDir, ".ai", "outside.txt"))
+			require.NoError(t, err)
+			assert.Equal(t, external, target)
+		})
+
+		t.Run(fmt.Sprintf("%s skips links outside the source", name), func(t *testing.T) {
+			destDir := t.TempDir()
+
+			require.NoError(t, copy(AutoCopierOptions{}, destDir))
+
+			// Links inside the source are still copied by content
+			info, err := os.Lstat(filepath.Join(destDir, ".ai", "link.md"))
+			require.NoError(t, err)
+			assert.True(t, info.Mode().IsRegular())
+			content, err := os.ReadFile(filepath.Join(destDir, ".ai", "link.md"))
+			require.NoError(t, err)
+			assert.Equal(t, "prompt", string(content))
+
+			_, err = os.Lstat(filepath.Join(destDir, ".ai", "outside.txt"))
+			assert.True(t, os.IsNotExist(err))
+		})
+	}
+
+	t.Run("parallel copier reports links outside the source", func(t *testing.T) {
+		var mu sync.Mutex
+		var copyErrors []CopyError
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+			ContinueOnError: true,
+			ErrorCallback: func(copyErr CopyError) {
+				mu.Lock()
+				defer mu.Unlock()
+				copyErrors = append(copyErrors, copyErr)
+			},
+		})
+		require.NoError(t, err)
+
+		require.NoError(t, copier.Run(testRepo.RepoDir, t.TempDir()))
+
+		mu.Lock()
+		defer mu.Unlock()
+		require.Len(t, copyErrors, 1)
+		assert.ErrorIs(t, copyErrors[0].Error, ErrSymlinkOutsideRoot)
+		assert.Equal(t, filepath.Join(testRepo.RepoDir, ".ai", "outside.txt"), copyErrors[0].SourcePath)
+	})
+}
+
+func TestRelinkSymlinks(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "relink-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".ai/prompt.md", "prompt")
+	testRepo.CreateFile(".ai/rules.md", "rules")
+	require.NoError(t, os.Symlink("prompt.md", filepath.Join(testRepo.RepoDir, ".ai", "link.md")))
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+
+	// run copies .ai/ with preserved symlinks and returns the copy errors
+	run := func(t *testing.T, destDir string, force bool) []CopyError {
+		var mu sync.Mutex
+		var copyErrors []CopyError
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+			PreserveSymlinks: true,
+			ForceRelink:      force,
+			ContinueOnError:  true,
+			ErrorCallback: func(copyErr CopyError) {
+				mu.Lock()
+				defer mu.Unlock()
+				copyErrors = append(copyErrors, copyErr)
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+		return copyErrors
+	}
+
+	t.Run("changed link target is refreshed", func(t *testing.T) {
+		destDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(destDir, ".ai"), 0755))
+		require.NoError(t, os.Symlink("rules.md", filepath.Join(destDir, ".ai", "link.md")))
+
+		assert.Empty(t, run(t, destDir, false))
+
+		target, err := os.Readlink(filepath.Join(destDir, ".ai", "link.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "prompt.md", target)
+	})
+
+	t.Run("unchanged link is left alone", func(t *testing.T) {
+		destDir := t.TempDir()
+		require.Empty(t, run(t, destDir, false))
+
+		var decisions []TraceEntry
+		copier, err := NewParallelCopier(repo, config, ParallelCopyOptions{
+			PreserveSymlinks: true,
+			Trace:            func(entry TraceEntry) { decisions = append(decisions, entry) },
+		})
+		require.NoError(t, err)
+		copier.sourceRoot, copier.destRoot = testRepo.RepoDir, destDir
+		written, err := copier.processTask(CopyTask{
+			SourcePath: filepath.Join(testRepo.RepoDir, ".ai", "link.md"),
+			DestPath:   filepath.Join(destDir, ".ai", "link.md"),
+		})
+		require.NoError(t, err)
+		assert.False(t, written)
+		require.Len(t, decisions, 1)
+		assert.Equal(t, TraceSkippedUnchanged, decisions[0].Decision)
+	})
+
+	t.Run("file is not replaced by a link without force", func(t *testing.T) {
+		destDir := t.TempDir()
+		destPath := filepath.Join(destDir, ".ai", "link.md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(destPath), 0755))
+		require.NoError(t, os.WriteFile(destPath, []byte("local"), 0644))
+
+		copyErrors := run(t, destDir, false)
+		require.Len(t, copyErrors, 1)
+		assert.ErrorIs(t, copyErrors[0].Error, ErrTypeConflict)
+		content, err := os.ReadFile(destPath)
+		require.NoError(t, err)
+		assert.Equal(t, "local", string(content))
+
+		assert.Empty(t, run(t, destDir, true))
+		target, err := os.Readlink(destPath)
+		require.NoError(t, err)
+		assert.Equal(t, "prompt.md", target)
+	})
+
+	t.Run("link is not replaced by a file without force", func(t *testing.T) {
+		external := filepath.Join(t.TempDir(), "external.md")
+		require.NoError(t, os.WriteFile(external, []byte("external"), 0644))
+		destDir := t.TempDir()
+		destPath := filepath.Join(destDir, ".ai", "rules.md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(destPath), 0755))
+		require.NoError(t, os.Symlink(external, destPath))
+
+		copyErrors := run(t, destDir, false)
+		require.Len(t, copyErrors, 1)
+		assert.ErrorIs(t, copyErrors[0].Error, ErrTypeConflict)
+		target, err := os.Readlink(destPath)
+		require.NoError(t, err)
+		assert.Equal(t, external, target)
+
+		// Forcing replaces the link without writing through it
+		assert.Empty(t, run(t, destDir, true))
+		info, err := os.Lstat(destPath)
+		require.NoError(t, err)
+		assert.True(t, info.Mode().IsRegular())
+		content, err := os.ReadFile(external)
+		require.NoError(t, err)
+		assert.Equal(t, "external", string(content))
+	})
+
+	t.Run("sync replaces types with force", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "relink-test-sync")
+		require.NoError(t, repo.CreateWorktree(destDir, "feature/relink", true))
+		destPath := filepath.Join(destDir, ".ai", "link.md")
+		require.NoError(t, os.MkdirAll(filepath.Dir(destPath), 0755))
+		require.NoError(t, os.WriteFile(destPath, []byte("local"), 0644))
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{PreserveSymlinks: true})
+		manifestPath := ManifestPath(t.TempDir())
+
+		_, err := copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{})
+		assert.ErrorIs(t, err, ErrTypeConflict)
+
+		_, err = copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{Force: true})
+		require.NoError(t, err)
+		target, err := os.Readlink(destPath)
+		require.NoError(t, err)
+		assert.Equal(t, "prompt.md", target)
+	})
+}
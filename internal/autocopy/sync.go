@@ -0,0 +1,375 @@
+package autocopy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncOptions controls a sync
+type SyncOptions struct {
+	ChangedOnly        bool // Skip files whose source matches the manifest
+	PropagateDeletions bool // Delete copies of files removed from the source
+	Force              bool // Delete modified copies, and replace files and links of the other type when preserving symlinks
+	// ConfirmDeletions is asked before deleting copies and may be nil to
+	// delete without asking. Declined files stay in the manifest.
+	ConfirmDeletions func(files []string) bool
+}
+
+// SyncReport summarizes a sync of one worktree
+type SyncReport struct {
+	Updated          int      `json:"updated"`
+	Removed          int      `json:"removed"` // Manifest entries whose source was deleted
+	Unchanged        int      `json:"unchanged"`
+	Deleted          []string `json:"deleted,omitempty"`          // Copies deleted because their source was removed
+	Kept             []string `json:"kept,omitempty"`             // Modified copies kept although their source was removed
+	Files            []string `json:"files,omitempty"`            // Updated files relative to the worktree
+	VerifyDurationMs int64    `json:"verifyDurationMs,omitempty"` // Time the deferred verification phase took
+}
+
+// String returns a compact summary of the sync
+func (r *SyncReport) String() string {
+	summary := fmt.Sprintf("updated %d, removed %d, unchanged %d", r.Updated, r.Removed, r.Unchanged)
+	if len(r.Deleted) > 0 {
+		summary += fmt.Sprintf(", deleted %d", len(r.Deleted))
+	}
+	if len(r.Kept) > 0 {
+		summary += fmt.Sprintf(", kept %d modified", len(r.Kept))
+	}
+	if r.VerifyDurationMs > 0 {
+		summary += fmt.Sprintf(", verified in %s", time.Duration(r.VerifyDurationMs)*time.Millisecond)
+	}
+	return summary
+}
+
+// Sync copies the configured files from sourceDir to destDir and records them
+// in the manifest at manifestPath. With ChangedOnly, files whose source still
+// matches its manifest entry (size and mtime, falling back to the checksum)
+// are skipped. Entries for files deleted from the source are dropped from the
+// manifest; their copies are only deleted with PropagateDeletions, and only
+// if unmodified unless Force is set.
+func (ac *AutoCopier) Sync(sourceDir, destDir, manifestPath string, options SyncOptions) (*SyncReport, error) {
+	if ac.config == nil {
+		return nil, fmt.Errorf("no configuration loaded")
+	}
+
+	if err := checkWritable(destDir); err != nil {
+		return nil, err
+	}
+
+	previous, err := LoadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tracked files are skipped below so they are not reported as updated
+	parallelOptions := ac.parallelOptions()
+	parallelOptions.SkipTracked = false
+	parallelOptions.ForceRelink = parallelOptions.ForceRelink || options.Force
+	parallelOptions.Trace = ac.options.Trace
+	copier, err := NewParallelCopier(ac.repo, ac.config, parallelOptions)
+	if err != nil {
+		return nil, err
+	}
+	copier.sourceRoot, copier.destRoot = sourceDir, destDir
+	tasks, err := copier.discoverTasks(sourceDir, destDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover copy tasks: %w", err)
+	}
+
+	report := &SyncReport{}
+	manifest := &Manifest{Files: make(map[string]ManifestEntry)}
+	var added []string
+
+	for _, task := range tasks {
+		if task.IsDir {
+			if _, err := copier.processTask(task); err != nil {
+				return nil, fmt.Errorf("failed to create directory %s: %w", task.DestPath, err)
+			}
+			continue
+		}
+
+		rel, err := filepath.Rel(destDir, task.DestPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", task.DestPath, err)
+		}
+		rel = filepath.ToSlash(rel)
+
+		if ac.options.SkipTracked {
+			tracked, err := skipTrackedDestination(ac.repo, destDir, task.DestPath)
+			if err != nil {
+				return nil, err
+			}
+			if tracked {
+				continue
+			}
+		}
+
+		info, err := os.Stat(task.SourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", task.SourcePath, err)
+		}
+
+		entry, known := previous.Files[rel]
+		if options.ChangedOnly {
+			if current, ok := unchangedEntry(sourceDir, task, info, entry, known); ok {
+				manifest.Files[rel] = current
+				report.Unchanged++
+				tracer(ac.options.Trace).record(sourceDir, task.SourcePath, TraceSkippedUnchanged, "source matches the manifest")
+				continue
+			}
+		}
+
+		written, retries, err := copier.processWithRetry(task)
+		if err != nil {
+			if retries > 0 {
+				return nil, fmt.Errorf("failed to copy %s after %d retries: %w", task.SourcePath, retries, err)
+			}
+			return nil, fmt.Errorf("failed to copy %s: %w", task.SourcePath, err)
+		}
+		if !written {
+			// Kept by the item's skip conflict policy
+			continue
+		}
+		current, err := newCopiedEntry(sourceDir, task, info)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Files[rel] = current
+		report.Updated++
+		report.Files = append(report.Files, rel)
+		if !known {
+			added = append(added, rel)
+		}
+	}
+
+	// Copies of files above DeferVerifyMinSize are only verified now
+	if err := copier.runVerification(); err != nil {
+		return nil, err
+	}
+	report.VerifyDurationMs = copier.Verification().Duration.Milliseconds()
+
+	var removed []string
+	for rel := range previous.Files {
+		if _, ok := manifest.Files[rel]; !ok {
+			removed = append(removed, rel)
+		}
+	}
+	sort.Strings(removed)
+	sort.Strings(report.Files)
+
+	report.Removed = len(removed)
+	if options.PropagateDeletions {
+		declined, err := propagateDeletions(destDir, previous, removed, options, report)
+		if err != nil {
+			return nil, err
+		}
+		// Declined files are offered again on the next sync
+		for _, rel := range declined {
+			manifest.Files[rel] = previous.Files[rel]
+			report.Removed--
+		}
+	}
+
+	// A manifest kept inside the worktree must never be committed
+	if rel, ok := relativeInside(destDir, manifestPath); ok {
+		added = append(added, rel)
+	}
+
+	if len(added) > 0 && !ac.options.NoGitignoreUpdate {
+		if err := ac.ignoreNewFiles(destDir, added); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest.SyncedAt = time.Now()
+	if err := manifest.Save(manifestPath); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// RecordManifest writes the manifest at manifestPath for files that were just
+// copied from sourceDir to destDir, so later syncs can skip unchanged files
+func (ac *AutoCopier) RecordManifest(sourceDir, destDir, manifestPath string) error {
+	if ac.config == nil {
+		return fmt.Errorf("no configuration loaded")
+	}
+
+	tasks, err := ac.Tasks(sourceDir, destDir)
+	if err != nil {
+		return err
+	}
+
+	manifest := &Manifest{SyncedAt: time.Now(), Files: make(map[string]ManifestEntry)}
+	for _, task := range tasks {
+		if task.IsDir {
+			continue
+		}
+		if _, err := os.Stat(task.DestPath); err != nil {
+			continue // Not copied
+		}
+
+		info, err := os.Stat(task.SourcePath)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", task.SourcePath, err)
+		}
+		entry, err := newCopiedEntry(sourceDir, task, info)
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(destDir, task.DestPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", task.DestPath, err)
+		}
+		manifest.Files[filepath.ToSlash(rel)] = entry
+	}
+
+	return manifest.Save(manifestPath)
+}
+
+// unchangedEntry reports whether the copy of task is up to date, returning
+// the manifest entry to keep for it. Files without an entry count as
+// unchanged when the existing copy has the same content as the source.
+func unchangedEntry(sourceDir string, task CopyTask, info os.FileInfo, entry ManifestEntry, known bool) (ManifestEntry, bool) {
+	if _, err := os.Stat(task.DestPath); err != nil {
+		return ManifestEntry{}, false
+	}
+
+	if known && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime()) {
+		return entry, true
+	}
+	if known && entry.Size != info.Size() {
+		return ManifestEntry{}, false
+	}
+
+	current, err := newManifestEntry(sourceDir, task, info)
+	if err != nil {
+		return ManifestEntry{}, false
+	}
+	if known {
+		// Touched but not modified
+		current.CopyChecksum = entry.CopyChecksum
+		return current, current.Checksum == entry.Checksum
+	}
+
+	destChecksum, err := copyChecksum(task.DestPath)
+	if err != nil {
+		return ManifestEntry{}, false
+	}
+	current.CopyChecksum = destChecksum
+	return current, current.Checksum == destChecksum
+}
+
+// newCopiedEntry describes the source of task and the copy just written
+func newCopiedEntry(sourceDir string, task CopyTask, info os.FileInfo) (ManifestEntry, error) {
+	entry, err := newManifestEntry(sourceDir, task, info)
+	if err != nil {
+		return ManifestEntry{}, err
+	}
+
+	if entry.CopyChecksum, err = copyChecksum(task.DestPath); err != nil {
+		return ManifestEntry{}, err
+	}
+	return entry, nil
+}
+
+// copyChecksum returns the checksum of the copy at path. A preserved symlink
+// is identified by its target, since the file it points to may not be copied
+// yet.
+func copyChecksum(path string) (string, error) {
+	if target, err := os.Readlink(path); err == nil {
+		sum := sha256.Sum256([]byte(target))
+		return hex.EncodeToString(sum[:]), nil
+	}
+	return fileChecksum(path)
+}
+
+// propagateDeletions deletes the copies of removed files from destDir and
+// records the outcome in report. Copies that differ from what was copied are
+// kept unless options.Force is set. It returns the files whose deletion was
+// declined.
+func propagateDeletions(destDir string, previous *Manifest, removed []string, options SyncOptions, report *SyncReport) ([]string, error) {
+	var candidates []string
+	for _, rel := range removed {
+		path := filepath.Join(destDir, filepath.FromSlash(rel))
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			continue // Already gone
+		}
+
+		if !options.Force {
+			checksum, err := copyChecksum(path)
+			if err != nil || checksum != previous.Files[rel].copyChecksum() {
+				report.Kept = append(report.Kept, rel)
+				continue
+			}
+		}
+		candidates = append(candidates, rel)
+	}
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	if options.ConfirmDeletions != nil && !options.ConfirmDeletions(candidates) {
+		return candidates, nil
+	}
+
+	for _, rel := range candidates {
+		path := filepath.Join(destDir, filepath.FromSlash(rel))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+		removeEmptyParents(destDir, filepath.Dir(path))
+		report.Deleted = append(report.Deleted, rel)
+	}
+
+	return nil, nil
+}
+
+// removeEmptyParents removes dir and its parents below root while they are
+// empty
+func removeEmptyParents(root, dir string) {
+	for dir != root && strings.HasPrefix(dir, root+string(filepath.Separator)) {
+		if err := os.Remove(dir); err != nil {
+			return // Not empty
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// ignoreNewFiles adds files that are not ignored yet to the worktree's
+// ignore file
+func (ac *AutoCopier) ignoreNewFiles(destDir string, files []string) error {
+	repo, err := repositoryAt(destDir)
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = filepath.Join(destDir, filepath.FromSlash(file))
+	}
+	ignored, err := ignoredPaths(repo, paths)
+	if err != nil {
+		return err
+	}
+
+	var entries []string
+	for i, file := range files {
+		if !ignored[paths[i]] {
+			entries = append(entries, file)
+		}
+	}
+
+	if err := UpdateIgnoreFile(destDir, ac.config.IgnoreTarget, entries); err != nil {
+		return fmt.Errorf("failed to update ignore file: %w", err)
+	}
+	return nil
+}
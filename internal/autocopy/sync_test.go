@@ -0,0 +1,361 @@
+package autocopy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoCopier_Sync(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "sync-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".ai/one.md", "one")
+	testRepo.CreateFile(".ai/two.md", "two")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+	copier := NewAutoCopier(repo, config, AutoCopierOptions{})
+
+	worktreePath := filepath.Join(testRepo.TempDir, "sync-test-feature")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/sync", true))
+	manifestPath := ManifestPath(filepath.Join(testRepo.TempDir, "manifest"))
+
+	sync := func(t *testing.T, changedOnly bool) *SyncReport {
+		report, err := copier.Sync(testRepo.RepoDir, worktreePath, manifestPath, SyncOptions{ChangedOnly: changedOnly})
+		require.NoError(t, err)
+		return report
+	}
+
+	t.Run("first sync copies everything", func(t *testing.T) {
+		report := sync(t, true)
+		assert.Equal(t, "updated 3, removed 0, unchanged 0", report.String())
+		assert.Equal(t, []string{".ai/one.md", ".ai/two.md", "CLAUDE.md"}, report.Files)
+		assert.FileExists(t, filepath.Join(worktreePath, ".ai", "one.md"))
+
+		manifest, err := LoadManifest(manifestPath)
+		require.NoError(t, err)
+		assert.Len(t, manifest.Files, 3)
+		assert.Equal(t, ".ai/one.md", manifest.Files[".ai/one.md"].Source)
+
+		gitignore, err := os.ReadFile(filepath.Join(worktreePath, ".gitignore"))
+		require.NoError(t, err)
+		assert.Contains(t, string(gitignore), "CLAUDE.md\n")
+	})
+
+	t.Run("unchanged files are skipped", func(t *testing.T) {
+		report := sync(t, true)
+		assert.Equal(t, "updated 0, removed 0, unchanged 3", report.String())
+	})
+
+	t.Run("modified and touched files", func(t *testing.T) {
+		testRepo.CreateFile("CLAUDE.md", "updated rules")
+		future := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(testRepo.RepoDir, ".ai", "one.md"), future, future))
+
+		report := sync(t, true)
+		assert.Equal(t, "updated 1, removed 0, unchanged 2", report.String())
+		assert.Equal(t, []string{"CLAUDE.md"}, report.Files)
+
+		content, err := os.ReadFile(filepath.Join(worktreePath, "CLAUDE.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "updated rules", string(content))
+
+		// The touched file's new mtime is recorded so it is not hashed again
+		manifest, err := LoadManifest(manifestPath)
+		require.NoError(t, err)
+		assert.True(t, manifest.Files[".ai/one.md"].ModTime.Equal(future))
+	})
+
+	t.Run("deleted sources are removed from the manifest", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(testRepo.RepoDir, ".ai", "two.md")))
+
+		report := sync(t, true)
+		assert.Equal(t, "updated 0, removed 1, unchanged 2", report.String())
+		assert.FileExists(t, filepath.Join(worktreePath, ".ai", "two.md"))
+
+		manifest, err := LoadManifest(manifestPath)
+		require.NoError(t, err)
+		assert.NotContains(t, manifest.Files, ".ai/two.md")
+	})
+
+	t.Run("full sync copies everything again", func(t *testing.T) {
+		report := sync(t, false)
+		assert.Equal(t, "updated 2, removed 0, unchanged 0", report.String())
+	})
+
+	t.Run("recorded manifest after a regular copy", func(t *testing.T) {
+		destDir := filepath.Join(testRepo.TempDir, "sync-test-recorded")
+		require.NoError(t, repo.CreateWorktree(destDir, "feature/recorded", true))
+		require.NoError(t, copier.Run(testRepo.RepoDir, destDir))
+
+		path := ManifestPath(filepath.Join(testRepo.TempDir, "recorded"))
+		require.NoError(t, copier.RecordManifest(testRepo.RepoDir, destDir, path))
+
+		report, err := copier.Sync(testRepo.RepoDir, destDir, path, SyncOptions{ChangedOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, "updated 0, removed 0, unchanged 2", report.String())
+	})
+}
+
+func TestSyncPropagateDeletions(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "sync-delete-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true}},
+	}
+	copier := NewAutoCopier(repo, config, AutoCopierOptions{NoGitignoreUpdate: true})
+
+	// setup copies .ai/keep.md, .ai/old/gone.md and .ai/edited.md into a fresh
+	// destination, then removes the last two from the source
+	setup := func(t *testing.T) (string, string) {
+		testRepo.CreateFile(".ai/keep.md", "keep")
+		testRepo.CreateFile(".ai/old/gone.md", "gone")
+		testRepo.CreateFile(".ai/edited.md", "edited")
+
+		destDir := t.TempDir()
+		manifestPath := filepath.Join(t.TempDir(), ManifestFile)
+		_, err := copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{})
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(filepath.Join(destDir, ".ai", "edited.md"), []byte("local change"), 0644))
+		require.NoError(t, os.RemoveAll(filepath.Join(testRepo.RepoDir, ".ai", "old")))
+		require.NoError(t, os.Remove(filepath.Join(testRepo.RepoDir, ".ai", "edited.md")))
+		return destDir, manifestPath
+	}
+
+	t.Run("copies are left alone by default", func(t *testing.T) {
+		destDir, manifestPath := setup(t)
+		report, err := copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{ChangedOnly: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, report.Removed)
+		assert.Empty(t, report.Deleted)
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "old", "gone.md"))
+	})
+
+	t.Run("unmodified copies are deleted", func(t *testing.T) {
+		destDir, manifestPath := setup(t)
+		var asked []string
+		report, err := copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{
+			ChangedOnly:        true,
+			PropagateDeletions: true,
+			ConfirmDeletions: func(files []string) bool {
+				asked = files
+				return true
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{".ai/old/gone.md"}, asked)
+		assert.Equal(t, []string{".ai/old/gone.md"}, report.Deleted)
+		assert.Equal(t, []string{".ai/edited.md"}, report.Kept)
+		assert.Equal(t, "updated 0, removed 2, unchanged 1, deleted 1, kept 1 modified", report.String())
+		assert.NoDirExists(t, filepath.Join(destDir, ".ai", "old"))
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "edited.md"))
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "keep.md"))
+
+		manifest, err := LoadManifest(manifestPath)
+		require.NoError(t, err)
+		assert.Len(t, manifest.Files, 1)
+	})
+
+	t.Run("force deletes modified copies", func(t *testing.T) {
+		destDir, manifestPath := setup(t)
+		report, err := copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{PropagateDeletions: true, Force: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{".ai/edited.md", ".ai/old/gone.md"}, report.Deleted)
+		assert.Empty(t, report.Kept)
+		assert.NoFileExists(t, filepath.Join(destDir, ".ai", "edited.md"))
+	})
+
+	t.Run("declined deletions are offered again", func(t *testing.T) {
+		destDir, manifestPath := setup(t)
+		decline := SyncOptions{PropagateDeletions: true, ConfirmDeletions: func([]string) bool { return false }}
+		report, err := copier.Sync(testRepo.RepoDir, destDir, manifestPath, decline)
+		require.NoError(t, err)
+
+		assert.Empty(t, report.Deleted)
+		assert.Equal(t, 1, report.Removed)
+		assert.FileExists(t, filepath.Join(destDir, ".ai", "old", "gone.md"))
+
+		report, err = copier.Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{PropagateDeletions: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{".ai/old/gone.md"}, report.Deleted)
+	})
+}
+
+func TestLoadManifest(t *testing.T) {
+	t.Run("missing manifest is empty", func(t *testing.T) {
+		manifest, err := LoadManifest(filepath.Join(t.TempDir(), ManifestFile))
+		require.NoError(t, err)
+		assert.Empty(t, manifest.Files)
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ManifestFile)
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+		_, err := LoadManifest(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestManifest_Stats(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "stats-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".ai/one.md", "one")
+	testRepo.CreateFile(".ai/two.md", "two")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+	copier := NewAutoCopier(repo, config, AutoCopierOptions{})
+
+	worktreePath := filepath.Join(testRepo.TempDir, "stats-test-feature")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/stats", true))
+	manifestPath := ManifestPath(filepath.Join(testRepo.TempDir, "manifest"))
+	_, err = copier.Sync(testRepo.RepoDir, worktreePath, manifestPath, SyncOptions{})
+	require.NoError(t, err)
+
+	manifest, err := LoadManifest(manifestPath)
+	require.NoError(t, err)
+
+	t.Run("freshly synced", func(t *testing.T) {
+		stats := manifest.Stats(testRepo.RepoDir, worktreePath)
+		assert.Equal(t, 3, stats.Files)
+		assert.Equal(t, int64(len("rules")+len("one")+len("two")), stats.Bytes)
+		assert.Empty(t, stats.Stale)
+	})
+
+	t.Run("touched but unchanged source", func(t *testing.T) {
+		later := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(testRepo.RepoDir, "CLAUDE.md"), later, later))
+
+		assert.Empty(t, manifest.Stats(testRepo.RepoDir, worktreePath).Stale)
+	})
+
+	t.Run("changed, removed and missing files", func(t *testing.T) {
+		testRepo.CreateFile("CLAUDE.md", "new rules")
+		require.NoError(t, os.Remove(filepath.Join(testRepo.RepoDir, ".ai", "one.md")))
+		require.NoError(t, os.Remove(filepath.Join(worktreePath, ".ai", "two.md")))
+
+		stats := manifest.Stats(testRepo.RepoDir, worktreePath)
+		assert.Equal(t, []string{".ai/one.md", ".ai/two.md", "CLAUDE.md"}, stats.Stale)
+	})
+}
+
+func TestSyncCustomManifestPath(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "manifest-path-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	testRepo.CreateFile(".hatcher/copy-manifest.json", "{}")
+	testRepo.CreateFile(".hatcher/settings.md", "settings")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: ".hatcher/", Directory: testutil.BoolPtr(true), Recursive: true, RootOnly: true},
+		},
+	}
+	custom := ".hatcher/copy-manifest.json"
+	copier := NewAutoCopier(repo, config, AutoCopierOptions{SkipPaths: []string{custom}})
+
+	worktreePath := filepath.Join(testRepo.TempDir, "manifest-path-test-feature")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/manifest", true))
+	gitDir, err := repo.GitDir(worktreePath)
+	require.NoError(t, err)
+	manifestPath := ResolveManifestPath(worktreePath, gitDir, custom)
+	assert.Equal(t, filepath.Join(worktreePath, ".hatcher", "copy-manifest.json"), manifestPath)
+
+	report, err := copier.Sync(testRepo.RepoDir, worktreePath, manifestPath, SyncOptions{ChangedOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{".hatcher/settings.md", "CLAUDE.md"}, report.Files)
+
+	// The manifest is written at the custom path, not copied over
+	manifest, err := LoadManifest(manifestPath)
+	require.NoError(t, err)
+	assert.Len(t, manifest.Files, 2)
+	assert.NotContains(t, manifest.Files, custom)
+	assert.NoFileExists(t, ManifestPath(gitDir))
+
+	gitignore, err := os.ReadFile(filepath.Join(worktreePath, ".gitignore"))
+	require.NoError(t, err)
+	assert.Contains(t, string(gitignore), custom+"\n")
+
+	report, err = copier.Sync(testRepo.RepoDir, worktreePath, manifestPath, SyncOptions{ChangedOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "updated 0, removed 0, unchanged 2", report.String())
+}
+
+func TestSyncDeferredVerification(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "sync-verify-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	large := strings.Repeat("large content ", 1024)
+	testRepo.CreateFile("large.bin", large)
+	testRepo.CreateFile("CLAUDE.md", "rules")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "large.bin", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+	copier := NewAutoCopier(repo, config, AutoCopierOptions{VerifyIntegrity: true, DeferVerifyMinSize: 1024})
+
+	worktreePath := filepath.Join(testRepo.TempDir, "sync-verify-test-feature")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/verify", true))
+
+	report, err := copier.Sync(testRepo.RepoDir, worktreePath, ManifestPath(t.TempDir()), SyncOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CLAUDE.md", "large.bin"}, report.Files)
+
+	copied, err := os.ReadFile(filepath.Join(worktreePath, "large.bin"))
+	require.NoError(t, err)
+	assert.Equal(t, large, string(copied))
+
+	summary := (&SyncReport{Updated: 2, VerifyDurationMs: 12}).String()
+	assert.Equal(t, "updated 2, removed 0, unchanged 0, verified in 12ms", summary)
+}
+
+func TestValidateManifestPath(t *testing.T) {
+	valid := []string{"", ".hatcher/copy-manifest.json", "copy-manifest.json", ".gitmanifest.json"}
+	for _, path := range valid {
+		assert.NoError(t, ValidateManifestPath(path), path)
+	}
+
+	invalid := []string{"/tmp/manifest.json", "../manifest.json", "a/../../manifest.json", ".", ".git/manifest.json", ".git"}
+	for _, path := range invalid {
+		assert.Error(t, ValidateManifestPath(path), path)
+	}
+}
@@ -0,0 +1,66 @@
+package autocopy
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// textSniffLimit is how many leading bytes isTextFile inspects, the same
+// amount git uses to tell binary files apart
+const textSniffLimit = 8000
+
+// isTextFile reports whether content looks like text: its first
+// textSniffLimit bytes contain no NUL byte and are valid UTF-8. The sample is
+// taken from sampleBytes when non-nil and read from path otherwise; an
+// unreadable file is not text. Transforms that rewrite content check it so
+// binaries are never corrupted.
+func isTextFile(path string, sampleBytes []byte) bool {
+	sample := sampleBytes
+	if sample == nil {
+		var err error
+		if sample, err = readSample(path, textSniffLimit); err != nil {
+			return false
+		}
+	}
+
+	truncated := len(sample) >= textSniffLimit
+	if truncated {
+		sample = sample[:textSniffLimit]
+	}
+
+	if bytes.IndexByte(sample, 0) >= 0 {
+		return false
+	}
+
+	// A sample cut from a longer file may end inside a multi-byte character
+	if truncated {
+		sample = trimPartialRune(sample)
+	}
+	return utf8.Valid(sample)
+}
+
+// readSample reads at most limit bytes from the start of the file at path
+func readSample(path string, limit int64) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(io.LimitReader(file, limit))
+}
+
+// trimPartialRune drops an incomplete UTF-8 sequence from the end of b
+func trimPartialRune(b []byte) []byte {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return b[:i]
+			}
+			break
+		}
+	}
+	return b
+}
@@ -0,0 +1,46 @@
+package autocopy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTextFile(t *testing.T) {
+	fixtures := []struct {
+		name    string
+		content []byte
+		text    bool
+	}{
+		{"empty", []byte{}, true},
+		{"markdown", []byte("# Rules\n\n- Use tabs\n"), true},
+		{"crlf", []byte("line one\r\nline two\r\n"), true},
+		{"utf-8", []byte("日本語のルール ✅\n"), true},
+		{"nul byte", []byte("text\x00binary"), false},
+		{"png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), false},
+		{"latin-1", []byte("caf\xe9\n"), false},
+		{"utf-16", []byte("\xff\xfeh\x00i\x00"), false},
+		// A multi-byte character straddling the sample limit is still text
+		{"long utf-8", []byte(strings.Repeat("a", textSniffLimit-1) + "é" + strings.Repeat("b", 100)), true},
+		// Content after the sample is not inspected
+		{"nul after sample", []byte(strings.Repeat("a", textSniffLimit) + "\x00"), true},
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(fixture.name, func(t *testing.T) {
+			assert.Equal(t, fixture.text, isTextFile("", fixture.content))
+
+			path := filepath.Join(t.TempDir(), "fixture")
+			require.NoError(t, os.WriteFile(path, fixture.content, 0644))
+			assert.Equal(t, fixture.text, isTextFile(path, nil))
+		})
+	}
+
+	t.Run("unreadable file", func(t *testing.T) {
+		assert.False(t, isTextFile(filepath.Join(t.TempDir(), "missing"), nil))
+	})
+}
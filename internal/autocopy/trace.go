@@ -0,0 +1,76 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+)
+
+// Decisions traced for the files a copy considers
+const (
+	TraceCopied           = "copied"            // Written to a new or identical destination
+	TraceSkippedFiltered  = "skipped-filtered"  // Left out by .hatcherignore or the item's patterns
+	TraceSkippedMissing   = "skipped-missing"   // The configured source does not exist
+	TraceSkippedUnchanged = "skipped-unchanged" // Unchanged since the last sync
+	TraceOverwritten      = "overwritten"       // Replaced a destination with different content
+	TraceConflict         = "conflict"          // Kept a differing destination by its item's onConflict policy
+)
+
+// TraceEntry is the decision made for a single file
+type TraceEntry struct {
+	Path     string // Source path relative to the source root
+	Decision string
+	Reason   string
+}
+
+// tracer receives trace entries; a nil tracer records nothing
+type tracer func(TraceEntry)
+
+// record passes a decision for the file at path below root to t
+func (t tracer) record(root, path, decision, reason string) {
+	if t != nil {
+		t(TraceEntry{Path: relativeSlash(root, path), Decision: decision, Reason: reason})
+	}
+}
+
+// copyDecision describes copying sourcePath over destPath with policy. It
+// inspects the destination, so it must run before the copy; with a nil
+// tracer nothing is inspected.
+func (t tracer) copyDecision(policy, sourcePath, destPath string, provenance bool) TraceEntry {
+	if t == nil {
+		return TraceEntry{}
+	}
+
+	info, err := os.Lstat(destPath)
+	if err != nil {
+		return TraceEntry{Decision: TraceCopied, Reason: "new file"}
+	}
+	if !info.Mode().IsRegular() {
+		return TraceEntry{Decision: TraceOverwritten, Reason: "replaced a non-regular file"}
+	}
+	if policy == ConflictNewer {
+		if newer, err := sourceNewer(sourcePath, info); err == nil && !newer {
+			return TraceEntry{Decision: TraceConflict, Reason: "destination is not older than the source, kept by onConflict newer"}
+		}
+	}
+	changed, err := contentChanged(sourcePath, destPath, info, provenance)
+	if err != nil || !changed {
+		return TraceEntry{Decision: TraceCopied, Reason: "destination has the same content"}
+	}
+
+	switch policy {
+	case ConflictSkip:
+		return TraceEntry{Decision: TraceConflict, Reason: "destination differs, kept by onConflict skip"}
+	case ConflictError:
+		return TraceEntry{Decision: TraceConflict, Reason: "destination differs, refused by onConflict error"}
+	case ConflictBackup:
+		return TraceEntry{Decision: TraceOverwritten, Reason: fmt.Sprintf("destination differs, previous content kept as %s", BackupPath(destPath))}
+	default:
+		return TraceEntry{Decision: TraceOverwritten, Reason: "destination differs"}
+	}
+}
+
+// recordEntry passes entry, made by copyDecision, for the file at path below
+// root to t
+func (t tracer) recordEntry(root, path string, entry TraceEntry) {
+	t.record(root, path, entry.Decision, entry.Reason)
+}
@@ -0,0 +1,104 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoCopier_Trace(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "trace-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("config/app.yaml", "app: true")
+	testRepo.CreateFile("config/local.yaml", "local: true")
+	testRepo.CreateFile("config/notes.txt", "notes")
+	testRepo.CreateFile("config/cache/state.yaml", "state: 1")
+	testRepo.CreateFile("CLAUDE.md", "shared rules")
+	testRepo.CreateFile("notes.md", "shared notes")
+	testRepo.CreateFile(".env.local", "TOKEN=main")
+
+	file := func(path, onConflict string) AutoCopyItem {
+		return AutoCopyItem{Path: path, Directory: testutil.BoolPtr(false), RootOnly: true, OnConflict: onConflict}
+	}
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{
+				Path:      "config",
+				Directory: testutil.BoolPtr(true),
+				Recursive: true,
+				RootOnly:  true,
+				Include:   []string{"*.yaml"},
+				Exclude:   []string{"local.yaml", "cache/"},
+			},
+			file("CLAUDE.md", ""),
+			file("notes.md", ""),
+			file(".env.local", ConflictSkip),
+			{Path: "missing.md", AutoDetect: true, RootOnly: true},
+		},
+	}
+
+	expected := map[string]TraceEntry{
+		"config/app.yaml":   {Decision: TraceCopied, Reason: "new file"},
+		"config/local.yaml": {Decision: TraceSkippedFiltered, Reason: "excluded by local.yaml"},
+		"config/notes.txt":  {Decision: TraceSkippedFiltered, Reason: "not matched by any include pattern"},
+		"config/cache":      {Decision: TraceSkippedFiltered, Reason: "excluded by cache/"},
+		"CLAUDE.md":         {Decision: TraceCopied, Reason: "destination has the same content"},
+		"notes.md":          {Decision: TraceOverwritten, Reason: "destination differs"},
+		".env.local":        {Decision: TraceConflict, Reason: "destination differs, kept by onConflict skip"},
+		"missing.md":        {Decision: TraceSkippedMissing, Reason: "auto-detected path does not exist"},
+	}
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel=%t", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, "CLAUDE.md"), []byte("shared rules"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, "notes.md"), []byte("local notes"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(destDir, ".env.local"), []byte("TOKEN=local"), 0644))
+
+			// Parallel workers trace concurrently
+			var mu sync.Mutex
+			traced := make(map[string]TraceEntry)
+			options := AutoCopierOptions{
+				UseParallel: parallel,
+				Trace: func(entry TraceEntry) {
+					mu.Lock()
+					defer mu.Unlock()
+					assert.NotContains(t, traced, entry.Path, "traced twice")
+					traced[entry.Path] = TraceEntry{Decision: entry.Decision, Reason: entry.Reason}
+				},
+			}
+
+			_, err := NewAutoCopier(repo, config, options).Copy(testRepo.RepoDir, destDir)
+			require.NoError(t, err)
+			assert.Equal(t, expected, traced)
+		})
+	}
+
+	t.Run("sync traces unchanged files", func(t *testing.T) {
+		destDir := t.TempDir()
+		manifestPath := filepath.Join(t.TempDir(), ManifestFile)
+		single := &AutoCopyConfig{Version: 2, Items: []AutoCopyItem{file("CLAUDE.md", "")}}
+
+		_, err := NewAutoCopier(repo, single, AutoCopierOptions{NoGitignoreUpdate: true}).Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{})
+		require.NoError(t, err)
+
+		var traced []TraceEntry
+		options := AutoCopierOptions{
+			NoGitignoreUpdate: true,
+			Trace:             func(entry TraceEntry) { traced = append(traced, entry) },
+		}
+		_, err = NewAutoCopier(repo, single, options).Sync(testRepo.RepoDir, destDir, manifestPath, SyncOptions{ChangedOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, []TraceEntry{{Path: "CLAUDE.md", Decision: TraceSkippedUnchanged, Reason: "source matches the manifest"}}, traced)
+	})
+}
@@ -0,0 +1,61 @@
+package autocopy
+
+import (
+	"os"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/logger"
+)
+
+// skipTrackedDestination reports whether destPath is a file tracked in the
+// worktree at root, warning that it is skipped. Overwriting it would leave
+// uncommitted changes on the worktree's branch. repo may be nil.
+func skipTrackedDestination(repo git.Repository, root, destPath string) (bool, error) {
+	// Only existing files can be overwritten
+	if _, err := os.Lstat(destPath); err != nil {
+		return false, nil
+	}
+
+	repo, err := trackingRepository(repo, root)
+	if err != nil {
+		return false, err
+	}
+
+	tracked, err := repo.IsTracked(root, destPath)
+	if err != nil {
+		return false, err
+	}
+	if tracked {
+		logger.Warning("Skipping %s: tracked in the worktree, copying would modify the branch", relativeSlash(root, destPath))
+	}
+	return tracked, nil
+}
+
+// withoutTracked returns copied entries, relative to root, without the files
+// tracked in the worktree at root, so they are never added to the ignore file
+func withoutTracked(repo git.Repository, root string, copied []string) ([]string, error) {
+	repo, err := trackingRepository(repo, root)
+	if err != nil {
+		return nil, err
+	}
+
+	var kept []string
+	for _, entry := range copied {
+		tracked, err := repo.IsTracked(root, entry)
+		if err != nil {
+			return nil, err
+		}
+		if !tracked {
+			kept = append(kept, entry)
+		}
+	}
+	return kept, nil
+}
+
+// trackingRepository returns repo, or the repository at root when repo is nil
+func trackingRepository(repo git.Repository, root string) (git.Repository, error) {
+	if repo != nil {
+		return repo, nil
+	}
+	return repositoryAt(root)
+}
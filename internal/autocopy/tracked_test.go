@@ -0,0 +1,105 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipTracked(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "tracked-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".cursorrules", "committed rules")
+	testRepo.CommitAll("Add rules")
+
+	// Local changes in the main worktree that must not reach the branch
+	testRepo.CreateFile(".cursorrules", "local rules")
+	testRepo.CreateFile("notes.txt", "local notes")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: ".cursorrules", Directory: testutil.BoolPtr(false)},
+			{Path: "notes.txt", Directory: testutil.BoolPtr(false)},
+		},
+	}
+
+	worktrees := 0
+	newWorktree := func(t *testing.T) string {
+		worktrees++
+		path := filepath.Join(testRepo.TempDir, fmt.Sprintf("worktree-%d", worktrees))
+		require.NoError(t, repo.CreateWorktree(path, fmt.Sprintf("tracked-%d", worktrees), true))
+		return path
+	}
+
+	assertProtected := func(t *testing.T, worktreePath string) {
+		content, err := os.ReadFile(filepath.Join(worktreePath, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "committed rules", string(content))
+
+		content, err = os.ReadFile(filepath.Join(worktreePath, "notes.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "local notes", string(content))
+	}
+
+	copiers := map[string]func(options AutoCopierOptions, worktreePath string) error{
+		"legacy copier": func(options AutoCopierOptions, worktreePath string) error {
+			_, err := NewLegacyAutoCopierWithOptions(options).CopyFiles(testRepo.RepoDir, worktreePath, config)
+			return err
+		},
+		"copy files": func(options AutoCopierOptions, worktreePath string) error {
+			_, err := NewAutoCopier(repo, config, options).CopyFiles(testRepo.RepoDir, worktreePath, config)
+			return err
+		},
+		"parallel copier": func(options AutoCopierOptions, worktreePath string) error {
+			options.UseParallel = true
+			_, err := NewAutoCopier(repo, config, options).Copy(testRepo.RepoDir, worktreePath)
+			return err
+		},
+	}
+
+	for name, copy := range copiers {
+		t.Run(fmt.Sprintf("%s keeps tracked files", name), func(t *testing.T) {
+			worktreePath := newWorktree(t)
+			require.NoError(t, copy(AutoCopierOptions{SkipTracked: true}, worktreePath))
+			assertProtected(t, worktreePath)
+		})
+	}
+
+	t.Run("copy does not report tracked files", func(t *testing.T) {
+		for _, parallel := range []bool{false, true} {
+			worktreePath := newWorktree(t)
+			copied, err := NewAutoCopier(repo, config, AutoCopierOptions{SkipTracked: true, UseParallel: parallel}).Copy(testRepo.RepoDir, worktreePath)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"notes.txt"}, copied, "parallel=%t", parallel)
+		}
+	})
+
+	t.Run("sync keeps tracked files", func(t *testing.T) {
+		worktreePath := newWorktree(t)
+		manifestPath := filepath.Join(t.TempDir(), "manifest.json")
+
+		report, err := NewAutoCopier(repo, config, AutoCopierOptions{SkipTracked: true}).Sync(testRepo.RepoDir, worktreePath, manifestPath, SyncOptions{})
+		require.NoError(t, err)
+		assertProtected(t, worktreePath)
+		assert.Equal(t, []string{"notes.txt"}, report.Files)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		worktreePath := newWorktree(t)
+		_, err := NewAutoCopier(repo, config, AutoCopierOptions{}).Copy(testRepo.RepoDir, worktreePath)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(worktreePath, ".cursorrules"))
+		require.NoError(t, err)
+		assert.Equal(t, "local rules", string(content))
+	})
+}
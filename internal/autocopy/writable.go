@@ -0,0 +1,44 @@
+package autocopy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checkWritable verifies that files can be created in destDir by creating and
+// removing a temporary file. A missing destDir is checked at its nearest
+// existing parent, where it would be created.
+func checkWritable(destDir string) error {
+	dir := destDir
+	for {
+		if _, err := os.Stat(dir); err == nil || !os.IsNotExist(err) {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	file, err := os.CreateTemp(dir, ".hatcher-write-check-*")
+	if err != nil {
+		return writeCheckError(destDir, dir, err)
+	}
+	file.Close()
+	os.Remove(file.Name())
+
+	return nil
+}
+
+// writeCheckError describes why files cannot be created in destDir, probed
+// at its existing ancestor dir. A read-only filesystem is reported with the
+// mount point, since no file below it can be written.
+func writeCheckError(destDir, dir string, err error) error {
+	if isReadOnlyFilesystem(err) {
+		return fmt.Errorf("%w: %s is on the filesystem mounted at %s; use a writable location, e.g. by setting worktree.baseDir",
+			ErrDestinationReadOnly, destDir, mountPoint(dir))
+	}
+	return fmt.Errorf("%w: %s: %v", ErrDestinationNotWritable, destDir, err)
+}
@@ -0,0 +1,72 @@
+package autocopy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWritable(t *testing.T) {
+	t.Run("writable directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, checkWritable(dir))
+
+		// The probe file is cleaned up
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("missing directory under writable parent", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "new", "worktree")
+		assert.NoError(t, checkWritable(dir))
+		assert.NoDirExists(t, dir)
+	})
+
+	t.Run("destination is a file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0644))
+
+		err := checkWritable(path)
+		assert.ErrorIs(t, err, ErrDestinationNotWritable)
+		assert.Contains(t, err.Error(), path)
+	})
+
+	t.Run("read-only directory", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("permissions are not enforced for root")
+		}
+		dir := filepath.Join(t.TempDir(), "readonly")
+		require.NoError(t, os.Mkdir(dir, 0555))
+		defer os.Chmod(dir, 0755)
+
+		assert.ErrorIs(t, checkWritable(dir), ErrDestinationNotWritable)
+	})
+}
+
+func TestCopyUnwritableDestination(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "unwritable-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	testRepo.CreateFile("CLAUDE.md", "rules")
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items:   []AutoCopyItem{{Path: "CLAUDE.md", Directory: testutil.BoolPtr(false), RootOnly: true}},
+	}
+
+	destPath := filepath.Join(t.TempDir(), "not-a-directory")
+	require.NoError(t, os.WriteFile(destPath, []byte("x"), 0644))
+
+	for _, parallel := range []bool{false, true} {
+		copier := NewAutoCopier(repo, config, AutoCopierOptions{UseParallel: parallel})
+		copied, err := copier.Copy(testRepo.RepoDir, destPath)
+		assert.ErrorIs(t, err, ErrDestinationNotWritable)
+		assert.Empty(t, copied)
+	}
+}
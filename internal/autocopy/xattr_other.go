@@ -0,0 +1,8 @@
+//go:build !linux && !darwin
+
+package autocopy
+
+// copyXattrs is a no-op on platforms without xattr support
+func copyXattrs(sourcePath, destPath string) error {
+	return nil
+}
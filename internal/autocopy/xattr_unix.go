@@ -0,0 +1,95 @@
+//go:build linux || darwin
+
+package autocopy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/keisukeshimizu/hatcher/internal/logger"
+	"golang.org/x/sys/unix"
+)
+
+// copyXattrs copies the extended attributes of sourcePath to destPath.
+// Filesystems without xattr support are skipped silently; attributes the
+// current user may not set, such as security labels, are skipped with a
+// warning.
+func copyXattrs(sourcePath, destPath string) error {
+	names, err := listXattrs(sourcePath)
+	if err != nil {
+		if errors.Is(err, unix.ENOTSUP) {
+			return nil
+		}
+		return fmt.Errorf("failed to list extended attributes of %s: %w", sourcePath, err)
+	}
+
+	for _, name := range names {
+		value, err := getXattr(sourcePath, name)
+		if err != nil {
+			return fmt.Errorf("failed to read extended attribute %s of %s: %w", name, sourcePath, err)
+		}
+
+		if err := unix.Setxattr(destPath, name, value, 0); err != nil {
+			switch {
+			case errors.Is(err, unix.ENOTSUP):
+				return nil
+			case errors.Is(err, unix.EPERM), errors.Is(err, unix.EACCES):
+				logger.Warning("Skipping extended attribute %s on %s: %v", name, destPath, err)
+				continue
+			}
+			return fmt.Errorf("failed to set extended attribute %s on %s: %w", name, destPath, err)
+		}
+	}
+
+	return nil
+}
+
+// listXattrs returns the names of the extended attributes of path
+func listXattrs(path string) ([]string, error) {
+	data, err := readXattrBuffer(func(buf []byte) (int, error) {
+		return unix.Listxattr(path, buf)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range bytes.Split(data, []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	return names, nil
+}
+
+// getXattr returns the value of the extended attribute name of path
+func getXattr(path, name string) ([]byte, error) {
+	return readXattrBuffer(func(buf []byte) (int, error) {
+		return unix.Getxattr(path, name, buf)
+	})
+}
+
+// readXattrBuffer sizes a buffer with a nil read and fills it, retrying if
+// the attribute grows in between
+func readXattrBuffer(read func(buf []byte) (int, error)) ([]byte, error) {
+	for {
+		size, err := read(nil)
+		if err != nil {
+			return nil, err
+		}
+		if size == 0 {
+			return nil, nil
+		}
+
+		buf := make([]byte, size)
+		n, err := read(buf)
+		if errors.Is(err, unix.ERANGE) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+}
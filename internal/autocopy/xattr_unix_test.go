@@ -0,0 +1,84 @@
+//go:build linux || darwin
+
+package autocopy
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+)
+
+func TestPreserveXattrs(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "xattr-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("labeled.md", "labeled")
+	testRepo.CreateFile("plain.md", "plain")
+
+	const name = "user.hatcher.test"
+	if err := unix.Setxattr(filepath.Join(testRepo.RepoDir, "labeled.md"), name, []byte("label"), 0); err != nil {
+		if errors.Is(err, unix.ENOTSUP) || errors.Is(err, unix.EPERM) {
+			t.Skipf("extended attributes not supported here: %v", err)
+		}
+		require.NoError(t, err)
+	}
+
+	config := &AutoCopyConfig{
+		Version: 2,
+		Items: []AutoCopyItem{
+			{Path: "labeled.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+			{Path: "plain.md", Directory: testutil.BoolPtr(false), RootOnly: true},
+		},
+	}
+
+	attribute := func(t *testing.T, path string) (string, bool) {
+		value, err := getXattr(path, name)
+		if err != nil {
+			return "", false
+		}
+		return string(value), true
+	}
+
+	t.Run("off by default", func(t *testing.T) {
+		destDir := t.TempDir()
+		_, err := NewLegacyAutoCopier().CopyFiles(testRepo.RepoDir, destDir, config)
+		require.NoError(t, err)
+
+		_, ok := attribute(t, filepath.Join(destDir, "labeled.md"))
+		assert.False(t, ok)
+	})
+
+	t.Run("legacy copier", func(t *testing.T) {
+		destDir := t.TempDir()
+		_, err := NewLegacyAutoCopierWithOptions(AutoCopierOptions{PreserveXattrs: true}).CopyFiles(testRepo.RepoDir, destDir, config)
+		require.NoError(t, err)
+
+		value, ok := attribute(t, filepath.Join(destDir, "labeled.md"))
+		assert.True(t, ok)
+		assert.Equal(t, "label", value)
+
+		names, err := listXattrs(filepath.Join(destDir, "plain.md"))
+		require.NoError(t, err)
+		assert.NotContains(t, names, name)
+	})
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("auto copier (parallel=%t)", parallel), func(t *testing.T) {
+			destDir := t.TempDir()
+			options := AutoCopierOptions{PreserveXattrs: true, UseParallel: parallel, NoGitignoreUpdate: true}
+			require.NoError(t, NewAutoCopier(repo, config, options).Run(testRepo.RepoDir, destDir))
+
+			value, ok := attribute(t, filepath.Join(destDir, "labeled.md"))
+			assert.True(t, ok)
+			assert.Equal(t, "label", value)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change
+const diffContext = 3
+
+// diffLine is a line of a diff, prefixed with ' ', '-' or '+'
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// UnifiedDiff returns a unified diff turning before into after, labelled with
+// path, or an empty string if both are equal
+func UnifiedDiff(path string, before, after []byte) string {
+	if string(before) == string(after) {
+		return ""
+	}
+
+	lines := diffLines(splitLines(string(before)), splitLines(string(after)))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", path, path)
+	for _, hunk := range diffHunks(lines) {
+		writeHunk(&out, lines, hunk[0], hunk[1])
+	}
+	return out.String()
+}
+
+// splitLines splits s into lines without their line endings
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines computes a line diff of a and b from their longest common
+// subsequence
+func diffLines(a, b []string) []diffLine {
+	// lcs[i][j] is the length of the LCS of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []diffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, diffLine{' ', a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{'-', a[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, diffLine{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, diffLine{'+', b[j]})
+	}
+	return lines
+}
+
+// diffHunks returns the [start, end) ranges of lines to print, each change
+// surrounded by up to diffContext unchanged lines
+func diffHunks(lines []diffLine) [][2]int {
+	var hunks [][2]int
+	for i, line := range lines {
+		if line.kind == ' ' {
+			continue
+		}
+		start := max(i-diffContext, 0)
+		end := min(i+diffContext+1, len(lines))
+		if n := len(hunks); n > 0 && start <= hunks[n-1][1] {
+			hunks[n-1][1] = end
+		} else {
+			hunks = append(hunks, [2]int{start, end})
+		}
+	}
+	return hunks
+}
+
+// writeHunk writes lines[start:end] as a hunk with its @@ header
+func writeHunk(out *strings.Builder, lines []diffLine, start, end int) {
+	// Line numbers of the hunk's first line in the old and new text
+	oldLine, newLine := 1, 1
+	for _, line := range lines[:start] {
+		if line.kind != '+' {
+			oldLine++
+		}
+		if line.kind != '-' {
+			newLine++
+		}
+	}
+
+	oldCount, newCount := 0, 0
+	for _, line := range lines[start:end] {
+		if line.kind != '+' {
+			oldCount++
+		}
+		if line.kind != '-' {
+			newCount++
+		}
+	}
+	// An empty side starts before its first line
+	if oldCount == 0 {
+		oldLine--
+	}
+	if newCount == 0 {
+		newLine--
+	}
+
+	fmt.Fprintf(out, "@@ -%d,%d +%d,%d @@\n", oldLine, oldCount, newLine, newCount)
+	for _, line := range lines[start:end] {
+		fmt.Fprintf(out, "%c%s\n", line.kind, line.text)
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("equal content", func(t *testing.T) {
+		assert.Empty(t, UnifiedDiff("config.json", []byte("a\nb\n"), []byte("a\nb\n")))
+	})
+
+	t.Run("new file", func(t *testing.T) {
+		diff := UnifiedDiff("config.json", nil, []byte("{\n}\n"))
+		assert.Equal(t, "--- config.json\n+++ config.json\n@@ -0,0 +1,2 @@\n+{\n+}\n", diff)
+	})
+
+	t.Run("changed line with context", func(t *testing.T) {
+		before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+		after := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\n"
+		diff := UnifiedDiff("config.json", []byte(before), []byte(after))
+		assert.Equal(t, "--- config.json\n+++ config.json\n@@ -2,7 +2,7 @@\n 2\n 3\n 4\n-5\n+five\n 6\n 7\n 8\n", diff)
+	})
+
+	t.Run("distant changes form separate hunks", func(t *testing.T) {
+		before := "a\n1\n2\n3\n4\n5\n6\n7\n8\nb\n"
+		after := "A\n1\n2\n3\n4\n5\n6\n7\n8\nB\n"
+		diff := UnifiedDiff("config.json", []byte(before), []byte(after))
+		assert.Equal(t, "--- config.json\n+++ config.json\n"+
+			"@@ -1,4 +1,4 @@\n-a\n+A\n 1\n 2\n 3\n"+
+			"@@ -7,4 +7,4 @@\n 6\n 7\n 8\n-b\n+B\n", diff)
+	})
+}
+
+func TestManager_MarshalConfig(t *testing.T) {
+	projectDir := t.TempDir()
+	manager := NewManager()
+	config := manager.defaultConfig.copy()
+
+	path, data, err := manager.MarshalConfig(config, projectDir, false, FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectDir, ".hatcher-auto-copy.json"), path)
+	assert.NoFileExists(t, path)
+
+	require.NoError(t, manager.SaveConfig(config, projectDir, false, FormatJSON))
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, data, written)
+}
@@ -0,0 +1,244 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFix describes a single repair made by FixConfigFile
+type ConfigFix struct {
+	Key    string // Dotted key of the repaired setting
+	Before string // Previous value
+	After  string // New value; empty when the entry was removed
+	Reason string
+}
+
+// ConfigFixResult is the outcome of FixConfigFile for one file
+type ConfigFixResult struct {
+	Path  string
+	Fixes []ConfigFix
+}
+
+// ConfigFiles returns the files LoadConfig reads for projectPath: the global
+// config followed by the project config, each when present
+func (m *Manager) ConfigFiles(projectPath string) []string {
+	var files []string
+
+	if homeDir, err := os.UserHomeDir(); err == nil {
+		if path := globalConfigFile(homeDir); path != "" {
+			files = append(files, path)
+		}
+	}
+
+	if projectPath != "" {
+		if path := projectConfigFile(projectPath); path != "" {
+			files = append(files, path)
+		}
+	}
+
+	return files
+}
+
+// globalConfigFile returns the global config file in homeDir, or an empty
+// string when there is none
+func globalConfigFile(homeDir string) string {
+	return firstExisting(
+		filepath.Join(homeDir, ".hatcher", "config.yaml"),
+		filepath.Join(homeDir, ".hatcher", "config.json"),
+	)
+}
+
+// projectConfigFile returns the config file of projectPath, or an empty
+// string when there is none
+func projectConfigFile(projectPath string) string {
+	return firstExisting(
+		filepath.Join(projectPath, ".hatcher-auto-copy.json"),
+		filepath.Join(projectPath, ".hatcher-auto-copy.yaml"),
+		filepath.Join(projectPath, ".hatcher", "config.json"),
+		filepath.Join(projectPath, ".hatcher", "config.yaml"),
+	)
+}
+
+// FixConfigFile repairs the issues ValidateConfig reports that have a safe
+// automatic fix: an unsupported autocopy version becomes 2, items with an
+// empty path or a path containing ".." are dropped and an unsupported output
+// format becomes "table". The file is rewritten in its own format unless
+// dryRun is set or nothing needed fixing.
+func (m *Manager) FixConfigFile(path string, dryRun bool) (*ConfigFixResult, error) {
+	raw, isYAML, err := readRawConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ConfigFixResult{Path: path, Fixes: fixRawConfig(raw, "")}
+	if len(result.Fixes) == 0 || dryRun {
+		return result, nil
+	}
+
+	var fixed []byte
+	if isYAML {
+		fixed, err = yaml.Marshal(raw)
+	} else {
+		fixed, err = json.MarshalIndent(raw, "", "  ")
+		fixed = append(fixed, '\n')
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal fixed config: %w", err)
+	}
+
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, fixed, mode); err != nil {
+		return nil, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return result, nil
+}
+
+// ParseConfigFile reports whether the config file at path is well-formed
+// JSON or YAML. LoadConfig skips files it cannot parse.
+func (m *Manager) ParseConfigFile(path string) error {
+	_, _, err := readRawConfigFile(path)
+	return err
+}
+
+// readRawConfigFile parses the config file at path by its extension and
+// reports whether it is YAML
+func readRawConfigFile(path string) (map[string]interface{}, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	isYAML := strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")
+	var raw map[string]interface{}
+	if isYAML {
+		err = yaml.Unmarshal(data, &raw)
+	} else {
+		err = json.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return raw, isYAML, nil
+}
+
+// fixRawConfig repairs raw in place and returns the fixes, with keys below
+// prefix. Environment overlays are repaired too.
+func fixRawConfig(raw map[string]interface{}, prefix string) []ConfigFix {
+	var fixes []ConfigFix
+
+	if autoCopy, ok := raw["autocopy"].(map[string]interface{}); ok {
+		fixes = append(fixes, fixAutoCopy(autoCopy, prefix+"autocopy.")...)
+	} else if isAutoCopyFile(raw) && prefix == "" {
+		// Auto-copy specific files keep their settings at the top level
+		fixes = append(fixes, fixAutoCopy(raw, "")...)
+	}
+
+	if global, ok := raw["global"].(map[string]interface{}); ok {
+		if format, ok := global["outputFormat"].(string); ok && format != "" && !isValidOutputFormat(format) {
+			global["outputFormat"] = "table"
+			fixes = append(fixes, ConfigFix{
+				Key:    prefix + "global.outputFormat",
+				Before: format,
+				After:  "table",
+				Reason: "unsupported output format",
+			})
+		}
+	}
+
+	if environments, ok := raw["environments"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(environments))
+		for name := range environments {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if overlay, ok := environments[name].(map[string]interface{}); ok {
+				fixes = append(fixes, fixRawConfig(overlay, prefix+"environments."+name+".")...)
+			}
+		}
+	}
+
+	return fixes
+}
+
+// fixAutoCopy repairs an auto-copy section in place
+func fixAutoCopy(raw map[string]interface{}, prefix string) []ConfigFix {
+	var fixes []ConfigFix
+
+	if version, ok := toInt(raw["version"]); ok && (version < 1 || version > 2) {
+		raw["version"] = 2
+		fixes = append(fixes, ConfigFix{
+			Key:    prefix + "version",
+			Before: fmt.Sprint(version),
+			After:  "2",
+			Reason: "unsupported autocopy version",
+		})
+	}
+
+	items, ok := raw["items"].([]interface{})
+	if !ok {
+		return fixes
+	}
+
+	kept := make([]interface{}, 0, len(items))
+	for i, item := range items {
+		itemMap, ok := item.(map[string]interface{})
+		if !ok {
+			kept = append(kept, item)
+			continue
+		}
+
+		path, _ := itemMap["path"].(string)
+		reason := ""
+		switch {
+		case path == "":
+			reason = "empty path"
+		case strings.Contains(path, ".."):
+			reason = "path contains .."
+		}
+		if reason == "" {
+			kept = append(kept, item)
+			continue
+		}
+
+		before, _ := json.Marshal(itemMap)
+		fixes = append(fixes, ConfigFix{
+			Key:    fmt.Sprintf("%sitems[%d]", prefix, i),
+			Before: string(before),
+			Reason: reason,
+		})
+	}
+	raw["items"] = kept
+
+	return fixes
+}
+
+// isAutoCopyFile reports whether raw is an auto-copy specific config, which
+// loadProjectConfig migrates instead of merging
+func isAutoCopyFile(raw map[string]interface{}) bool {
+	if _, hasVersion := raw["version"]; !hasVersion {
+		return false
+	}
+	_, hasItems := raw["items"]
+	return hasItems || raw["files"] != nil
+}
+
+// firstExisting returns the first of paths that exists, or an empty string
+func firstExisting(paths ...string) string {
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
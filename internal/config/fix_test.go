@@ -0,0 +1,145 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestManager_FixConfigFile(t *testing.T) {
+	manager := NewManager()
+
+	t.Run("json config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"autocopy": {"version": 5, "items": [
+				{"path": ".cursorrules"},
+				{"path": ""},
+				{"path": "../secrets"}
+			]},
+			"global": {"outputFormat": "xml", "verbose": true},
+			"custom": "kept"
+		}`), 0600))
+
+		result, err := manager.FixConfigFile(path, false)
+		require.NoError(t, err)
+		assert.Equal(t, []ConfigFix{
+			{Key: "autocopy.version", Before: "5", After: "2", Reason: "unsupported autocopy version"},
+			{Key: "autocopy.items[1]", Before: `{"path":""}`, Reason: "empty path"},
+			{Key: "autocopy.items[2]", Before: `{"path":"../secrets"}`, Reason: "path contains .."},
+			{Key: "global.outputFormat", Before: "xml", After: "table", Reason: "unsupported output format"},
+		}, result.Fixes)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		assert.Equal(t, "kept", raw["custom"])
+		autoCopy := raw["autocopy"].(map[string]interface{})
+		assert.Equal(t, float64(2), autoCopy["version"])
+		assert.Len(t, autoCopy["items"], 1)
+		global := raw["global"].(map[string]interface{})
+		assert.Equal(t, "table", global["outputFormat"])
+		assert.Equal(t, true, global["verbose"])
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+
+	t.Run("yaml config keeps its format", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("global:\n  outputFormat: xml\n"), 0644))
+
+		result, err := manager.FixConfigFile(path, false)
+		require.NoError(t, err)
+		assert.Len(t, result.Fixes, 1)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(data, &raw))
+		assert.Equal(t, "table", raw["global"].(map[string]interface{})["outputFormat"])
+	})
+
+	t.Run("auto-copy file and environments", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), ".hatcher-auto-copy.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": 0, "items": [{"path": "a/../b"}]}`), 0644))
+
+		result, err := manager.FixConfigFile(path, false)
+		require.NoError(t, err)
+		require.Len(t, result.Fixes, 2)
+		assert.Equal(t, "version", result.Fixes[0].Key)
+		assert.Equal(t, "items[0]", result.Fixes[1].Key)
+
+		path = filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"environments": {"ci": {"global": {"outputFormat": "xml"}}}}`), 0644))
+
+		result, err = manager.FixConfigFile(path, false)
+		require.NoError(t, err)
+		require.Len(t, result.Fixes, 1)
+		assert.Equal(t, "environments.ci.global.outputFormat", result.Fixes[0].Key)
+	})
+
+	t.Run("dry run leaves the file alone", func(t *testing.T) {
+		content := `{"global": {"outputFormat": "xml"}}`
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		result, err := manager.FixConfigFile(path, true)
+		require.NoError(t, err)
+		assert.Len(t, result.Fixes, 1)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("valid config is not rewritten", func(t *testing.T) {
+		content := `{"global": {"outputFormat": "json"}}`
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		result, err := manager.FixConfigFile(path, false)
+		require.NoError(t, err)
+		assert.Empty(t, result.Fixes)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, content, string(data))
+	})
+
+	t.Run("invalid syntax", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{`), 0644))
+
+		_, err := manager.FixConfigFile(path, false)
+		assert.Error(t, err)
+	})
+}
+
+func TestManager_ReadConfig(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", t.TempDir())
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".hatcher"), 0755))
+	configPath := filepath.Join(projectDir, ".hatcher", "config.json")
+	require.NoError(t, os.WriteFile(configPath, []byte(`{"global": {"outputFormat": "xml"}}`), 0644))
+
+	manager := NewManager()
+	assert.Equal(t, []string{configPath}, manager.ConfigFiles(projectDir))
+
+	_, err := manager.LoadConfig(projectDir)
+	assert.Error(t, err)
+
+	config, err := manager.ReadConfig(projectDir)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"unsupported output format: xml"}, manager.ValidateConfig(config))
+}
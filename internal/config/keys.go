@@ -0,0 +1,406 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrUnknownKey is returned for config keys that do not name a setting
+	ErrUnknownKey = errors.New("unknown config key")
+	// ErrInvalidValue is returned when a value does not fit a setting's type
+	ErrInvalidValue = errors.New("invalid config value")
+)
+
+// Keys returns every key Get and Set accept, sorted. List entries and map
+// keys are shown as <index> and <name>.
+func Keys() []string {
+	keys := settingKeys(reflect.TypeOf(Config{}), "")
+	sort.Strings(keys)
+	return keys
+}
+
+// settingKeys returns the keys of the settings of type t below prefix
+func settingKeys(t reflect.Type, prefix string) []string {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return settingKeys(t.Elem(), prefix)
+	case reflect.Struct:
+		var keys []string
+		for i := 0; i < t.NumField(); i++ {
+			if name, ok := settingName(t.Field(i)); ok {
+				keys = append(keys, settingKeys(t.Field(i).Type, prefix+name+".")...)
+			}
+		}
+		return keys
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Struct {
+			return settingKeys(t.Elem(), prefix+"<index>.")
+		}
+	case reflect.Map:
+		return []string{prefix + "<name>"}
+	}
+	return []string{strings.TrimSuffix(prefix, ".")}
+}
+
+// settingName returns the key of a config struct field. Environments hold
+// whole overlays and are not addressable.
+func settingName(field reflect.StructField) (string, bool) {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" || name == "environments" {
+		return "", false
+	}
+	return name, true
+}
+
+// Get returns the value of the setting at the dotted key, such as
+// editor.preferred or autocopy.items.0.recursive
+func (c *Config) Get(key string) (interface{}, error) {
+	v := reflect.ValueOf(c).Elem()
+	for _, segment := range strings.Split(key, ".") {
+		switch v.Kind() {
+		case reflect.Ptr:
+			if v.IsNil() {
+				return nil, unknownKeyError(key)
+			}
+			v = v.Elem()
+		}
+
+		var ok bool
+		if v, ok = childSetting(v, segment); !ok {
+			return nil, unknownKeyError(key)
+		}
+	}
+
+	if isSection(v.Type()) {
+		return nil, sectionError(key)
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, nil
+	}
+	return reflect.Indirect(v).Interface(), nil
+}
+
+// Set parses value for the type of the setting at the dotted key and stores
+// it. Lists take comma-separated values.
+func (c *Config) Set(key, value string) error {
+	return setSetting(reflect.ValueOf(c).Elem(), strings.Split(key, "."), key, value)
+}
+
+// setSetting stores value in the setting at segments below v
+func setSetting(v reflect.Value, segments []string, key, value string) error {
+	if len(segments) == 0 {
+		return setLeaf(v, key, value)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setSetting(v.Elem(), segments, key, value)
+	case reflect.Map:
+		if len(segments) != 1 {
+			return unknownKeyError(key)
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if err := setLeaf(elem, key, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(reflect.ValueOf(segments[0]), elem)
+		return nil
+	}
+
+	child, ok := childSetting(v, segments[0])
+	if !ok {
+		return unknownKeyError(key)
+	}
+	return setSetting(child, segments[1:], key, value)
+}
+
+// childSetting returns the setting named segment inside the struct, list or
+// map v
+func childSetting(v reflect.Value, segment string) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if name, ok := settingName(v.Type().Field(i)); ok && name == segment {
+				return v.Field(i), true
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		index, err := strconv.Atoi(segment)
+		if err != nil || index < 0 || index >= v.Len() {
+			return reflect.Value{}, false
+		}
+		return v.Index(index), true
+	case reflect.Map:
+		elem := v.MapIndex(reflect.ValueOf(segment))
+		return elem, elem.IsValid()
+	}
+	return reflect.Value{}, false
+}
+
+// setLeaf parses value into the scalar or string list setting v
+func setLeaf(v reflect.Value, key, value string) error {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if isSection(v.Type()) {
+			return sectionError(key)
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := setLeaf(elem.Elem(), key, value); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s expects true or false, got %q", ErrInvalidValue, key, value)
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v.OverflowInt(n) {
+			return fmt.Errorf("%w: %s expects a whole number, got %q", ErrInvalidValue, key, value)
+		}
+		v.SetInt(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return sectionError(key)
+		}
+		list := []string{}
+		for _, element := range strings.Split(value, ",") {
+			if element = strings.TrimSpace(element); element != "" {
+				list = append(list, element)
+			}
+		}
+		v.Set(reflect.ValueOf(list))
+	default:
+		return sectionError(key)
+	}
+	return nil
+}
+
+// isSection reports whether t groups settings instead of holding a value
+func isSection(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return isSection(t.Elem())
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.String
+	}
+	return false
+}
+
+// unknownKeyError reports a key that names no setting
+func unknownKeyError(key string) error {
+	return fmt.Errorf("%w: %s", ErrUnknownKey, key)
+}
+
+// sectionError reports a key that names a group of settings
+func sectionError(key string) error {
+	return fmt.Errorf("%w: %s is a section, not a single setting", ErrUnknownKey, key)
+}
+
+// SetConfigValue sets the dotted key to value in the global config file, or
+// in the project config file of projectPath unless global is set, creating
+// the file if needed. The configuration is validated with the new value
+// before the file is written, and the file keeps its format and its other
+// settings. It returns the path of the written file.
+func (m *Manager) SetConfigValue(projectPath string, global bool, key, value string) (string, error) {
+	readPath := projectPath
+	if global {
+		readPath = ""
+	}
+	config, err := m.ReadConfig(readPath)
+	if err != nil {
+		return "", err
+	}
+	if err := config.Set(key, value); err != nil {
+		return "", err
+	}
+	if errs := m.ValidateConfig(config); len(errs) > 0 {
+		return "", fmt.Errorf("%w: %s", ErrInvalidValue, strings.Join(errs, "; "))
+	}
+
+	path, err := m.writableConfigFile(projectPath, global)
+	if err != nil {
+		return "", err
+	}
+
+	raw := map[string]interface{}{}
+	isYAML := strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml")
+	if data, err := os.ReadFile(path); err == nil {
+		if isYAML {
+			err = yaml.Unmarshal(data, &raw)
+		} else {
+			err = json.Unmarshal(data, &raw)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if raw == nil {
+			raw = map[string]interface{}{}
+		}
+	}
+
+	segments := storedSegments(strings.Split(key, "."))
+	stored, err := config.Get(strings.Join(segments, "."))
+	if err != nil && !errors.Is(err, ErrUnknownKey) {
+		return "", err
+	}
+	if err != nil {
+		// A list of items is stored as a whole
+		stored = reflect.Indirect(settingValue(config, segments)).Interface()
+	}
+	if stored, err = plainValue(stored); err != nil {
+		return "", err
+	}
+
+	if isAutoCopyFile(raw) {
+		// Auto-copy specific files keep their settings at the top level
+		if segments[0] != "autocopy" {
+			return "", fmt.Errorf("%s only holds autocopy settings; use --global or move it to .hatcher/config.json to set %s", path, key)
+		}
+		segments = segments[1:]
+	}
+	setRawValue(raw, segments, stored)
+
+	var data []byte
+	if isYAML {
+		data, err = yaml.Marshal(raw)
+	} else {
+		data, err = json.MarshalIndent(raw, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", fmt.Errorf("failed to write config file: %w", err)
+	}
+	return path, nil
+}
+
+// writableConfigFile returns the config file SetConfigValue writes: the
+// existing global or project file, or the default location for a new one
+func (m *Manager) writableConfigFile(projectPath string, global bool) (string, error) {
+	if global {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		if path := globalConfigFile(homeDir); path != "" {
+			return path, nil
+		}
+		return filepath.Join(homeDir, ".hatcher", "config.yaml"), nil
+	}
+
+	if projectPath == "" {
+		return "", fmt.Errorf("project path is required for project config")
+	}
+	if path := projectConfigFile(projectPath); path != "" {
+		return path, nil
+	}
+	return filepath.Join(projectPath, ".hatcher", "config.json"), nil
+}
+
+// storedSegments returns the part of a key that is written to a config file:
+// everything before an item index, since lists of items are stored whole
+func storedSegments(segments []string) []string {
+	t := reflect.TypeOf(Config{})
+	for i, segment := range segments {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByName(t, segment)
+			if !ok {
+				return segments
+			}
+			t = field.Type
+		case reflect.Slice:
+			return segments[:i]
+		default:
+			return segments
+		}
+	}
+	return segments
+}
+
+// fieldByName returns the field of struct type t with the key name
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		if fieldName, ok := settingName(t.Field(i)); ok && fieldName == name {
+			return t.Field(i), true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// settingValue returns the setting at segments, which must exist
+func settingValue(config *Config, segments []string) reflect.Value {
+	v := reflect.ValueOf(config).Elem()
+	for _, segment := range segments {
+		v, _ = childSetting(reflect.Indirect(v), segment)
+	}
+	return v
+}
+
+// plainValue converts a setting to the maps, lists and scalars config files
+// are decoded into
+func plainValue(value interface{}) (interface{}, error) {
+	switch value.(type) {
+	case nil, string, bool, int:
+		return value, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert config value: %w", err)
+	}
+	var plain interface{}
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return nil, fmt.Errorf("failed to convert config value: %w", err)
+	}
+	return plain, nil
+}
+
+// setRawValue stores value at segments in a decoded config file, creating
+// sections as needed
+func setRawValue(raw map[string]interface{}, segments []string, value interface{}) {
+	for _, segment := range segments[:len(segments)-1] {
+		section, ok := raw[segment].(map[string]interface{})
+		if !ok {
+			section = map[string]interface{}{}
+			raw[segment] = section
+		}
+		raw = section
+	}
+	raw[segments[len(segments)-1]] = value
+}
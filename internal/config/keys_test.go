@@ -0,0 +1,204 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfig_GetSet(t *testing.T) {
+	newConfig := func() *Config {
+		config := getDefaultConfig()
+		config.AutoCopy.Items = []AutoCopyItem{
+			{Path: ".cursor/", Recursive: true},
+			{Path: ".env", Exclude: []string{"*.bak"}},
+		}
+		return config
+	}
+
+	t.Run("scalar settings", func(t *testing.T) {
+		config := newConfig()
+
+		require.NoError(t, config.Set("editor.preferred", "vim"))
+		require.NoError(t, config.Set("global.verbose", "true"))
+		require.NoError(t, config.Set("autocopy.version", "1"))
+		require.NoError(t, config.Set("autocopy.maxTotalBytes", "10737418240"))
+
+		value, err := config.Get("editor.preferred")
+		require.NoError(t, err)
+		assert.Equal(t, "vim", value)
+		assert.True(t, config.Global.Verbose)
+		assert.Equal(t, 1, config.AutoCopy.Version)
+		assert.Equal(t, int64(10737418240), config.AutoCopy.MaxTotalBytes)
+	})
+
+	t.Run("nested items", func(t *testing.T) {
+		config := newConfig()
+
+		value, err := config.Get("autocopy.items.0.recursive")
+		require.NoError(t, err)
+		assert.Equal(t, true, value)
+
+		require.NoError(t, config.Set("autocopy.items.0.recursive", "false"))
+		require.NoError(t, config.Set("autocopy.items.1.exclude", "*.log, tmp"))
+		require.NoError(t, config.Set("autocopy.items.1.directory", "false"))
+
+		assert.False(t, config.AutoCopy.Items[0].Recursive)
+		assert.Equal(t, []string{"*.log", "tmp"}, config.AutoCopy.Items[1].Exclude)
+		require.NotNil(t, config.AutoCopy.Items[1].Directory)
+		assert.False(t, *config.AutoCopy.Items[1].Directory)
+
+		value, err = config.Get("autocopy.items.0.directory")
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("map entries", func(t *testing.T) {
+		config := newConfig()
+
+		require.NoError(t, config.Set("editor.commands.zed", "zed"))
+		value, err := config.Get("editor.commands.zed")
+		require.NoError(t, err)
+		assert.Equal(t, "zed", value)
+	})
+
+	t.Run("unknown keys", func(t *testing.T) {
+		config := newConfig()
+
+		for _, key := range []string{
+			"editor.missing",
+			"autocopy.items.5.recursive",
+			"autocopy.items.first.recursive",
+			"autocopy.items",
+			"editor",
+			"environments.ci",
+		} {
+			_, err := config.Get(key)
+			assert.ErrorIs(t, err, ErrUnknownKey, key)
+			assert.ErrorIs(t, config.Set(key, "x"), ErrUnknownKey, key)
+		}
+	})
+
+	t.Run("type mismatches", func(t *testing.T) {
+		config := newConfig()
+
+		err := config.Set("autocopy.items.0.recursive", "maybe")
+		assert.ErrorIs(t, err, ErrInvalidValue)
+		assert.Contains(t, err.Error(), "true or false")
+		assert.ErrorIs(t, config.Set("autocopy.version", "two"), ErrInvalidValue)
+		assert.True(t, config.AutoCopy.Items[0].Recursive)
+	})
+
+	t.Run("keys", func(t *testing.T) {
+		keys := Keys()
+		assert.Contains(t, keys, "editor.preferred")
+		assert.Contains(t, keys, "global.outputFormat")
+		assert.Contains(t, keys, "autocopy.items.<index>.recursive")
+		assert.Contains(t, keys, "editor.commands.<name>")
+		for _, key := range keys {
+			assert.NotContains(t, key, "environments")
+		}
+	})
+}
+
+func TestManager_SetConfigValue(t *testing.T) {
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	homeDir := t.TempDir()
+	os.Setenv("HOME", homeDir)
+
+	manager := NewManager()
+
+	readJSON := func(t *testing.T, path string) map[string]interface{} {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &raw))
+		return raw
+	}
+
+	t.Run("creates the project config", func(t *testing.T) {
+		projectDir := t.TempDir()
+
+		path, err := manager.SetConfigValue(projectDir, false, "editor.preferred", "vim")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(projectDir, ".hatcher", "config.json"), path)
+		assert.Equal(t, map[string]interface{}{
+			"editor": map[string]interface{}{"preferred": "vim"},
+		}, readJSON(t, path))
+	})
+
+	t.Run("keeps other settings", func(t *testing.T) {
+		projectDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".hatcher"), 0755))
+		configPath := filepath.Join(projectDir, ".hatcher", "config.json")
+		require.NoError(t, os.WriteFile(configPath, []byte(`{
+			"autocopy": {"version": 2, "items": [{"path": ".cursor/", "recursive": true}, {"path": ".env"}]},
+			"global": {"verbose": true}
+		}`), 0644))
+
+		_, err := manager.SetConfigValue(projectDir, false, "autocopy.items.0.recursive", "false")
+		require.NoError(t, err)
+
+		raw := readJSON(t, configPath)
+		assert.Equal(t, map[string]interface{}{"verbose": true}, raw["global"])
+		items := raw["autocopy"].(map[string]interface{})["items"].([]interface{})
+		require.Len(t, items, 2)
+		assert.Equal(t, ".cursor/", items[0].(map[string]interface{})["path"])
+		assert.Equal(t, false, items[0].(map[string]interface{})["recursive"])
+		assert.Equal(t, ".env", items[1].(map[string]interface{})["path"])
+
+		config, err := manager.LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.False(t, config.AutoCopy.Items[0].Recursive)
+	})
+
+	t.Run("auto-copy file", func(t *testing.T) {
+		projectDir := t.TempDir()
+		configPath := filepath.Join(projectDir, ".hatcher-auto-copy.json")
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"version": 2, "items": [{"path": ".env"}]}`), 0644))
+
+		_, err := manager.SetConfigValue(projectDir, false, "autocopy.items.0.recursive", "true")
+		require.NoError(t, err)
+		raw := readJSON(t, configPath)
+		assert.Equal(t, true, raw["items"].([]interface{})[0].(map[string]interface{})["recursive"])
+
+		_, err = manager.SetConfigValue(projectDir, false, "editor.preferred", "vim")
+		assert.Error(t, err)
+	})
+
+	t.Run("global yaml config", func(t *testing.T) {
+		configPath := filepath.Join(homeDir, ".hatcher", "config.yaml")
+		require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+		require.NoError(t, os.WriteFile(configPath, []byte("global:\n  verbose: true\n"), 0644))
+		defer os.Remove(configPath)
+
+		path, err := manager.SetConfigValue(t.TempDir(), true, "global.outputFormat", "json")
+		require.NoError(t, err)
+		assert.Equal(t, configPath, path)
+
+		data, err := os.ReadFile(configPath)
+		require.NoError(t, err)
+		var raw map[string]interface{}
+		require.NoError(t, yaml.Unmarshal(data, &raw))
+		assert.Equal(t, map[string]interface{}{"verbose": true, "outputFormat": "json"}, raw["global"])
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		projectDir := t.TempDir()
+
+		_, err := manager.SetConfigValue(projectDir, false, "global.outputFormat", "xml")
+		assert.ErrorIs(t, err, ErrInvalidValue)
+		_, err = manager.SetConfigValue(projectDir, false, "global.verbose", "loud")
+		assert.ErrorIs(t, err, ErrInvalidValue)
+		_, err = manager.SetConfigValue(projectDir, false, "global.missing", "x")
+		assert.ErrorIs(t, err, ErrUnknownKey)
+
+		assert.NoFileExists(t, filepath.Join(projectDir, ".hatcher", "config.json"))
+	})
+}
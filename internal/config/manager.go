@@ -5,9 +5,15 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/editor"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
 	"gopkg.in/yaml.v3"
 )
@@ -17,13 +23,37 @@ type Config struct {
 	AutoCopy AutoCopyConfig `json:"autocopy" yaml:"autocopy"`
 	Editor   EditorConfig   `json:"editor" yaml:"editor"`
 	Global   GlobalConfig   `json:"global" yaml:"global"`
+	Git      GitConfig      `json:"git,omitempty" yaml:"git,omitempty"`
+	Doctor   DoctorConfig   `json:"doctor,omitempty" yaml:"doctor,omitempty"`
+	Worktree WorktreeConfig `json:"worktree,omitempty" yaml:"worktree,omitempty"`
+	List     ListConfig     `json:"list,omitempty" yaml:"list,omitempty"`
+	// Overlays keyed by environment name, applied when selected with HATCHER_ENV
+	Environments map[string]map[string]interface{} `json:"environments,omitempty" yaml:"environments,omitempty"`
 }
 
+// EnvironmentVariable selects one of Config.Environments
+const EnvironmentVariable = "HATCHER_ENV"
+
 // AutoCopyConfig represents auto-copy configuration
 type AutoCopyConfig struct {
-	Version int            `json:"version" yaml:"version"`
-	Items   []AutoCopyItem `json:"items" yaml:"items"`
-	Files   []string       `json:"files,omitempty" yaml:"files,omitempty"` // For v1 compatibility
+	Version             int            `json:"version" yaml:"version"`
+	Items               []AutoCopyItem `json:"items" yaml:"items"`
+	Files               []string       `json:"files,omitempty" yaml:"files,omitempty"`                             // For v1 compatibility
+	IgnoreTarget        string         `json:"ignoreTarget,omitempty" yaml:"ignoreTarget,omitempty"`               // "gitignore" (default) or "exclude"
+	MaxConfirmFiles     int            `json:"maxConfirmFiles,omitempty" yaml:"maxConfirmFiles,omitempty"`         // Confirm before copying more files (0 uses the default)
+	MaxTotalFiles       int            `json:"maxTotalFiles,omitempty" yaml:"maxTotalFiles,omitempty"`             // Abort copies above this many files (0 uses the default, negative disables)
+	MaxTotalBytes       int64          `json:"maxTotalBytes,omitempty" yaml:"maxTotalBytes,omitempty"`             // Abort copies whose files add up to more bytes (0 is unlimited)
+	RespectGitignore    bool           `json:"respectGitignore,omitempty" yaml:"respectGitignore,omitempty"`       // Skip gitignored files inside copied directories
+	RespectExportIgnore bool           `json:"respectExportIgnore,omitempty" yaml:"respectExportIgnore,omitempty"` // Skip files marked export-ignore inside copied directories
+	AddProvenanceHeader bool           `json:"addProvenanceHeader,omitempty" yaml:"addProvenanceHeader,omitempty"` // Prepend a "copied by hatcher" comment to text files
+	PreserveXattrs      bool           `json:"preserveXattrs,omitempty" yaml:"preserveXattrs,omitempty"`           // Copy extended attributes on Linux and macOS
+	PreserveSymlinks    bool           `json:"preserveSymlinks,omitempty" yaml:"preserveSymlinks,omitempty"`       // Recreate symlinks instead of copying their targets
+	SkipTracked         bool           `json:"skipTracked,omitempty" yaml:"skipTracked,omitempty"`                 // Never overwrite files tracked in the worktree
+	UseParallel         bool           `json:"useParallel,omitempty" yaml:"useParallel,omitempty"`                 // Copy with parallel workers unless --sequential is given
+	DeferVerifyMinSize  int64          `json:"deferVerifyMinSize,omitempty" yaml:"deferVerifyMinSize,omitempty"`   // Verify parallel copies of files this large in a separate phase (0 verifies inline)
+	MaxRetries          int            `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`                   // Re-attempts of parallel copies failing with a transient error (0 disables)
+	RetryBackoff        string         `json:"retryBackoff,omitempty" yaml:"retryBackoff,omitempty"`               // Delay before the first retry as a Go duration, e.g. "200ms" (empty uses the default)
+	ManifestPath        string         `json:"manifestPath,omitempty" yaml:"manifestPath,omitempty"`               // Copy manifest location relative to the worktree (empty keeps it in the git dir)
 }
 
 // AutoCopyItem represents a single item to be copied
@@ -35,6 +65,8 @@ type AutoCopyItem struct {
 	AutoDetect bool     `json:"autoDetect" yaml:"autoDetect"`
 	Exclude    []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 	Include    []string `json:"include,omitempty" yaml:"include,omitempty"`
+	Priority   int      `json:"priority,omitempty" yaml:"priority,omitempty"`     // Lower priorities are copied first
+	OnConflict string   `json:"onConflict,omitempty" yaml:"onConflict,omitempty"` // overwrite, skip, backup, error or newer for differing destination files
 }
 
 // EditorConfig represents editor configuration
@@ -43,6 +75,7 @@ type EditorConfig struct {
 	AutoSwitch  bool              `json:"autoSwitch" yaml:"autoSwitch"`
 	Commands    map[string]string `json:"commands,omitempty" yaml:"commands,omitempty"`
 	WindowReuse bool              `json:"windowReuse" yaml:"windowReuse"`
+	Order       []string          `json:"order,omitempty" yaml:"order,omitempty"` // Editor commands to try first, overriding priority
 }
 
 // GlobalConfig represents global settings
@@ -50,6 +83,48 @@ type GlobalConfig struct {
 	Verbose      bool   `json:"verbose" yaml:"verbose"`
 	OutputFormat string `json:"outputFormat" yaml:"outputFormat"`
 	ColorOutput  bool   `json:"colorOutput" yaml:"colorOutput"`
+	// MergeStrategy selects how project auto-copy items combine with the
+	// global ones: replace (default) or merge
+	MergeStrategy string `json:"mergeStrategy,omitempty" yaml:"mergeStrategy,omitempty"`
+}
+
+// GitConfig represents settings for running git
+type GitConfig struct {
+	MaxConcurrent int `json:"maxConcurrent,omitempty" yaml:"maxConcurrent,omitempty"` // Concurrent git processes (0 uses the number of CPUs)
+}
+
+// WorktreeConfig represents settings for worktree directories
+type WorktreeConfig struct {
+	Sanitize SanitizeConfig `json:"sanitize,omitempty" yaml:"sanitize,omitempty"`
+	BaseDir  string         `json:"baseDir,omitempty" yaml:"baseDir,omitempty"` // Create worktrees in this directory instead of next to the repository
+}
+
+// Layout returns the worktree layout these settings select
+func (w WorktreeConfig) Layout() worktree.Layout {
+	return worktree.Layout{
+		BaseDir: w.BaseDir,
+		Sanitizer: worktree.Sanitizer{
+			Separator: w.Sanitize.Separator,
+			Nested:    w.Sanitize.Nested,
+		},
+	}
+}
+
+// SanitizeConfig represents how branch names become directory names
+type SanitizeConfig struct {
+	Separator string `json:"separator,omitempty" yaml:"separator,omitempty"` // Replaces unsafe characters (default "-")
+	Nested    bool   `json:"nested,omitempty" yaml:"nested,omitempty"`       // Keep "/" so feature/x becomes a nested feature/x directory
+}
+
+// ListConfig represents defaults for hch list
+type ListConfig struct {
+	ExcludeMain bool `json:"excludeMain,omitempty" yaml:"excludeMain,omitempty"` // Hide the main repository unless --exclude-main=false is passed
+}
+
+// DoctorConfig represents settings for hch doctor
+type DoctorConfig struct {
+	Weights       map[string]int `json:"weights,omitempty" yaml:"weights,omitempty"`             // Health score weight per check (default 1)
+	MinGitVersion string         `json:"minGitVersion,omitempty" yaml:"minGitVersion,omitempty"` // Oldest Git the git-version check accepts (default 2.17.0)
 }
 
 // Manager handles configuration loading, saving, and validation
@@ -66,6 +141,22 @@ func NewManager() *Manager {
 
 // LoadConfig loads configuration from various sources with priority order
 func (m *Manager) LoadConfig(projectPath string) (*Config, error) {
+	config, err := m.ReadConfig(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate final configuration
+	if errors := m.ValidateConfig(config); len(errors) > 0 {
+		return nil, fmt.Errorf("configuration validation failed: %s", strings.Join(errors, "; "))
+	}
+
+	return config, nil
+}
+
+// ReadConfig loads configuration like LoadConfig without validating it, so
+// every validation error can be reported
+func (m *Manager) ReadConfig(projectPath string) (*Config, error) {
 	config := m.defaultConfig.copy()
 
 	// 1. Load global config
@@ -80,58 +171,96 @@ func (m *Manager) LoadConfig(projectPath string) (*Config, error) {
 		}
 	}
 
-	// 3. Apply environment variable overrides
-	m.applyEnvironmentOverrides(config)
-
-	// 4. Validate final configuration
-	if errors := m.ValidateConfig(config); len(errors) > 0 {
-		return nil, fmt.Errorf("configuration validation failed: %s", strings.Join(errors, "; "))
+	// 3. Apply the overlay of the selected environment
+	if err := m.applyEnvironment(config, os.Getenv(EnvironmentVariable)); err != nil {
+		return nil, err
 	}
 
+	// 4. Apply environment variable overrides
+	m.applyEnvironmentOverrides(config)
+
 	return config, nil
 }
 
-// SaveConfig saves configuration to the specified location
-func (m *Manager) SaveConfig(config *Config, projectPath string, global bool) error {
-	var configPath string
-	var data []byte
-	var err error
+// Strategies for GlobalConfig.MergeStrategy
+const (
+	MergeStrategyReplace = "replace" // Project items replace the global ones
+	MergeStrategyMerge   = "merge"   // Project items override global ones with the same path and add the others
+)
+
+// Formats project configs can be saved in
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// ProjectConfigPath returns the auto-copy config file of the project at
+// projectPath for format; an empty format is JSON
+func ProjectConfigPath(projectPath, format string) string {
+	if format == FormatYAML {
+		return filepath.Join(projectPath, ".hatcher-auto-copy.yaml")
+	}
+	return filepath.Join(projectPath, ".hatcher-auto-copy.json")
+}
+
+// SaveConfig saves configuration to the specified location. Project configs
+// are written in format (json when empty); global configs are always YAML.
+func (m *Manager) SaveConfig(config *Config, projectPath string, global bool, format string) error {
+	configPath, data, err := m.MarshalConfig(config, projectPath, global, format)
+	if err != nil {
+		return err
+	}
 
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return nil
+}
+
+// MarshalConfig returns the file SaveConfig writes and its content, without
+// writing it
+func (m *Manager) MarshalConfig(config *Config, projectPath string, global bool, format string) (string, []byte, error) {
 	if global {
 		// Save as global YAML config
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
-			return fmt.Errorf("failed to get home directory: %w", err)
-		}
-
-		configDir := filepath.Join(homeDir, ".hatcher")
-		if err := os.MkdirAll(configDir, 0755); err != nil {
-			return fmt.Errorf("failed to create config directory: %w", err)
+			return "", nil, fmt.Errorf("failed to get home directory: %w", err)
 		}
 
-		configPath = filepath.Join(configDir, "config.yaml")
-		data, err = yaml.Marshal(config)
+		data, err := yaml.Marshal(config)
 		if err != nil {
-			return fmt.Errorf("failed to marshal YAML: %w", err)
-		}
-	} else {
-		// Save as project JSON config (auto-copy only)
-		if projectPath == "" {
-			return fmt.Errorf("project path is required for project config")
+			return "", nil, fmt.Errorf("failed to marshal YAML: %w", err)
 		}
+		return filepath.Join(homeDir, ".hatcher", "config.yaml"), data, nil
+	}
+
+	// Save as project config (auto-copy only)
+	if projectPath == "" {
+		return "", nil, fmt.Errorf("project path is required for project config")
+	}
 
-		configPath = filepath.Join(projectPath, ".hatcher-auto-copy.json")
+	var data []byte
+	var err error
+	switch format {
+	case "", FormatJSON:
 		data, err = json.MarshalIndent(config.AutoCopy, "", "  ")
 		if err != nil {
-			return fmt.Errorf("failed to marshal JSON: %w", err)
+			return "", nil, fmt.Errorf("failed to marshal JSON: %w", err)
 		}
+	case FormatYAML:
+		data, err = yaml.Marshal(config.AutoCopy)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal YAML: %w", err)
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported config format %q (use json or yaml)", format)
 	}
-
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write config file: %w", err)
-	}
-
-	return nil
+	return ProjectConfigPath(projectPath, format), data, nil
 }
 
 // ValidateConfig validates the configuration and returns any errors
@@ -143,6 +272,30 @@ func (m *Manager) ValidateConfig(config *Config) []string {
 		errors = append(errors, fmt.Sprintf("unsupported autocopy version: %d", config.AutoCopy.Version))
 	}
 
+	switch config.AutoCopy.IgnoreTarget {
+	case "", "gitignore", "exclude":
+	default:
+		errors = append(errors, fmt.Sprintf("unsupported autocopy ignoreTarget: %s (expected gitignore or exclude)", config.AutoCopy.IgnoreTarget))
+	}
+
+	if config.AutoCopy.MaxConfirmFiles < 0 {
+		errors = append(errors, fmt.Sprintf("autocopy maxConfirmFiles must not be negative: %d", config.AutoCopy.MaxConfirmFiles))
+	}
+
+	if err := autocopy.ValidateManifestPath(config.AutoCopy.ManifestPath); err != nil {
+		errors = append(errors, fmt.Sprintf("invalid autocopy manifestPath: %v", err))
+	}
+
+	if config.AutoCopy.MaxRetries < 0 {
+		errors = append(errors, fmt.Sprintf("autocopy maxRetries must not be negative: %d", config.AutoCopy.MaxRetries))
+	}
+
+	if config.AutoCopy.RetryBackoff != "" {
+		if backoff, err := time.ParseDuration(config.AutoCopy.RetryBackoff); err != nil || backoff < 0 {
+			errors = append(errors, fmt.Sprintf("invalid autocopy retryBackoff: %s (expected a duration such as 200ms)", config.AutoCopy.RetryBackoff))
+		}
+	}
+
 	for i, item := range config.AutoCopy.Items {
 		if item.Path == "" {
 			errors = append(errors, fmt.Sprintf("autocopy item %d has empty path", i))
@@ -151,13 +304,42 @@ func (m *Manager) ValidateConfig(config *Config) []string {
 		if strings.Contains(item.Path, "..") {
 			errors = append(errors, fmt.Sprintf("autocopy item %d contains invalid path: %s", i, item.Path))
 		}
+
+		for _, pattern := range append(append([]string(nil), item.Exclude...), item.Include...) {
+			if err := autocopy.ValidateFilterPattern(pattern); err != nil {
+				errors = append(errors, fmt.Sprintf("autocopy item %d: %v", i, err))
+			}
+		}
+
+		if err := autocopy.ValidateConflictPolicy(item.OnConflict); err != nil {
+			errors = append(errors, fmt.Sprintf("autocopy item %d: %v", i, err))
+		}
+	}
+
+	if config.Git.MaxConcurrent < 0 {
+		errors = append(errors, fmt.Sprintf("git maxConcurrent must not be negative: %d", config.Git.MaxConcurrent))
+	}
+
+	if err := worktree.ValidateSeparator(config.Worktree.Sanitize.Separator); err != nil {
+		errors = append(errors, fmt.Sprintf("worktree %v", err))
+	}
+
+	for check, weight := range config.Doctor.Weights {
+		if weight < 0 {
+			errors = append(errors, fmt.Sprintf("doctor weight of %s must not be negative: %d", check, weight))
+		}
+	}
+
+	if config.Doctor.MinGitVersion != "" {
+		if _, err := git.ParseVersion(config.Doctor.MinGitVersion); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid doctor minGitVersion: %s", config.Doctor.MinGitVersion))
+		}
 	}
 
 	// Validate Editor configuration
 	if config.Editor.Preferred != "" {
-		validEditors := []string{"cursor", "code", "vim", "nano", ""}
 		valid := false
-		for _, editor := range validEditors {
+		for _, editor := range preferredEditors {
 			if config.Editor.Preferred == editor {
 				valid = true
 				break
@@ -168,34 +350,73 @@ func (m *Manager) ValidateConfig(config *Config) []string {
 		}
 	}
 
-	// Validate Global configuration
-	if config.Global.OutputFormat != "" {
-		validFormats := []string{"table", "json", "yaml", "simple"}
+	known := editor.NewDetector().KnownCommands()
+	for _, command := range config.Editor.Order {
 		valid := false
-		for _, format := range validFormats {
-			if config.Global.OutputFormat == format {
+		for _, k := range known {
+			if command == k {
 				valid = true
 				break
 			}
 		}
 		if !valid {
-			errors = append(errors, fmt.Sprintf("unsupported output format: %s", config.Global.OutputFormat))
+			errors = append(errors, fmt.Sprintf("unsupported editor in editor.order: %s (available: %s)", command, strings.Join(known, ", ")))
+		}
+	}
+
+	// Validate Global configuration
+	if config.Global.OutputFormat != "" && !isValidOutputFormat(config.Global.OutputFormat) {
+		errors = append(errors, fmt.Sprintf("unsupported output format: %s", config.Global.OutputFormat))
+	}
+	switch config.Global.MergeStrategy {
+	case "", MergeStrategyReplace, MergeStrategyMerge:
+	default:
+		errors = append(errors, fmt.Sprintf("unsupported merge strategy: %s (use replace or merge)", config.Global.MergeStrategy))
+	}
+
+	// Every environment must yield a valid configuration, not just the
+	// selected one
+	for _, name := range environmentNames(config) {
+		envConfig := config.copy()
+		envConfig.Environments = nil
+		if err := m.mergeConfig(envConfig, config.Environments[name]); err != nil {
+			errors = append(errors, fmt.Sprintf("environment %s: %v", name, err))
+			continue
+		}
+		for _, envError := range m.ValidateConfig(envConfig) {
+			errors = append(errors, fmt.Sprintf("environment %s: %s", name, envError))
 		}
 	}
 
 	return errors
 }
 
+// Values ValidateConfig accepts for editor.preferred and global.outputFormat
+var (
+	preferredEditors = []string{"cursor", "code", "vim", "nano"}
+	outputFormats    = []string{"table", "json", "yaml", "simple"}
+)
+
+// isValidOutputFormat reports whether format is a supported output format
+func isValidOutputFormat(format string) bool {
+	for _, valid := range outputFormats {
+		if format == valid {
+			return true
+		}
+	}
+	return false
+}
+
 // MigrateConfig migrates configuration from older versions
 func (m *Manager) MigrateConfig(rawConfig map[string]interface{}) (*Config, error) {
 	config := m.defaultConfig.copy()
 
-	version, ok := rawConfig["version"].(float64)
+	version, ok := toInt(rawConfig["version"])
 	if !ok {
 		version = 1 // Default to v1 if no version specified
 	}
 
-	switch int(version) {
+	switch version {
 	case 1:
 		// Migrate from v1 to v2
 		if files, ok := rawConfig["files"].([]interface{}); ok {
@@ -223,13 +444,21 @@ func (m *Manager) MigrateConfig(rawConfig map[string]interface{}) (*Config, erro
 		}
 
 	case 2:
+		// Auto-copy specific files keep their settings at the top level
+		if _, nested := rawConfig["autocopy"]; !nested {
+			if err := m.parseAutoCopyConfig(&config.AutoCopy, rawConfig); err != nil {
+				return nil, fmt.Errorf("failed to parse v2 config: %w", err)
+			}
+			break
+		}
+
 		// Already v2, just parse normally
 		if err := m.parseV2Config(config, rawConfig); err != nil {
 			return nil, fmt.Errorf("failed to parse v2 config: %w", err)
 		}
 
 	default:
-		return nil, fmt.Errorf("unsupported config version: %d", int(version))
+		return nil, fmt.Errorf("unsupported config version: %d", version)
 	}
 
 	return config, nil
@@ -334,6 +563,9 @@ func (m *Manager) loadProjectConfig(config *Config, projectPath string) error {
 			continue
 		}
 
+		// Items the project's items replace or, with the merge strategy, extend
+		baseItems := config.AutoCopy.Items
+
 		// Check if this is an old format auto-copy config
 		if _, hasVersion := rawConfig["version"]; hasVersion {
 			if _, hasItems := rawConfig["items"]; hasItems || rawConfig["files"] != nil {
@@ -342,7 +574,17 @@ func (m *Manager) loadProjectConfig(config *Config, projectPath string) error {
 				if err != nil {
 					return err
 				}
-				config.AutoCopy = migratedConfig.AutoCopy
+				if config.Global.MergeStrategy != MergeStrategyMerge {
+					config.AutoCopy = migratedConfig.AutoCopy
+					break
+				}
+
+				// Keep the global settings the file leaves out
+				if err := m.parseAutoCopyConfig(&config.AutoCopy, rawConfig); err != nil {
+					return err
+				}
+				config.AutoCopy.Version = migratedConfig.AutoCopy.Version
+				config.AutoCopy.Items = mergeAutoCopyItems(baseItems, migratedConfig.AutoCopy.Items)
 				break
 			}
 		}
@@ -351,6 +593,10 @@ func (m *Manager) loadProjectConfig(config *Config, projectPath string) error {
 		if err := m.mergeConfig(config, rawConfig); err != nil {
 			return err
 		}
+		if autocopy, ok := rawConfig["autocopy"].(map[string]interface{}); ok && autocopy["items"] != nil &&
+			config.Global.MergeStrategy == MergeStrategyMerge {
+			config.AutoCopy.Items = mergeAutoCopyItems(baseItems, config.AutoCopy.Items)
+		}
 
 		break // Use first found config
 	}
@@ -358,6 +604,26 @@ func (m *Manager) loadProjectConfig(config *Config, projectPath string) error {
 	return nil
 }
 
+// mergeAutoCopyItems returns base with the items of overrides that share a
+// path replacing them in place and the others appended
+func mergeAutoCopyItems(base, overrides []AutoCopyItem) []AutoCopyItem {
+	merged := append([]AutoCopyItem(nil), base...)
+	index := make(map[string]int, len(merged))
+	for i, item := range merged {
+		index[item.Path] = i
+	}
+
+	for _, item := range overrides {
+		if i, ok := index[item.Path]; ok {
+			merged[i] = item
+			continue
+		}
+		index[item.Path] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
 // applyEnvironmentOverrides applies environment variable overrides
 func (m *Manager) applyEnvironmentOverrides(config *Config) {
 	if editor := os.Getenv("HATCHER_EDITOR"); editor != "" {
@@ -381,6 +647,38 @@ func (m *Manager) applyEnvironmentOverrides(config *Config) {
 	}
 }
 
+// applyEnvironment merges the overlay of the environment name into config.
+// An empty name selects no environment.
+func (m *Manager) applyEnvironment(config *Config, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	overlay, ok := config.Environments[name]
+	if !ok {
+		defined := environmentNames(config)
+		if len(defined) == 0 {
+			return fmt.Errorf("unknown environment %q: no environments are configured", name)
+		}
+		return fmt.Errorf("unknown environment %q (configured: %s)", name, strings.Join(defined, ", "))
+	}
+
+	if err := m.mergeConfig(config, overlay); err != nil {
+		return fmt.Errorf("failed to apply environment %s: %w", name, err)
+	}
+	return nil
+}
+
+// environmentNames returns the configured environment names, sorted
+func environmentNames(config *Config) []string {
+	names := make([]string, 0, len(config.Environments))
+	for name := range config.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 // mergeConfig merges raw configuration into the config object
 func (m *Manager) mergeConfig(config *Config, rawConfig map[string]interface{}) error {
 	// This is a simplified merge - in a real implementation,
@@ -404,6 +702,56 @@ func (m *Manager) mergeConfig(config *Config, rawConfig map[string]interface{})
 		}
 	}
 
+	if git, ok := rawConfig["git"].(map[string]interface{}); ok {
+		if err := m.parseGitConfig(&config.Git, git); err != nil {
+			return err
+		}
+	}
+
+	if doctor, ok := rawConfig["doctor"].(map[string]interface{}); ok {
+		if err := m.parseDoctorConfig(&config.Doctor, doctor); err != nil {
+			return err
+		}
+	}
+
+	if worktreeSection, ok := rawConfig["worktree"].(map[string]interface{}); ok {
+		if err := m.parseWorktreeConfig(&config.Worktree, worktreeSection); err != nil {
+			return err
+		}
+	}
+
+	if list, ok := rawConfig["list"].(map[string]interface{}); ok {
+		if err := m.parseListConfig(&config.List, list); err != nil {
+			return err
+		}
+	}
+
+	if environments, ok := rawConfig["environments"].(map[string]interface{}); ok {
+		if err := m.parseEnvironments(config, environments); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseEnvironments parses environment overlays. An environment defined again
+// by a later config file replaces the earlier definition.
+func (m *Manager) parseEnvironments(config *Config, raw map[string]interface{}) error {
+	for name, value := range raw {
+		overlay, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("environment %s must be an object", name)
+		}
+		if _, nested := overlay["environments"]; nested {
+			return fmt.Errorf("environment %s cannot define environments", name)
+		}
+
+		if config.Environments == nil {
+			config.Environments = make(map[string]map[string]interface{})
+		}
+		config.Environments[name] = overlay
+	}
 	return nil
 }
 
@@ -414,8 +762,67 @@ func (m *Manager) parseV2Config(config *Config, rawConfig map[string]interface{}
 
 // parseAutoCopyConfig parses auto-copy configuration
 func (m *Manager) parseAutoCopyConfig(config *AutoCopyConfig, raw map[string]interface{}) error {
-	if version, ok := raw["version"].(float64); ok {
-		config.Version = int(version)
+	if version, ok := toInt(raw["version"]); ok {
+		config.Version = version
+	}
+
+	if ignoreTarget, ok := raw["ignoreTarget"].(string); ok {
+		config.IgnoreTarget = ignoreTarget
+	}
+
+	if manifestPath, ok := raw["manifestPath"].(string); ok {
+		config.ManifestPath = manifestPath
+	}
+
+	if maxConfirmFiles, ok := toInt(raw["maxConfirmFiles"]); ok {
+		config.MaxConfirmFiles = maxConfirmFiles
+	}
+
+	if maxTotalFiles, ok := toInt(raw["maxTotalFiles"]); ok {
+		config.MaxTotalFiles = maxTotalFiles
+	}
+
+	if maxTotalBytes, ok := toInt(raw["maxTotalBytes"]); ok {
+		config.MaxTotalBytes = int64(maxTotalBytes)
+	}
+
+	if respectGitignore, ok := raw["respectGitignore"].(bool); ok {
+		config.RespectGitignore = respectGitignore
+	}
+
+	if respectExportIgnore, ok := raw["respectExportIgnore"].(bool); ok {
+		config.RespectExportIgnore = respectExportIgnore
+	}
+
+	if addProvenanceHeader, ok := raw["addProvenanceHeader"].(bool); ok {
+		config.AddProvenanceHeader = addProvenanceHeader
+	}
+
+	if preserveXattrs, ok := raw["preserveXattrs"].(bool); ok {
+		config.PreserveXattrs = preserveXattrs
+	}
+
+	if preserveSymlinks, ok := raw["preserveSymlinks"].(bool); ok {
+		config.PreserveSymlinks = preserveSymlinks
+	}
+	if skipTracked, ok := raw["skipTracked"].(bool); ok {
+		config.SkipTracked = skipTracked
+	}
+
+	if useParallel, ok := raw["useParallel"].(bool); ok {
+		config.UseParallel = useParallel
+	}
+
+	if deferVerifyMinSize, ok := toInt(raw["deferVerifyMinSize"]); ok {
+		config.DeferVerifyMinSize = int64(deferVerifyMinSize)
+	}
+
+	if maxRetries, ok := toInt(raw["maxRetries"]); ok {
+		config.MaxRetries = maxRetries
+	}
+
+	if retryBackoff, ok := raw["retryBackoff"].(string); ok {
+		config.RetryBackoff = retryBackoff
 	}
 
 	if items, ok := raw["items"].([]interface{}); ok {
@@ -456,6 +863,22 @@ func (m *Manager) parseAutoCopyItem(item *AutoCopyItem, raw map[string]interface
 		item.AutoDetect = autoDetect
 	}
 
+	if priority, ok := toInt(raw["priority"]); ok {
+		item.Priority = priority
+	}
+
+	if exclude, ok := toStrings(raw["exclude"]); ok {
+		item.Exclude = exclude
+	}
+
+	if include, ok := toStrings(raw["include"]); ok {
+		item.Include = include
+	}
+
+	if onConflict, ok := raw["onConflict"].(string); ok {
+		item.OnConflict = onConflict
+	}
+
 	return nil
 }
 
@@ -473,6 +896,15 @@ func (m *Manager) parseEditorConfig(config *EditorConfig, raw map[string]interfa
 		config.WindowReuse = windowReuse
 	}
 
+	if order, ok := raw["order"].([]interface{}); ok {
+		config.Order = make([]string, 0, len(order))
+		for _, command := range order {
+			if command, ok := command.(string); ok {
+				config.Order = append(config.Order, command)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -490,6 +922,66 @@ func (m *Manager) parseGlobalConfig(config *GlobalConfig, raw map[string]interfa
 		config.ColorOutput = colorOutput
 	}
 
+	if mergeStrategy, ok := raw["mergeStrategy"].(string); ok {
+		config.MergeStrategy = mergeStrategy
+	}
+
+	return nil
+}
+
+// parseGitConfig parses git configuration
+func (m *Manager) parseGitConfig(config *GitConfig, raw map[string]interface{}) error {
+	if maxConcurrent, ok := toInt(raw["maxConcurrent"]); ok {
+		config.MaxConcurrent = maxConcurrent
+	}
+
+	return nil
+}
+
+// parseDoctorConfig parses doctor configuration
+func (m *Manager) parseDoctorConfig(config *DoctorConfig, raw map[string]interface{}) error {
+	if weights, ok := raw["weights"].(map[string]interface{}); ok {
+		config.Weights = make(map[string]int, len(weights))
+		for check, value := range weights {
+			weight, ok := toInt(value)
+			if !ok {
+				return fmt.Errorf("doctor weight of %s must be a number", check)
+			}
+			config.Weights[check] = weight
+		}
+	}
+
+	if minGitVersion, ok := raw["minGitVersion"].(string); ok {
+		config.MinGitVersion = minGitVersion
+	}
+
+	return nil
+}
+
+// parseListConfig parses list configuration
+func (m *Manager) parseListConfig(config *ListConfig, raw map[string]interface{}) error {
+	if excludeMain, ok := raw["excludeMain"].(bool); ok {
+		config.ExcludeMain = excludeMain
+	}
+
+	return nil
+}
+
+// parseWorktreeConfig parses worktree configuration
+func (m *Manager) parseWorktreeConfig(config *WorktreeConfig, raw map[string]interface{}) error {
+	if sanitize, ok := raw["sanitize"].(map[string]interface{}); ok {
+		if separator, ok := sanitize["separator"].(string); ok {
+			config.Sanitize.Separator = separator
+		}
+		if nested, ok := sanitize["nested"].(bool); ok {
+			config.Sanitize.Nested = nested
+		}
+	}
+
+	if baseDir, ok := raw["baseDir"].(string); ok {
+		config.BaseDir = baseDir
+	}
+
 	return nil
 }
 
@@ -539,16 +1031,63 @@ func getDefaultConfig() *Config {
 func (c *Config) copy() *Config {
 	newConfig := &Config{
 		AutoCopy: AutoCopyConfig{
-			Version: c.AutoCopy.Version,
-			Items:   make([]AutoCopyItem, len(c.AutoCopy.Items)),
-			Files:   make([]string, len(c.AutoCopy.Files)),
+			Version:             c.AutoCopy.Version,
+			Items:               make([]AutoCopyItem, len(c.AutoCopy.Items)),
+			Files:               make([]string, len(c.AutoCopy.Files)),
+			IgnoreTarget:        c.AutoCopy.IgnoreTarget,
+			MaxConfirmFiles:     c.AutoCopy.MaxConfirmFiles,
+			MaxTotalFiles:       c.AutoCopy.MaxTotalFiles,
+			MaxTotalBytes:       c.AutoCopy.MaxTotalBytes,
+			RespectGitignore:    c.AutoCopy.RespectGitignore,
+			RespectExportIgnore: c.AutoCopy.RespectExportIgnore,
+			AddProvenanceHeader: c.AutoCopy.AddProvenanceHeader,
+			PreserveXattrs:      c.AutoCopy.PreserveXattrs,
+			PreserveSymlinks:    c.AutoCopy.PreserveSymlinks,
+			SkipTracked:         c.AutoCopy.SkipTracked,
+			UseParallel:         c.AutoCopy.UseParallel,
+			DeferVerifyMinSize:  c.AutoCopy.DeferVerifyMinSize,
+			MaxRetries:          c.AutoCopy.MaxRetries,
+			RetryBackoff:        c.AutoCopy.RetryBackoff,
+			ManifestPath:        c.AutoCopy.ManifestPath,
 		},
-		Editor: c.Editor,
-		Global: c.Global,
+		Editor:   c.Editor,
+		Global:   c.Global,
+		Git:      c.Git,
+		Worktree: c.Worktree,
+		List:     c.List,
 	}
 
 	copy(newConfig.AutoCopy.Items, c.AutoCopy.Items)
 	copy(newConfig.AutoCopy.Files, c.AutoCopy.Files)
+	for i, item := range newConfig.AutoCopy.Items {
+		if item.Exclude != nil {
+			newConfig.AutoCopy.Items[i].Exclude = append([]string(nil), item.Exclude...)
+		}
+		if item.Include != nil {
+			newConfig.AutoCopy.Items[i].Include = append([]string(nil), item.Include...)
+		}
+	}
+
+	if c.Editor.Order != nil {
+		newConfig.Editor.Order = append([]string(nil), c.Editor.Order...)
+	}
+
+	if c.Doctor.Weights != nil {
+		newConfig.Doctor.Weights = make(map[string]int, len(c.Doctor.Weights))
+		for check, weight := range c.Doctor.Weights {
+			newConfig.Doctor.Weights[check] = weight
+		}
+	}
+
+	if c.Environments != nil {
+		newConfig.Environments = make(map[string]map[string]interface{}, len(c.Environments))
+		for name, overlay := range c.Environments {
+			newConfig.Environments[name] = make(map[string]interface{}, len(overlay))
+			for key, value := range overlay {
+				newConfig.Environments[name][key] = value
+			}
+		}
+	}
 
 	// Deep copy directory pointers
 	for i := range newConfig.AutoCopy.Items {
@@ -561,3 +1100,32 @@ func (c *Config) copy() *Config {
 }
 
 // Helper function
+
+// toInt converts a numeric value decoded from JSON (float64) or YAML (int)
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// toStrings converts a list decoded from JSON or YAML to strings, skipping
+// other values
+func toStrings(value interface{}) ([]string, bool) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+
+	strs := make([]string, 0, len(list))
+	for _, element := range list {
+		if str, ok := element.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs, true
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -56,6 +57,30 @@ func TestManager_LoadConfig(t *testing.T) {
 		assert.Equal(t, "custom-file.txt", config.AutoCopy.Items[1].Path)
 	})
 
+	t.Run("load copy settings from project config", func(t *testing.T) {
+		projectDir := t.TempDir()
+		projectConfig := `{"version": 2, "ignoreTarget": "exclude", "maxConfirmFiles": 50, "manifestPath": ".hatcher/copy-manifest.json", "respectExportIgnore": true, "skipTracked": true, "useParallel": true, "deferVerifyMinSize": 1048576, "maxRetries": 3, "retryBackoff": "200ms", "items": [{"path": ".env", "priority": 10, "onConflict": "skip"}, {"path": ".ai/", "exclude": [".ai/cache/"], "include": ["*.md"]}]}`
+		err := os.WriteFile(filepath.Join(projectDir, ".hatcher-auto-copy.json"), []byte(projectConfig), 0644)
+		require.NoError(t, err)
+
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, "exclude", config.AutoCopy.IgnoreTarget)
+		assert.Equal(t, 50, config.AutoCopy.MaxConfirmFiles)
+		assert.Equal(t, ".hatcher/copy-manifest.json", config.AutoCopy.ManifestPath)
+		assert.True(t, config.AutoCopy.RespectExportIgnore)
+		assert.True(t, config.AutoCopy.UseParallel)
+		assert.Equal(t, int64(1048576), config.AutoCopy.DeferVerifyMinSize)
+		assert.Equal(t, 3, config.AutoCopy.MaxRetries)
+		assert.Equal(t, "200ms", config.AutoCopy.RetryBackoff)
+		assert.True(t, config.AutoCopy.SkipTracked)
+		require.Len(t, config.AutoCopy.Items, 2)
+		assert.Equal(t, 10, config.AutoCopy.Items[0].Priority)
+		assert.Equal(t, "skip", config.AutoCopy.Items[0].OnConflict)
+		assert.Equal(t, []string{".ai/cache/"}, config.AutoCopy.Items[1].Exclude)
+		assert.Equal(t, []string{"*.md"}, config.AutoCopy.Items[1].Include)
+	})
+
 	t.Run("load global config", func(t *testing.T) {
 		// Create global config directory
 		globalConfigDir := filepath.Join(tempDir, ".hatcher")
@@ -163,6 +188,232 @@ editor:
 		assert.Equal(t, "vim", config.Editor.Preferred)
 		assert.True(t, config.Global.Verbose)
 	})
+
+	t.Run("git settings from global config", func(t *testing.T) {
+		homeDir := t.TempDir()
+		globalConfigDir := filepath.Join(homeDir, ".hatcher")
+		require.NoError(t, os.MkdirAll(globalConfigDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(globalConfigDir, "config.yaml"), []byte("git:\n  maxConcurrent: 3\n"), 0644))
+
+		originalHome := os.Getenv("HOME")
+		defer os.Setenv("HOME", originalHome)
+		os.Setenv("HOME", homeDir)
+
+		config, err := NewManager().LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, 3, config.Git.MaxConcurrent)
+	})
+
+	t.Run("worktree settings from project config", func(t *testing.T) {
+		originalHome := os.Getenv("HOME")
+		defer os.Setenv("HOME", originalHome)
+		os.Setenv("HOME", t.TempDir())
+
+		projectDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".hatcher"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".hatcher", "config.json"), []byte(`{"worktree": {"baseDir": "~/worktrees", "sanitize": {"separator": "_", "nested": true}}}`), 0644))
+
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, "_", config.Worktree.Sanitize.Separator)
+		assert.True(t, config.Worktree.Sanitize.Nested)
+		assert.Equal(t, "~/worktrees", config.Worktree.BaseDir)
+		assert.Equal(t, worktree.Layout{
+			BaseDir:   "~/worktrees",
+			Sanitizer: worktree.Sanitizer{Separator: "_", Nested: true},
+		}, config.Worktree.Layout())
+	})
+
+	t.Run("doctor weights from global config", func(t *testing.T) {
+		homeDir := t.TempDir()
+		globalConfigDir := filepath.Join(homeDir, ".hatcher")
+		require.NoError(t, os.MkdirAll(globalConfigDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(globalConfigDir, "config.yaml"), []byte("doctor:\n  weights:\n    git: 5\n    editors: 0\n  minGitVersion: \"2.30\"\n"), 0644))
+
+		originalHome := os.Getenv("HOME")
+		defer os.Setenv("HOME", originalHome)
+		os.Setenv("HOME", homeDir)
+
+		config, err := NewManager().LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"git": 5, "editors": 0}, config.Doctor.Weights)
+		assert.Equal(t, "2.30", config.Doctor.MinGitVersion)
+	})
+
+	t.Run("editor order from global config", func(t *testing.T) {
+		homeDir := t.TempDir()
+		globalConfigDir := filepath.Join(homeDir, ".hatcher")
+		require.NoError(t, os.MkdirAll(globalConfigDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(globalConfigDir, "config.yaml"), []byte("editor:\n  order: [code, cursor]\n"), 0644))
+
+		originalHome := os.Getenv("HOME")
+		defer os.Setenv("HOME", originalHome)
+		os.Setenv("HOME", homeDir)
+
+		config, err := NewManager().LoadConfig("")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"code", "cursor"}, config.Editor.Order)
+	})
+}
+
+func TestManager_MergeStrategy(t *testing.T) {
+	homeDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", originalHome)
+	os.Setenv("HOME", homeDir)
+
+	writeGlobal := func(t *testing.T, strategy string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(homeDir, ".hatcher"), 0755))
+		globalConfig := `
+autocopy:
+  version: 2
+  maxConfirmFiles: 50
+  items:
+    - path: ".cursorrules"
+      directory: false
+      onConflict: skip
+    - path: ".env"
+      directory: false
+editor:
+  preferred: "cursor"
+global:
+  mergeStrategy: "` + strategy + `"
+`
+		require.NoError(t, os.WriteFile(filepath.Join(homeDir, ".hatcher", "config.yaml"), []byte(globalConfig), 0644))
+	}
+
+	projectDir := t.TempDir()
+	projectConfig := `{"version": 2, "items": [
+		{"path": ".cursorrules", "directory": false, "onConflict": "backup"},
+		{"path": ".ai/", "directory": true, "recursive": true}
+	]}`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".hatcher-auto-copy.json"), []byte(projectConfig), 0644))
+
+	paths := func(items []AutoCopyItem) []string {
+		var result []string
+		for _, item := range items {
+			result = append(result, item.Path)
+		}
+		return result
+	}
+
+	t.Run("replace discards global items", func(t *testing.T) {
+		writeGlobal(t, MergeStrategyReplace)
+
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{".cursorrules", ".ai/"}, paths(config.AutoCopy.Items))
+		assert.Equal(t, "cursor", config.Editor.Preferred)
+	})
+
+	t.Run("merge overrides items by path and keeps the rest", func(t *testing.T) {
+		writeGlobal(t, MergeStrategyMerge)
+
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{".cursorrules", ".env", ".ai/"}, paths(config.AutoCopy.Items))
+		assert.Equal(t, "backup", config.AutoCopy.Items[0].OnConflict)
+		assert.Equal(t, 50, config.AutoCopy.MaxConfirmFiles)
+
+		// A global-only editor setting survives the project autocopy override
+		assert.Equal(t, "cursor", config.Editor.Preferred)
+	})
+
+	t.Run("merge with a full project config", func(t *testing.T) {
+		writeGlobal(t, MergeStrategyMerge)
+		fullDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(fullDir, ".hatcher"), 0755))
+		fullConfig := `{
+			"autocopy": {"version": 2, "items": [{"path": ".env", "directory": false, "priority": 5}]},
+			"editor": {"autoSwitch": true}
+		}`
+		require.NoError(t, os.WriteFile(filepath.Join(fullDir, ".hatcher", "config.json"), []byte(fullConfig), 0644))
+
+		config, err := NewManager().LoadConfig(fullDir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{".cursorrules", ".env"}, paths(config.AutoCopy.Items))
+		assert.Equal(t, 5, config.AutoCopy.Items[1].Priority)
+		assert.Equal(t, "cursor", config.Editor.Preferred)
+		assert.True(t, config.Editor.AutoSwitch)
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		writeGlobal(t, "append")
+
+		_, err := NewManager().LoadConfig(projectDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported merge strategy")
+	})
+}
+
+func TestManager_Environments(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	projectDir := t.TempDir()
+	projectConfig := `{
+		"editor": {"preferred": "cursor"},
+		"environments": {
+			"ci": {"autocopy": {"items": []}, "editor": {"preferred": "code"}},
+			"local": {"global": {"verbose": true}}
+		}
+	}`
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".hatcher"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, ".hatcher", "config.json"), []byte(projectConfig), 0644))
+
+	t.Run("no environment selected", func(t *testing.T) {
+		t.Setenv(EnvironmentVariable, "")
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, "cursor", config.Editor.Preferred)
+		assert.NotEmpty(t, config.AutoCopy.Items)
+		assert.Len(t, config.Environments, 2)
+	})
+
+	t.Run("selected environment overlays the config", func(t *testing.T) {
+		t.Setenv(EnvironmentVariable, "ci")
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, "code", config.Editor.Preferred)
+		assert.Empty(t, config.AutoCopy.Items)
+		assert.False(t, config.Global.Verbose)
+	})
+
+	t.Run("variable overrides apply after the environment", func(t *testing.T) {
+		t.Setenv(EnvironmentVariable, "ci")
+		t.Setenv("HATCHER_EDITOR", "vim")
+		config, err := NewManager().LoadConfig(projectDir)
+		require.NoError(t, err)
+		assert.Equal(t, "vim", config.Editor.Preferred)
+	})
+
+	t.Run("unknown environment", func(t *testing.T) {
+		t.Setenv(EnvironmentVariable, "staging")
+		_, err := NewManager().LoadConfig(projectDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown environment "staging" (configured: ci, local)`)
+	})
+
+	t.Run("invalid environment", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{Version: 2},
+			Environments: map[string]map[string]interface{}{
+				"ci": {"editor": map[string]interface{}{"preferred": "notepad"}},
+			},
+		}
+
+		errors := NewManager().ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "environment ci: unsupported editor")
+	})
+
+	t.Run("environment must be an object", func(t *testing.T) {
+		config := &Config{}
+		err := NewManager().mergeConfig(config, map[string]interface{}{
+			"environments": map[string]interface{}{"ci": "fast"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "environment ci must be an object")
+	})
 }
 
 func TestManager_SaveConfig(t *testing.T) {
@@ -183,7 +434,7 @@ func TestManager_SaveConfig(t *testing.T) {
 		}
 
 		manager := NewManager()
-		err := manager.SaveConfig(config, tempDir, false)
+		err := manager.SaveConfig(config, tempDir, false, FormatJSON)
 		require.NoError(t, err)
 
 		// Check if file was created
@@ -197,6 +448,38 @@ func TestManager_SaveConfig(t *testing.T) {
 		assert.Equal(t, ".ai/", savedConfig.AutoCopy.Items[0].Path)
 	})
 
+	t.Run("save project config in both formats", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{
+				Version:      2,
+				IgnoreTarget: "exclude",
+				Items: []AutoCopyItem{
+					{Path: ".ai/", Directory: testutil.BoolPtr(true), Recursive: true, OnConflict: "skip"},
+					{Path: ".cursorrules", Directory: testutil.BoolPtr(false)},
+				},
+			},
+		}
+
+		manager := NewManager()
+		for _, format := range []string{FormatJSON, FormatYAML} {
+			t.Run(format, func(t *testing.T) {
+				projectDir := t.TempDir()
+				require.NoError(t, manager.SaveConfig(config, projectDir, false, format))
+				assert.FileExists(t, filepath.Join(projectDir, ".hatcher-auto-copy."+format))
+
+				savedConfig, err := manager.LoadConfig(projectDir)
+				require.NoError(t, err)
+				assert.Equal(t, config.AutoCopy.Version, savedConfig.AutoCopy.Version)
+				assert.Equal(t, "exclude", savedConfig.AutoCopy.IgnoreTarget)
+				assert.Equal(t, config.AutoCopy.Items, savedConfig.AutoCopy.Items)
+			})
+		}
+
+		err := manager.SaveConfig(config, t.TempDir(), false, "toml")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported config format")
+	})
+
 	t.Run("save global config", func(t *testing.T) {
 		config := &Config{
 			Editor: EditorConfig{
@@ -220,7 +503,7 @@ func TestManager_SaveConfig(t *testing.T) {
 		os.Setenv("HOME", tempDir)
 
 		manager := NewManager()
-		err := manager.SaveConfig(config, "", true)
+		err := manager.SaveConfig(config, "", true, "")
 		require.NoError(t, err)
 
 		// Check if file was created
@@ -290,6 +573,111 @@ func TestManager_ValidateConfig(t *testing.T) {
 		assert.Contains(t, errors[0], "empty path")
 	})
 
+	t.Run("invalid ignore target", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{
+				Version:      2,
+				IgnoreTarget: "global",
+			},
+		}
+
+		errors := manager.ValidateConfig(config)
+		assert.NotEmpty(t, errors)
+		assert.Contains(t, errors[0], "ignoreTarget")
+
+		config.AutoCopy.IgnoreTarget = "exclude"
+		assert.Empty(t, manager.ValidateConfig(config))
+	})
+
+	t.Run("manifest path outside the worktree", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{
+				Version:      2,
+				ManifestPath: "../copy-manifest.json",
+			},
+		}
+
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "manifestPath")
+
+		config.AutoCopy.ManifestPath = ".hatcher/copy-manifest.json"
+		assert.Empty(t, manager.ValidateConfig(config))
+	})
+
+	t.Run("invalid retry settings", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{
+				Version:      2,
+				MaxRetries:   -1,
+				RetryBackoff: "soon",
+			},
+		}
+
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 2)
+		assert.Contains(t, errors[0], "maxRetries")
+		assert.Contains(t, errors[1], "retryBackoff")
+
+		config.AutoCopy.MaxRetries = 3
+		config.AutoCopy.RetryBackoff = "200ms"
+		assert.Empty(t, manager.ValidateConfig(config))
+	})
+
+	t.Run("invalid item patterns", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{
+				Version: 2,
+				Items: []AutoCopyItem{
+					{Path: ".ai/", Exclude: []string{"cache/["}, Include: []string{"*.md"}},
+				},
+			},
+		}
+
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "cache/[")
+	})
+
+	t.Run("invalid item conflict policy", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{
+				Version: 2,
+				Items: []AutoCopyItem{
+					{Path: "CLAUDE.md", OnConflict: "overwrite"},
+					{Path: ".env.local", OnConflict: "merge"},
+				},
+			},
+		}
+
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "autocopy item 1")
+		assert.Contains(t, errors[0], `"merge"`)
+	})
+
+	t.Run("negative git maxConcurrent", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{Version: 2},
+			Git:      GitConfig{MaxConcurrent: -1},
+		}
+
+		errors := manager.ValidateConfig(config)
+		assert.NotEmpty(t, errors)
+		assert.Contains(t, errors[0], "maxConcurrent")
+	})
+
+	t.Run("invalid sanitize separator", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{Version: 2},
+			Worktree: WorktreeConfig{Sanitize: SanitizeConfig{Separator: "/"}},
+		}
+
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "separator")
+	})
+
 	t.Run("invalid editor", func(t *testing.T) {
 		config := &Config{
 			Editor: EditorConfig{
@@ -301,6 +689,30 @@ func TestManager_ValidateConfig(t *testing.T) {
 		assert.NotEmpty(t, errors)
 		assert.Contains(t, errors[0], "unsupported editor")
 	})
+
+	t.Run("unknown editor in order", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{Version: 2},
+			Editor:   EditorConfig{Order: []string{"cursor", "zed"}},
+		}
+
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "editor.order: zed")
+	})
+
+	t.Run("doctor minGitVersion", func(t *testing.T) {
+		config := &Config{
+			AutoCopy: AutoCopyConfig{Version: 2},
+			Doctor:   DoctorConfig{MinGitVersion: "2.30"},
+		}
+		assert.Empty(t, manager.ValidateConfig(config))
+
+		config.Doctor.MinGitVersion = "latest"
+		errors := manager.ValidateConfig(config)
+		require.Len(t, errors, 1)
+		assert.Contains(t, errors[0], "invalid doctor minGitVersion: latest")
+	})
 }
 
 func TestManager_MigrateConfig(t *testing.T) {
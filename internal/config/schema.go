@@ -0,0 +1,131 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/editor"
+)
+
+// SchemaDialect is the JSON Schema draft Schema is written in
+const SchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema returns a JSON Schema of the .hatcher-auto-copy.json format or, with
+// full, of the complete configuration in .hatcher/config.json and
+// ~/.hatcher/config.yaml. Constraints mirror what ValidateConfig enforces.
+func Schema(full bool) map[string]interface{} {
+	defs := map[string]interface{}{}
+	constraints := schemaConstraints()
+
+	root := reflect.TypeOf(AutoCopyConfig{})
+	if full {
+		root = reflect.TypeOf(Config{})
+	}
+	schema := typeSchema(root, defs, constraints)
+	schema["$schema"] = SchemaDialect
+	schema["$defs"] = defs
+	// Lets files name their schema for editors; the loader ignores it
+	defs[root.Name()].(map[string]interface{})["properties"].(map[string]interface{})["$schema"] = map[string]interface{}{"type": "string"}
+
+	if full {
+		schema["title"] = "Hatcher configuration"
+		return schema
+	}
+
+	schema["title"] = "Hatcher auto-copy configuration (.hatcher-auto-copy.json)"
+	// The loader only recognizes files with a version and items (or v1 files)
+	schema["required"] = []string{"version"}
+	schema["anyOf"] = []interface{}{
+		map[string]interface{}{"required": []string{"items"}},
+		map[string]interface{}{"required": []string{"files"}},
+	}
+	return schema
+}
+
+// schemaConstraints returns the keywords added to the schema of each field,
+// keyed by <type>.<field>
+func schemaConstraints() map[string]map[string]interface{} {
+	return map[string]map[string]interface{}{
+		"AutoCopyConfig.version":         {"minimum": 1, "maximum": 2},
+		"AutoCopyConfig.ignoreTarget":    {"enum": []string{"gitignore", "exclude"}},
+		"AutoCopyConfig.maxConfirmFiles": {"minimum": 0},
+		"AutoCopyConfig.files":           {"deprecated": true, "description": "Version 1 list of paths, migrated to items"},
+		"AutoCopyItem.path":              {"minLength": 1, "not": map[string]interface{}{"pattern": `\.\.`}},
+		"AutoCopyItem.onConflict":        {"enum": autocopy.ConflictPolicies()},
+		"EditorConfig.preferred":         {"enum": preferredEditors},
+		"EditorConfig.order":             {"items": map[string]interface{}{"type": "string", "enum": editor.NewDetector().KnownCommands()}},
+		"GlobalConfig.outputFormat":      {"enum": outputFormats},
+		"GlobalConfig.mergeStrategy":     {"enum": []string{MergeStrategyReplace, MergeStrategyMerge}},
+		"GitConfig.maxConcurrent":        {"minimum": 0},
+		"SanitizeConfig.separator":       {"maxLength": 1},
+		"DoctorConfig.weights":           {"additionalProperties": map[string]interface{}{"type": "integer", "minimum": 0}},
+		"DoctorConfig.minGitVersion":     {"pattern": `\d+\.\d+`},
+		"Config.environments":            {"additionalProperties": map[string]interface{}{"$ref": "#/$defs/Config"}},
+	}
+}
+
+// schemaRequired lists the fields each type requires
+var schemaRequired = map[string][]string{
+	"AutoCopyItem": {"path"},
+}
+
+// typeSchema returns the schema of values of type t. Structs are described
+// once in defs and referenced.
+func typeSchema(t reflect.Type, defs map[string]interface{}, constraints map[string]map[string]interface{}) map[string]interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return typeSchema(t.Elem(), defs, constraints)
+	case reflect.Struct:
+		return structSchema(t, defs, constraints)
+	case reflect.Slice:
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), defs, constraints)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), defs, constraints)}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// structSchema adds the object schema of struct type t to defs and returns
+// a reference to it
+func structSchema(t reflect.Type, defs map[string]interface{}, constraints map[string]map[string]interface{}) map[string]interface{} {
+	ref := map[string]interface{}{"$ref": "#/$defs/" + t.Name()}
+	if _, ok := defs[t.Name()]; ok {
+		return ref
+	}
+	// Registered before the fields so recursive types terminate
+	defs[t.Name()] = nil
+
+	properties := map[string]interface{}{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		property := typeSchema(field.Type, defs, constraints)
+		for keyword, value := range constraints[t.Name()+"."+name] {
+			property[keyword] = value
+		}
+		properties[name] = property
+	}
+
+	object := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if required, ok := schemaRequired[t.Name()]; ok {
+		object["required"] = required
+	}
+	defs[t.Name()] = object
+	return ref
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema(t *testing.T) {
+	decode := func(t *testing.T, full bool) map[string]interface{} {
+		data, err := json.Marshal(Schema(full))
+		require.NoError(t, err)
+
+		var schema map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &schema))
+		return schema
+	}
+	def := func(schema map[string]interface{}, name string) map[string]interface{} {
+		return schema["$defs"].(map[string]interface{})[name].(map[string]interface{})
+	}
+	property := func(object map[string]interface{}, name string) map[string]interface{} {
+		return object["properties"].(map[string]interface{})[name].(map[string]interface{})
+	}
+
+	t.Run("auto-copy file", func(t *testing.T) {
+		schema := decode(t, false)
+		assert.Equal(t, SchemaDialect, schema["$schema"])
+		assert.Equal(t, "#/$defs/AutoCopyConfig", schema["$ref"])
+		assert.Equal(t, []interface{}{"version"}, schema["required"])
+
+		autoCopy := def(schema, "AutoCopyConfig")
+		items := property(autoCopy, "items")
+		assert.Equal(t, "array", items["type"])
+		assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/AutoCopyItem"}, items["items"])
+
+		version := property(autoCopy, "version")
+		assert.Equal(t, "integer", version["type"])
+		assert.Equal(t, float64(1), version["minimum"])
+		assert.Equal(t, float64(2), version["maximum"])
+
+		item := def(schema, "AutoCopyItem")
+		assert.Equal(t, []interface{}{"path"}, item["required"])
+		assert.Contains(t, property(item, "onConflict")["enum"], "newer")
+		assert.Equal(t, map[string]interface{}{"type": "boolean"}, property(item, "directory"))
+
+		// Only the auto-copy types are described
+		assert.NotContains(t, schema["$defs"], "EditorConfig")
+	})
+
+	t.Run("full configuration", func(t *testing.T) {
+		schema := decode(t, true)
+		assert.Equal(t, "#/$defs/Config", schema["$ref"])
+
+		assert.Equal(t, []interface{}{"cursor", "code", "vim", "nano"}, property(def(schema, "EditorConfig"), "preferred")["enum"])
+		assert.Contains(t, property(def(schema, "GlobalConfig"), "outputFormat")["enum"], "table")
+		assert.Equal(t, map[string]interface{}{"$ref": "#/$defs/Config"}, property(def(schema, "Config"), "environments")["additionalProperties"])
+	})
+
+	t.Run("describes every field of a saved config", func(t *testing.T) {
+		schema := decode(t, true)
+
+		data, err := json.Marshal(getDefaultConfig())
+		require.NoError(t, err)
+		var saved map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &saved))
+
+		for key := range saved {
+			assert.Contains(t, def(schema, "Config")["properties"], key)
+		}
+		for key := range saved["autocopy"].(map[string]interface{}) {
+			assert.Contains(t, def(schema, "AutoCopyConfig")["properties"], key)
+		}
+		for key := range saved["editor"].(map[string]interface{}) {
+			assert.Contains(t, def(schema, "EditorConfig")["properties"], key)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -11,7 +12,10 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
+	"github.com/keisukeshimizu/hatcher/internal/config"
 	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/internal/worktree"
 )
 
 // CheckStatus represents the status of a diagnostic check
@@ -34,11 +38,12 @@ type CheckResult struct {
 
 // DiagnosticSummary provides an overview of all checks
 type DiagnosticSummary struct {
-	Total   int  `json:"total"`
-	Passed  int  `json:"passed"`
-	Warned  int  `json:"warned"`
-	Failed  int  `json:"failed"`
-	Healthy bool `json:"healthy"`
+	Total       int  `json:"total"`
+	Passed      int  `json:"passed"`
+	Warned      int  `json:"warned"`
+	Failed      int  `json:"failed"`
+	Healthy     bool `json:"healthy"`
+	HealthScore int  `json:"healthScore"` // 0-100, see calculateSummary
 }
 
 // DiagnosticResult contains the results of all diagnostic checks
@@ -47,36 +52,196 @@ type DiagnosticResult struct {
 	Summary DiagnosticSummary `json:"summary"`
 }
 
+// DefaultCheckWeight is the health score weight of checks without a
+// configured weight
+const DefaultCheckWeight = 1
+
 // Checker performs system diagnostic checks
 type Checker struct {
-	repo git.Repository
+	repo          git.Repository
+	weights       map[string]int
+	minGitVersion git.Version
+	layout        worktree.Layout
+
+	// Measure disk space for CheckDiskSpace; replaced in tests
+	copyFootprint func(root string) (int64, error)
+	diskFree      func(path string) (uint64, error)
 }
 
 // NewChecker creates a new Checker instance
 func NewChecker(repo git.Repository) *Checker {
 	return &Checker{
-		repo: repo,
+		repo:          repo,
+		minGitVersion: git.MinimumVersion,
+		copyFootprint: autoCopyFootprint,
+		diskFree:      diskFree,
+	}
+}
+
+// SetWeights sets the health score weight of checks by key (see CheckKeys).
+// Checks without a weight use DefaultCheckWeight; a weight of 0 leaves the
+// check out of the score.
+func (c *Checker) SetWeights(weights map[string]int) error {
+	keys := c.CheckKeys()
+	for key, weight := range weights {
+		known := false
+		for _, k := range keys {
+			if k == key {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return fmt.Errorf("unknown check %q (available: %s)", key, strings.Join(keys, ", "))
+		}
+		if weight < 0 {
+			return fmt.Errorf("weight of check %q must not be negative: %d", key, weight)
+		}
+	}
+
+	c.weights = weights
+	return nil
+}
+
+// SetMinGitVersion sets the oldest Git version CheckGitVersion accepts
+// without a warning (default git.MinimumVersion)
+func (c *Checker) SetMinGitVersion(version git.Version) {
+	c.minGitVersion = version
+}
+
+// SetLayout sets where new worktrees are created, for CheckDiskSpace
+func (c *Checker) SetLayout(layout worktree.Layout) {
+	c.layout = layout
+}
+
+// weight returns the health score weight of the check with key
+func (c *Checker) weight(key string) int {
+	if weight, ok := c.weights[key]; ok {
+		return weight
 	}
+	return DefaultCheckWeight
+}
+
+// ProgressReporter is notified while CheckSystemWithProgress runs
+type ProgressReporter interface {
+	ChecksPlanned(names []string)
+	CheckStarted(name string)
+	CheckFinished(result CheckResult)
+}
+
+// plannedCheck is a check CheckSystem can run
+type plannedCheck struct {
+	key          string // Name used to select the check, e.g. with doctor --check
+	name         string
+	requiresRepo bool
+	run          func() CheckResult
+}
+
+// allChecks returns every check in the order they run
+func (c *Checker) allChecks() []plannedCheck {
+	return []plannedCheck{
+		{"git", "Git Installation", false, c.CheckGitInstallation},
+		{"git-version", "Git Version", false, c.CheckGitVersion},
+		{"repository", "Git Repository", true, c.CheckGitRepository},
+		{"worktrees", "Worktrees", true, c.CheckWorktrees},
+		{"configuration", "Configuration", true, c.CheckConfiguration},
+		{"config-validity", "Configuration Validity", true, c.CheckConfigValidity},
+		{"permissions", "Permissions", true, c.CheckPermissions},
+		{"copy-state", "Copy State", true, c.CheckCopyState},
+		{"disk-space", "Disk Space", true, c.CheckDiskSpace},
+		{"editors", "Editors", false, c.CheckEditors},
+	}
+}
+
+// CheckKeys returns the names that select individual checks
+func (c *Checker) CheckKeys() []string {
+	var keys []string
+	for _, check := range c.allChecks() {
+		keys = append(keys, check.key)
+	}
+	return keys
+}
+
+// plannedChecks returns the checks selected by keys, in run order. Without
+// keys all checks that apply are selected.
+func (c *Checker) plannedChecks(keys []string) ([]plannedCheck, error) {
+	all := c.allChecks()
+
+	selected := make(map[string]bool)
+	for _, key := range keys {
+		known := false
+		for _, check := range all {
+			if check.key == key {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown check %q (available: %s)", key, strings.Join(c.CheckKeys(), ", "))
+		}
+		selected[key] = true
+	}
+
+	var checks []plannedCheck
+	for _, check := range all {
+		if len(keys) > 0 && !selected[check.key] {
+			continue
+		}
+		if check.requiresRepo && c.repo == nil {
+			if len(keys) > 0 {
+				return nil, fmt.Errorf("check %q requires a Git repository", check.key)
+			}
+			continue
+		}
+		checks = append(checks, check)
+	}
+
+	return checks, nil
 }
 
 // CheckSystem runs all diagnostic checks
 func (c *Checker) CheckSystem() (*DiagnosticResult, error) {
-	var checks []CheckResult
+	return c.RunChecks(nil, nil)
+}
 
-	// Run all checks
-	checks = append(checks, c.CheckGitInstallation())
+// CheckSystemWithProgress runs all diagnostic checks, reporting each one to
+// reporter as it starts and finishes. reporter may be nil.
+func (c *Checker) CheckSystemWithProgress(reporter ProgressReporter) (*DiagnosticResult, error) {
+	return c.RunChecks(nil, reporter)
+}
 
-	if c.repo != nil {
-		checks = append(checks, c.CheckGitRepository())
-		checks = append(checks, c.CheckWorktrees())
-		checks = append(checks, c.CheckConfiguration())
-		checks = append(checks, c.CheckPermissions())
+// RunChecks runs the checks selected by keys (see CheckKeys), or all checks
+// when keys is empty, reporting progress to reporter if it is not nil
+func (c *Checker) RunChecks(keys []string, reporter ProgressReporter) (*DiagnosticResult, error) {
+	planned, err := c.plannedChecks(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	if reporter != nil {
+		names := make([]string, len(planned))
+		for i, check := range planned {
+			names[i] = check.name
+		}
+		reporter.ChecksPlanned(names)
 	}
 
-	checks = append(checks, c.CheckEditors())
+	var checks []CheckResult
+	var weights []int
+	for _, check := range planned {
+		if reporter != nil {
+			reporter.CheckStarted(check.name)
+		}
+		result := check.run()
+		if reporter != nil {
+			reporter.CheckFinished(result)
+		}
+		checks = append(checks, result)
+		weights = append(weights, c.weight(check.key))
+	}
 
 	// Calculate summary
-	summary := c.calculateSummary(checks)
+	summary := c.calculateSummary(checks, weights)
 
 	return &DiagnosticResult{
 		Checks:  checks,
@@ -92,8 +257,7 @@ func (c *Checker) CheckGitInstallation() CheckResult {
 	}
 
 	// Check if git command is available
-	cmd := exec.Command("git", "--version")
-	output, err := cmd.Output()
+	version, err := git.VersionOutput()
 	if err != nil {
 		result.Status = CheckStatusFail
 		result.Details = "Git is not installed or not in PATH"
@@ -104,14 +268,75 @@ func (c *Checker) CheckGitInstallation() CheckResult {
 		return result
 	}
 
-	// Parse version
-	version := strings.TrimSpace(string(output))
 	result.Status = CheckStatusPass
 	result.Details = fmt.Sprintf("Git is installed: %s", version)
 
 	return result
 }
 
+// CheckGitVersion checks that the installed Git is at least the required
+// version and supports the worktree features hatcher uses
+func (c *Checker) CheckGitVersion() CheckResult {
+	result := CheckResult{
+		Name:        "Git Version",
+		Description: fmt.Sprintf("Verify Git is %s or newer", c.minGitVersion),
+	}
+
+	var version git.Version
+	var err error
+	if c.repo != nil {
+		version, err = c.repo.GitVersion()
+	} else {
+		version, err = git.InstalledVersion()
+	}
+	if err != nil {
+		result.Status = CheckStatusWarn
+		result.Details = fmt.Sprintf("Could not determine the Git version: %v", err)
+		return result
+	}
+
+	return gitVersionResult(result, version, c.minGitVersion)
+}
+
+// gitVersionResult completes result for the installed Git version. Git
+// older than required warns; Git without 'git worktree add' fails, as
+// hatcher cannot create worktrees at all.
+func gitVersionResult(result CheckResult, version, required git.Version) CheckResult {
+	unsupported := git.UnsupportedFeatures(version)
+	if version.AtLeast(required) && len(unsupported) == 0 {
+		result.Status = CheckStatusPass
+		result.Details = fmt.Sprintf("Git %s (required: %s) supports all worktree features", version, required)
+		return result
+	}
+
+	result.Status = CheckStatusWarn
+	if !version.AtLeast(git.FeatureWorktreeAdd.MinVersion) {
+		result.Status = CheckStatusFail
+	}
+
+	if version.AtLeast(required) {
+		result.Details = fmt.Sprintf("Git %s (required: %s)", version, required)
+	} else {
+		result.Details = fmt.Sprintf("Git %s is older than the required %s", version, required)
+	}
+	if len(unsupported) > 0 {
+		names := make([]string, len(unsupported))
+		for i, feature := range unsupported {
+			names[i] = fmt.Sprintf("%s (%s)", feature.Name, feature.MinVersion)
+		}
+		result.Details += "; unavailable: " + strings.Join(names, ", ")
+	}
+
+	upgradeTo := required
+	if !upgradeTo.AtLeast(git.MinimumVersion) {
+		upgradeTo = git.MinimumVersion
+	}
+	result.Suggestions = []string{
+		fmt.Sprintf("Upgrade Git to %s or newer", upgradeTo),
+	}
+	return result
+}
+
 // CheckGitRepository checks the current Git repository
 func (c *Checker) CheckGitRepository() CheckResult {
 	result := CheckResult{
@@ -145,7 +370,7 @@ func (c *Checker) CheckGitRepository() CheckResult {
 	}
 
 	// Check for worktrees
-	worktrees, err := c.repo.ListWorktrees()
+	worktrees, err := c.repo.ListWorktreeEntries()
 	if err != nil {
 		result.Status = CheckStatusWarn
 		result.Details = fmt.Sprintf("Repository found at %s, but could not list worktrees", root)
@@ -172,13 +397,13 @@ func (c *Checker) CheckWorktrees() CheckResult {
 	}
 
 	// List worktrees
-	worktrees, err := c.repo.ListWorktrees()
+	worktrees, err := c.repo.ListWorktreeEntries()
 	if err != nil {
 		result.Status = CheckStatusFail
 		result.Details = "Failed to list worktrees"
 		result.Suggestions = []string{
 			"Check Git repository integrity",
-			"Run 'git worktree prune' to clean up stale entries",
+			"Run 'hch prune' to clean up stale entries",
 		}
 		return result
 	}
@@ -199,7 +424,7 @@ func (c *Checker) CheckWorktrees() CheckResult {
 		result.Status = CheckStatusWarn
 		result.Details = fmt.Sprintf("Found %d worktrees with %d missing directories", len(worktrees), len(issues))
 		result.Suggestions = []string{
-			"Run 'git worktree prune' to clean up missing worktrees",
+			"Run 'hch prune' to clean up missing worktrees",
 			"Recreate missing worktrees if needed",
 		}
 	} else {
@@ -289,6 +514,15 @@ func (c *Checker) CheckConfiguration() CheckResult {
 		suggestions = append(suggestions, "Create .hatcher-auto-copy.json for automatic file copying")
 	}
 
+	// Report how gitignored files inside copied directories are handled
+	if cfg, err := config.NewManager().LoadConfig(root); err == nil {
+		if cfg.AutoCopy.RespectGitignore {
+			details = append(details, "✓ Gitignored files are skipped inside copied directories")
+		} else {
+			details = append(details, "ℹ Gitignored files are copied; set autocopy.respectGitignore to skip them")
+		}
+	}
+
 	// Check for global configuration
 	homeDir, err := os.UserHomeDir()
 	if err == nil {
@@ -313,6 +547,168 @@ func (c *Checker) CheckConfiguration() CheckResult {
 	return result
 }
 
+// CheckConfigValidity loads the configuration like hatcher commands do and
+// reports config files that do not parse and settings ValidateConfig rejects
+func (c *Checker) CheckConfigValidity() CheckResult {
+	result := CheckResult{
+		Name:        "Configuration Validity",
+		Description: "Check that the configuration parses and validates",
+	}
+
+	if c.repo == nil {
+		result.Status = CheckStatusWarn
+		result.Details = "No Git repository context for configuration check"
+		return result
+	}
+
+	root, err := c.repo.GetRoot()
+	if err != nil {
+		result.Status = CheckStatusWarn
+		result.Details = "Could not determine repository root"
+		return result
+	}
+
+	manager := config.NewManager()
+	files := manager.ConfigFiles(root)
+
+	// The loader skips files it cannot parse, so check them first
+	var parseErrors []string
+	for _, path := range files {
+		if err := manager.ParseConfigFile(path); err != nil {
+			parseErrors = append(parseErrors, err.Error())
+		}
+	}
+	if len(parseErrors) > 0 {
+		result.Status = CheckStatusFail
+		result.Details = fmt.Sprintf("✗ %d configuration files cannot be parsed", len(parseErrors))
+		result.Suggestions = parseErrors
+		return result
+	}
+
+	cfg, err := manager.ReadConfig(root)
+	if err != nil {
+		result.Status = CheckStatusFail
+		result.Details = "✗ Configuration cannot be loaded"
+		result.Suggestions = []string{err.Error()}
+		return result
+	}
+
+	if validationErrors := manager.ValidateConfig(cfg); len(validationErrors) > 0 {
+		result.Status = CheckStatusFail
+		result.Details = fmt.Sprintf("✗ %d validation errors", len(validationErrors))
+		result.Suggestions = append(validationErrors, "Run 'hch config validate --fix' to repair common issues")
+		return result
+	}
+
+	result.Status = CheckStatusPass
+	if len(files) == 0 {
+		result.Details = "✓ No configuration files; using the defaults"
+	} else {
+		result.Details = "✓ Valid: " + strings.Join(files, ", ")
+	}
+	return result
+}
+
+// CheckCopyState aggregates the copy manifests of all worktrees: how many
+// files and bytes were auto-copied and which worktrees have copies that no
+// longer match the main worktree
+func (c *Checker) CheckCopyState() CheckResult {
+	result := CheckResult{
+		Name:        "Copy State",
+		Description: "Check auto-copied files across worktrees",
+	}
+
+	if c.repo == nil {
+		result.Status = CheckStatusWarn
+		result.Details = "No Git repository context for copy state check"
+		return result
+	}
+
+	worktrees, err := c.repo.ListWorktreeEntries()
+	if err != nil || len(worktrees) == 0 {
+		result.Status = CheckStatusWarn
+		result.Details = "Failed to list worktrees"
+		return result
+	}
+
+	// Git always lists the main worktree first; it is the source of the copies
+	srcRoot := worktrees[0].Path
+	var customManifest string
+	if cfg, err := config.NewManager().LoadConfig(srcRoot); err == nil {
+		customManifest = cfg.AutoCopy.ManifestPath
+	}
+
+	var manifests, files int
+	var copiedBytes int64
+	var stale, unreadable []string
+	for _, wt := range worktrees[1:] {
+		gitDir, err := c.repo.GitDir(wt.Path)
+		if err != nil {
+			continue
+		}
+		manifestPath := autocopy.ResolveManifestPath(wt.Path, gitDir, customManifest)
+		if _, err := os.Stat(manifestPath); err != nil {
+			continue
+		}
+
+		manifest, err := autocopy.LoadManifest(manifestPath)
+		if err != nil {
+			unreadable = append(unreadable, wt.Branch)
+			continue
+		}
+
+		manifests++
+		stats := manifest.Stats(srcRoot, wt.Path)
+		files += stats.Files
+		copiedBytes += stats.Bytes
+		if len(stats.Stale) > 0 {
+			stale = append(stale, fmt.Sprintf("%s (%d files)", wt.Branch, len(stats.Stale)))
+		}
+	}
+
+	if manifests == 0 && len(unreadable) == 0 {
+		result.Status = CheckStatusPass
+		result.Details = "No copy manifests found; run 'hch sync' to record copied files"
+		return result
+	}
+
+	details := []string{
+		fmt.Sprintf("✓ %d of %d worktrees have copy manifests", manifests, len(worktrees)-1),
+		fmt.Sprintf("✓ %d files copied (%s)", files, formatBytes(copiedBytes)),
+	}
+	if len(stale) > 0 {
+		details = append(details, fmt.Sprintf("✗ %d worktrees out of sync: %s", len(stale), strings.Join(stale, ", ")))
+		result.Suggestions = append(result.Suggestions, "Run 'hch sync --changed-only' to update outdated copies")
+	}
+	if len(unreadable) > 0 {
+		details = append(details, fmt.Sprintf("✗ Unreadable copy manifests: %s", strings.Join(unreadable, ", ")))
+		result.Suggestions = append(result.Suggestions, "Run 'hch sync' to rewrite unreadable manifests")
+	}
+
+	if len(result.Suggestions) > 0 {
+		result.Status = CheckStatusWarn
+	} else {
+		result.Status = CheckStatusPass
+	}
+	result.Details = strings.Join(details, "\n")
+
+	return result
+}
+
+// formatBytes formats a byte count, e.g. "512 B" or "3.4 KB"
+func formatBytes(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	case size < 1024*1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	default:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1024*1024*1024))
+	}
+}
+
 // CheckPermissions checks file and directory permissions
 func (c *Checker) CheckPermissions() CheckResult {
 	result := CheckResult{
@@ -392,24 +788,41 @@ func (c *Checker) isEditorAvailable(command string) bool {
 	return err == nil && len(strings.TrimSpace(string(output))) > 0
 }
 
-// calculateSummary calculates the diagnostic summary
-func (c *Checker) calculateSummary(checks []CheckResult) DiagnosticSummary {
+// calculateSummary calculates the diagnostic summary. weights holds the
+// weight of each check. The health score is the weighted share of points
+// earned, where a pass earns 1, a warning 0.5 and a failure 0:
+//
+//	healthScore = round(100 * sum(weight * points) / sum(weight))
+//
+// Without weighted checks the score is 100.
+func (c *Checker) calculateSummary(checks []CheckResult, weights []int) DiagnosticSummary {
 	summary := DiagnosticSummary{
 		Total: len(checks),
 	}
 
-	for _, check := range checks {
+	var earned float64
+	var total int
+	for i, check := range checks {
+		weight := weights[i]
+		total += weight
+
 		switch check.Status {
 		case CheckStatusPass:
 			summary.Passed++
+			earned += float64(weight)
 		case CheckStatusWarn:
 			summary.Warned++
+			earned += float64(weight) / 2
 		case CheckStatusFail:
 			summary.Failed++
 		}
 	}
 
 	summary.Healthy = summary.Failed == 0
+	summary.HealthScore = 100
+	if total > 0 {
+		summary.HealthScore = int(math.Round(100 * earned / float64(total)))
+	}
 
 	return summary
 }
@@ -425,28 +838,19 @@ func (r *DiagnosticResult) FormatAsTable() string {
 
 	// Rows
 	for _, check := range r.Checks {
-		var status string
-		switch check.Status {
-		case CheckStatusPass:
-			status = "PASS"
-		case CheckStatusWarn:
-			status = "WARN"
-		case CheckStatusFail:
-			status = "FAIL"
-		}
-
-		// Truncate details for table display
-		details := check.Details
-		if len(details) > 60 {
-			details = details[:57] + "..."
-		}
-
-		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, status, details)
+		fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, tableStatus(check.Status), tableDetails(check.Details))
 	}
 
 	w.Flush()
 
-	// Add summary
+	output.WriteString(r.FormatTableSummary())
+	return output.String()
+}
+
+// FormatTableSummary formats the summary printed below the table
+func (r *DiagnosticResult) FormatTableSummary() string {
+	var output strings.Builder
+
 	fmt.Fprintf(&output, "\nSummary: %d total, %d passed, %d warned, %d failed\n",
 		r.Summary.Total, r.Summary.Passed, r.Summary.Warned, r.Summary.Failed)
 
@@ -459,6 +863,29 @@ func (r *DiagnosticResult) FormatAsTable() string {
 	return output.String()
 }
 
+// tableStatus returns the label of a status in table output
+func tableStatus(status CheckStatus) string {
+	switch status {
+	case CheckStatusPass:
+		return "PASS"
+	case CheckStatusWarn:
+		return "WARN"
+	case CheckStatusFail:
+		return "FAIL"
+	}
+	return ""
+}
+
+// tableDetails puts multi-line details on one line and truncates them for
+// table display
+func tableDetails(details string) string {
+	details = strings.ReplaceAll(details, "\n", "; ")
+	if runes := []rune(details); len(runes) > 60 {
+		return string(runes[:57]) + "..."
+	}
+	return details
+}
+
 // FormatAsJSON formats the diagnostic result as JSON
 func (r *DiagnosticResult) FormatAsJSON() string {
 	data, err := json.MarshalIndent(r, "", "  ")
@@ -473,27 +900,40 @@ func (r *DiagnosticResult) FormatAsSimple() string {
 	var output strings.Builder
 
 	for _, check := range r.Checks {
-		var icon string
-		switch check.Status {
-		case CheckStatusPass:
-			icon = "✅"
-		case CheckStatusWarn:
-			icon = "⚠️"
-		case CheckStatusFail:
-			icon = "❌"
-		}
+		output.WriteString(formatSimpleCheck(check))
+	}
 
-		fmt.Fprintf(&output, "%s %s: %s\n", icon, check.Name, check.Details)
+	output.WriteString(r.FormatSimpleSummary())
+	return output.String()
+}
 
-		// Add suggestions if any
-		for _, suggestion := range check.Suggestions {
-			fmt.Fprintf(&output, "   💡 %s\n", suggestion)
-		}
+// FormatSimpleSummary formats the summary printed after the simple list
+func (r *DiagnosticResult) FormatSimpleSummary() string {
+	return fmt.Sprintf("\n📊 Summary: %d total, %d passed, %d warned, %d failed\n",
+		r.Summary.Total, r.Summary.Passed, r.Summary.Warned, r.Summary.Failed)
+}
+
+// formatSimpleCheck formats a single check and its suggestions for the
+// simple list
+func formatSimpleCheck(check CheckResult) string {
+	var output strings.Builder
+
+	var icon string
+	switch check.Status {
+	case CheckStatusPass:
+		icon = "✅"
+	case CheckStatusWarn:
+		icon = "⚠️"
+	case CheckStatusFail:
+		icon = "❌"
 	}
 
-	// Add summary
-	fmt.Fprintf(&output, "\n📊 Summary: %d total, %d passed, %d warned, %d failed\n",
-		r.Summary.Total, r.Summary.Passed, r.Summary.Warned, r.Summary.Failed)
+	fmt.Fprintf(&output, "%s %s: %s\n", icon, check.Name, check.Details)
+
+	// Add suggestions if any
+	for _, suggestion := range check.Suggestions {
+		fmt.Fprintf(&output, "   💡 %s\n", suggestion)
+	}
 
 	return output.String()
 }
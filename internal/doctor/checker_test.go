@@ -1,10 +1,14 @@
 package doctor
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/keisukeshimizu/hatcher/internal/autocopy"
 	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
 	"github.com/stretchr/testify/assert"
@@ -70,6 +74,67 @@ func TestChecker_CheckGitInstallation(t *testing.T) {
 	})
 }
 
+func TestChecker_CheckGitVersion(t *testing.T) {
+	base := CheckResult{Name: "Git Version"}
+
+	t.Run("supported version", func(t *testing.T) {
+		result := gitVersionResult(base, git.Version{Major: 2, Minor: 43, Patch: 0}, git.MinimumVersion)
+		assert.Equal(t, CheckStatusPass, result.Status)
+		assert.Contains(t, result.Details, "2.43.0")
+		assert.Contains(t, result.Details, "required: 2.17.0")
+	})
+
+	t.Run("old version lists unavailable features", func(t *testing.T) {
+		result := gitVersionResult(base, git.Version{Major: 2, Minor: 10, Patch: 0}, git.MinimumVersion)
+		assert.Equal(t, CheckStatusWarn, result.Status)
+		assert.Contains(t, result.Details, "git worktree remove (2.17.0)")
+		assert.NotContains(t, result.Details, "git worktree add")
+		assert.NotEmpty(t, result.Suggestions)
+	})
+
+	tests := []struct {
+		output   string
+		required git.Version
+		status   CheckStatus
+		details  string
+	}{
+		{"git version 2.39.3 (Apple Git-145)", git.MinimumVersion, CheckStatusPass, "Git 2.39.3 (required: 2.17.0)"},
+		{"git version 2.42.0.windows.1\n", git.MinimumVersion, CheckStatusPass, "Git 2.42.0"},
+		{"git version 2.17", git.MinimumVersion, CheckStatusPass, "Git 2.17.0"},
+		{"git version 2.16.6", git.MinimumVersion, CheckStatusWarn, "older than the required 2.17.0"},
+		{"git version 2.39.3 (Apple Git-145)", git.Version{Major: 2, Minor: 40}, CheckStatusWarn, "Git 2.39.3 is older than the required 2.40.0"},
+		{"git version 2.10.0", git.Version{Major: 2, Minor: 5}, CheckStatusWarn, "Git 2.10.0 (required: 2.5.0); unavailable"},
+		{"git version 1.9.5.msysgit.1", git.MinimumVersion, CheckStatusFail, "unavailable: git worktree add (2.5.0)"},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s requires %s", strings.TrimSpace(tt.output), tt.required), func(t *testing.T) {
+			version, err := git.ParseVersion(tt.output)
+			require.NoError(t, err)
+
+			result := gitVersionResult(base, version, tt.required)
+			assert.Equal(t, tt.status, result.Status)
+			assert.Contains(t, result.Details, tt.details)
+		})
+	}
+
+	t.Run("configured minimum", func(t *testing.T) {
+		checker := NewChecker(nil)
+		checker.SetMinGitVersion(git.Version{Major: 99})
+
+		result := checker.CheckGitVersion()
+		assert.Equal(t, CheckStatusWarn, result.Status)
+		assert.Contains(t, result.Description, "99.0.0")
+		assert.Contains(t, result.Details, "older than the required 99.0.0")
+		assert.Equal(t, []string{"Upgrade Git to 99.0.0 or newer"}, result.Suggestions)
+	})
+
+	t.Run("installed git", func(t *testing.T) {
+		result := NewChecker(nil).CheckGitVersion()
+		assert.Equal(t, "Git Version", result.Name)
+		assert.NotEqual(t, CheckStatusFail, result.Status)
+	})
+}
+
 func TestChecker_CheckGitRepository(t *testing.T) {
 	// Create test repository
 	testRepo := testutil.NewTestGitRepository(t, "git-repo-test")
@@ -204,6 +269,174 @@ func TestChecker_CheckConfiguration(t *testing.T) {
 	})
 }
 
+func TestChecker_CheckConfigValidity(t *testing.T) {
+	env := testutil.NewMockEnvironment(t)
+	env.SetEnv("HOME", t.TempDir())
+
+	testRepo := testutil.NewTestGitRepository(t, "config-validity-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	checker := NewChecker(repo)
+	configPath := filepath.Join(testRepo.RepoDir, ".hatcher-auto-copy.json")
+
+	t.Run("no configuration", func(t *testing.T) {
+		result := checker.CheckConfigValidity()
+		assert.Equal(t, "Configuration Validity", result.Name)
+		assert.Equal(t, CheckStatusPass, result.Status)
+	})
+
+	t.Run("valid configuration", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"version": 2, "items": [{"path": ".env"}]}`), 0644))
+
+		result := checker.CheckConfigValidity()
+		assert.Equal(t, CheckStatusPass, result.Status)
+		assert.Contains(t, result.Details, configPath)
+		assert.Empty(t, result.Suggestions)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte(`{
+			"version": 2,
+			"items": [
+				{"path": "../outside"},
+				{"path": ".env", "onConflict": "clobber"}
+			]
+		}`), 0644))
+
+		result := checker.CheckConfigValidity()
+		assert.Equal(t, CheckStatusFail, result.Status)
+		assert.Contains(t, result.Details, "2 validation errors")
+		assert.Contains(t, result.Suggestions, "autocopy item 0 contains invalid path: ../outside")
+		assert.Contains(t, strings.Join(result.Suggestions, "\n"), "autocopy item 1:")
+		assert.Contains(t, strings.Join(result.Suggestions, "\n"), "clobber")
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(configPath, []byte(`{"version": 2, "items": [`), 0644))
+
+		result := checker.CheckConfigValidity()
+		assert.Equal(t, CheckStatusFail, result.Status)
+		assert.Contains(t, result.Details, "cannot be parsed")
+		require.Len(t, result.Suggestions, 1)
+		assert.Contains(t, result.Suggestions[0], "failed to parse "+configPath)
+	})
+}
+
+func TestChecker_CheckCopyState(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "copy-state-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	checker := NewChecker(repo)
+
+	worktreePath := filepath.Join(testRepo.TempDir, "copy-state-test-feature")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/copy-state", true))
+
+	t.Run("no manifests", func(t *testing.T) {
+		result := checker.CheckCopyState()
+		assert.Equal(t, "Copy State", result.Name)
+		assert.Equal(t, CheckStatusPass, result.Status)
+		assert.Contains(t, result.Details, "No copy manifests")
+	})
+
+	testRepo.CreateFile("CLAUDE.md", "rules")
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "CLAUDE.md"), []byte("rules"), 0644))
+	info, err := os.Stat(filepath.Join(testRepo.RepoDir, "CLAUDE.md"))
+	require.NoError(t, err)
+	gitDir, err := repo.GitDir(worktreePath)
+	require.NoError(t, err)
+	manifest := &autocopy.Manifest{Files: map[string]autocopy.ManifestEntry{
+		"CLAUDE.md": {Source: "CLAUDE.md", Size: info.Size(), ModTime: info.ModTime()},
+	}}
+	require.NoError(t, manifest.Save(autocopy.ManifestPath(gitDir)))
+
+	t.Run("in sync", func(t *testing.T) {
+		result := checker.CheckCopyState()
+		assert.Equal(t, CheckStatusPass, result.Status)
+		assert.Contains(t, result.Details, "1 of 1 worktrees have copy manifests")
+		assert.Contains(t, result.Details, "1 files copied (5 B)")
+	})
+
+	t.Run("out of sync", func(t *testing.T) {
+		testRepo.CreateFile("CLAUDE.md", "new rules")
+
+		result := checker.CheckCopyState()
+		assert.Equal(t, CheckStatusWarn, result.Status)
+		assert.Contains(t, result.Details, "1 worktrees out of sync: feature/copy-state (1 files)")
+		assert.NotEmpty(t, result.Suggestions)
+	})
+}
+
+func TestChecker_CheckDiskSpace(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "disk-space-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	const footprint = 100 * 1024 * 1024
+	checker := NewChecker(repo)
+	checker.copyFootprint = func(root string) (int64, error) { return footprint, nil }
+
+	withFree := func(free uint64) {
+		checker.diskFree = func(path string) (uint64, error) {
+			assert.Equal(t, testRepo.TempDir, path)
+			return free, nil
+		}
+	}
+
+	t.Run("enough space", func(t *testing.T) {
+		withFree(footprint * 3)
+		result := checker.CheckDiskSpace()
+		assert.Equal(t, "Disk Space", result.Name)
+		assert.Equal(t, CheckStatusPass, result.Status)
+		assert.Contains(t, result.Details, "Auto-copy footprint 100.0 MB, 300.0 MB free")
+		assert.Empty(t, result.Suggestions)
+	})
+
+	t.Run("under twice the footprint", func(t *testing.T) {
+		withFree(footprint * 3 / 2)
+		result := checker.CheckDiskSpace()
+		assert.Equal(t, CheckStatusWarn, result.Status)
+		assert.NotEmpty(t, result.Suggestions)
+	})
+
+	t.Run("under the footprint", func(t *testing.T) {
+		withFree(footprint / 2)
+		result := checker.CheckDiskSpace()
+		assert.Equal(t, CheckStatusFail, result.Status)
+		assert.Contains(t, result.Details, "Not enough space")
+	})
+
+	t.Run("measures the real filesystem", func(t *testing.T) {
+		free, err := diskFree(testRepo.TempDir)
+		require.NoError(t, err)
+		assert.Greater(t, free, uint64(0))
+	})
+
+	t.Run("free space unknown", func(t *testing.T) {
+		checker.diskFree = func(path string) (uint64, error) { return 0, errors.New("statfs failed") }
+		result := checker.CheckDiskSpace()
+		assert.Equal(t, CheckStatusWarn, result.Status)
+		assert.Contains(t, result.Details, "statfs failed")
+	})
+}
+
+func TestAutoCopyFootprint(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "footprint-test")
+	testRepo.CreateFile(".hatcher/config.json", `{"autocopy": {"version": 2, "items": [
+		{"path": ".ai/", "directory": true, "recursive": true},
+		{"path": "CLAUDE.md", "directory": false},
+		{"path": "missing.txt", "directory": false}
+	]}}`)
+	testRepo.CreateFile(".ai/rules.md", "12345")
+	testRepo.CreateFile(".ai/nested/prompt.md", "123")
+	testRepo.CreateFile("CLAUDE.md", "12")
+
+	footprint, err := autoCopyFootprint(testRepo.RepoDir)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), footprint)
+}
+
 func TestChecker_CheckPermissions(t *testing.T) {
 	// Create test repository
 	testRepo := testutil.NewTestGitRepository(t, "permissions-test")
@@ -323,3 +556,99 @@ func TestDiagnosticResult_GetOverallStatus(t *testing.T) {
 		assert.Equal(t, CheckStatusFail, status)
 	})
 }
+
+func TestChecker_RunChecks(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "doctor-select-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	checker := NewChecker(repo)
+
+	t.Run("run a single check", func(t *testing.T) {
+		result, err := checker.RunChecks([]string{"git"}, nil)
+		require.NoError(t, err)
+		require.Len(t, result.Checks, 1)
+		assert.Equal(t, "Git Installation", result.Checks[0].Name)
+		assert.Equal(t, 1, result.Summary.Total)
+	})
+
+	t.Run("multiple checks keep run order", func(t *testing.T) {
+		result, err := checker.RunChecks([]string{"editors", "worktrees"}, nil)
+		require.NoError(t, err)
+		require.Len(t, result.Checks, 2)
+		assert.Equal(t, "Worktrees", result.Checks[0].Name)
+		assert.Equal(t, "Editors", result.Checks[1].Name)
+	})
+
+	t.Run("unknown check lists available names", func(t *testing.T) {
+		_, err := checker.RunChecks([]string{"bogus"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown check "bogus"`)
+		for _, key := range checker.CheckKeys() {
+			assert.Contains(t, err.Error(), key)
+		}
+	})
+
+	t.Run("repository checks need a repository", func(t *testing.T) {
+		_, err := NewChecker(nil).RunChecks([]string{"worktrees"}, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires a Git repository")
+
+		result, err := NewChecker(nil).RunChecks([]string{"git"}, nil)
+		require.NoError(t, err)
+		assert.Len(t, result.Checks, 1)
+	})
+}
+
+func TestChecker_HealthScore(t *testing.T) {
+	checks := []CheckResult{
+		{Name: "Pass", Status: CheckStatusPass},
+		{Name: "Warn", Status: CheckStatusWarn},
+		{Name: "Fail", Status: CheckStatusFail},
+	}
+	checker := NewChecker(nil)
+
+	t.Run("equal weights", func(t *testing.T) {
+		summary := checker.calculateSummary(checks, []int{1, 1, 1})
+		assert.Equal(t, 50, summary.HealthScore)
+		assert.False(t, summary.Healthy)
+	})
+
+	t.Run("weighted checks", func(t *testing.T) {
+		// (3*1 + 1*0.5 + 0*0) / 4
+		summary := checker.calculateSummary(checks, []int{3, 1, 0})
+		assert.Equal(t, 88, summary.HealthScore)
+	})
+
+	t.Run("all passing", func(t *testing.T) {
+		summary := checker.calculateSummary(checks[:1], []int{1})
+		assert.Equal(t, 100, summary.HealthScore)
+		assert.True(t, summary.Healthy)
+	})
+
+	t.Run("no weighted checks", func(t *testing.T) {
+		assert.Equal(t, 100, checker.calculateSummary(nil, nil).HealthScore)
+		assert.Equal(t, 100, checker.calculateSummary(checks, []int{0, 0, 0}).HealthScore)
+	})
+
+	t.Run("configured weights apply to checks", func(t *testing.T) {
+		checker := NewChecker(nil)
+		require.NoError(t, checker.SetWeights(map[string]int{"editors": 0}))
+
+		result, err := checker.RunChecks([]string{"git", "editors"}, nil)
+		require.NoError(t, err)
+		if result.Checks[0].Status == CheckStatusPass {
+			assert.Equal(t, 100, result.Summary.HealthScore)
+		}
+		assert.Contains(t, result.FormatAsJSON(), `"healthScore":`)
+	})
+
+	t.Run("invalid weights", func(t *testing.T) {
+		err := checker.SetWeights(map[string]int{"unknown": 1})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "available")
+
+		err = checker.SetWeights(map[string]int{"git": -1})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,124 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/config"
+)
+
+// diskSpaceMargin is the multiple of the auto-copy footprint below which
+// free space is reported as low
+const diskSpaceMargin = 2
+
+// CheckDiskSpace compares the free space where worktrees are created with
+// the size of the files auto-copy puts into each new worktree
+func (c *Checker) CheckDiskSpace() CheckResult {
+	result := CheckResult{
+		Name:        "Disk Space",
+		Description: "Check free space for auto-copied files",
+	}
+
+	if c.repo == nil {
+		result.Status = CheckStatusWarn
+		result.Details = "No Git repository context for disk space check"
+		return result
+	}
+
+	root, err := c.repo.GetRoot()
+	if err != nil {
+		result.Status = CheckStatusWarn
+		result.Details = "Could not determine repository root"
+		return result
+	}
+
+	footprint, err := c.copyFootprint(root)
+	if err != nil {
+		result.Status = CheckStatusWarn
+		result.Details = fmt.Sprintf("Could not estimate the auto-copy footprint: %v", err)
+		return result
+	}
+
+	// New worktrees are created next to each other in the base directory
+	targetDir := existingAncestor(filepath.Dir(c.layout.WorktreePath(root, c.repo.GetProjectName(), "disk-space")))
+	free, err := c.diskFree(targetDir)
+	if err != nil {
+		result.Status = CheckStatusWarn
+		result.Details = fmt.Sprintf("Could not determine free space on %s: %v", targetDir, err)
+		return result
+	}
+
+	details := fmt.Sprintf("Auto-copy footprint %s, %s free on %s", formatBytes(footprint), formatBytes(int64(free)), targetDir)
+	switch {
+	case free < uint64(footprint):
+		result.Status = CheckStatusFail
+		result.Details = "✗ Not enough space for one worktree's copies: " + details
+	case free < uint64(footprint)*diskSpaceMargin:
+		result.Status = CheckStatusWarn
+		result.Details = "✗ Free space is low: " + details
+	default:
+		result.Status = CheckStatusPass
+		result.Details = "✓ " + details
+		return result
+	}
+
+	result.Suggestions = []string{
+		fmt.Sprintf("Free up space on the filesystem holding %s", targetDir),
+		"Exclude large directories from the auto-copy items",
+	}
+	return result
+}
+
+// autoCopyFootprint returns the bytes of the files below the auto-copy items
+// configured for root. Directories count completely, so filters can only
+// make a copy smaller.
+func autoCopyFootprint(root string) (int64, error) {
+	cfg, err := config.NewManager().LoadConfig(root)
+	if err != nil {
+		return 0, err
+	}
+
+	paths := append([]string(nil), cfg.AutoCopy.Files...)
+	for _, item := range cfg.AutoCopy.Items {
+		paths = append(paths, item.Path)
+	}
+
+	var total int64
+	for _, path := range paths {
+		err := filepath.WalkDir(filepath.Join(root, filepath.FromSlash(path)), func(walkPath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.Type().IsRegular() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			total += info.Size()
+			return nil
+		})
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return 0, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	return total, nil
+}
+
+// existingAncestor returns path or its closest existing parent directory
+func existingAncestor(path string) string {
+	for {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return path
+		}
+		path = parent
+	}
+}
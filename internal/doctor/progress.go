@@ -0,0 +1,113 @@
+package doctor
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// spinnerFrames are shown in turn while a check runs
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// ProgressPrinter prints each check's result as soon as it finishes, in
+// table or simple format. The summary is left to the caller.
+type ProgressPrinter struct {
+	out     io.Writer
+	format  string
+	spinner bool
+
+	nameWidth int
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewProgressPrinter creates a printer writing to out. format is "table" or
+// "simple"; spinner enables an animated indicator while a check runs, which
+// only makes sense on a terminal.
+func NewProgressPrinter(out io.Writer, format string, spinner bool) *ProgressPrinter {
+	return &ProgressPrinter{
+		out:     out,
+		format:  format,
+		spinner: spinner,
+	}
+}
+
+// ChecksPlanned prints the table header, sized for the planned checks
+func (p *ProgressPrinter) ChecksPlanned(names []string) {
+	if p.format != "table" {
+		return
+	}
+
+	p.nameWidth = len("CHECK")
+	for _, name := range names {
+		if len(name) > p.nameWidth {
+			p.nameWidth = len(name)
+		}
+	}
+
+	p.printRow("CHECK", "STATUS", "DETAILS")
+	p.printRow("-----", "------", "-------")
+}
+
+// CheckStarted starts the spinner for a running check
+func (p *ProgressPrinter) CheckStarted(name string) {
+	if !p.spinner {
+		return
+	}
+
+	p.stop = make(chan struct{})
+	p.done = make(chan struct{})
+	go p.spin(name, p.stop, p.done)
+}
+
+// CheckFinished clears the spinner and prints the check's result
+func (p *ProgressPrinter) CheckFinished(result CheckResult) {
+	if p.stop != nil {
+		close(p.stop)
+		<-p.done
+		p.stop = nil
+	}
+
+	if p.format == "table" {
+		p.printRow(result.Name, tableStatus(result.Status), tableDetails(result.Details))
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	fmt.Fprint(p.out, formatSimpleCheck(result))
+}
+
+// spin redraws the spinner line until stop is closed, then erases it
+func (p *ProgressPrinter) spin(name string, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		p.mu.Lock()
+		fmt.Fprintf(p.out, "\r%s Checking %s...", spinnerFrames[frame%len(spinnerFrames)], name)
+		p.mu.Unlock()
+
+		select {
+		case <-stop:
+			p.mu.Lock()
+			fmt.Fprint(p.out, "\r\033[K")
+			p.mu.Unlock()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// printRow prints a table row aligned like FormatAsTable
+func (p *ProgressPrinter) printRow(name, status, details string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	fmt.Fprintf(p.out, "%-*s  %-6s  %s\n", p.nameWidth, name, status, details)
+}
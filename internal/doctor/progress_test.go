@@ -0,0 +1,73 @@
+package doctor
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingReporter records progress events in order
+type recordingReporter struct {
+	planned []string
+	events  []string
+}
+
+func (r *recordingReporter) ChecksPlanned(names []string) { r.planned = names }
+func (r *recordingReporter) CheckStarted(name string)     { r.events = append(r.events, "start "+name) }
+func (r *recordingReporter) CheckFinished(result CheckResult) {
+	r.events = append(r.events, "finish "+result.Name)
+}
+
+func TestChecker_CheckSystemWithProgress(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "doctor-progress-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	checker := NewChecker(repo)
+
+	t.Run("reports each check as it runs", func(t *testing.T) {
+		reporter := &recordingReporter{}
+		result, err := checker.CheckSystemWithProgress(reporter)
+		require.NoError(t, err)
+
+		require.Len(t, reporter.planned, len(result.Checks))
+		var expected []string
+		for i, check := range result.Checks {
+			assert.Equal(t, reporter.planned[i], check.Name)
+			expected = append(expected, "start "+check.Name, "finish "+check.Name)
+		}
+		assert.Equal(t, expected, reporter.events)
+	})
+
+	t.Run("table rows match the buffered table", func(t *testing.T) {
+		var output bytes.Buffer
+		result, err := checker.CheckSystemWithProgress(NewProgressPrinter(&output, "table", false))
+		require.NoError(t, err)
+
+		table := result.FormatAsTable()
+		assert.Equal(t, strings.TrimSuffix(table, result.FormatTableSummary()), output.String())
+	})
+
+	t.Run("simple lines match the buffered list", func(t *testing.T) {
+		var output bytes.Buffer
+		result, err := checker.CheckSystemWithProgress(NewProgressPrinter(&output, "simple", false))
+		require.NoError(t, err)
+
+		simple := result.FormatAsSimple()
+		assert.Equal(t, strings.TrimSuffix(simple, result.FormatSimpleSummary()), output.String())
+	})
+
+	t.Run("spinner is cleared before each result", func(t *testing.T) {
+		var output bytes.Buffer
+		_, err := checker.CheckSystemWithProgress(NewProgressPrinter(&output, "simple", true))
+		require.NoError(t, err)
+
+		assert.Contains(t, output.String(), "Checking Git Installation...")
+		assert.Contains(t, output.String(), "\r\033[K✅ Git Installation")
+	})
+}
@@ -1,6 +1,7 @@
 package editor
 
 import (
+	"fmt"
 	"os/exec"
 	"sort"
 	"strings"
@@ -30,6 +31,7 @@ type EditorInfo struct {
 // Detector handles editor detection
 type Detector struct {
 	editors []EditorInfo
+	order   []string // Commands tried first, overriding priority
 }
 
 // NewDetector creates a new editor detector
@@ -52,7 +54,31 @@ func NewDetector() *Detector {
 	}
 }
 
-// DetectAvailable returns all available editors sorted by priority
+// KnownCommands returns the commands of the editors the detector knows
+func (d *Detector) KnownCommands() []string {
+	commands := make([]string, len(d.editors))
+	for i, info := range d.editors {
+		commands[i] = info.Command
+	}
+	return commands
+}
+
+// SetOrder makes the detector prefer editors in the given order of commands.
+// Editors missing from order follow by priority. Unknown commands are
+// rejected.
+func (d *Detector) SetOrder(order []string) error {
+	for _, command := range order {
+		if d.GetEditorByName(command) == nil {
+			return fmt.Errorf("unknown editor %q (available: %s)", command, strings.Join(d.KnownCommands(), ", "))
+		}
+	}
+
+	d.order = order
+	return nil
+}
+
+// DetectAvailable returns all available editors sorted by the configured
+// order, then by priority
 func (d *Detector) DetectAvailable() []Editor {
 	var available []Editor
 
@@ -63,15 +89,32 @@ func (d *Detector) DetectAvailable() []Editor {
 		}
 	}
 
-	// Sort by priority (lower number = higher priority)
-	sort.Slice(available, func(i, j int) bool {
-		return available[i].Priority() < available[j].Priority()
-	})
-
+	sortEditors(available, d.order)
 	return available
 }
 
-// GetBestEditor returns the best available editor (highest priority)
+// sortEditors sorts editors by their position in order, placing editors
+// missing from order after them by priority (lower number = higher priority)
+func sortEditors(editors []Editor, order []string) {
+	rank := func(e Editor) int {
+		for i, command := range order {
+			if command == e.Command() {
+				return i
+			}
+		}
+		return len(order)
+	}
+
+	sort.SliceStable(editors, func(i, j int) bool {
+		if ri, rj := rank(editors[i]), rank(editors[j]); ri != rj {
+			return ri < rj
+		}
+		return editors[i].Priority() < editors[j].Priority()
+	})
+}
+
+// GetBestEditor returns the best available editor, the first installed one
+// of the configured order or else the one with the highest priority
 func (d *Detector) GetBestEditor() Editor {
 	available := d.DetectAvailable()
 	if len(available) > 0 {
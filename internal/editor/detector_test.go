@@ -234,6 +234,52 @@ func TestEditorPriority(t *testing.T) {
 	})
 }
 
+func TestEditorOrder(t *testing.T) {
+	commands := func(editors []Editor) []string {
+		var result []string
+		for _, e := range editors {
+			result = append(result, e.Command())
+		}
+		return result
+	}
+	newEditors := func() []Editor {
+		return []Editor{
+			NewEditor(&EditorInfo{Name: "Cursor", Command: "cursor", Priority: 1}),
+			NewEditor(&EditorInfo{Name: "VS Code", Command: "code", Priority: 2}),
+			NewEditor(&EditorInfo{Name: "Other", Command: "other", Priority: 3}),
+		}
+	}
+
+	t.Run("priority without order", func(t *testing.T) {
+		editors := newEditors()
+		sortEditors(editors, nil)
+		assert.Equal(t, []string{"cursor", "code", "other"}, commands(editors))
+	})
+
+	t.Run("order overrides priority", func(t *testing.T) {
+		editors := newEditors()
+		sortEditors(editors, []string{"code", "cursor"})
+		assert.Equal(t, []string{"code", "cursor", "other"}, commands(editors))
+	})
+
+	t.Run("unlisted editors follow by priority", func(t *testing.T) {
+		editors := newEditors()
+		sortEditors(editors, []string{"other"})
+		assert.Equal(t, []string{"other", "cursor", "code"}, commands(editors))
+	})
+
+	t.Run("set order", func(t *testing.T) {
+		detector := NewDetector()
+		require.NoError(t, detector.SetOrder([]string{"code", "cursor"}))
+		assert.Equal(t, []string{"code", "cursor"}, detector.order)
+
+		err := detector.SetOrder([]string{"zed"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "zed")
+		assert.Equal(t, []string{"code", "cursor"}, detector.order)
+	})
+}
+
 func TestEditorDetection_Integration(t *testing.T) {
 	t.Run("full detection workflow", func(t *testing.T) {
 		detector := NewDetector()
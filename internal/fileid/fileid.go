@@ -0,0 +1,9 @@
+// Package fileid identifies the file behind a path, so hardlinked copies can
+// be recognized as sharing their content.
+package fileid
+
+// ID identifies the inode behind a file
+type ID struct {
+	dev uint64
+	ino uint64
+}
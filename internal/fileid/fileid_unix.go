@@ -0,0 +1,17 @@
+//go:build !windows
+
+package fileid
+
+import (
+	"os"
+	"syscall"
+)
+
+// Of returns the inode of the file info describes
+func Of(info os.FileInfo) (ID, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return ID{}, false
+	}
+	return ID{dev: uint64(stat.Dev), ino: uint64(stat.Ino)}, true
+}
@@ -0,0 +1,68 @@
+// Package filelock serializes updates to shared files such as .gitignore
+// across goroutines and hatcher processes.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// lockSuffix is appended to the guarded file's path to name its lockfile
+const lockSuffix = ".lock"
+
+const (
+	// retryInterval is how long acquire waits before retrying a held lock
+	retryInterval = 10 * time.Millisecond
+	// staleAfter is the age after which a lockfile left behind by a crashed
+	// process is removed where the lockfile itself is the lock
+	staleAfter = 30 * time.Second
+)
+
+// timeout bounds how long Acquire waits for another holder; replaced in tests
+var timeout = 5 * time.Second
+
+// ErrTimeout is returned when another holder keeps a lock for too long
+var ErrTimeout = errors.New("timed out waiting for lock")
+
+// Lock is an exclusive lock on a file, held through a sibling lockfile
+type Lock struct {
+	path string
+	file *os.File
+}
+
+// Acquire waits until it holds the exclusive lock for path, failing with
+// ErrTimeout when that takes longer than five seconds. The lockfile
+// path+".lock" is created on demand and removed again by Unlock.
+func Acquire(path string) (*Lock, error) {
+	lockPath := path + lockSuffix
+	file, err := acquire(lockPath, time.Now().Add(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	return &Lock{path: lockPath, file: file}, nil
+}
+
+// Unlock removes the lockfile and releases the lock
+func (l *Lock) Unlock() error {
+	if err := release(l.path, l.file); err != nil {
+		return fmt.Errorf("failed to unlock %s: %w", l.path, err)
+	}
+	return nil
+}
+
+// With runs fn while holding the lock for path
+func With(path string, fn func() error) (err error) {
+	lock, err := Acquire(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if unlockErr := lock.Unlock(); err == nil {
+			err = unlockErr
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,92 @@
+package filelock
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	t.Run("serializes read-modify-write", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "counter")
+		require.NoError(t, os.WriteFile(path, []byte("0"), 0644))
+
+		const workers = 50
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := With(path, func() error {
+					data, err := os.ReadFile(path)
+					if err != nil {
+						return err
+					}
+					n, err := strconv.Atoi(strings.TrimSpace(string(data)))
+					if err != nil {
+						return err
+					}
+					return os.WriteFile(path, []byte(strconv.Itoa(n+1)), 0644)
+				})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, strconv.Itoa(workers), string(data))
+		assert.NoFileExists(t, path+lockSuffix)
+	})
+
+	t.Run("returns the callback error and releases the lock", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		err := With(path, func() error { return os.ErrInvalid })
+		assert.ErrorIs(t, err, os.ErrInvalid)
+
+		lock, err := Acquire(path)
+		require.NoError(t, err)
+		require.NoError(t, lock.Unlock())
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := Acquire(filepath.Join(t.TempDir(), "missing", "file"))
+		assert.Error(t, err)
+	})
+
+	t.Run("times out while another holder keeps the lock", func(t *testing.T) {
+		defer func(previous time.Duration) { timeout = previous }(timeout)
+		timeout = 50 * time.Millisecond
+
+		path := filepath.Join(t.TempDir(), "file")
+		held, err := Acquire(path)
+		require.NoError(t, err)
+
+		_, err = Acquire(path)
+		assert.ErrorIs(t, err, ErrTimeout)
+
+		require.NoError(t, held.Unlock())
+		lock, err := Acquire(path)
+		require.NoError(t, err)
+		require.NoError(t, lock.Unlock())
+	})
+
+	t.Run("lockfile left behind by a crashed process", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "file")
+		require.NoError(t, os.WriteFile(path+lockSuffix, nil, 0644))
+		old := time.Now().Add(-2 * staleAfter)
+		require.NoError(t, os.Chtimes(path+lockSuffix, old, old))
+
+		lock, err := Acquire(path)
+		require.NoError(t, err)
+		require.NoError(t, lock.Unlock())
+		assert.NoFileExists(t, path+lockSuffix)
+	})
+}
@@ -0,0 +1,73 @@
+//go:build !windows
+
+package filelock
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// acquire opens lockPath and takes an flock on it, waiting while another
+// holder has it until deadline. A holder removes the lockfile before
+// unlocking, so the lock only counts if lockPath still names the locked
+// file afterwards; otherwise it is retried on the new file. The kernel drops
+// the flocks of crashed processes, so leftover lockfiles are never stale.
+func acquire(lockPath string, deadline time.Time) (*os.File, error) {
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := flock(file, syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+			file.Close()
+			if err != syscall.EWOULDBLOCK {
+				return nil, err
+			}
+			if time.Now().After(deadline) {
+				return nil, ErrTimeout
+			}
+			time.Sleep(retryInterval)
+			continue
+		}
+
+		if sameFile(file, lockPath) {
+			return file, nil
+		}
+		file.Close()
+	}
+}
+
+// release removes the lockfile, then drops the flock. Removing first means
+// waiters that wake up notice the lockfile is gone and retry.
+func release(lockPath string, file *os.File) error {
+	defer file.Close()
+	if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return flock(file, syscall.LOCK_UN)
+}
+
+// flock retries the system call when it is interrupted by a signal
+func flock(file *os.File, how int) error {
+	for {
+		err := syscall.Flock(int(file.Fd()), how)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
+
+// sameFile reports whether path still refers to the open file
+func sameFile(file *os.File, path string) bool {
+	opened, err := file.Stat()
+	if err != nil {
+		return false
+	}
+	current, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(opened, current)
+}
@@ -0,0 +1,41 @@
+//go:build windows
+
+package filelock
+
+import (
+	"os"
+	"time"
+)
+
+// acquire creates lockPath exclusively, waiting while another holder has it
+// until deadline. A lockfile older than staleAfter was left behind by a
+// crashed process and is removed.
+func acquire(lockPath string, deadline time.Time) (*os.File, error) {
+	for {
+		file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_RDWR, 0644)
+		if err == nil {
+			return file, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > staleAfter {
+			os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, ErrTimeout
+		}
+		time.Sleep(retryInterval)
+	}
+}
+
+// release closes and removes the lockfile so the next waiter can create it
+func release(lockPath string, file *os.File) error {
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Remove(lockPath)
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"os/exec"
+	"runtime"
+	"sync"
+)
+
+var (
+	gitSlotsMu sync.Mutex
+	gitSlots   = make(chan struct{}, runtime.NumCPU())
+)
+
+// SetMaxConcurrent limits how many git processes hatcher runs at the same
+// time. Values below 1 restore the default of one per CPU.
+func SetMaxConcurrent(n int) {
+	if n < 1 {
+		n = runtime.NumCPU()
+	}
+
+	gitSlotsMu.Lock()
+	defer gitSlotsMu.Unlock()
+	gitSlots = make(chan struct{}, n)
+}
+
+// acquireGitSlot blocks until a git process may be started and returns the
+// function that releases the slot again
+func acquireGitSlot() func() {
+	gitSlotsMu.Lock()
+	slots := gitSlots
+	gitSlotsMu.Unlock()
+
+	slots <- struct{}{}
+	return func() { <-slots }
+}
+
+// runGit runs a git command once a concurrency slot is free
+func runGit(cmd *exec.Cmd) error {
+	release := acquireGitSlot()
+	defer release()
+	return cmd.Run()
+}
+
+// outputGit runs a git command like runGit and returns its standard output
+func outputGit(cmd *exec.Cmd) ([]byte, error) {
+	release := acquireGitSlot()
+	defer release()
+	return cmd.Output()
+}
+
+// combinedOutputGit runs a git command like runGit and returns its combined
+// standard output and standard error
+func combinedOutputGit(cmd *exec.Cmd) ([]byte, error) {
+	release := acquireGitSlot()
+	defer release()
+	return cmd.CombinedOutput()
+}
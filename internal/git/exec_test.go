@@ -0,0 +1,67 @@
+package git
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMaxConcurrent(t *testing.T) {
+	SetMaxConcurrent(2)
+	t.Cleanup(func() { SetMaxConcurrent(0) })
+
+	t.Run("limit is respected", func(t *testing.T) {
+		var active, peak int32
+		var wg sync.WaitGroup
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				release := acquireGitSlot()
+				defer release()
+
+				current := atomic.AddInt32(&active, 1)
+				for {
+					old := atomic.LoadInt32(&peak)
+					if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				atomic.AddInt32(&active, -1)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(2), peak)
+	})
+
+	t.Run("git commands run under the limit", func(t *testing.T) {
+		testRepo := testutil.NewTestGitRepository(t, "test-project")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 8)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := repo.RefExists("HEAD")
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			assert.NoError(t, err)
+		}
+	})
+}
@@ -1,19 +1,45 @@
 package git
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/keisukeshimizu/hatcher/internal/filelock"
 )
 
+// ErrBranchCheckedOutElsewhere is returned when a worktree cannot be created
+// because its branch is already checked out in another worktree
+var ErrBranchCheckedOutElsewhere = errors.New("branch is already checked out in another worktree")
+
+// ErrNotWorktree is returned for paths outside every worktree of the repository
+var ErrNotWorktree = errors.New("path is not inside a worktree of this repository")
+
+// ErrNoCommonAncestor is returned when commits are compared whose histories
+// are unrelated
+var ErrNoCommonAncestor = errors.New("no common ancestor")
+
+// checkedOutPattern matches git's report of the worktree a branch is
+// checked out in; newer git versions say "used by worktree"
+var checkedOutPattern = regexp.MustCompile(`is already (?:checked out|used by worktree) at '([^']+)'`)
+
 // Repository represents a Git repository
 type Repository interface {
 	// Repository information
 	GetRoot() (string, error)
 	GetProjectName() string
 	IsGitRepository() bool
+	GitDir(worktreePath string) (string, error)
+	GitPath(worktreePath, name string) (string, error)
+	GitVersion() (Version, error)
+	SupportsFeature(feature Feature) bool
 
 	// Branch operations
 	BranchExists(branch string) (bool, error)
@@ -21,16 +47,37 @@ type Repository interface {
 	GetCurrentBranch() (string, error)
 	CreateBranch(branch string) error
 	RemoveBranch(branch string, force bool) error
+	RenameBranch(oldName, newName string) error
 	RemoveRemoteBranch(branch string) error
+	RefExists(ref string) (bool, error)
+	ResolveCommit(ref string) (string, error)
+	GetUpstream(branch string) (string, error)
+	GetDefaultBranch() (string, error)
+	MergedBranches(ref string) ([]string, error)
+	GetAheadBehind(branch, base string) (ahead, behind int, err error)
+	FetchRemote(remote string) error
 
 	// Worktree operations
 	CreateWorktree(path, branch string, newBranch bool) error
+	CreateWorktreeFromRef(path, branch, ref string) error
+	CreateWorktreeTracking(path, branch, upstream string) error
+	CreateWorktreeForceBranch(path, branch, ref string) error
 	RemoveWorktree(path string, force bool) error
+	MoveWorktree(oldPath, newPath string) error
 	ListWorktrees() ([]Worktree, error)
+	ListWorktreeEntries() ([]Worktree, error)
+	WorktreeStatus(path string) WorktreeStatus
 	GetWorktreePath(branch string) (string, error)
+	BranchForWorktree(path string) (string, error)
+	HasUncommittedChanges(path string) (bool, error)
+	PruneWorktrees() error
+	PruneCandidates() ([]string, error)
 
 	// Other operations
 	UpdateGitignore(files []string) error
+	FilterIgnored(paths []string) ([]string, error)
+	CheckAttr(attr string, paths []string) (map[string]string, error)
+	IsTracked(dir, path string) (bool, error)
 }
 
 // Worktree represents a Git worktree
@@ -55,6 +102,15 @@ const (
 type GitRepository struct {
 	root        string
 	projectName string
+	fetchMu     sync.Mutex // Serializes FetchRemote
+
+	// Values cached for the lifetime of a command; cleared by operations
+	// that change them
+	cacheMu       sync.Mutex
+	currentBranch *string
+	defaultBranch *string
+	worktrees     []Worktree
+	gitVersion    *Version
 }
 
 // NewRepository creates a new Git repository instance
@@ -104,11 +160,58 @@ func (r *GitRepository) IsGitRepository() bool {
 	return err == nil
 }
 
+// GitDir returns the absolute git directory of the worktree at worktreePath.
+// For linked worktrees this is .git/worktrees/<name> in the main repository.
+// An empty worktreePath resolves the git directory of the repository root.
+func (r *GitRepository) GitDir(worktreePath string) (string, error) {
+	if worktreePath == "" {
+		worktreePath = r.root
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--git-dir")
+	cmd.Dir = worktreePath
+	output, err := outputGit(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve git directory of %s: %w", worktreePath, err)
+	}
+
+	gitDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(worktreePath, gitDir)
+	}
+
+	return filepath.Clean(gitDir), nil
+}
+
+// GitPath returns the absolute path of name inside the git directory of the
+// worktree at worktreePath, as resolved by 'git rev-parse --git-path'. Files
+// shared by all worktrees, such as info/exclude, resolve to the common git
+// directory.
+func (r *GitRepository) GitPath(worktreePath, name string) (string, error) {
+	if worktreePath == "" {
+		worktreePath = r.root
+	}
+
+	cmd := exec.Command("git", "rev-parse", "--git-path", name)
+	cmd.Dir = worktreePath
+	output, err := outputGit(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s for %s: %w", name, worktreePath, err)
+	}
+
+	path := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(worktreePath, path)
+	}
+
+	return filepath.Clean(path), nil
+}
+
 // BranchExists checks if a local branch exists
 func (r *GitRepository) BranchExists(branch string) (bool, error) {
 	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
 	cmd.Dir = r.root
-	err := cmd.Run()
+	err := runGit(cmd)
 
 	if err != nil {
 		// Check if it's an exit error (branch doesn't exist)
@@ -125,7 +228,7 @@ func (r *GitRepository) BranchExists(branch string) (bool, error) {
 func (r *GitRepository) RemoteBranchExists(branch string) (bool, error) {
 	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/remotes/origin/"+branch)
 	cmd.Dir = r.root
-	err := cmd.Run()
+	err := runGit(cmd)
 
 	if err != nil {
 		// Check if it's an exit error (branch doesn't exist)
@@ -138,23 +241,210 @@ func (r *GitRepository) RemoteBranchExists(branch string) (bool, error) {
 	return true, nil
 }
 
+// RefExists checks whether ref resolves to a commit
+func (r *GitRepository) RefExists(ref string) (bool, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = r.root
+	err := runGit(cmd)
+
+	if err != nil {
+		// Check if it's an exit error (ref doesn't exist)
+		if _, ok := err.(*exec.ExitError); ok {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check ref existence: %w", err)
+	}
+
+	return true, nil
+}
+
+// ResolveCommit returns the full hash of the commit ref points at
+func (r *GitRepository) ResolveCommit(ref string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	cmd.Dir = r.root
+	output, err := outputGit(cmd)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s to a commit", ref)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetUpstream returns the upstream ref of a branch (e.g. origin/main),
+// or an empty string if the branch has no upstream configured
+func (r *GitRepository) GetUpstream(branch string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", branch+"@{upstream}")
+	cmd.Dir = r.root
+	output, err := outputGit(cmd)
+
+	if err != nil {
+		if _, ok := err.(*exec.ExitError); ok {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get upstream of %s: %w", branch, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetDefaultBranch returns the branch origin/HEAD points at, or a local main or
+// master branch when the remote does not name one. A default branch that
+// only exists on the remote is returned as "origin/<name>".
+func (r *GitRepository) GetDefaultBranch() (string, error) {
+	// The lookup runs git, so it is not done under the cache lock
+	r.cacheMu.Lock()
+	cached := r.defaultBranch
+	r.cacheMu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
+	branch, err := r.detectDefaultBranch()
+	if err != nil {
+		return "", err
+	}
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	r.defaultBranch = &branch
+	return branch, nil
+}
+
+// detectDefaultBranch looks the default branch up for GetDefaultBranch
+func (r *GitRepository) detectDefaultBranch() (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = r.root
+	if output, err := outputGit(cmd); err == nil {
+		remoteBranch := strings.TrimSpace(string(output))
+		branch := strings.TrimPrefix(remoteBranch, "origin/")
+		if exists, err := r.BranchExists(branch); err == nil && exists {
+			return branch, nil
+		}
+		return remoteBranch, nil
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		exists, err := r.BranchExists(branch)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return branch, nil
+		}
+	}
+	return "", fmt.Errorf("cannot determine the default branch: origin/HEAD is not set and there is no main or master branch")
+}
+
+// GetAheadBehind counts the commits branch has that base does not (ahead)
+// and the commits base has that branch does not (behind). Both may be any
+// commit-ish. It fails with ErrNoCommonAncestor for unrelated histories,
+// whose counts would just be the length of each history.
+func (r *GitRepository) GetAheadBehind(branch, base string) (int, int, error) {
+	mergeBase := exec.Command("git", "merge-base", base, branch)
+	mergeBase.Dir = r.root
+	if err := runGit(mergeBase); err != nil {
+		// merge-base exits with 1 and no error message without a common
+		// ancestor; unknown commits are reported by rev-list below
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return 0, 0, fmt.Errorf("cannot compare %s with %s: %w", branch, base, ErrNoCommonAncestor)
+		}
+	}
+
+	cmd := exec.Command("git", "rev-list", "--left-right", "--count", base+"..."+branch)
+	cmd.Dir = r.root
+	output, err := outputGit(cmd)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to compare %s with %s: %w", branch, base, err)
+	}
+	return parseAheadBehind(string(output))
+}
+
+// parseAheadBehind parses the output of 'git rev-list --left-right --count
+// base...branch': the commits only in base, then those only in branch
+func parseAheadBehind(output string) (int, int, error) {
+	fields := strings.Fields(output)
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(output))
+	}
+
+	behind, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(output))
+	}
+	ahead, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("unexpected rev-list output: %q", strings.TrimSpace(output))
+	}
+	return ahead, behind, nil
+}
+
+// MergedBranches returns the local branches whose tips are reachable from
+// ref, i.e. that are merged into it
+func (r *GitRepository) MergedBranches(ref string) ([]string, error) {
+	cmd := exec.Command("git", "branch", "--merged", ref, "--format=%(refname:short)")
+	cmd.Dir = r.root
+	output, err := outputGit(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches merged into %s: %w", ref, err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if branch := strings.TrimSpace(line); branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches, nil
+}
+
+// FetchRemote fetches the branches of remote, updating its remote-tracking
+// branches
+func (r *GitRepository) FetchRemote(remote string) error {
+	// Concurrent fetches fail to lock the refs they update
+	r.fetchMu.Lock()
+	defer r.fetchMu.Unlock()
+
+	cmd := exec.Command("git", "fetch", "--quiet", remote)
+	cmd.Dir = r.root
+	output, err := combinedOutputGit(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %s", remote, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
 // GetCurrentBranch returns the current branch name
 func (r *GitRepository) GetCurrentBranch() (string, error) {
+	// Like GetDefaultBranch, git runs outside the cache lock
+	r.cacheMu.Lock()
+	cached := r.currentBranch
+	r.cacheMu.Unlock()
+	if cached != nil {
+		return *cached, nil
+	}
+
 	cmd := exec.Command("git", "branch", "--show-current")
 	cmd.Dir = r.root
-	output, err := cmd.Output()
+	output, err := outputGit(cmd)
 	if err != nil {
 		return "", fmt.Errorf("failed to get current branch: %w", err)
 	}
 
-	return strings.TrimSpace(string(output)), nil
+	branch := strings.TrimSpace(string(output))
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	r.currentBranch = &branch
+	return branch, nil
 }
 
 // CreateBranch creates a new branch
 func (r *GitRepository) CreateBranch(branch string) error {
+	defer r.invalidateCache()
+
 	cmd := exec.Command("git", "checkout", "-b", branch)
 	cmd.Dir = r.root
-	if err := cmd.Run(); err != nil {
+	if err := runGit(cmd); err != nil {
 		return fmt.Errorf("failed to create branch %s: %w", branch, err)
 	}
 
@@ -163,6 +453,8 @@ func (r *GitRepository) CreateBranch(branch string) error {
 
 // RemoveBranch deletes a local branch
 func (r *GitRepository) RemoveBranch(branch string, force bool) error {
+	defer r.invalidateCache()
+
 	flag := "-d"
 	if force {
 		flag = "-D"
@@ -170,18 +462,32 @@ func (r *GitRepository) RemoveBranch(branch string, force bool) error {
 
 	cmd := exec.Command("git", "branch", flag, branch)
 	cmd.Dir = r.root
-	if err := cmd.Run(); err != nil {
+	if err := runGit(cmd); err != nil {
 		return fmt.Errorf("failed to delete branch %s: %w", branch, err)
 	}
 
 	return nil
 }
 
+// RenameBranch renames a local branch, including one checked out in a
+// worktree
+func (r *GitRepository) RenameBranch(oldName, newName string) error {
+	defer r.invalidateCache()
+
+	cmd := exec.Command("git", "branch", "-m", oldName, newName)
+	cmd.Dir = r.root
+	if output, err := combinedOutputGit(cmd); err != nil {
+		return fmt.Errorf("failed to rename branch %s: %s", oldName, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
 // RemoveRemoteBranch deletes a remote branch
 func (r *GitRepository) RemoveRemoteBranch(branch string) error {
 	cmd := exec.Command("git", "push", "origin", "--delete", branch)
 	cmd.Dir = r.root
-	if err := cmd.Run(); err != nil {
+	if err := runGit(cmd); err != nil {
 		return fmt.Errorf("failed to delete remote branch %s: %w", branch, err)
 	}
 
@@ -190,6 +496,18 @@ func (r *GitRepository) RemoveRemoteBranch(branch string) error {
 
 // CreateWorktree creates a new Git worktree
 func (r *GitRepository) CreateWorktree(path, branch string, newBranch bool) error {
+	if err := r.requireFeature(FeatureWorktreeAdd); err != nil {
+		return err
+	}
+	defer r.invalidateCache()
+
+	// A branch can only be checked out in one worktree at a time
+	if !newBranch {
+		if existing, err := r.GetWorktreePath(branch); err == nil {
+			return branchCheckedOutError(branch, existing)
+		}
+	}
+
 	var cmd *exec.Cmd
 
 	if newBranch {
@@ -199,16 +517,88 @@ func (r *GitRepository) CreateWorktree(path, branch string, newBranch bool) erro
 	}
 
 	cmd.Dir = r.root
-	output, err := cmd.CombinedOutput()
+	output, err := combinedOutputGit(cmd)
 	if err != nil {
+		// Worktrees added concurrently are only caught by git itself
+		if match := checkedOutPattern.FindSubmatch(output); match != nil {
+			return branchCheckedOutError(branch, string(match[1]))
+		}
 		return fmt.Errorf("failed to create worktree: %s", output)
 	}
 
 	return nil
 }
 
+// branchCheckedOutError returns ErrBranchCheckedOutElsewhere naming the
+// worktree branch is checked out in
+func branchCheckedOutError(branch, worktreePath string) error {
+	return fmt.Errorf("%w: %s is checked out at %s", ErrBranchCheckedOutElsewhere, branch, worktreePath)
+}
+
+// CreateWorktreeFromRef creates a worktree with a new branch starting at ref
+func (r *GitRepository) CreateWorktreeFromRef(path, branch, ref string) error {
+	if err := r.requireFeature(FeatureWorktreeAdd); err != nil {
+		return err
+	}
+	defer r.invalidateCache()
+
+	cmd := exec.Command("git", "worktree", "add", "-b", branch, path, ref)
+	cmd.Dir = r.root
+	output, err := combinedOutputGit(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to create worktree from %s: %s", ref, output)
+	}
+
+	return nil
+}
+
+// CreateWorktreeTracking creates a worktree with a new branch starting at the
+// remote-tracking branch upstream and set up to track it
+func (r *GitRepository) CreateWorktreeTracking(path, branch, upstream string) error {
+	if err := r.requireFeature(FeatureWorktreeAdd); err != nil {
+		return err
+	}
+	defer r.invalidateCache()
+
+	cmd := exec.Command("git", "worktree", "add", "--track", "-b", branch, path, upstream)
+	cmd.Dir = r.root
+	output, err := combinedOutputGit(cmd)
+	if err != nil {
+		return fmt.Errorf("failed to create worktree tracking %s: %s", upstream, output)
+	}
+
+	return nil
+}
+
+// CreateWorktreeForceBranch creates a worktree for branch starting at ref,
+// creating the branch or resetting it to ref if it already exists
+func (r *GitRepository) CreateWorktreeForceBranch(path, branch, ref string) error {
+	if err := r.requireFeature(FeatureWorktreeAdd); err != nil {
+		return err
+	}
+	defer r.invalidateCache()
+
+	cmd := exec.Command("git", "worktree", "add", "-B", branch, path, ref)
+	cmd.Dir = r.root
+	output, err := combinedOutputGit(cmd)
+	if err != nil {
+		if match := checkedOutPattern.FindSubmatch(output); match != nil {
+			return branchCheckedOutError(branch, string(match[1]))
+		}
+		return fmt.Errorf("failed to create worktree from %s: %s", ref, output)
+	}
+
+	return nil
+}
+
 // RemoveWorktree removes a Git worktree
 func (r *GitRepository) RemoveWorktree(path string, force bool) error {
+	defer r.invalidateCache()
+
+	if !r.SupportsFeature(FeatureWorktreeRemove) {
+		return r.removeWorktreeManually(path, force)
+	}
+
 	args := []string{"worktree", "remove"}
 	if force {
 		args = append(args, "--force")
@@ -217,7 +607,7 @@ func (r *GitRepository) RemoveWorktree(path string, force bool) error {
 
 	cmd := exec.Command("git", args...)
 	cmd.Dir = r.root
-	output, err := cmd.CombinedOutput()
+	output, err := combinedOutputGit(cmd)
 	if err != nil {
 		return fmt.Errorf("failed to remove worktree: %s", output)
 	}
@@ -225,11 +615,142 @@ func (r *GitRepository) RemoveWorktree(path string, force bool) error {
 	return nil
 }
 
-// ListWorktrees returns a list of all worktrees
+// MoveWorktree moves the worktree at oldPath to newPath. Like mv, git moves
+// it into newPath when that is an existing directory.
+func (r *GitRepository) MoveWorktree(oldPath, newPath string) error {
+	if err := r.requireFeature(FeatureWorktreeMove); err != nil {
+		return err
+	}
+	defer r.invalidateCache()
+
+	cmd := exec.Command("git", "worktree", "move", oldPath, newPath)
+	cmd.Dir = r.root
+	if output, err := combinedOutputGit(cmd); err != nil {
+		return fmt.Errorf("failed to move worktree: %s", strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+// removeWorktreeManually removes a worktree on gits without 'git worktree
+// remove' by deleting its directory and pruning its administrative files.
+// Like 'git worktree remove', it refuses worktrees with changes unless force
+// is set.
+func (r *GitRepository) removeWorktreeManually(path string, force bool) error {
+	if !force {
+		dirty, err := uncommittedChanges(path)
+		if err != nil {
+			return fmt.Errorf("failed to check worktree status: %w", err)
+		}
+		if dirty {
+			return fmt.Errorf("failed to remove worktree: %s contains modified or untracked files, use --force to delete it", path)
+		}
+	}
+
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("failed to remove worktree directory: %w", err)
+	}
+
+	return r.PruneWorktrees()
+}
+
+// PruneWorktrees removes the administrative entries of worktrees whose
+// directories no longer exist
+func (r *GitRepository) PruneWorktrees() error {
+	defer r.invalidateCache()
+
+	cmd := exec.Command("git", "worktree", "prune")
+	cmd.Dir = r.root
+	if output, err := combinedOutputGit(cmd); err != nil {
+		return fmt.Errorf("failed to prune worktrees: %s", output)
+	}
+
+	return nil
+}
+
+// PruneCandidates returns the entries PruneWorktrees would remove, each as
+// "worktrees/<name>: <reason>"
+func (r *GitRepository) PruneCandidates() ([]string, error) {
+	cmd := exec.Command("git", "worktree", "prune", "--dry-run", "--verbose")
+	cmd.Dir = r.root
+	output, err := combinedOutputGit(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prunable worktrees: %s", output)
+	}
+
+	var candidates []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if entry, ok := strings.CutPrefix(strings.TrimSpace(line), "Removing "); ok {
+			candidates = append(candidates, entry)
+		}
+	}
+	return candidates, nil
+}
+
+// ListWorktrees returns a list of all worktrees with their status. Working
+// out the status runs git status in every worktree, so callers that only
+// need paths or branches use ListWorktreeEntries.
 func (r *GitRepository) ListWorktrees() ([]Worktree, error) {
+	worktrees, err := r.ListWorktreeEntries()
+	if err != nil {
+		return nil, err
+	}
+	if statusesSet(worktrees) {
+		return worktrees, nil
+	}
+
+	// The status scan is slow, so it does not run under the cache lock
+	setWorktreeStatuses(worktrees)
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	if r.worktrees != nil {
+		r.worktrees = append([]Worktree(nil), worktrees...)
+	}
+	return worktrees, nil
+}
+
+// ListWorktreeEntries returns the worktrees like ListWorktrees without
+// running git status in each of them, so Status is only set when cached or
+// for bare entries. WorktreeStatus computes it for a single worktree.
+func (r *GitRepository) ListWorktreeEntries() ([]Worktree, error) {
+	if err := r.requireFeature(FeatureWorktreeList); err != nil {
+		return nil, err
+	}
+
+	r.cacheMu.Lock()
+	cached := r.worktrees
+	r.cacheMu.Unlock()
+	if cached != nil {
+		return append([]Worktree(nil), cached...), nil
+	}
+
+	worktrees, err := r.readWorktreeList()
+	if err != nil {
+		return nil, err
+	}
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	r.worktrees = worktrees
+	return append([]Worktree(nil), worktrees...), nil
+}
+
+// statusesSet reports whether every worktree has a status
+func statusesSet(worktrees []Worktree) bool {
+	for _, wt := range worktrees {
+		if wt.Status == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readWorktreeList runs git worktree list and parses its output
+func (r *GitRepository) readWorktreeList() ([]Worktree, error) {
 	cmd := exec.Command("git", "worktree", "list", "--porcelain")
 	cmd.Dir = r.root
-	output, err := cmd.Output()
+	output, err := outputGit(cmd)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
@@ -237,9 +758,95 @@ func (r *GitRepository) ListWorktrees() ([]Worktree, error) {
 	return parseWorktreeList(string(output))
 }
 
+// WorktreeStatus returns the status ListWorktrees reports for the worktree
+// at path
+func (r *GitRepository) WorktreeStatus(path string) WorktreeStatus {
+	return statusAt(currentDir(), path)
+}
+
+// setWorktreeStatuses sets the status of worktrees that do not have one yet:
+// active for the worktree at the current directory, otherwise clean or dirty
+// as reported by git status, or unknown when that fails
+func setWorktreeStatuses(worktrees []Worktree) {
+	cwd := currentDir()
+
+	var wg sync.WaitGroup
+	for i := range worktrees {
+		wt := &worktrees[i]
+		if wt.Status != "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wt.Status = statusAt(cwd, wt.Path)
+		}()
+	}
+	wg.Wait()
+}
+
+// currentDir returns the resolved working directory, or "" when unknown
+func currentDir() string {
+	if wd, err := os.Getwd(); err == nil {
+		return resolvePath(wd)
+	}
+	return ""
+}
+
+// statusAt returns active when the worktree at path is the resolved working
+// directory cwd, and its worktreeStatus otherwise
+func statusAt(cwd, path string) WorktreeStatus {
+	if cwd != "" && resolvePath(path) == cwd {
+		return StatusActive
+	}
+	return worktreeStatus(path)
+}
+
+// worktreeStatus returns whether the worktree at path has uncommitted changes
+func worktreeStatus(path string) WorktreeStatus {
+	dirty, err := uncommittedChanges(path)
+	if err != nil {
+		return StatusUnknown
+	}
+	if dirty {
+		return StatusDirty
+	}
+	return StatusClean
+}
+
+// HasUncommittedChanges reports whether git status shows staged, modified
+// or untracked files in the worktree at path
+func (r *GitRepository) HasUncommittedChanges(path string) (bool, error) {
+	return uncommittedChanges(path)
+}
+
+// uncommittedChanges runs git status in the worktree at path
+func uncommittedChanges(path string) (bool, error) {
+	cmd := exec.Command("git", "status", "--porcelain")
+	cmd.Dir = path
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := outputGit(cmd)
+	if err != nil {
+		return false, fmt.Errorf("failed to get status of %s: %s", path, strings.TrimSpace(stderr.String()))
+	}
+	return len(bytes.TrimSpace(output)) > 0, nil
+}
+
+// invalidateCache drops cached values after an operation that changes
+// branches or worktrees
+func (r *GitRepository) invalidateCache() {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	r.currentBranch = nil
+	r.defaultBranch = nil
+	r.worktrees = nil
+}
+
 // GetWorktreePath returns the path of a worktree for the given branch
 func (r *GitRepository) GetWorktreePath(branch string) (string, error) {
-	worktrees, err := r.ListWorktrees()
+	worktrees, err := r.ListWorktreeEntries()
 	if err != nil {
 		return "", err
 	}
@@ -253,6 +860,56 @@ func (r *GitRepository) GetWorktreePath(branch string) (string, error) {
 	return "", fmt.Errorf("worktree for branch %s not found", branch)
 }
 
+// BranchForWorktree returns the branch checked out in the worktree that
+// contains path, as reported by git. A detached HEAD is reported as
+// "(detached at <short commit>)", which can never be a branch name.
+func (r *GitRepository) BranchForWorktree(path string) (string, error) {
+	worktrees, err := r.ListWorktreeEntries()
+	if err != nil {
+		return "", err
+	}
+
+	// Worktrees can be nested, so the deepest one containing path wins
+	target := resolvePath(path)
+	var match *Worktree
+	for i, wt := range worktrees {
+		root := resolvePath(wt.Path)
+		if target != root && !strings.HasPrefix(target, root+string(filepath.Separator)) {
+			continue
+		}
+		if match == nil || len(root) > len(resolvePath(match.Path)) {
+			match = &worktrees[i]
+		}
+	}
+
+	switch {
+	case match == nil:
+		return "", fmt.Errorf("%w: %s", ErrNotWorktree, path)
+	case match.Branch != "":
+		return match.Branch, nil
+	case match.Head != "":
+		head := match.Head
+		if len(head) > 7 {
+			head = head[:7]
+		}
+		return fmt.Sprintf("(detached at %s)", head), nil
+	default:
+		return "(detached)", nil
+	}
+}
+
+// resolvePath returns path as an absolute path with symlinks resolved, so
+// that paths git reports compare equal to the ones users pass
+func resolvePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return filepath.Clean(path)
+}
+
 // UpdateGitignore adds files to .gitignore
 func (r *GitRepository) UpdateGitignore(files []string) error {
 	if len(files) == 0 {
@@ -261,32 +918,156 @@ func (r *GitRepository) UpdateGitignore(files []string) error {
 
 	gitignorePath := filepath.Join(r.root, ".gitignore")
 
-	// Read existing .gitignore
-	var existing []byte
-	if _, err := os.Stat(gitignorePath); err == nil {
-		existing, _ = os.ReadFile(gitignorePath)
+	// Hold the lock across the read and write so concurrent updates are not lost
+	return filelock.With(gitignorePath, func() error {
+		// Read existing .gitignore
+		var existing []byte
+		if _, err := os.Stat(gitignorePath); err == nil {
+			existing, _ = os.ReadFile(gitignorePath)
+		}
+
+		// Prepare new content
+		content := string(existing)
+		if len(content) > 0 && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+
+		// Add separator comment
+		content += "\n# Auto-copied files (added by hatcher)\n"
+
+		// Add files
+		for _, file := range files {
+			content += file + "\n"
+		}
+
+		// Write back to .gitignore
+		return os.WriteFile(gitignorePath, []byte(content), 0644)
+	})
+}
+
+// FilterIgnored returns the paths that git ignores. Paths may be absolute or
+// relative to the repository root and must lie inside the repository; they
+// are returned in the form they were given. All paths are checked with a
+// single `git check-ignore` invocation.
+func (r *GitRepository) FilterIgnored(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	var input bytes.Buffer
+	for _, path := range paths {
+		input.WriteString(filepath.ToSlash(path))
+		input.WriteByte(0)
+	}
+
+	cmd := exec.Command("git", "check-ignore", "--stdin", "-z")
+	cmd.Dir = r.root
+	cmd.Stdin = &input
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := outputGit(cmd)
+	if err != nil {
+		// Exit status 1 means none of the paths are ignored
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to check ignored paths: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	// check-ignore echoes the paths as given; map them back to the caller's form
+	byInput := make(map[string]string, len(paths))
+	for _, path := range paths {
+		byInput[filepath.ToSlash(path)] = path
+	}
+
+	var ignored []string
+	for _, echoed := range strings.Split(string(output), "\x00") {
+		if original, ok := byInput[echoed]; ok {
+			ignored = append(ignored, original)
+		}
+	}
+
+	return ignored, nil
+}
+
+// CheckAttr returns the value of the gitattribute attr for each of paths:
+// "set", "unset", "unspecified" or the assigned value. Paths may be absolute
+// or relative to the repository root and are keyed in the form they were
+// given. All paths are checked with a single `git check-attr` invocation.
+func (r *GitRepository) CheckAttr(attr string, paths []string) (map[string]string, error) {
+	values := make(map[string]string, len(paths))
+	if len(paths) == 0 {
+		return values, nil
+	}
+
+	var input bytes.Buffer
+	for _, path := range paths {
+		input.WriteString(filepath.ToSlash(path))
+		input.WriteByte(0)
+	}
+
+	cmd := exec.Command("git", "check-attr", "--stdin", "-z", attr)
+	cmd.Dir = r.root
+	cmd.Stdin = &input
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := outputGit(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check attribute %s: %s", attr, strings.TrimSpace(stderr.String()))
+	}
+
+	// check-attr echoes the paths as given; map them back to the caller's form
+	byInput := make(map[string]string, len(paths))
+	for _, path := range paths {
+		byInput[filepath.ToSlash(path)] = path
 	}
 
-	// Prepare new content
-	content := string(existing)
-	if len(content) > 0 && !strings.HasSuffix(content, "\n") {
-		content += "\n"
+	// Output is a sequence of <path> NUL <attribute> NUL <value> NUL records
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i+2 < len(fields); i += 3 {
+		if original, ok := byInput[fields[i]]; ok {
+			values[original] = fields[i+2]
+		}
 	}
 
-	// Add separator comment
-	content += "\n# Auto-copied files (added by hatcher)\n"
+	return values, nil
+}
 
-	// Add files
-	for _, file := range files {
-		content += file + "\n"
+// IsTracked reports whether path is a file tracked in the worktree at dir.
+// path may be absolute or relative to dir.
+func (r *GitRepository) IsTracked(dir, path string) (bool, error) {
+	rel := path
+	if filepath.IsAbs(path) {
+		var err error
+		if rel, err = filepath.Rel(dir, path); err != nil {
+			return false, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+	}
+	rel = filepath.ToSlash(filepath.Clean(rel))
+
+	// A literal pathspec keeps glob characters in names from matching others
+	cmd := exec.Command("git", "ls-files", "-z", "--", ":(literal)"+rel)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := outputGit(cmd)
+	if err != nil {
+		return false, fmt.Errorf("failed to check whether %s is tracked: %s", rel, strings.TrimSpace(stderr.String()))
 	}
 
-	// Write back to .gitignore
-	return os.WriteFile(gitignorePath, []byte(content), 0644)
+	// A directory lists the files below it; only the path itself counts
+	for _, name := range strings.Split(string(output), "\x00") {
+		if name == rel {
+			return true, nil
+		}
+	}
+	return false, nil
 }
 
 // DeleteBranch deletes a local branch
 func (r *GitRepository) DeleteBranch(branch string, force bool) error {
+	defer r.invalidateCache()
+
 	args := []string{"branch"}
 	if force {
 		args = append(args, "-D")
@@ -297,7 +1078,7 @@ func (r *GitRepository) DeleteBranch(branch string, force bool) error {
 
 	cmd := exec.Command("git", args...)
 	cmd.Dir = r.root
-	err := cmd.Run()
+	err := runGit(cmd)
 
 	if err != nil {
 		if _, ok := err.(*exec.ExitError); ok {
@@ -313,7 +1094,7 @@ func (r *GitRepository) DeleteBranch(branch string, force bool) error {
 func (r *GitRepository) DeleteRemoteBranch(branch string) error {
 	cmd := exec.Command("git", "push", "origin", "--delete", branch)
 	cmd.Dir = r.root
-	err := cmd.Run()
+	err := runGit(cmd)
 
 	if err != nil {
 		if _, ok := err.(*exec.ExitError); ok {
@@ -328,7 +1109,7 @@ func (r *GitRepository) DeleteRemoteBranch(branch string) error {
 // getGitRoot returns the root directory of the Git repository
 func getGitRoot() (string, error) {
 	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
-	output, err := cmd.Output()
+	output, err := outputGit(cmd)
 	if err != nil {
 		return "", err
 	}
@@ -358,6 +1139,9 @@ func parseWorktreeList(output string) ([]Worktree, error) {
 			current.Head = strings.TrimPrefix(line, "HEAD ")
 		} else if strings.HasPrefix(line, "branch ") {
 			current.Branch = strings.TrimPrefix(line, "branch refs/heads/")
+		} else if line == "bare" || line == "detached" {
+			// No branch to report the status of
+			current.Status = StatusUnknown
 		}
 	}
 
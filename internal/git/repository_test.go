@@ -2,7 +2,9 @@ package git
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/keisukeshimizu/hatcher/test/testutil"
@@ -127,13 +129,13 @@ func TestDeleteBranch(t *testing.T) {
 	err = repo.CreateBranch(branchName)
 	require.NoError(t, err)
 
-	// Switch back to main branch
-	mainBranch, err := repo.GetCurrentBranch()
+	// Switch back to the default branch to delete the current one
+	currentBranch, err := repo.GetCurrentBranch()
 	require.NoError(t, err)
-	if mainBranch == branchName {
-		// We need to switch to a different branch to delete the current one
-		// Go back to the original branch (likely main or master)
-		testRepo.SwitchToBranch("main")
+	if currentBranch == branchName {
+		defaultBranch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		testRepo.SwitchToBranch(defaultBranch)
 	}
 
 	// Delete the branch
@@ -168,6 +170,129 @@ func TestCreateWorktree(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestCreateWorktreeFromRef(t *testing.T) {
+	// Create a test Git repository
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	baseBranch := testRepo.GetCurrentBranch()
+	worktreePath := filepath.Join(testRepo.TempDir, "test-project-feature-from-ref")
+
+	err = repo.CreateWorktreeFromRef(worktreePath, "feature/from-ref", baseBranch)
+	require.NoError(t, err)
+	assert.DirExists(t, worktreePath)
+
+	exists, err := repo.BranchExists("feature/from-ref")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	// Unknown refs are rejected
+	err = repo.CreateWorktreeFromRef(filepath.Join(testRepo.TempDir, "other"), "feature/other", "does-not-exist")
+	assert.Error(t, err)
+}
+
+func TestRefExists(t *testing.T) {
+	// Create a test Git repository
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	exists, err := repo.RefExists(testRepo.GetCurrentBranch())
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.RefExists("HEAD")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.RefExists("does-not-exist")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGetUpstream(t *testing.T) {
+	// Create a test Git repository
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	baseBranch := testRepo.GetCurrentBranch()
+	testRepo.CreateBranch("feature/upstream")
+
+	upstream, err := repo.GetUpstream("feature/upstream")
+	require.NoError(t, err)
+	assert.Empty(t, upstream)
+
+	// Track the base branch as upstream
+	cmd := exec.Command("git", "branch", "--set-upstream-to", baseBranch, "feature/upstream")
+	cmd.Dir = testRepo.RepoDir
+	require.NoError(t, cmd.Run())
+
+	upstream, err = repo.GetUpstream("feature/upstream")
+	require.NoError(t, err)
+	assert.Equal(t, baseBranch, upstream)
+}
+
+func TestGitDir(t *testing.T) {
+	// Create a test Git repository
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	t.Run("main worktree", func(t *testing.T) {
+		gitDir, err := repo.GitDir(testRepo.RepoDir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(testRepo.RepoDir, ".git"), gitDir)
+
+		// Empty path defaults to the repository root
+		gitDir, err = repo.GitDir("")
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(testRepo.RepoDir, ".git"), gitDir)
+	})
+
+	t.Run("linked worktree", func(t *testing.T) {
+		worktreePath := filepath.Join(testRepo.TempDir, "test-project-feature-git-dir")
+		require.NoError(t, repo.CreateWorktree(worktreePath, "feature/git-dir", true))
+
+		gitDir, err := repo.GitDir(worktreePath)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(testRepo.RepoDir, ".git", "worktrees", filepath.Base(worktreePath)), gitDir)
+		assert.FileExists(t, filepath.Join(gitDir, "HEAD"))
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		_, err := repo.GitDir(t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestGitPath(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	excludePath := filepath.Join(testRepo.RepoDir, ".git", "info", "exclude")
+
+	path, err := repo.GitPath("", "info/exclude")
+	require.NoError(t, err)
+	assert.Equal(t, excludePath, path)
+
+	// Linked worktrees share info/exclude but have their own HEAD
+	worktreePath := filepath.Join(testRepo.TempDir, "test-project-feature-git-path")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/git-path", true))
+	path, err = repo.GitPath(worktreePath, "info/exclude")
+	require.NoError(t, err)
+	assert.Equal(t, excludePath, path)
+
+	path, err = repo.GitPath(worktreePath, "HEAD")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(testRepo.RepoDir, ".git", "worktrees", filepath.Base(worktreePath), "HEAD"), path)
+
+	_, err = repo.GitPath(t.TempDir(), "info/exclude")
+	assert.Error(t, err)
+}
+
 func TestRemoveWorktree(t *testing.T) {
 	// Create a test Git repository
 	testRepo := testutil.NewTestGitRepository(t, "test-project")
@@ -234,6 +359,164 @@ func TestListWorktrees(t *testing.T) {
 	assert.True(t, found, "New worktree should be in the list")
 }
 
+func TestListWorktreesStatus(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	cleanPath := filepath.Join(testRepo.TempDir, "test-project-clean")
+	dirtyPath := filepath.Join(testRepo.TempDir, "test-project-dirty")
+	activePath := filepath.Join(testRepo.TempDir, "test-project-active")
+	detachedPath := filepath.Join(testRepo.TempDir, "test-project-detached")
+	require.NoError(t, repo.CreateWorktree(cleanPath, "feature/clean", true))
+	require.NoError(t, repo.CreateWorktree(dirtyPath, "feature/dirty", true))
+	require.NoError(t, repo.CreateWorktree(activePath, "feature/active", true))
+	output, err := exec.Command("git", "-C", testRepo.RepoDir, "worktree", "add", "--detach", detachedPath).CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dirtyPath, "untracked.txt"), []byte("change"), 0644))
+
+	originalWd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(activePath))
+	defer os.Chdir(originalWd)
+
+	// A fresh instance, since the worktree list is cached
+	repo, err = NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	worktrees, err := repo.ListWorktrees()
+	require.NoError(t, err)
+
+	statuses := make(map[string]WorktreeStatus)
+	for _, wt := range worktrees {
+		statuses[wt.Path] = wt.Status
+	}
+	assert.Equal(t, StatusClean, statuses[cleanPath])
+	assert.Equal(t, StatusDirty, statuses[dirtyPath])
+	assert.Equal(t, StatusActive, statuses[activePath])
+	assert.Equal(t, StatusUnknown, statuses[detachedPath])
+}
+
+func TestHasUncommittedChanges(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	dirty, err := repo.HasUncommittedChanges(testRepo.RepoDir)
+	require.NoError(t, err)
+	assert.False(t, dirty)
+
+	testRepo.CreateFile("staged.txt", "staged")
+	output, err := exec.Command("git", "-C", testRepo.RepoDir, "add", "staged.txt").CombinedOutput()
+	require.NoError(t, err, string(output))
+	dirty, err = repo.HasUncommittedChanges(testRepo.RepoDir)
+	require.NoError(t, err)
+	assert.True(t, dirty)
+
+	_, err = repo.HasUncommittedChanges(t.TempDir())
+	assert.Error(t, err)
+}
+
+func TestListWorktreeEntries(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	worktreePath := filepath.Join(testRepo.TempDir, "test-project-entries")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/entries", true))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "notes.txt"), []byte("wip"), 0644))
+
+	entries, err := repo.ListWorktreeEntries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, testRepo.RepoDir, entries[0].Path)
+	assert.Equal(t, worktreePath, entries[1].Path)
+	for _, entry := range entries {
+		assert.Empty(t, entry.Status, entry.Path)
+	}
+
+	assert.Equal(t, StatusDirty, repo.WorktreeStatus(worktreePath))
+	assert.Equal(t, StatusUnknown, repo.WorktreeStatus(t.TempDir()))
+
+	// Path and branch lookups do not compute statuses
+	_, err = repo.GetWorktreePath("feature/entries")
+	require.NoError(t, err)
+	_, err = repo.BranchForWorktree(worktreePath)
+	require.NoError(t, err)
+	entries, err = repo.ListWorktreeEntries()
+	require.NoError(t, err)
+	assert.Empty(t, entries[1].Status)
+
+	// Once ListWorktrees has computed the statuses they are reused
+	_, err = repo.ListWorktrees()
+	require.NoError(t, err)
+	entries, err = repo.ListWorktreeEntries()
+	require.NoError(t, err)
+	assert.Equal(t, StatusDirty, entries[1].Status)
+}
+
+func TestPruneWorktrees(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	keptPath := filepath.Join(testRepo.TempDir, "test-project-kept")
+	deletedPath := filepath.Join(testRepo.TempDir, "test-project-deleted")
+	require.NoError(t, repo.CreateWorktree(keptPath, "feature/kept", true))
+	require.NoError(t, repo.CreateWorktree(deletedPath, "feature/deleted", true))
+	require.NoError(t, os.RemoveAll(deletedPath))
+
+	candidates, err := repo.PruneCandidates()
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.Contains(t, candidates[0], "test-project-deleted")
+
+	// A dry run leaves the entry in place
+	_, err = repo.GetWorktreePath("feature/deleted")
+	require.NoError(t, err)
+
+	require.NoError(t, repo.PruneWorktrees())
+	_, err = repo.GetWorktreePath("feature/deleted")
+	assert.Error(t, err)
+	path, err := repo.GetWorktreePath("feature/kept")
+	require.NoError(t, err)
+	assert.Equal(t, keptPath, path)
+
+	candidates, err = repo.PruneCandidates()
+	require.NoError(t, err)
+	assert.Empty(t, candidates)
+}
+
+func TestMoveWorktree(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	oldPath := filepath.Join(testRepo.TempDir, "test-project-old")
+	newPath := filepath.Join(testRepo.TempDir, "test-project-new")
+	require.NoError(t, repo.CreateWorktree(oldPath, "feature/old", true))
+
+	require.NoError(t, repo.RenameBranch("feature/old", "feature/new"))
+	require.NoError(t, repo.MoveWorktree(oldPath, newPath))
+
+	path, err := repo.GetWorktreePath("feature/new")
+	require.NoError(t, err)
+	assert.Equal(t, newPath, path)
+	assert.NoDirExists(t, oldPath)
+
+	exists, err := repo.BranchExists("feature/old")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestParseWorktreeListBare(t *testing.T) {
+	worktrees, err := parseWorktreeList("worktree /repos/project.git\nbare\n\nworktree /repos/feature\nHEAD abc\nbranch refs/heads/feature\n")
+	require.NoError(t, err)
+	require.Len(t, worktrees, 2)
+	assert.Equal(t, StatusUnknown, worktrees[0].Status)
+	assert.Empty(t, worktrees[1].Status)
+}
+
 func TestUpdateGitignore(t *testing.T) {
 	// Create a test Git repository
 	testRepo := testutil.NewTestGitRepository(t, "test-project")
@@ -282,3 +565,560 @@ func TestGetWorktreePath(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestBranchForWorktree(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+
+	// Create a detached worktree behind hatcher's back
+	detachedPath := filepath.Join(testRepo.TempDir, "test-project-detached")
+	output, err := exec.Command("git", "-C", testRepo.RepoDir, "worktree", "add", "--detach", detachedPath).CombinedOutput()
+	require.NoError(t, err, string(output))
+	head, err := exec.Command("git", "-C", testRepo.RepoDir, "rev-parse", "--short=7", "HEAD").Output()
+	require.NoError(t, err)
+
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	branchName := "feature/lookup"
+	worktreePath := filepath.Join(testRepo.TempDir, "renamed-directory")
+	require.NoError(t, repo.CreateWorktree(worktreePath, branchName, true))
+	require.NoError(t, os.MkdirAll(filepath.Join(worktreePath, "sub", "dir"), 0755))
+
+	t.Run("worktree root", func(t *testing.T) {
+		branch, err := repo.BranchForWorktree(worktreePath)
+		require.NoError(t, err)
+		assert.Equal(t, branchName, branch)
+	})
+
+	t.Run("path inside a worktree", func(t *testing.T) {
+		branch, err := repo.BranchForWorktree(filepath.Join(worktreePath, "sub", "dir"))
+		require.NoError(t, err)
+		assert.Equal(t, branchName, branch)
+
+		branch, err = repo.BranchForWorktree(filepath.Join(testRepo.RepoDir, "."))
+		require.NoError(t, err)
+		assert.Equal(t, testRepo.GetCurrentBranch(), branch)
+	})
+
+	t.Run("symlinked path", func(t *testing.T) {
+		link := filepath.Join(t.TempDir(), "link")
+		require.NoError(t, os.Symlink(worktreePath, link))
+
+		branch, err := repo.BranchForWorktree(link)
+		require.NoError(t, err)
+		assert.Equal(t, branchName, branch)
+	})
+
+	t.Run("detached worktree", func(t *testing.T) {
+		branch, err := repo.BranchForWorktree(detachedPath)
+		require.NoError(t, err)
+		assert.Equal(t, "(detached at "+string(head[:7])+")", branch)
+	})
+
+	t.Run("path outside every worktree", func(t *testing.T) {
+		_, err := repo.BranchForWorktree(t.TempDir())
+		assert.ErrorIs(t, err, ErrNotWorktree)
+	})
+}
+
+func TestFilterIgnored(t *testing.T) {
+	// Create a test Git repository
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".gitignore", "*.log\nbuild/\n")
+	testRepo.CreateFile("keep.txt", "keep")
+	testRepo.CreateDirectory("build")
+
+	t.Run("returns ignored paths as given", func(t *testing.T) {
+		absolute := filepath.Join(testRepo.RepoDir, "debug.log")
+		ignored, err := repo.FilterIgnored([]string{"keep.txt", "build", "sub/trace.log", absolute})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"build", "sub/trace.log", absolute}, ignored)
+	})
+
+	t.Run("nothing ignored", func(t *testing.T) {
+		ignored, err := repo.FilterIgnored([]string{"keep.txt"})
+		require.NoError(t, err)
+		assert.Empty(t, ignored)
+	})
+
+	t.Run("no paths", func(t *testing.T) {
+		ignored, err := repo.FilterIgnored(nil)
+		require.NoError(t, err)
+		assert.Empty(t, ignored)
+	})
+}
+
+func TestCheckAttr(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile(".gitattributes", "secret.txt export-ignore\n*.md -export-ignore\n*.sh eol=lf\n")
+
+	t.Run("returns values as given", func(t *testing.T) {
+		absolute := filepath.Join(testRepo.RepoDir, "secret.txt")
+		values, err := repo.CheckAttr("export-ignore", []string{absolute, "README.md", "main.go"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			absolute:    "set",
+			"README.md": "unset",
+			"main.go":   "unspecified",
+		}, values)
+	})
+
+	t.Run("assigned value", func(t *testing.T) {
+		values, err := repo.CheckAttr("eol", []string{"build.sh"})
+		require.NoError(t, err)
+		assert.Equal(t, "lf", values["build.sh"])
+	})
+
+	t.Run("no paths", func(t *testing.T) {
+		values, err := repo.CheckAttr("export-ignore", nil)
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+}
+
+func TestCreateWorktreeCheckedOutElsewhere(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	t.Run("branch of another worktree", func(t *testing.T) {
+		first := filepath.Join(testRepo.TempDir, "first")
+		require.NoError(t, repo.CreateWorktree(first, "feature/twice", true))
+
+		err := repo.CreateWorktree(filepath.Join(testRepo.TempDir, "second"), "feature/twice", false)
+		require.ErrorIs(t, err, ErrBranchCheckedOutElsewhere)
+		assert.Contains(t, err.Error(), first)
+		assert.NoDirExists(t, filepath.Join(testRepo.TempDir, "second"))
+	})
+
+	t.Run("branch of the main worktree", func(t *testing.T) {
+		current := testRepo.GetCurrentBranch()
+		err := repo.CreateWorktree(filepath.Join(testRepo.TempDir, "main-copy"), current, false)
+		require.ErrorIs(t, err, ErrBranchCheckedOutElsewhere)
+		assert.Contains(t, err.Error(), testRepo.RepoDir)
+	})
+
+	t.Run("git messages", func(t *testing.T) {
+		for _, output := range []string{
+			"fatal: 'feature/x' is already checked out at '/work/app-feature-x'",
+			"fatal: 'feature/x' is already used by worktree at '/work/app-feature-x'",
+		} {
+			match := checkedOutPattern.FindStringSubmatch(output)
+			require.NotNil(t, match, output)
+			assert.Equal(t, "/work/app-feature-x", match[1])
+		}
+	})
+}
+
+func TestCreateWorktreeForceBranch(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	base := testRepo.GetCurrentBranch()
+	baseTip, err := repo.ResolveCommit(base)
+	require.NoError(t, err)
+
+	testRepo.CreateBranch("feature/reset")
+	testRepo.CreateFile("feature.txt", "feature work")
+	testRepo.CommitAll("Feature work")
+	featureTip, err := repo.ResolveCommit("feature/reset")
+	require.NoError(t, err)
+	require.NotEqual(t, baseTip, featureTip)
+	testRepo.SwitchToBranch(base)
+
+	t.Run("resets an existing branch", func(t *testing.T) {
+		path := filepath.Join(testRepo.TempDir, "reset")
+		require.NoError(t, repo.CreateWorktreeForceBranch(path, "feature/reset", base))
+
+		tip, err := repo.ResolveCommit("feature/reset")
+		require.NoError(t, err)
+		assert.Equal(t, baseTip, tip)
+		assert.NoFileExists(t, filepath.Join(path, "feature.txt"))
+	})
+
+	t.Run("creates a missing branch", func(t *testing.T) {
+		require.NoError(t, repo.CreateWorktreeForceBranch(filepath.Join(testRepo.TempDir, "fresh"), "feature/fresh", base))
+
+		tip, err := repo.ResolveCommit("feature/fresh")
+		require.NoError(t, err)
+		assert.Equal(t, baseTip, tip)
+	})
+
+	t.Run("branch of the main worktree", func(t *testing.T) {
+		err := repo.CreateWorktreeForceBranch(filepath.Join(testRepo.TempDir, "main-reset"), base, featureTip)
+		require.ErrorIs(t, err, ErrBranchCheckedOutElsewhere)
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := repo.ResolveCommit("does-not-exist")
+		assert.Error(t, err)
+	})
+}
+
+func TestIsTracked(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	testRepo.CreateFile("config/.cursorrules", "rules")
+	testRepo.CreateFile("config/[draft].md", "draft")
+	testRepo.CommitAll("Add config")
+	testRepo.CreateFile("config/local.md", "local")
+	testRepo.CreateFile("config/d.md", "untracked")
+
+	tests := []struct {
+		path    string
+		tracked bool
+	}{
+		{"README.md", true},
+		{"config/.cursorrules", true},
+		{filepath.Join(testRepo.RepoDir, "config", ".cursorrules"), true},
+		{"config/local.md", false},
+		{"missing.txt", false},
+		// Directories holding tracked files are not tracked themselves
+		{"config", false},
+		// Glob characters are matched literally
+		{"config/[draft].md", true},
+		{"config/d.md", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			tracked, err := repo.IsTracked(testRepo.RepoDir, tt.path)
+			require.NoError(t, err)
+			assert.Equal(t, tt.tracked, tracked)
+		})
+	}
+
+	t.Run("other worktree", func(t *testing.T) {
+		worktreePath := filepath.Join(testRepo.TempDir, "other")
+		require.NoError(t, repo.CreateWorktree(worktreePath, "other", true))
+
+		tracked, err := repo.IsTracked(worktreePath, filepath.Join(worktreePath, "config", ".cursorrules"))
+		require.NoError(t, err)
+		assert.True(t, tracked)
+
+		tracked, err = repo.IsTracked(worktreePath, "config/local.md")
+		require.NoError(t, err)
+		assert.False(t, tracked)
+	})
+
+	t.Run("not a repository", func(t *testing.T) {
+		_, err := repo.IsTracked(t.TempDir(), "README.md")
+		assert.Error(t, err)
+	})
+}
+
+func TestRepositoryCache(t *testing.T) {
+	// Create a test Git repository
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	worktrees, err := repo.ListWorktrees()
+	require.NoError(t, err)
+	require.Len(t, worktrees, 1)
+
+	branch, err := repo.GetCurrentBranch()
+	require.NoError(t, err)
+
+	t.Run("values are cached", func(t *testing.T) {
+		// Changes made behind the repository's back are not seen
+		testRepo.CreateBranch("feature/outside")
+		cmd := exec.Command("git", "worktree", "add", "-b", "feature/outside-wt", filepath.Join(testRepo.TempDir, "outside"))
+		cmd.Dir = testRepo.RepoDir
+		require.NoError(t, cmd.Run())
+
+		cached, err := repo.ListWorktrees()
+		require.NoError(t, err)
+		assert.Len(t, cached, 1)
+
+		cachedBranch, err := repo.GetCurrentBranch()
+		require.NoError(t, err)
+		assert.Equal(t, branch, cachedBranch)
+	})
+
+	t.Run("worktree add invalidates the cache", func(t *testing.T) {
+		err := repo.CreateWorktree(filepath.Join(testRepo.TempDir, "cache-test"), "feature/cache", true)
+		require.NoError(t, err)
+
+		worktrees, err := repo.ListWorktrees()
+		require.NoError(t, err)
+		assert.Len(t, worktrees, 3)
+	})
+
+	t.Run("concurrent lookups", func(t *testing.T) {
+		repo.invalidateCache()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(3)
+			go func() {
+				defer wg.Done()
+				_, err := repo.GetCurrentBranch()
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := repo.ListWorktrees()
+				assert.NoError(t, err)
+			}()
+			go func() {
+				defer wg.Done()
+				_, err := repo.ListWorktreeEntries()
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("returned slices do not alias the cache", func(t *testing.T) {
+		worktrees, err := repo.ListWorktrees()
+		require.NoError(t, err)
+		worktrees[0].Branch = "modified"
+
+		again, err := repo.ListWorktrees()
+		require.NoError(t, err)
+		assert.NotEqual(t, "modified", again[0].Branch)
+	})
+}
+
+func TestGetDefaultBranch(t *testing.T) {
+	// renameBranch makes name the only local branch besides feature branches
+	renameBranch := func(testRepo *testutil.TestGitRepository, name string) {
+		cmd := exec.Command("git", "branch", "-M", name)
+		cmd.Dir = testRepo.RepoDir
+		require.NoError(t, cmd.Run())
+	}
+
+	t.Run("master without a remote", func(t *testing.T) {
+		testRepo := testutil.NewTestGitRepository(t, "default-master")
+		renameBranch(testRepo, "master")
+		testRepo.CreateBranch("feature/work")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		branch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "master", branch)
+	})
+
+	t.Run("main is preferred over master", func(t *testing.T) {
+		testRepo := testutil.NewTestGitRepository(t, "default-main")
+		renameBranch(testRepo, "master")
+		testRepo.CreateBranch("main")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		branch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "main", branch)
+	})
+
+	t.Run("origin HEAD", func(t *testing.T) {
+		remote := testutil.NewTestGitRepository(t, "default-remote")
+		renameBranch(remote, "develop")
+
+		testRepo := testutil.NewTestGitRepository(t, "default-origin")
+		renameBranch(testRepo, "master")
+		for _, args := range [][]string{
+			{"remote", "add", "origin", remote.RepoDir},
+			{"fetch", "origin"},
+			{"remote", "set-head", "origin", "develop"},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = testRepo.RepoDir
+			require.NoError(t, cmd.Run())
+		}
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		// Only the remote has develop
+		branch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "origin/develop", branch)
+
+		repo.invalidateCache()
+		testRepo.CreateBranch("develop")
+		branch, err = repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "develop", branch)
+	})
+
+	t.Run("no default branch", func(t *testing.T) {
+		testRepo := testutil.NewTestGitRepository(t, "default-none")
+		renameBranch(testRepo, "trunk")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		_, err = repo.GetDefaultBranch()
+		assert.Error(t, err)
+	})
+
+	t.Run("cached until branches change", func(t *testing.T) {
+		testRepo := testutil.NewTestGitRepository(t, "default-cached")
+		renameBranch(testRepo, "master")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		branch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "master", branch)
+
+		// Created behind the repository's back, so the cached value stays
+		testRepo.CreateBranch("main")
+		branch, err = repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "master", branch)
+
+		require.NoError(t, repo.CreateBranch("feature/new"))
+		branch, err = repo.GetDefaultBranch()
+		require.NoError(t, err)
+		assert.Equal(t, "main", branch)
+	})
+}
+
+func TestMergedBranches(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "merged-branches")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	mainBranch := testRepo.GetCurrentBranch()
+
+	testRepo.CreateBranch("feature/merged")
+	testRepo.CreateBranch("feature/unmerged")
+	testRepo.SwitchToBranch("feature/unmerged")
+	testRepo.CreateFile("work.txt", "work")
+	testRepo.CommitAll("Work in progress")
+	testRepo.SwitchToBranch(mainBranch)
+
+	t.Run("lists branches reachable from the ref", func(t *testing.T) {
+		branches, err := repo.MergedBranches(mainBranch)
+		require.NoError(t, err)
+		assert.Contains(t, branches, "feature/merged")
+		assert.Contains(t, branches, mainBranch)
+		assert.NotContains(t, branches, "feature/unmerged")
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := repo.MergedBranches("missing")
+		assert.Error(t, err)
+	})
+}
+
+func TestGetAheadBehind(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "ahead-behind")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	mainBranch := testRepo.GetCurrentBranch()
+
+	testRepo.CreateBranch("feature/ahead")
+	testRepo.CreateFile("one.txt", "one")
+	testRepo.CommitAll("One")
+	testRepo.CreateFile("two.txt", "two")
+	testRepo.CommitAll("Two")
+	testRepo.SwitchToBranch(mainBranch)
+	testRepo.CreateFile("main.txt", "main")
+	testRepo.CommitAll("Main")
+
+	t.Run("diverged branches", func(t *testing.T) {
+		ahead, behind, err := repo.GetAheadBehind("feature/ahead", mainBranch)
+		require.NoError(t, err)
+		assert.Equal(t, 2, ahead)
+		assert.Equal(t, 1, behind)
+
+		ahead, behind, err = repo.GetAheadBehind(mainBranch, "feature/ahead")
+		require.NoError(t, err)
+		assert.Equal(t, 1, ahead)
+		assert.Equal(t, 2, behind)
+	})
+
+	t.Run("same branch", func(t *testing.T) {
+		ahead, behind, err := repo.GetAheadBehind(mainBranch, mainBranch)
+		require.NoError(t, err)
+		assert.Zero(t, ahead)
+		assert.Zero(t, behind)
+	})
+
+	t.Run("unknown branch", func(t *testing.T) {
+		_, _, err := repo.GetAheadBehind("feature/missing", mainBranch)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNoCommonAncestor)
+	})
+
+	t.Run("unrelated histories", func(t *testing.T) {
+		output, err := exec.Command("git", "-C", testRepo.RepoDir, "checkout", "--orphan", "unrelated").CombinedOutput()
+		require.NoError(t, err, string(output))
+		testRepo.CreateFile("unrelated.txt", "unrelated")
+		testRepo.CommitAll("Unrelated")
+		testRepo.SwitchToBranch(mainBranch)
+
+		_, _, err = repo.GetAheadBehind("unrelated", mainBranch)
+		assert.ErrorIs(t, err, ErrNoCommonAncestor)
+	})
+}
+
+func TestParseAheadBehind(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		ahead  int
+		behind int
+		valid  bool
+	}{
+		{"diverged", "1\t2\n", 2, 1, true},
+		{"up to date", "0\t0\n", 0, 0, true},
+		{"space separated", "3 0", 0, 3, true},
+		{"empty", "", 0, 0, false},
+		{"single count", "4\n", 0, 0, false},
+		{"extra field", "1\t2\t3\n", 0, 0, false},
+		{"not a number", "one\t2\n", 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ahead, behind, err := parseAheadBehind(tt.output)
+			if !tt.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.ahead, ahead)
+			assert.Equal(t, tt.behind, behind)
+		})
+	}
+}
+
+func TestFetchRemote(t *testing.T) {
+	remote := testutil.NewTestGitRepository(t, "fetch-remote")
+	remote.CreateBranch("feature/remote")
+
+	testRepo := testutil.NewTestGitRepository(t, "fetch-local")
+	repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	t.Run("unknown remote", func(t *testing.T) {
+		assert.Error(t, repo.FetchRemote("origin"))
+	})
+
+	t.Run("updates remote-tracking branches", func(t *testing.T) {
+		cmd := exec.Command("git", "remote", "add", "origin", remote.RepoDir)
+		cmd.Dir = testRepo.RepoDir
+		require.NoError(t, cmd.Run())
+
+		exists, err := repo.RemoteBranchExists("feature/remote")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, repo.FetchRemote("origin"))
+		exists, err = repo.RemoteBranchExists("feature/remote")
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+}
@@ -0,0 +1,144 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Version is a git release version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// String returns the version as major.minor.patch
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the same as or newer than other
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// Feature is a git feature hatcher uses, with the release that introduced it
+type Feature struct {
+	Name       string
+	MinVersion Version
+}
+
+// Git features hatcher uses
+var (
+	FeatureWorktreeAdd    = Feature{"git worktree add", Version{2, 5, 0}}
+	FeatureWorktreeList   = Feature{"git worktree list --porcelain", Version{2, 7, 0}}
+	FeatureWorktreeRemove = Feature{"git worktree remove", Version{2, 17, 0}}
+	FeatureWorktreeMove   = Feature{"git worktree move", Version{2, 17, 0}}
+)
+
+// Features lists the git features hatcher uses, oldest first
+var Features = []Feature{
+	FeatureWorktreeAdd,
+	FeatureWorktreeList,
+	FeatureWorktreeRemove,
+	FeatureWorktreeMove,
+}
+
+// MinimumVersion is the oldest git release supporting every feature in
+// Features
+var MinimumVersion = Version{2, 17, 0}
+
+// UnsupportedFeatures returns the features that v does not support
+func UnsupportedFeatures(v Version) []Feature {
+	var unsupported []Feature
+	for _, feature := range Features {
+		if !v.AtLeast(feature.MinVersion) {
+			unsupported = append(unsupported, feature)
+		}
+	}
+	return unsupported
+}
+
+var versionPattern = regexp.MustCompile(`(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// ParseVersion parses the output of 'git --version', such as
+// "git version 2.39.2 (Apple Git-143)" or "git version 2.42.0.windows.1"
+func ParseVersion(output string) (Version, error) {
+	output = strings.TrimSpace(output)
+	match := versionPattern.FindStringSubmatch(strings.TrimPrefix(output, "git version"))
+	if match == nil {
+		return Version{}, fmt.Errorf("unrecognized git version: %q", output)
+	}
+
+	var v Version
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		v.Patch, _ = strconv.Atoi(match[3])
+	}
+	return v, nil
+}
+
+// VersionOutput returns the output of 'git --version' for the git on PATH,
+// e.g. "git version 2.39.2 (Apple Git-143)"
+func VersionOutput() (string, error) {
+	output, err := outputGit(exec.Command("git", "--version"))
+	if err != nil {
+		return "", fmt.Errorf("failed to run git --version: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// InstalledVersion returns the version of the git on PATH
+func InstalledVersion() (Version, error) {
+	output, err := VersionOutput()
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(output)
+}
+
+// GitVersion returns the version of the installed git, detected once per
+// repository instance
+func (r *GitRepository) GitVersion() (Version, error) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	if r.gitVersion == nil {
+		version, err := InstalledVersion()
+		if err != nil {
+			return Version{}, err
+		}
+		r.gitVersion = &version
+	}
+	return *r.gitVersion, nil
+}
+
+// SupportsFeature reports whether the installed git supports feature. When
+// the version cannot be determined the feature is assumed to be supported,
+// leaving git to report any problem itself.
+func (r *GitRepository) SupportsFeature(feature Feature) bool {
+	version, err := r.GitVersion()
+	if err != nil {
+		return true
+	}
+	return version.AtLeast(feature.MinVersion)
+}
+
+// requireFeature returns an error naming the required git version when the
+// installed git does not support feature
+func (r *GitRepository) requireFeature(feature Feature) error {
+	if r.SupportsFeature(feature) {
+		return nil
+	}
+	return fmt.Errorf("%s requires git >= %s", feature.Name, feature.MinVersion)
+}
@@ -0,0 +1,165 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		output string
+		want   Version
+	}{
+		{"git version 2.39.2", Version{2, 39, 2}},
+		{"git version 2.39.2 (Apple Git-143)\n", Version{2, 39, 2}},
+		{"git version 2.42.0.windows.1", Version{2, 42, 0}},
+		{"git version 2.17.0.rc1", Version{2, 17, 0}},
+		{"git version 1.8", Version{1, 8, 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.output, func(t *testing.T) {
+			version, err := ParseVersion(tt.output)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+		})
+	}
+
+	t.Run("unrecognized output", func(t *testing.T) {
+		_, err := ParseVersion("git: command not found")
+		assert.Error(t, err)
+	})
+}
+
+func TestVersion_AtLeast(t *testing.T) {
+	v := Version{2, 17, 1}
+	assert.True(t, v.AtLeast(Version{2, 17, 1}))
+	assert.True(t, v.AtLeast(Version{2, 17, 0}))
+	assert.True(t, v.AtLeast(Version{1, 99, 99}))
+	assert.False(t, v.AtLeast(Version{2, 17, 2}))
+	assert.False(t, v.AtLeast(Version{2, 18, 0}))
+	assert.False(t, v.AtLeast(Version{3, 0, 0}))
+}
+
+func TestUnsupportedFeatures(t *testing.T) {
+	assert.Empty(t, UnsupportedFeatures(MinimumVersion))
+
+	unsupported := UnsupportedFeatures(Version{2, 10, 0})
+	require.Len(t, unsupported, 2)
+	assert.Equal(t, "git worktree remove", unsupported[0].Name)
+	assert.Equal(t, "git worktree move", unsupported[1].Name)
+
+	// MinimumVersion covers every listed feature
+	for _, feature := range Features {
+		assert.True(t, MinimumVersion.AtLeast(feature.MinVersion), feature.Name)
+	}
+}
+
+// useGitVersion puts a git wrapper on PATH that reports version and fails
+// 'git worktree remove', passing everything else to the real git
+func useGitVersion(t *testing.T, version string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the git wrapper is a shell script")
+	}
+
+	realGit, err := exec.LookPath("git")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	script := fmt.Sprintf(`#!/bin/sh
+if [ "$1" = "--version" ]; then
+	echo "git version %s"
+	exit 0
+fi
+if [ "$1" = "worktree" ] && [ "$2" = "remove" ]; then
+	echo "git: 'worktree remove' is not a git command" >&2
+	exit 1
+fi
+exec %q "$@"
+`, version, realGit)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "git"), []byte(script), 0755))
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestVersionOutput(t *testing.T) {
+	useGitVersion(t, "2.39.2 (Apple Git-143)")
+
+	output, err := VersionOutput()
+	require.NoError(t, err)
+	assert.Equal(t, "git version 2.39.2 (Apple Git-143)", output)
+
+	version, err := InstalledVersion()
+	require.NoError(t, err)
+	assert.Equal(t, Version{2, 39, 2}, version)
+}
+
+func TestSupportsFeature(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "feature-test")
+
+	t.Run("new git", func(t *testing.T) {
+		useGitVersion(t, "2.43.0")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		version, err := repo.GitVersion()
+		require.NoError(t, err)
+		assert.Equal(t, Version{2, 43, 0}, version)
+		assert.True(t, repo.SupportsFeature(FeatureWorktreeRemove))
+	})
+
+	t.Run("old git removes worktrees manually", func(t *testing.T) {
+		useGitVersion(t, "2.15.1")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+		assert.False(t, repo.SupportsFeature(FeatureWorktreeRemove))
+		assert.True(t, repo.SupportsFeature(FeatureWorktreeList))
+
+		worktreePath := filepath.Join(testRepo.TempDir, "old-git-worktree")
+		require.NoError(t, repo.CreateWorktree(worktreePath, "feature/old-git", true))
+
+		// Untracked files need force, as with git worktree remove
+		require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "scratch.txt"), []byte("wip"), 0644))
+		err = repo.RemoveWorktree(worktreePath, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--force")
+		assert.DirExists(t, worktreePath)
+
+		// Moving has no fallback
+		err = repo.MoveWorktree(worktreePath, filepath.Join(testRepo.TempDir, "old-git-moved"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "git worktree move requires git >= 2.17.0")
+		assert.DirExists(t, worktreePath)
+
+		require.NoError(t, repo.RemoveWorktree(worktreePath, true))
+		assert.NoDirExists(t, worktreePath)
+
+		worktrees, err := repo.ListWorktrees()
+		require.NoError(t, err)
+		for _, wt := range worktrees {
+			assert.NotEqual(t, "feature/old-git", wt.Branch)
+		}
+	})
+
+	t.Run("too old git fails with the required version", func(t *testing.T) {
+		useGitVersion(t, "2.4.0")
+		repo, err := NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		err = repo.CreateWorktree(filepath.Join(testRepo.TempDir, "too-old"), "feature/too-old", true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires git >= 2.5.0")
+
+		_, err = repo.ListWorktrees()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires git >= 2.7.0")
+	})
+}
@@ -0,0 +1,127 @@
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// Orders accepted by ListOptions.Sort
+const (
+	SortBranch = "branch" // Main repository first, then by branch name
+	SortPath   = "path"   // Main repository first, then by path
+	SortMtime  = "mtime"  // Most recently modified first
+)
+
+// activityWalkLimit bounds the entries lastModified inspects per worktree,
+// so huge worktrees (e.g. with node_modules) are not walked completely
+const activityWalkLimit = 10000
+
+// errWalkLimit stops a walk at activityWalkLimit
+var errWalkLimit = errors.New("walk limit reached")
+
+// ValidateListSort checks a ListOptions.Sort value. An empty value sorts by
+// branch.
+func ValidateListSort(order string) error {
+	switch order {
+	case "", SortBranch, SortPath, SortMtime:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort %q (use branch, path or mtime)", order)
+	}
+}
+
+// lastModified returns the latest modification time of the files and
+// directories in the worktree at path, skipping its git metadata. At most
+// activityWalkLimit entries are inspected.
+func lastModified(path string) (time.Time, error) {
+	var latest time.Time
+	entries := 0
+
+	err := filepath.WalkDir(path, func(walkPath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable entries don't change how recently the worktree was used
+			if walkPath == path {
+				return err
+			}
+			return nil
+		}
+		if filepath.Dir(walkPath) == path && d.Name() == ".git" {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		entries++
+		if entries > activityWalkLimit {
+			return errWalkLimit
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errWalkLimit) {
+		return time.Time{}, fmt.Errorf("failed to read worktree %s: %w", path, err)
+	}
+	return latest, nil
+}
+
+// addLastModified sets LastModified on every worktree. Worktrees whose
+// directory is gone keep a zero time.
+func addLastModified(worktrees []WorktreeInfo) error {
+	for i := range worktrees {
+		modified, err := lastModified(worktrees[i].Path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return err
+		}
+		worktrees[i].LastModified = modified
+	}
+	return nil
+}
+
+// staleWorktrees returns the worktrees not modified within staleFor of now
+func staleWorktrees(worktrees []WorktreeInfo, staleFor time.Duration, now time.Time) []WorktreeInfo {
+	cutoff := now.Add(-staleFor)
+
+	var stale []WorktreeInfo
+	for _, wt := range worktrees {
+		if wt.LastModified.Before(cutoff) {
+			stale = append(stale, wt)
+		}
+	}
+	return stale
+}
+
+// sortWorktrees orders worktrees as order selects
+func sortWorktrees(worktrees []WorktreeInfo, order string) {
+	if order == SortMtime {
+		sort.SliceStable(worktrees, func(i, j int) bool {
+			return worktrees[i].LastModified.After(worktrees[j].LastModified)
+		})
+		return
+	}
+
+	sort.SliceStable(worktrees, func(i, j int) bool {
+		// Main repository first
+		if worktrees[i].IsMain != worktrees[j].IsMain {
+			return worktrees[i].IsMain
+		}
+		if order == SortPath {
+			return worktrees[i].Path < worktrees[j].Path
+		}
+		return worktrees[i].Branch < worktrees[j].Branch
+	})
+}
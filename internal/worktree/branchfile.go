@@ -0,0 +1,42 @@
+package worktree
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadBranchFile reads branch names from the file at path, one per line.
+// Blank lines and lines starting with # are skipped, as is text after a #
+// following whitespace. Duplicates are dropped, keeping the first occurrence.
+func ReadBranchFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open branch file: %w", err)
+	}
+	defer file.Close()
+
+	var branches []string
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || seen[line] {
+			continue
+		}
+
+		seen[line] = true
+		branches = append(branches, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read branch file: %w", err)
+	}
+
+	return branches, nil
+}
@@ -0,0 +1,36 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBranchFile(t *testing.T) {
+	t.Run("branches with comments", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "branches.txt")
+		content := "# PRs to review\nfeature/a\n\n  feature/b  \nfix/c # flaky test\nfeature/a\n\t# indented comment\n"
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+		branches, err := ReadBranchFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"feature/a", "feature/b", "fix/c"}, branches)
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "branches.txt")
+		require.NoError(t, os.WriteFile(path, []byte("# nothing yet\n"), 0644))
+
+		branches, err := ReadBranchFile(path)
+		require.NoError(t, err)
+		assert.Empty(t, branches)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ReadBranchFile(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
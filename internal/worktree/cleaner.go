@@ -0,0 +1,131 @@
+package worktree
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+// CleanOptions selects the worktrees Cleaner offers for removal. A worktree
+// is selected when it matches any of the enabled criteria.
+type CleanOptions struct {
+	Merged   bool          // Select worktrees whose branch is merged into Into
+	Into     string        // Branch merges are checked against; the default branch when empty
+	StaleFor time.Duration // Select worktrees whose files were not modified for this long (0 disables)
+}
+
+// CleanCandidate is a worktree selected for removal
+type CleanCandidate struct {
+	WorktreeInfo
+	Merged  bool     // Whether the branch is merged, so it can be deleted safely
+	Reasons []string // Why the worktree was selected
+}
+
+// Cleaner selects hatcher-managed worktrees to remove in bulk
+type Cleaner struct {
+	repo   git.Repository
+	finder *Finder
+}
+
+// NewCleaner creates a new Cleaner instance
+func NewCleaner(repo git.Repository) *Cleaner {
+	return &Cleaner{
+		repo:   repo,
+		finder: NewFinder(repo),
+	}
+}
+
+// SetLayout sets how hatcher-managed worktrees are recognized
+func (c *Cleaner) SetLayout(layout Layout) {
+	c.finder.SetLayout(layout)
+}
+
+// Candidates returns the hatcher-managed worktrees options select, sorted by
+// branch. The main repository, the current worktree and worktrees without a
+// branch are never selected.
+func (c *Cleaner) Candidates(options CleanOptions) ([]CleanCandidate, error) {
+	if !options.Merged && options.StaleFor <= 0 {
+		return nil, fmt.Errorf("no clean criteria: select merged and/or stale worktrees")
+	}
+
+	worktrees, err := c.finder.ListHatcherWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	mainPath, err := mainWorktreePath(c.repo)
+	if err != nil {
+		return nil, err
+	}
+	currentPath, err := c.repo.GetRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current worktree: %w", err)
+	}
+
+	into, merged, err := c.mergedBranches(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []CleanCandidate
+	for _, wt := range worktrees {
+		if !wt.IsHatcherManaged || wt.Branch == "" || wt.Branch == into {
+			continue
+		}
+		if sameDir(wt.Path, mainPath) || sameDir(wt.Path, currentPath) {
+			continue
+		}
+
+		candidate := CleanCandidate{WorktreeInfo: wt, Merged: merged[wt.Branch]}
+		if options.Merged && candidate.Merged {
+			candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("merged into %s", into))
+		}
+		if options.StaleFor > 0 {
+			modified, err := lastModified(wt.Path)
+			if err != nil {
+				return nil, err
+			}
+			candidate.LastModified = modified
+			if modified.Before(time.Now().Add(-options.StaleFor)) {
+				candidate.Reasons = append(candidate.Reasons, fmt.Sprintf("not modified since %s", modified.Local().Format("2006-01-02")))
+			}
+		}
+
+		if len(candidate.Reasons) > 0 {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Branch < candidates[j].Branch
+	})
+	return candidates, nil
+}
+
+// mergedBranches returns the branch merges are checked against and the set
+// of branches merged into it. Without Merged, an undeterminable default
+// branch just leaves every branch unmerged.
+func (c *Cleaner) mergedBranches(options CleanOptions) (string, map[string]bool, error) {
+	into := options.Into
+	if into == "" {
+		branch, err := c.repo.GetDefaultBranch()
+		if err != nil {
+			if options.Merged {
+				return "", nil, err
+			}
+			return "", map[string]bool{}, nil
+		}
+		into = branch
+	}
+
+	branches, err := c.repo.MergedBranches(into)
+	if err != nil {
+		return "", nil, err
+	}
+	merged := make(map[string]bool, len(branches))
+	for _, branch := range branches {
+		merged[branch] = true
+	}
+	return into, merged, nil
+}
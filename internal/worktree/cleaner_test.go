@@ -0,0 +1,99 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleaner_Candidates(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "cleaner-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	mainBranch := testRepo.GetCurrentBranch()
+
+	createWorktree := func(branch string) string {
+		path := filepath.Join(testRepo.TempDir, "cleaner-test-"+branch)
+		require.NoError(t, repo.CreateWorktree(path, branch, true))
+		return path
+	}
+
+	// A worktree without new commits is merged into the main branch
+	mergedPath := createWorktree("merged")
+
+	unmergedPath := createWorktree("unmerged")
+	require.NoError(t, os.WriteFile(filepath.Join(unmergedPath, "work.txt"), []byte("work"), 0644))
+	for _, args := range [][]string{{"add", "."}, {"commit", "-m", "Work in progress"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = unmergedPath
+		require.NoError(t, cmd.Run())
+	}
+
+	// Not hatcher-managed, so never selected
+	external := filepath.Join(t.TempDir(), "external")
+	require.NoError(t, repo.CreateWorktree(external, "external", true))
+
+	cleaner := NewCleaner(repo)
+
+	t.Run("merged branches", func(t *testing.T) {
+		candidates, err := cleaner.Candidates(CleanOptions{Merged: true, Into: mainBranch})
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, "merged", candidates[0].Branch)
+		assert.Equal(t, mergedPath, candidates[0].Path)
+		assert.True(t, candidates[0].Merged)
+		assert.Equal(t, []string{"merged into " + mainBranch}, candidates[0].Reasons)
+	})
+
+	t.Run("stale worktrees", func(t *testing.T) {
+		// Nothing was modified a day ago
+		candidates, err := cleaner.Candidates(CleanOptions{Into: mainBranch, StaleFor: 24 * time.Hour})
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+
+		old := time.Now().Add(-48 * time.Hour)
+		require.NoError(t, filepath.Walk(unmergedPath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			return os.Chtimes(path, old, old)
+		}))
+
+		candidates, err = cleaner.Candidates(CleanOptions{Into: mainBranch, StaleFor: 24 * time.Hour})
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+		assert.Equal(t, "unmerged", candidates[0].Branch)
+		assert.False(t, candidates[0].Merged)
+		require.Len(t, candidates[0].Reasons, 1)
+		assert.Contains(t, candidates[0].Reasons[0], "not modified since")
+	})
+
+	t.Run("merged or stale", func(t *testing.T) {
+		candidates, err := cleaner.Candidates(CleanOptions{Merged: true, Into: mainBranch, StaleFor: 24 * time.Hour})
+		require.NoError(t, err)
+		require.Len(t, candidates, 2)
+		assert.Equal(t, "merged", candidates[0].Branch)
+		assert.Equal(t, "unmerged", candidates[1].Branch)
+	})
+
+	t.Run("never selects the current worktree", func(t *testing.T) {
+		current, err := git.NewRepositoryFromPath(mergedPath)
+		require.NoError(t, err)
+
+		candidates, err := NewCleaner(current).Candidates(CleanOptions{Merged: true, Into: mainBranch})
+		require.NoError(t, err)
+		assert.Empty(t, candidates)
+	})
+
+	t.Run("no criteria", func(t *testing.T) {
+		_, err := cleaner.Candidates(CleanOptions{})
+		assert.Error(t, err)
+	})
+}
@@ -3,7 +3,6 @@ package worktree
 import (
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/keisukeshimizu/hatcher/internal/git"
@@ -11,7 +10,8 @@ import (
 
 // Creator handles worktree creation logic
 type Creator struct {
-	repo git.Repository
+	repo   git.Repository
+	layout Layout
 }
 
 // NewCreator creates a new worktree creator
@@ -21,6 +21,12 @@ func NewCreator(repo git.Repository) *Creator {
 	}
 }
 
+// SetLayout sets where worktrees are created and how their directories are
+// named
+func (c *Creator) SetLayout(layout Layout) {
+	c.layout = layout
+}
+
 // CreateOptions contains options for worktree creation
 type CreateOptions struct {
 	BranchName        string
@@ -28,6 +34,14 @@ type CreateOptions struct {
 	NoCopy            bool
 	NoGitignoreUpdate bool
 	DryRun            bool
+	// BaseRef is the commit a new branch starts from; HEAD when empty
+	BaseRef string
+	// ForceBranch resets an existing branch to BaseRef instead of checking
+	// out its current tip
+	ForceBranch bool
+	// TrackRemote creates a branch that only exists on origin from the
+	// freshly fetched origin/<branch>, tracking it
+	TrackRemote bool
 }
 
 // CreateResult contains the result of worktree creation
@@ -36,6 +50,12 @@ type CreateResult struct {
 	BranchName   string
 	IsNewBranch  bool
 	Message      string
+	// PreviousTip is the commit an existing branch pointed at before
+	// ForceBranch reset it, so it can be recovered
+	PreviousTip string
+	// TracksRemote reports that the branch was created to track
+	// origin/<branch>
+	TracksRemote bool
 }
 
 // Create creates a new worktree with the specified options
@@ -52,11 +72,13 @@ func (c *Creator) Create(opts CreateOptions) (*CreateResult, error) {
 	}
 
 	projectName := c.repo.GetProjectName()
-	branchNameSafe := SanitizeBranchName(opts.BranchName)
-	dirName := fmt.Sprintf("%s-%s", projectName, branchNameSafe)
+	worktreePath := c.layout.WorktreePath(root, projectName, opts.BranchName)
 
-	parentDir := filepath.Dir(root)
-	worktreePath := filepath.Join(parentDir, dirName)
+	// A branch can only be checked out once; refuse before --force removes
+	// the existing worktree's directory
+	if existing, err := c.repo.GetWorktreePath(opts.BranchName); err == nil {
+		return nil, fmt.Errorf("%w: %s is checked out at %s", git.ErrBranchCheckedOutElsewhere, opts.BranchName, existing)
+	}
 
 	// Check if directory already exists
 	if _, err := os.Stat(worktreePath); err == nil && !opts.Force {
@@ -75,16 +97,59 @@ func (c *Creator) Create(opts CreateOptions) (*CreateResult, error) {
 	}
 
 	isNewBranch := !localExists && !remoteExists
+	trackRemote := opts.TrackRemote && !localExists && remoteExists && opts.BaseRef == ""
+	upstream := "origin/" + opts.BranchName
+
+	var previousTip string
+	if opts.ForceBranch {
+		if opts.BaseRef == "" {
+			return nil, fmt.Errorf("resetting a branch requires a base ref")
+		}
+		// Resetting creates the local branch, discarding only its own tip
+		isNewBranch = !localExists
+		if localExists {
+			if previousTip, err = c.repo.ResolveCommit(opts.BranchName); err != nil {
+				return nil, fmt.Errorf("failed to resolve current tip of %s: %w", opts.BranchName, err)
+			}
+		}
+	} else if opts.BaseRef != "" && !isNewBranch {
+		return nil, fmt.Errorf("branch %s already exists (use --force-branch to reset it to %s)", opts.BranchName, opts.BaseRef)
+	}
+
+	if opts.BaseRef != "" {
+		exists, err := c.repo.RefExists(opts.BaseRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check base ref: %w", err)
+		}
+		if !exists {
+			return nil, fmt.Errorf("base ref not found: %s", opts.BaseRef)
+		}
+	}
 
 	if opts.DryRun {
+		message := fmt.Sprintf("Would create worktree at: %s", worktreePath)
+		if previousTip != "" {
+			message = fmt.Sprintf("Would reset %s to %s and create worktree at: %s", opts.BranchName, opts.BaseRef, worktreePath)
+		} else if trackRemote {
+			message = fmt.Sprintf("Would fetch origin and create worktree tracking %s at: %s", upstream, worktreePath)
+		}
 		return &CreateResult{
 			WorktreePath: worktreePath,
 			BranchName:   opts.BranchName,
 			IsNewBranch:  isNewBranch,
-			Message:      fmt.Sprintf("Would create worktree at: %s", worktreePath),
+			Message:      message,
+			PreviousTip:  previousTip,
+			TracksRemote: trackRemote,
 		}, nil
 	}
 
+	// Start from the remote's current tip, not a stale remote-tracking branch
+	if trackRemote {
+		if err := c.repo.FetchRemote("origin"); err != nil {
+			return nil, err
+		}
+	}
+
 	// Remove existing directory if force is enabled
 	if opts.Force {
 		if err := os.RemoveAll(worktreePath); err != nil {
@@ -93,7 +158,17 @@ func (c *Creator) Create(opts CreateOptions) (*CreateResult, error) {
 	}
 
 	// Create the worktree
-	if err := c.repo.CreateWorktree(worktreePath, opts.BranchName, isNewBranch); err != nil {
+	switch {
+	case opts.ForceBranch:
+		err = c.repo.CreateWorktreeForceBranch(worktreePath, opts.BranchName, opts.BaseRef)
+	case trackRemote:
+		err = c.repo.CreateWorktreeTracking(worktreePath, opts.BranchName, upstream)
+	case opts.BaseRef != "":
+		err = c.repo.CreateWorktreeFromRef(worktreePath, opts.BranchName, opts.BaseRef)
+	default:
+		err = c.repo.CreateWorktree(worktreePath, opts.BranchName, isNewBranch)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to create worktree: %w", err)
 	}
 
@@ -102,6 +177,8 @@ func (c *Creator) Create(opts CreateOptions) (*CreateResult, error) {
 		BranchName:   opts.BranchName,
 		IsNewBranch:  isNewBranch,
 		Message:      fmt.Sprintf("Worktree created: %s", worktreePath),
+		PreviousTip:  previousTip,
+		TracksRemote: trackRemote,
 	}
 
 	return result, nil
@@ -2,6 +2,7 @@ package worktree
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 
@@ -139,6 +140,96 @@ func TestSanitizeBranchName(t *testing.T) {
 	}
 }
 
+func TestSanitizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		sanitizer Sanitizer
+		input     string
+		expected  string
+	}{
+		{"underscore separator", Sanitizer{Separator: "_"}, "feature/user@auth", "feature_user_auth"},
+		{"underscore keeps dashes", Sanitizer{Separator: "_"}, "feature/user-auth", "feature_user-auth"},
+		{"underscore collapses", Sanitizer{Separator: "_"}, "_feature//x__y_", "feature_x_y"},
+		{"nested", Sanitizer{Nested: true}, "feature/user-auth", "feature/user-auth"},
+		{"nested sanitizes segments", Sanitizer{Nested: true}, "feature/user@auth#2024", "feature/user-auth-2024"},
+		{"nested drops empty segments", Sanitizer{Nested: true}, "/feature//x/", "feature/x"},
+		{"nested drops dot segments", Sanitizer{Nested: true}, "feature/./x", "feature/x"},
+		{"nested with separator", Sanitizer{Separator: "_", Nested: true}, "team a/fix it", "team_a/fix_it"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sanitizer.Sanitize(tt.input))
+		})
+	}
+
+	t.Run("default matches SanitizeBranchName", func(t *testing.T) {
+		assert.Equal(t, "feature-user-auth-2024-v1", Sanitizer{}.Sanitize("feature/user@auth#2024:v1"))
+	})
+
+	t.Run("validate separator", func(t *testing.T) {
+		for _, separator := range []string{"", "-", "_", ".", "+"} {
+			assert.NoError(t, ValidateSeparator(separator), separator)
+		}
+		for _, separator := range []string{"/", "\\", "--", ":", " ", "*"} {
+			assert.Error(t, ValidateSeparator(separator), separator)
+		}
+	})
+}
+
+func TestNestedSanitizerRoundTrip(t *testing.T) {
+	layout := Layout{Sanitizer: Sanitizer{Nested: true}}
+
+	testRepo := testutil.NewTestGitRepository(t, "nested-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	creator := NewCreator(repo)
+	creator.SetLayout(layout)
+	result, err := creator.Create(CreateOptions{BranchName: "feature/x"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(testRepo.TempDir, "nested-project-feature", "x"), result.WorktreePath)
+	assert.DirExists(t, result.WorktreePath)
+
+	t.Run("finder", func(t *testing.T) {
+		finder := NewFinder(repo)
+		finder.SetLayout(layout)
+		path, found, err := finder.FindWorktree("feature/x")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, result.WorktreePath, path)
+
+		info, err := finder.GetWorktreeInfo(result.WorktreePath)
+		require.NoError(t, err)
+		assert.True(t, info.IsHatcherManaged)
+	})
+
+	t.Run("lister", func(t *testing.T) {
+		lister := NewLister(repo)
+		lister.SetLayout(layout)
+		list, err := lister.ListWorktrees(ListOptions{})
+		require.NoError(t, err)
+
+		var managed []string
+		for _, wt := range list.Worktrees {
+			if wt.IsHatcherManaged {
+				managed = append(managed, wt.Branch)
+			}
+		}
+		assert.Equal(t, []string{"feature/x"}, managed)
+	})
+
+	t.Run("remover cleans up namespace directory", func(t *testing.T) {
+		remover := NewRemover(repo)
+		remover.SetLayout(layout)
+		_, err := remover.RemoveWorktree(RemoveOptions{BranchName: "feature/x", Force: true, SkipConfirm: true})
+		require.NoError(t, err)
+		assert.NoDirExists(t, result.WorktreePath)
+		assert.NoDirExists(t, filepath.Dir(result.WorktreePath))
+		assert.DirExists(t, testRepo.TempDir)
+	})
+}
+
 func TestGenerateWorktreePath(t *testing.T) {
 	repoRoot := "/Users/test/projects/my-app"
 	projectName := "my-app"
@@ -151,6 +242,96 @@ func TestGenerateWorktreePath(t *testing.T) {
 	assert.Equal(t, NormalizePath(expected), NormalizePath(result))
 }
 
+func TestGenerateWorktreePath_BaseDir(t *testing.T) {
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+
+	repoRoot := "/Users/test/projects/my-app"
+	tests := []struct {
+		name     string
+		baseDir  string
+		expected string
+	}{
+		{"default sibling", "", "/Users/test/projects/my-app-feature-user-auth"},
+		{"absolute base", "/srv/worktrees", "/srv/worktrees/my-app-feature-user-auth"},
+		{"relative base", "worktrees", "/Users/test/projects/worktrees/my-app-feature-user-auth"},
+		{"home base", "~/worktrees", filepath.Join(home, "worktrees", "my-app-feature-user-auth")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Layout{BaseDir: tt.baseDir}.WorktreePath(repoRoot, "my-app", "feature/user-auth")
+			assert.Equal(t, NormalizePath(tt.expected), NormalizePath(result))
+		})
+	}
+}
+
+func TestBaseDirRoundTrip(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "base-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// A sibling created before the base directory was configured
+	sibling, err := NewCreator(repo).Create(CreateOptions{BranchName: "feature/old"})
+	require.NoError(t, err)
+
+	baseDir := filepath.Join(testRepo.TempDir, "worktrees")
+	layout := Layout{BaseDir: baseDir}
+
+	creator := NewCreator(repo)
+	creator.SetLayout(layout)
+	result, err := creator.Create(CreateOptions{BranchName: "feature/x"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(baseDir, "base-project-feature-x"), result.WorktreePath)
+	assert.DirExists(t, result.WorktreePath)
+
+	t.Run("finder", func(t *testing.T) {
+		finder := NewFinder(repo)
+		finder.SetLayout(layout)
+		path, found, err := finder.FindWorktree("feature/x")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, result.WorktreePath, path)
+
+		worktrees, err := finder.ListHatcherWorktrees()
+		require.NoError(t, err)
+		managed := make(map[string]string)
+		for _, wt := range worktrees {
+			if wt.IsHatcherManaged {
+				managed[wt.Branch] = wt.Path
+			}
+		}
+		assert.Equal(t, map[string]string{
+			"feature/old": sibling.WorktreePath,
+			"feature/x":   result.WorktreePath,
+		}, managed)
+	})
+
+	t.Run("lister", func(t *testing.T) {
+		lister := NewLister(repo)
+		lister.SetLayout(layout)
+		list, err := lister.ListWorktrees(ListOptions{})
+		require.NoError(t, err)
+
+		var managed []string
+		for _, wt := range list.Worktrees {
+			if wt.IsHatcherManaged {
+				managed = append(managed, wt.Branch)
+			}
+		}
+		assert.ElementsMatch(t, []string{"feature/old", "feature/x"}, managed)
+	})
+
+	t.Run("remover keeps the base directory", func(t *testing.T) {
+		remover := NewRemover(repo)
+		remover.SetLayout(layout)
+		_, err := remover.RemoveWorktree(RemoveOptions{BranchName: "feature/x", Force: true, SkipConfirm: true})
+		require.NoError(t, err)
+		assert.NoDirExists(t, result.WorktreePath)
+		assert.DirExists(t, baseDir)
+	})
+}
+
 func TestIsHatcherWorktree(t *testing.T) {
 	projectName := "my-app"
 
@@ -223,8 +404,10 @@ func TestCreator_Create(t *testing.T) {
 		err := repo.CreateBranch(branchName)
 		require.NoError(t, err)
 
-		// Switch back to main to avoid issues
-		testRepo.SwitchToBranch("main")
+		// Switch back to the default branch to avoid issues
+		defaultBranch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		testRepo.SwitchToBranch(defaultBranch)
 
 		opts := CreateOptions{
 			BranchName: branchName,
@@ -341,3 +524,160 @@ func TestCreator_Create(t *testing.T) {
 		assert.Contains(t, err.Error(), "directory already exists")
 	})
 }
+
+func TestCreator_BranchCheckedOutElsewhere(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	creator := NewCreator(repo)
+
+	first, err := creator.Create(CreateOptions{BranchName: "feature/twice"})
+	require.NoError(t, err)
+	testFile := filepath.Join(first.WorktreePath, "work.txt")
+	require.NoError(t, os.WriteFile(testFile, []byte("unsaved work"), 0644))
+
+	// Even --force must not remove the existing worktree
+	_, err = creator.Create(CreateOptions{BranchName: "feature/twice", Force: true})
+	require.ErrorIs(t, err, git.ErrBranchCheckedOutElsewhere)
+	assert.Contains(t, err.Error(), first.WorktreePath)
+	assert.FileExists(t, testFile)
+}
+
+func TestCreator_ForceBranch(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	base := testRepo.GetCurrentBranch()
+	baseTip, err := repo.ResolveCommit(base)
+	require.NoError(t, err)
+
+	testRepo.CreateBranch("feature/stale")
+	testRepo.CreateFile("stale.txt", "stale work")
+	testRepo.CommitAll("Stale work")
+	staleTip, err := repo.ResolveCommit("feature/stale")
+	require.NoError(t, err)
+	testRepo.SwitchToBranch(base)
+
+	creator := NewCreator(repo)
+
+	t.Run("requires a base ref", func(t *testing.T) {
+		_, err := creator.Create(CreateOptions{BranchName: "feature/stale", ForceBranch: true})
+		assert.Error(t, err)
+	})
+
+	t.Run("refuses existing branch without force", func(t *testing.T) {
+		_, err := creator.Create(CreateOptions{BranchName: "feature/stale", BaseRef: base})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--force-branch")
+	})
+
+	t.Run("dry run leaves the branch alone", func(t *testing.T) {
+		result, err := creator.Create(CreateOptions{BranchName: "feature/stale", BaseRef: base, ForceBranch: true, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, staleTip, result.PreviousTip)
+
+		tip, err := repo.ResolveCommit("feature/stale")
+		require.NoError(t, err)
+		assert.Equal(t, staleTip, tip)
+	})
+
+	t.Run("resets existing branch", func(t *testing.T) {
+		result, err := creator.Create(CreateOptions{BranchName: "feature/stale", BaseRef: base, ForceBranch: true})
+		require.NoError(t, err)
+		assert.False(t, result.IsNewBranch)
+		assert.Equal(t, staleTip, result.PreviousTip)
+
+		tip, err := repo.ResolveCommit("feature/stale")
+		require.NoError(t, err)
+		assert.Equal(t, baseTip, tip)
+		assert.NoFileExists(t, filepath.Join(result.WorktreePath, "stale.txt"))
+
+		// The previous tip is still reachable for recovery
+		exists, err := repo.RefExists(result.PreviousTip)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("creates missing branch from base", func(t *testing.T) {
+		result, err := creator.Create(CreateOptions{BranchName: "feature/new", BaseRef: staleTip})
+		require.NoError(t, err)
+		assert.True(t, result.IsNewBranch)
+		assert.Empty(t, result.PreviousTip)
+		assert.FileExists(t, filepath.Join(result.WorktreePath, "stale.txt"))
+	})
+
+	t.Run("unknown base ref", func(t *testing.T) {
+		_, err := creator.Create(CreateOptions{BranchName: "feature/other", BaseRef: "does-not-exist"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "base ref not found")
+	})
+}
+
+func TestCreator_TrackRemote(t *testing.T) {
+	remote := testutil.NewTestGitRepository(t, "remote-project")
+	remoteBase := remote.GetCurrentBranch()
+	remote.CreateBranch("feature/remote")
+	remote.CreateFile("remote.txt", "first")
+	remote.CommitAll("Remote work")
+	remote.SwitchToBranch(remoteBase)
+
+	testRepo := testutil.NewTestGitRepository(t, "test-project")
+	for _, args := range [][]string{{"remote", "add", "origin", remote.RepoDir}, {"fetch", "--quiet", "origin"}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = testRepo.RepoDir
+		require.NoError(t, cmd.Run())
+	}
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// Pushed after the last fetch, so only an auto-fetch sees it
+	remote.SwitchToBranch("feature/remote")
+	remote.CreateFile("remote.txt", "second")
+	remote.CommitAll("More remote work")
+	remoteRepo, err := git.NewRepositoryFromPath(remote.RepoDir)
+	require.NoError(t, err)
+	remoteTip, err := remoteRepo.ResolveCommit("feature/remote")
+	require.NoError(t, err)
+	remote.SwitchToBranch(remoteBase)
+
+	creator := NewCreator(repo)
+
+	t.Run("dry run does not fetch", func(t *testing.T) {
+		result, err := creator.Create(CreateOptions{BranchName: "feature/remote", TrackRemote: true, DryRun: true})
+		require.NoError(t, err)
+		assert.True(t, result.TracksRemote)
+		assert.False(t, result.IsNewBranch)
+		assert.NoDirExists(t, result.WorktreePath)
+
+		tip, err := repo.ResolveCommit("origin/feature/remote")
+		require.NoError(t, err)
+		assert.NotEqual(t, remoteTip, tip)
+	})
+
+	t.Run("fetches and tracks the remote branch", func(t *testing.T) {
+		result, err := creator.Create(CreateOptions{BranchName: "feature/remote", TrackRemote: true})
+		require.NoError(t, err)
+		assert.True(t, result.TracksRemote)
+
+		upstream, err := repo.GetUpstream("feature/remote")
+		require.NoError(t, err)
+		assert.Equal(t, "origin/feature/remote", upstream)
+
+		tip, err := repo.ResolveCommit("feature/remote")
+		require.NoError(t, err)
+		assert.Equal(t, remoteTip, tip)
+
+		content, err := os.ReadFile(filepath.Join(result.WorktreePath, "remote.txt"))
+		require.NoError(t, err)
+		assert.Equal(t, "second", string(content))
+	})
+
+	t.Run("local branches are not tracked", func(t *testing.T) {
+		result, err := creator.Create(CreateOptions{BranchName: "feature/local", TrackRemote: true})
+		require.NoError(t, err)
+		assert.False(t, result.TracksRemote)
+		assert.True(t, result.IsNewBranch)
+	})
+}
@@ -0,0 +1,86 @@
+package worktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/keisukeshimizu/hatcher/internal/editor"
+)
+
+// EditorStateFile is the name of the file, inside a worktree's git directory,
+// recording the editor the worktree was last opened in
+const EditorStateFile = "hatcher-editor.json"
+
+// EditorState records the editor a worktree was opened in
+type EditorState struct {
+	Editor   string    `json:"editor"` // Editor command
+	OpenedAt time.Time `json:"openedAt"`
+}
+
+// LoadEditorState reads the editor state at path. A missing file yields nil.
+func LoadEditorState(path string) (*EditorState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read editor state: %w", err)
+	}
+
+	var state EditorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse editor state %s: %w", path, err)
+	}
+	return &state, nil
+}
+
+// SaveEditorState records at path that the worktree was opened in ed
+func SaveEditorState(path string, ed editor.Editor) error {
+	data, err := json.MarshalIndent(EditorState{Editor: ed.Command(), OpenedAt: time.Now()}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode editor state: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write editor state: %w", err)
+	}
+	return nil
+}
+
+// editorStatePath returns where the editor state of the worktree at
+// worktreePath is kept
+func (m *Mover) editorStatePath(worktreePath string) (string, error) {
+	gitDir, err := m.repo.GitDir(worktreePath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(gitDir, EditorStateFile), nil
+}
+
+// isOpenIn reports whether the worktree at worktreePath was last opened in ed
+// and ed is still running, so the worktree presumably still has a window
+func (m *Mover) isOpenIn(worktreePath string, ed editor.Editor) bool {
+	statePath, err := m.editorStatePath(worktreePath)
+	if err != nil {
+		return false
+	}
+
+	state, err := LoadEditorState(statePath)
+	if err != nil || state == nil {
+		return false
+	}
+	return state.Editor == ed.Command() && ed.IsRunning()
+}
+
+// recordOpened remembers that the worktree at worktreePath was opened in ed.
+// Failing to record it only means a later move opens a new window.
+func (m *Mover) recordOpened(worktreePath string, ed editor.Editor) {
+	statePath, err := m.editorStatePath(worktreePath)
+	if err != nil {
+		return
+	}
+	SaveEditorState(statePath, ed)
+}
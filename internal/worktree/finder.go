@@ -3,8 +3,6 @@ package worktree
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/keisukeshimizu/hatcher/internal/git"
@@ -12,7 +10,8 @@ import (
 
 // Finder handles worktree discovery and management
 type Finder struct {
-	repo git.Repository
+	repo   git.Repository
+	layout Layout
 }
 
 // NewFinder creates a new worktree finder
@@ -22,20 +21,23 @@ func NewFinder(repo git.Repository) *Finder {
 	}
 }
 
+// SetLayout sets where worktrees are expected and how their directories are
+// named
+func (f *Finder) SetLayout(layout Layout) {
+	f.layout = layout
+}
+
 // FindWorktree finds a worktree for the given branch name
 func (f *Finder) FindWorktree(branchName string) (string, bool, error) {
 	// Get all worktrees
-	worktrees, err := f.repo.ListWorktrees()
+	worktrees, err := f.repo.ListWorktreeEntries()
 	if err != nil {
 		return "", false, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
 	projectName := f.repo.GetProjectName()
-	expectedPath := GenerateWorktreePath(
-		func() string { root, _ := f.repo.GetRoot(); return root }(),
-		projectName,
-		branchName,
-	)
+	root, _ := f.repo.GetRoot()
+	expectedPath := f.layout.WorktreePath(root, projectName, branchName)
 
 	// First, try to find by exact branch match (works for any worktree, not just hatcher-managed)
 	for _, wt := range worktrees {
@@ -51,13 +53,13 @@ func (f *Finder) FindWorktree(branchName string) (string, bool, error) {
 		}
 	}
 
-	// Third, try to find by hatcher naming convention
+	// Third, try to find by hatcher naming convention, e.g. worktrees moved
+	// next to another checkout. Sanitizing is lossy, so the branch name is
+	// sanitized and compared rather than recovered from the path.
+	safeName := f.layout.Sanitizer.Sanitize(branchName)
 	for _, wt := range worktrees {
-		if IsHatcherWorktree(wt.Path, projectName) {
-			// Extract branch name from path and compare
-			if f.extractBranchFromPath(wt.Path, projectName) == branchName {
-				return wt.Path, true, nil
-			}
+		if dir, ok := f.layout.hatcherBranchDir(root, wt.Path, projectName); ok && dir == safeName {
+			return wt.Path, true, nil
 		}
 	}
 
@@ -67,20 +69,24 @@ func (f *Finder) FindWorktree(branchName string) (string, bool, error) {
 // ListHatcherWorktrees returns all worktrees managed by hatcher
 func (f *Finder) ListHatcherWorktrees() ([]WorktreeInfo, error) {
 	// Get all worktrees from Git
-	gitWorktrees, err := f.repo.ListWorktrees()
+	gitWorktrees, err := f.repo.ListWorktreeEntries()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
 	var hatcherWorktrees []WorktreeInfo
 	projectName := f.repo.GetProjectName()
+	root, _ := f.repo.GetRoot()
+	metadata := loadMetadata(f.repo)
 
 	for _, gitWt := range gitWorktrees {
-		info, err := f.convertToWorktreeInfo(gitWt, projectName)
+		info, err := f.convertToWorktreeInfo(gitWt, root, projectName)
 		if err != nil {
 			// Log error but continue with other worktrees
 			continue
 		}
+		info.Tags = metadata[info.Branch].Tags
+		info.Note = metadata[info.Branch].Note
 		hatcherWorktrees = append(hatcherWorktrees, *info)
 	}
 
@@ -94,27 +100,57 @@ func (f *Finder) GetWorktreeInfo(worktreePath string) (*WorktreeInfo, error) {
 		return nil, fmt.Errorf("worktree path does not exist: %s", worktreePath)
 	}
 
-	// Get all worktrees and find the matching one
-	gitWorktrees, err := f.repo.ListWorktrees()
+	// Ask git which branch is checked out there rather than guessing from
+	// the directory name
+	branch, err := f.repo.BranchForWorktree(worktreePath)
+	if err != nil {
+		return nil, fmt.Errorf("worktree not found in Git worktree list: %w", err)
+	}
+
+	gitWorktrees, err := f.repo.ListWorktreeEntries()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list worktrees: %w", err)
 	}
 
 	projectName := f.repo.GetProjectName()
+	root, _ := f.repo.GetRoot()
 
 	for _, gitWt := range gitWorktrees {
-		if gitWt.Path == worktreePath {
-			return f.convertToWorktreeInfo(gitWt, projectName)
+		if gitWt.Branch != branch && !sameDir(gitWt.Path, worktreePath) {
+			continue
 		}
+
+		info, err := f.convertToWorktreeInfo(gitWt, root, projectName)
+		if err != nil {
+			return nil, err
+		}
+		info.Branch = branch
+		meta := loadMetadata(f.repo)[info.Branch]
+		info.Tags = meta.Tags
+		info.Note = meta.Note
+		return info, nil
 	}
 
 	return nil, fmt.Errorf("worktree not found in Git worktree list: %s", worktreePath)
 }
 
+// sameDir reports whether a and b name the same existing directory
+func sameDir(a, b string) bool {
+	aInfo, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+	bInfo, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+	return os.SameFile(aInfo, bInfo)
+}
+
 // convertToWorktreeInfo converts a Git worktree to WorktreeInfo
-func (f *Finder) convertToWorktreeInfo(gitWt git.Worktree, projectName string) (*WorktreeInfo, error) {
+func (f *Finder) convertToWorktreeInfo(gitWt git.Worktree, root, projectName string) (*WorktreeInfo, error) {
 	// Determine if this is a hatcher-managed worktree
-	isHatcher := IsHatcherWorktree(gitWt.Path, projectName)
+	_, isHatcher := f.layout.hatcherBranchDir(root, gitWt.Path, projectName)
 
 	// Get file modification time as creation time approximation
 	var created time.Time
@@ -134,31 +170,3 @@ func (f *Finder) convertToWorktreeInfo(gitWt git.Worktree, projectName string) (
 		Editor:           "", // Will be populated by editor detection
 	}, nil
 }
-
-// extractBranchFromPath extracts the branch name from a hatcher worktree path
-func (f *Finder) extractBranchFromPath(worktreePath, projectName string) string {
-	dirName := filepath.Base(worktreePath)
-	expectedPrefix := projectName + "-"
-
-	if !strings.HasPrefix(dirName, expectedPrefix) {
-		return ""
-	}
-
-	// Remove project prefix and convert back to branch name
-	branchPart := strings.TrimPrefix(dirName, expectedPrefix)
-
-	// Convert sanitized name back to original branch name (best effort)
-	// This is a simplified reverse conversion
-	branchName := strings.ReplaceAll(branchPart, "-", "/")
-
-	// Handle common patterns
-	if strings.HasPrefix(branchName, "feature/") ||
-		strings.HasPrefix(branchName, "bugfix/") ||
-		strings.HasPrefix(branchName, "hotfix/") ||
-		strings.HasPrefix(branchName, "release/") {
-		return branchName
-	}
-
-	// If no clear pattern, return as-is with dashes converted to slashes
-	return branchName
-}
@@ -235,6 +235,18 @@ func TestWorktreeFinder_GetWorktreeInfo(t *testing.T) {
 		assert.False(t, info.IsHatcherManaged) // Main repo is not created by hatcher
 	})
 
+	t.Run("get worktree info for a worktree outside hatcher's naming", func(t *testing.T) {
+		branchName := "feature/renamed"
+		worktreePath := filepath.Join(testRepo.TempDir, "somewhere-else")
+		require.NoError(t, repo.CreateWorktree(worktreePath, branchName, true))
+
+		info, err := finder.GetWorktreeInfo(worktreePath)
+		require.NoError(t, err)
+		assert.Equal(t, branchName, info.Branch)
+		assert.Equal(t, worktreePath, info.Path)
+		assert.False(t, info.IsHatcherManaged)
+	})
+
 	t.Run("get worktree info for non-existent path", func(t *testing.T) {
 		// Try to get info for non-existent path
 		info, err := finder.GetWorktreeInfo("/non/existent/path")
@@ -4,30 +4,45 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"path/filepath"
-	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/keisukeshimizu/hatcher/internal/git"
 )
 
 // ListOptions contains options for listing worktrees
 type ListOptions struct {
-	ShowAll    bool // Show all worktrees, not just Hatcher-managed ones
-	ShowPaths  bool // Show full paths in output
-	ShowStatus bool // Show status information (clean/dirty)
+	ShowAll     bool   // Show all worktrees, not just Hatcher-managed ones
+	ExcludeMain bool   // Hide the main repository
+	ShowPaths   bool   // Show full paths in output
+	ShowStatus  bool   // Show status information (clean/dirty)
+	ShowNotes   bool   // Show the notes column in table output
+	Tag         string // Only include worktrees carrying this tag
+	Sort        string // SortBranch (default), SortPath or SortMtime; ignored by Stream
+	// Only include worktrees whose files were not modified for this long
+	// (0 includes all)
+	StaleFor time.Duration
+}
+
+// needsActivity reports whether the worktrees' last modification times
+// must be read
+func (o ListOptions) needsActivity() bool {
+	return o.Sort == SortMtime || o.StaleFor > 0
 }
 
 // ListResult contains the result of listing worktrees
 type ListResult struct {
 	Worktrees []WorktreeInfo `json:"worktrees"`
 	Total     int            `json:"total"`
+
+	showNotes bool
 }
 
 // Lister handles worktree listing operations
 type Lister struct {
-	repo git.Repository
+	repo   git.Repository
+	layout Layout
 }
 
 // NewLister creates a new Lister instance
@@ -37,89 +52,176 @@ func NewLister(repo git.Repository) *Lister {
 	}
 }
 
+// SetLayout sets how hatcher-managed worktrees are recognized
+func (l *Lister) SetLayout(layout Layout) {
+	l.layout = layout
+}
+
 // ListWorktrees lists all worktrees based on the provided options
 func (l *Lister) ListWorktrees(options ListOptions) (*ListResult, error) {
-	// Get all worktrees from Git
-	gitWorktrees, err := l.repo.ListWorktrees()
+	if err := ValidateListSort(options.Sort); err != nil {
+		return nil, err
+	}
+
+	// Get all worktrees from Git, running git status in each of them only
+	// when the status is shown
+	listWorktrees := l.repo.ListWorktreeEntries
+	if options.ShowStatus {
+		listWorktrees = l.repo.ListWorktrees
+	}
+	gitWorktrees, err := listWorktrees()
 	if err != nil {
 		return nil, fmt.Errorf("failed to list Git worktrees: %w", err)
 	}
 
-	// Get repository root for comparison
-	repoRoot, err := l.repo.GetRoot()
+	// Compare against the main worktree rather than the current root, which
+	// is a linked worktree when listing from inside one
+	repoRoot, err := mainWorktreePath(l.repo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repository root: %w", err)
+		return nil, err
 	}
 
 	var worktrees []WorktreeInfo
+	metadata := loadMetadata(l.repo)
 
 	for _, gitWt := range gitWorktrees {
-		wtInfo := WorktreeInfo{
-			Branch: gitWt.Branch,
-			Path:   gitWt.Path,
-			Head:   gitWt.Head,
-			IsMain: gitWt.Path == repoRoot,
+		wtInfo, ok := l.worktreeInfo(gitWt, repoRoot, metadata, options)
+		if !ok {
+			continue
 		}
 
-		// Determine if this is Hatcher-managed
-		wtInfo.IsHatcherManaged = l.isHatcherManaged(gitWt.Path, gitWt.Branch)
-
 		// Get status if requested
 		if options.ShowStatus {
-			status, err := l.GetWorktreeStatus(gitWt.Path)
-			if err != nil {
-				// Don't fail the entire operation for status errors
-				status = git.StatusUnknown
+			wtInfo.Status = gitWt.Status
+			if wtInfo.Status == "" {
+				wtInfo.Status = git.StatusUnknown
 			}
-			wtInfo.Status = status
-		}
-
-		// Filter based on options
-		if !options.ShowAll && !wtInfo.IsHatcherManaged && !wtInfo.IsMain {
-			continue // Skip non-Hatcher worktrees when ShowAll is false
 		}
 
 		worktrees = append(worktrees, wtInfo)
 	}
 
-	// Sort worktrees by branch name
-	sort.Slice(worktrees, func(i, j int) bool {
-		// Main repository first
-		if worktrees[i].IsMain && !worktrees[j].IsMain {
-			return true
+	if options.needsActivity() {
+		if err := addLastModified(worktrees); err != nil {
+			return nil, err
 		}
-		if !worktrees[i].IsMain && worktrees[j].IsMain {
-			return false
-		}
-		// Then by branch name
-		return worktrees[i].Branch < worktrees[j].Branch
-	})
+	}
+	if options.StaleFor > 0 {
+		worktrees = staleWorktrees(worktrees, options.StaleFor, time.Now())
+	}
+	sortWorktrees(worktrees, options.Sort)
 
 	return &ListResult{
 		Worktrees: worktrees,
 		Total:     len(worktrees),
+		showNotes: options.ShowNotes,
 	}, nil
 }
 
+// Stream calls fn with each worktree ListWorktrees would return, in the
+// order git lists them, without waiting for the whole list. With ShowStatus
+// the status of each worktree is computed just before it is passed to fn,
+// as is its last modification time with StaleFor.
+func (l *Lister) Stream(options ListOptions, fn func(WorktreeInfo) error) error {
+	gitWorktrees, err := l.repo.ListWorktreeEntries()
+	if err != nil {
+		return fmt.Errorf("failed to list Git worktrees: %w", err)
+	}
+
+	repoRoot, err := mainWorktreePath(l.repo)
+	if err != nil {
+		return err
+	}
+
+	metadata := loadMetadata(l.repo)
+	for _, gitWt := range gitWorktrees {
+		wtInfo, ok := l.worktreeInfo(gitWt, repoRoot, metadata, options)
+		if !ok {
+			continue
+		}
+
+		if options.StaleFor > 0 {
+			single := []WorktreeInfo{wtInfo}
+			if err := addLastModified(single); err != nil {
+				return err
+			}
+			if len(staleWorktrees(single, options.StaleFor, time.Now())) == 0 {
+				continue
+			}
+			wtInfo = single[0]
+		}
+
+		if options.ShowStatus {
+			wtInfo.Status = gitWt.Status
+			if wtInfo.Status == "" {
+				wtInfo.Status = l.repo.WorktreeStatus(gitWt.Path)
+			}
+		}
+
+		if err := fn(wtInfo); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// worktreeInfo describes a git worktree, reporting false when options
+// filter it out
+func (l *Lister) worktreeInfo(gitWt git.Worktree, repoRoot string, metadata map[string]WorktreeMetadata, options ListOptions) (WorktreeInfo, bool) {
+	wtInfo := WorktreeInfo{
+		Branch: gitWt.Branch,
+		Path:   gitWt.Path,
+		Head:   gitWt.Head,
+		IsMain: gitWt.Path == repoRoot,
+		Tags:   metadata[gitWt.Branch].Tags,
+		Note:   metadata[gitWt.Branch].Note,
+	}
+
+	// Determine if this is Hatcher-managed
+	wtInfo.IsHatcherManaged = l.isHatcherManaged(repoRoot, gitWt.Path, gitWt.Branch)
+
+	// Filter based on options
+	if !options.ShowAll && !wtInfo.IsHatcherManaged && !wtInfo.IsMain {
+		return wtInfo, false // Skip non-Hatcher worktrees when ShowAll is false
+	}
+	if options.ExcludeMain && wtInfo.IsMain {
+		return wtInfo, false
+	}
+	if options.Tag != "" && !wtInfo.HasTag(options.Tag) {
+		return wtInfo, false
+	}
+
+	return wtInfo, true
+}
+
 // GetWorktreeStatus gets the status of a specific worktree
 func (l *Lister) GetWorktreeStatus(worktreePath string) (git.WorktreeStatus, error) {
-	// This is a simplified implementation
-	// In a real implementation, we'd check git status in the worktree directory
+	worktrees, err := l.repo.ListWorktreeEntries()
+	if err != nil {
+		return git.StatusUnknown, fmt.Errorf("failed to list Git worktrees: %w", err)
+	}
+
+	for _, wt := range worktrees {
+		if wt.Path == worktreePath {
+			if wt.Status == "" {
+				return l.repo.WorktreeStatus(wt.Path), nil
+			}
+			return wt.Status, nil
+		}
+	}
 
-	// For now, assume clean status for existing directories
-	return git.StatusClean, nil
+	return git.StatusUnknown, fmt.Errorf("worktree not found: %s", worktreePath)
 }
 
 // isHatcherManaged determines if a worktree is managed by Hatcher
-func (l *Lister) isHatcherManaged(worktreePath, branchName string) bool {
+func (l *Lister) isHatcherManaged(repoRoot, worktreePath, branchName string) bool {
 	// Get project name
 	projectName := l.repo.GetProjectName()
 
 	// Check if the path follows Hatcher naming convention
-	expectedName := fmt.Sprintf("%s-%s", projectName, SanitizeBranchName(branchName))
-	actualName := filepath.Base(worktreePath)
-
-	return actualName == expectedName
+	dir, ok := l.layout.hatcherBranchDir(repoRoot, worktreePath, projectName)
+	return ok && dir == l.layout.Sanitizer.Sanitize(branchName)
 }
 
 // FormatAsTable formats the result as a table
@@ -131,9 +233,31 @@ func (r *ListResult) FormatAsTable() string {
 	var output bytes.Buffer
 	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
 
+	// Only show the tags column when at least one worktree is tagged, and
+	// the modified column when modification times were read
+	showTags, showModified := false, false
+	for _, wt := range r.Worktrees {
+		showTags = showTags || len(wt.Tags) > 0
+		showModified = showModified || !wt.LastModified.IsZero()
+	}
+
 	// Header
-	fmt.Fprintln(w, "BRANCH\tPATH\tSTATUS\tTYPE")
-	fmt.Fprintln(w, "------\t----\t------\t----")
+	headers := []string{"BRANCH", "PATH", "STATUS", "TYPE"}
+	if showModified {
+		headers = append(headers, "MODIFIED")
+	}
+	if showTags {
+		headers = append(headers, "TAGS")
+	}
+	if r.showNotes {
+		headers = append(headers, "NOTE")
+	}
+	separators := make([]string, len(headers))
+	for i, header := range headers {
+		separators[i] = strings.Repeat("-", len(header))
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+	fmt.Fprintln(w, strings.Join(separators, "\t"))
 
 	// Rows
 	for _, wt := range r.Worktrees {
@@ -151,13 +275,36 @@ func (r *ListResult) FormatAsTable() string {
 			status = "-"
 		}
 
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", wt.Branch, wt.Path, status, wtType)
+		row := []string{wt.Branch, wt.Path, status, wtType}
+		if showModified {
+			modified := ""
+			if !wt.LastModified.IsZero() {
+				modified = wt.LastModified.Local().Format("2006-01-02 15:04")
+			}
+			row = append(row, valueOrDash(modified))
+		}
+		if showTags {
+			row = append(row, valueOrDash(strings.Join(wt.Tags, ",")))
+		}
+		if r.showNotes {
+			// Collapse whitespace so multi-line notes don't break the table
+			row = append(row, valueOrDash(strings.Join(strings.Fields(wt.Note), " ")))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
 	}
 
 	w.Flush()
 	return output.String()
 }
 
+// valueOrDash returns value, or "-" when it is empty
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
 // FormatAsJSON formats the result as JSON
 func (r *ListResult) FormatAsJSON() string {
 	data, err := json.MarshalIndent(r, "", "  ")
@@ -189,16 +336,22 @@ func (r *ListResult) FormatAsSimple() string {
 	return output.String()
 }
 
+// FormatAsPaths formats the result as bare worktree paths, one per line, for
+// piping into other commands. Nothing is printed when there are no worktrees.
+func (r *ListResult) FormatAsPaths() string {
+	var output strings.Builder
+	for _, wt := range r.Worktrees {
+		output.WriteString(wt.Path + "\n")
+	}
+	return output.String()
+}
+
 // FilterByBranchPattern filters worktrees by branch name pattern
 func (r *ListResult) FilterByBranchPattern(pattern string) []WorktreeInfo {
 	var filtered []WorktreeInfo
 
-	// Convert simple glob pattern to basic matching
-	// This is a simplified implementation - a real one would use proper glob matching
-	prefix := strings.TrimSuffix(pattern, "*")
-
 	for _, wt := range r.Worktrees {
-		if strings.HasPrefix(wt.Branch, prefix) {
+		if MatchBranchPattern(wt.Branch, pattern) {
 			filtered = append(filtered, wt)
 		}
 	}
@@ -206,6 +359,14 @@ func (r *ListResult) FilterByBranchPattern(pattern string) []WorktreeInfo {
 	return filtered
 }
 
+// MatchBranchPattern reports whether branch matches a --filter pattern
+func MatchBranchPattern(branch, pattern string) bool {
+	// Convert simple glob pattern to basic matching
+	// This is a simplified implementation - a real one would use proper glob matching
+	prefix := strings.TrimSuffix(pattern, "*")
+	return strings.HasPrefix(branch, prefix)
+}
+
 // FilterByStatus filters worktrees by status
 func (r *ListResult) FilterByStatus(status git.WorktreeStatus) []WorktreeInfo {
 	var filtered []WorktreeInfo
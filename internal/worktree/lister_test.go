@@ -1,9 +1,11 @@
 package worktree
 
 import (
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
@@ -46,6 +48,34 @@ func TestLister_ListWorktrees(t *testing.T) {
 		assert.True(t, mainFound, "Main repository should be found")
 	})
 
+	t.Run("exclude main repository", func(t *testing.T) {
+		result, err := lister.ListWorktrees(ListOptions{ShowAll: true, ExcludeMain: true})
+		require.NoError(t, err)
+
+		for _, wt := range result.Worktrees {
+			assert.False(t, wt.IsMain)
+			assert.NotEqual(t, testRepo.RepoDir, wt.Path)
+		}
+	})
+
+	t.Run("main repository detected from a linked worktree", func(t *testing.T) {
+		linkedPath := filepath.Join(testRepo.TempDir, "lister-test-feature-linked")
+		require.NoError(t, repo.CreateWorktree(linkedPath, "feature/linked", true))
+		linkedRepo, err := git.NewRepositoryFromPath(linkedPath)
+		require.NoError(t, err)
+
+		result, err := NewLister(linkedRepo).ListWorktrees(ListOptions{ShowAll: true})
+		require.NoError(t, err)
+
+		var mainPaths []string
+		for _, wt := range result.Worktrees {
+			if wt.IsMain {
+				mainPaths = append(mainPaths, wt.Path)
+			}
+		}
+		assert.Equal(t, []string{testRepo.RepoDir}, mainPaths)
+	})
+
 	t.Run("list worktrees with Hatcher naming", func(t *testing.T) {
 		// Create worktrees with Hatcher naming convention
 		branchName1 := "feature/list-test-1"
@@ -192,6 +222,17 @@ func TestLister_ListWorktrees(t *testing.T) {
 		simpleOutput := result.FormatAsSimple()
 		assert.NotEmpty(t, simpleOutput)
 		assert.Contains(t, simpleOutput, branchName)
+
+		pathsOutput := result.FormatAsPaths()
+		lines := strings.Split(strings.TrimSuffix(pathsOutput, "\n"), "\n")
+		require.Len(t, lines, len(result.Worktrees))
+		for i, wt := range result.Worktrees {
+			assert.Equal(t, wt.Path, lines[i])
+		}
+		assert.Contains(t, lines, worktreePath)
+
+		empty := &ListResult{}
+		assert.Empty(t, empty.FormatAsPaths())
 	})
 }
 
@@ -219,6 +260,26 @@ func TestLister_GetWorktreeStatus(t *testing.T) {
 		assert.Equal(t, StatusClean, status)
 	})
 
+	t.Run("get status of dirty worktree", func(t *testing.T) {
+		branchName := "feature/dirty-test"
+		worktreePath := filepath.Join(testRepo.TempDir, "status-test-feature-dirty-test")
+
+		require.NoError(t, repo.CreateWorktree(worktreePath, branchName, true))
+		require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "notes.txt"), []byte("wip"), 0644))
+
+		status, err := lister.GetWorktreeStatus(worktreePath)
+		require.NoError(t, err)
+		assert.Equal(t, StatusDirty, status)
+
+		result, err := lister.ListWorktrees(ListOptions{ShowStatus: true})
+		require.NoError(t, err)
+		for _, wt := range result.Worktrees {
+			if wt.Path == worktreePath {
+				assert.Equal(t, StatusDirty, wt.Status)
+			}
+		}
+	})
+
 	t.Run("get status of main repository", func(t *testing.T) {
 		// Get status of main repository
 		status, err := lister.GetWorktreeStatus(testRepo.RepoDir)
@@ -229,6 +290,62 @@ func TestLister_GetWorktreeStatus(t *testing.T) {
 	})
 }
 
+func TestLister_Stream(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "stream-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	dirtyPath := filepath.Join(testRepo.TempDir, "stream-test-feature-dirty")
+	cleanPath := filepath.Join(testRepo.TempDir, "stream-test-feature-clean")
+	require.NoError(t, repo.CreateWorktree(dirtyPath, "feature/dirty", true))
+	require.NoError(t, repo.CreateWorktree(cleanPath, "feature/clean", true))
+	require.NoError(t, os.WriteFile(filepath.Join(dirtyPath, "notes.txt"), []byte("wip"), 0644))
+
+	collect := func(t *testing.T, options ListOptions) []WorktreeInfo {
+		// A fresh repository has no cached statuses
+		repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+
+		var streamed []WorktreeInfo
+		require.NoError(t, NewLister(repo).Stream(options, func(wt WorktreeInfo) error {
+			streamed = append(streamed, wt)
+			return nil
+		}))
+		return streamed
+	}
+
+	t.Run("streams in git order without status", func(t *testing.T) {
+		streamed := collect(t, ListOptions{})
+		require.Len(t, streamed, 3)
+		assert.True(t, streamed[0].IsMain)
+		assert.ElementsMatch(t, []string{dirtyPath, cleanPath}, []string{streamed[1].Path, streamed[2].Path})
+		for _, wt := range streamed {
+			assert.Empty(t, wt.Status)
+		}
+	})
+
+	t.Run("computes status per worktree", func(t *testing.T) {
+		streamed := collect(t, ListOptions{ShowStatus: true, ExcludeMain: true})
+		require.Len(t, streamed, 2)
+		statuses := make(map[string]WorktreeStatus)
+		for _, wt := range streamed {
+			statuses[wt.Path] = wt.Status
+		}
+		assert.Equal(t, StatusDirty, statuses[dirtyPath])
+		assert.Equal(t, StatusClean, statuses[cleanPath])
+	})
+
+	t.Run("stops at the first error", func(t *testing.T) {
+		calls := 0
+		err := NewLister(repo).Stream(ListOptions{}, func(wt WorktreeInfo) error {
+			calls++
+			return assert.AnError
+		})
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, calls)
+	})
+}
+
 func TestLister_FilterWorktrees(t *testing.T) {
 	// Create test repository
 	testRepo := testutil.NewTestGitRepository(t, "filter-test")
@@ -298,3 +415,95 @@ func TestLister_FilterWorktrees(t *testing.T) {
 		}
 	})
 }
+
+func TestLister_SortAndStale(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "activity-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	// Path order is the reverse of branch order
+	pathA := filepath.Join(testRepo.TempDir, "wt-a")
+	pathB := filepath.Join(testRepo.TempDir, "wt-b")
+	pathC := filepath.Join(testRepo.TempDir, "wt-c")
+	require.NoError(t, repo.CreateWorktree(pathA, "zeta", true))
+	require.NoError(t, repo.CreateWorktree(pathB, "yak", true))
+	require.NoError(t, repo.CreateWorktree(pathC, "xray", true))
+
+	// touchTree sets the modification time of everything in a worktree but
+	// its git metadata
+	touchTree := func(t *testing.T, root string, age time.Duration) {
+		modified := time.Now().Add(-age)
+		require.NoError(t, filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if filepath.Dir(path) == root && info.Name() == ".git" {
+				if info.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			return os.Chtimes(path, modified, modified)
+		}))
+	}
+	day := 24 * time.Hour
+	touchTree(t, testRepo.RepoDir, 60*day)
+	touchTree(t, pathA, 2*time.Hour)
+	touchTree(t, pathB, 31*day)
+	touchTree(t, pathC, time.Minute)
+
+	paths := func(worktrees []WorktreeInfo) []string {
+		var result []string
+		for _, wt := range worktrees {
+			result = append(result, wt.Path)
+		}
+		return result
+	}
+	list := func(t *testing.T, options ListOptions) []WorktreeInfo {
+		options.ShowAll = true
+		result, err := NewLister(repo).ListWorktrees(options)
+		require.NoError(t, err)
+		return result.Worktrees
+	}
+
+	t.Run("sort by branch", func(t *testing.T) {
+		worktrees := list(t, ListOptions{Sort: SortBranch})
+		assert.Equal(t, []string{testRepo.RepoDir, pathC, pathB, pathA}, paths(worktrees))
+		for _, wt := range worktrees {
+			assert.True(t, wt.LastModified.IsZero(), "modification times are only read when needed")
+		}
+	})
+
+	t.Run("sort by path", func(t *testing.T) {
+		assert.Equal(t, []string{testRepo.RepoDir, pathA, pathB, pathC}, paths(list(t, ListOptions{Sort: SortPath})))
+	})
+
+	t.Run("sort by mtime", func(t *testing.T) {
+		worktrees := list(t, ListOptions{Sort: SortMtime})
+		assert.Equal(t, []string{pathC, pathA, pathB, testRepo.RepoDir}, paths(worktrees))
+		assert.WithinDuration(t, time.Now().Add(-time.Minute), worktrees[0].LastModified, time.Second)
+	})
+
+	t.Run("stale filter", func(t *testing.T) {
+		worktrees := list(t, ListOptions{StaleFor: 30 * day})
+		assert.Equal(t, []string{testRepo.RepoDir, pathB}, paths(worktrees))
+
+		assert.Equal(t, []string{pathA, pathB, testRepo.RepoDir}, paths(list(t, ListOptions{StaleFor: time.Hour, Sort: SortMtime})))
+		assert.Empty(t, list(t, ListOptions{StaleFor: 90 * day}))
+	})
+
+	t.Run("stale filter while streaming", func(t *testing.T) {
+		var streamed []WorktreeInfo
+		require.NoError(t, NewLister(repo).Stream(ListOptions{ShowAll: true, StaleFor: time.Hour}, func(wt WorktreeInfo) error {
+			streamed = append(streamed, wt)
+			return nil
+		}))
+		assert.ElementsMatch(t, []string{testRepo.RepoDir, pathA, pathB}, paths(streamed))
+	})
+
+	t.Run("unknown sort", func(t *testing.T) {
+		_, err := NewLister(repo).ListWorktrees(ListOptions{Sort: "size"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown sort")
+	})
+}
@@ -0,0 +1,254 @@
+package worktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/keisukeshimizu/hatcher/internal/filelock"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+const (
+	// MetadataDir is the directory, relative to the main repository, holding hatcher state
+	MetadataDir = ".hatcher"
+	// MetadataFile is the name of the worktree metadata file inside MetadataDir
+	MetadataFile = "worktrees.json"
+)
+
+// WorktreeMetadata contains user-provided annotations for a worktree
+type WorktreeMetadata struct {
+	Tags   []string `json:"tags,omitempty"`
+	Note   string   `json:"note,omitempty"`
+	Editor string   `json:"editor,omitempty"` // Editor command the worktree always opens in
+}
+
+// isEmpty reports whether the metadata carries no information
+func (m WorktreeMetadata) isEmpty() bool {
+	return len(m.Tags) == 0 && m.Note == "" && m.Editor == ""
+}
+
+// metadataMu serializes read-modify-write cycles within this process
+var metadataMu sync.Mutex
+
+// MetadataStore persists worktree metadata keyed by branch name
+type MetadataStore struct {
+	path string
+}
+
+// NewMetadataStore creates a metadata store for the given repository.
+// Metadata lives in the main worktree so every worktree shares the same file.
+func NewMetadataStore(repo git.Repository) (*MetadataStore, error) {
+	root, err := mainWorktreePath(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMetadataStoreAt(filepath.Join(root, MetadataDir, MetadataFile)), nil
+}
+
+// NewMetadataStoreAt creates a metadata store backed by the given file
+func NewMetadataStoreAt(path string) *MetadataStore {
+	return &MetadataStore{
+		path: path,
+	}
+}
+
+// Path returns the path of the metadata file
+func (s *MetadataStore) Path() string {
+	return s.path
+}
+
+// Load reads all worktree metadata. A missing file yields an empty map.
+func (s *MetadataStore) Load() (map[string]WorktreeMetadata, error) {
+	entries := make(map[string]WorktreeMetadata)
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return entries, nil
+		}
+		return nil, fmt.Errorf("failed to read worktree metadata: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse worktree metadata %s: %w", s.path, err)
+	}
+
+	return entries, nil
+}
+
+// Get returns the metadata for a branch
+func (s *MetadataStore) Get(branch string) (WorktreeMetadata, error) {
+	entries, err := s.Load()
+	if err != nil {
+		return WorktreeMetadata{}, err
+	}
+
+	return entries[branch], nil
+}
+
+// AddTags adds tags to a branch, ignoring duplicates
+func (s *MetadataStore) AddTags(branch string, tags ...string) error {
+	return s.update(branch, func(meta *WorktreeMetadata) {
+		for _, tag := range tags {
+			if tag != "" && !containsString(meta.Tags, tag) {
+				meta.Tags = append(meta.Tags, tag)
+			}
+		}
+		sort.Strings(meta.Tags)
+	})
+}
+
+// RemoveTags removes tags from a branch
+func (s *MetadataStore) RemoveTags(branch string, tags ...string) error {
+	return s.update(branch, func(meta *WorktreeMetadata) {
+		var kept []string
+		for _, tag := range meta.Tags {
+			if !containsString(tags, tag) {
+				kept = append(kept, tag)
+			}
+		}
+		meta.Tags = kept
+	})
+}
+
+// SetNote sets the free-text note of a branch. An empty note clears it.
+func (s *MetadataStore) SetNote(branch, note string) error {
+	return s.update(branch, func(meta *WorktreeMetadata) {
+		meta.Note = note
+	})
+}
+
+// SetEditor pins the editor command a branch's worktree opens in. An empty
+// command clears the pin.
+func (s *MetadataStore) SetEditor(branch, editorCommand string) error {
+	return s.update(branch, func(meta *WorktreeMetadata) {
+		meta.Editor = editorCommand
+	})
+}
+
+// Rename moves the metadata of oldBranch to newBranch, replacing any
+// metadata newBranch had
+func (s *MetadataStore) Rename(oldBranch, newBranch string) error {
+	return s.modify(func(entries map[string]WorktreeMetadata) bool {
+		meta, ok := entries[oldBranch]
+		if !ok {
+			return false
+		}
+		delete(entries, oldBranch)
+		entries[newBranch] = meta
+		return true
+	})
+}
+
+// update applies fn to the metadata of a branch and saves the result
+func (s *MetadataStore) update(branch string, fn func(meta *WorktreeMetadata)) error {
+	return s.modify(func(entries map[string]WorktreeMetadata) bool {
+		meta := entries[branch]
+		fn(&meta)
+
+		if meta.isEmpty() {
+			delete(entries, branch)
+		} else {
+			entries[branch] = meta
+		}
+		return true
+	})
+}
+
+// modify applies fn to all worktree metadata and saves the result unless fn
+// reports that nothing changed.
+// The cycle is guarded by an in-process mutex and a lock file so that
+// concurrent hatcher invocations do not lose each other's changes.
+func (s *MetadataStore) modify(fn func(entries map[string]WorktreeMetadata) bool) error {
+	metadataMu.Lock()
+	defer metadataMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	return filelock.With(s.path, func() error {
+		entries, err := s.Load()
+		if err != nil {
+			return err
+		}
+
+		if !fn(entries) {
+			return nil
+		}
+		return s.save(entries)
+	})
+}
+
+// save writes all worktree metadata to disk
+func (s *MetadataStore) save(entries map[string]WorktreeMetadata) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal worktree metadata: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial file
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write worktree metadata: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write worktree metadata: %w", err)
+	}
+
+	return nil
+}
+
+// loadMetadata returns the metadata for all worktrees of the repository.
+// Metadata is informational, so read errors yield an empty map.
+func loadMetadata(repo git.Repository) map[string]WorktreeMetadata {
+	store, err := NewMetadataStore(repo)
+	if err != nil {
+		return map[string]WorktreeMetadata{}
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		return map[string]WorktreeMetadata{}
+	}
+	return entries
+}
+
+// HasTag reports whether the worktree is tagged with tag
+func (w WorktreeInfo) HasTag(tag string) bool {
+	return containsString(w.Tags, tag)
+}
+
+// mainWorktreePath returns the path of the main worktree of the repository
+func mainWorktreePath(repo git.Repository) (string, error) {
+	// Git always lists the main worktree first; statuses are not needed
+	if worktrees, err := repo.ListWorktreeEntries(); err == nil && len(worktrees) > 0 {
+		return worktrees[0].Path, nil
+	}
+
+	root, err := repo.GetRoot()
+	if err != nil {
+		return "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	return root, nil
+}
+
+// containsString reports whether values contains s
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
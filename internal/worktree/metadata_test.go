@@ -0,0 +1,190 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataStore(t *testing.T) {
+	t.Run("missing file yields empty metadata", func(t *testing.T) {
+		store := NewMetadataStoreAt(filepath.Join(t.TempDir(), MetadataDir, MetadataFile))
+
+		entries, err := store.Load()
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+
+		meta, err := store.Get("feature/none")
+		require.NoError(t, err)
+		assert.Empty(t, meta.Tags)
+	})
+
+	t.Run("add and remove tags", func(t *testing.T) {
+		store := NewMetadataStoreAt(filepath.Join(t.TempDir(), MetadataDir, MetadataFile))
+
+		require.NoError(t, store.AddTags("feature/a", "review", "JIRA-123"))
+		require.NoError(t, store.AddTags("feature/a", "review"))
+
+		meta, err := store.Get("feature/a")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"JIRA-123", "review"}, meta.Tags)
+
+		require.NoError(t, store.RemoveTags("feature/a", "review"))
+		meta, err = store.Get("feature/a")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"JIRA-123"}, meta.Tags)
+
+		// Removing the last tag drops the entry entirely
+		require.NoError(t, store.RemoveTags("feature/a", "JIRA-123"))
+		entries, err := store.Load()
+		require.NoError(t, err)
+		assert.NotContains(t, entries, "feature/a")
+	})
+
+	t.Run("set and clear note", func(t *testing.T) {
+		store := NewMetadataStoreAt(filepath.Join(t.TempDir(), MetadataDir, MetadataFile))
+
+		require.NoError(t, store.AddTags("feature/b", "review"))
+		require.NoError(t, store.SetNote("feature/b", "waiting on review"))
+
+		meta, err := store.Get("feature/b")
+		require.NoError(t, err)
+		assert.Equal(t, "waiting on review", meta.Note)
+		assert.Equal(t, []string{"review"}, meta.Tags)
+
+		require.NoError(t, store.SetNote("feature/b", ""))
+		meta, err = store.Get("feature/b")
+		require.NoError(t, err)
+		assert.Empty(t, meta.Note)
+		assert.Equal(t, []string{"review"}, meta.Tags)
+	})
+
+	t.Run("set and clear editor", func(t *testing.T) {
+		store := NewMetadataStoreAt(filepath.Join(t.TempDir(), MetadataDir, MetadataFile))
+
+		require.NoError(t, store.SetEditor("docs/guide", "code"))
+		meta, err := store.Get("docs/guide")
+		require.NoError(t, err)
+		assert.Equal(t, "code", meta.Editor)
+
+		// Clearing the only field drops the entry
+		require.NoError(t, store.SetEditor("docs/guide", ""))
+		entries, err := store.Load()
+		require.NoError(t, err)
+		assert.NotContains(t, entries, "docs/guide")
+	})
+
+	t.Run("rename moves the entry", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), MetadataDir, MetadataFile)
+		store := NewMetadataStoreAt(path)
+
+		// Nothing to move writes nothing
+		require.NoError(t, store.Rename("feature/none", "feature/other"))
+		assert.NoFileExists(t, path)
+
+		require.NoError(t, store.AddTags("feature/old", "review"))
+		require.NoError(t, store.Rename("feature/old", "feature/new"))
+
+		entries, err := store.Load()
+		require.NoError(t, err)
+		assert.NotContains(t, entries, "feature/old")
+		assert.Equal(t, []string{"review"}, entries["feature/new"].Tags)
+	})
+
+	t.Run("concurrent updates are not lost", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), MetadataDir, MetadataFile)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				// Separate store instances mimic independent callers
+				store := NewMetadataStoreAt(path)
+				assert.NoError(t, store.SetNote(fmt.Sprintf("branch-%d", i), "note"))
+			}(i)
+		}
+		wg.Wait()
+
+		entries, err := NewMetadataStoreAt(path).Load()
+		require.NoError(t, err)
+		assert.Len(t, entries, 20)
+		assert.NoFileExists(t, path+".lock")
+	})
+
+	t.Run("invalid file returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), MetadataFile)
+		store := NewMetadataStoreAt(path)
+		require.NoError(t, os.WriteFile(path, []byte("{not json"), 0644))
+
+		_, err := store.Load()
+		assert.Error(t, err)
+	})
+}
+
+func TestLister_Tags(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "tags-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	reviewPath := filepath.Join(testRepo.TempDir, "tags-test-feature-review")
+	otherPath := filepath.Join(testRepo.TempDir, "tags-test-feature-other")
+	require.NoError(t, repo.CreateWorktree(reviewPath, "feature/review", true))
+	require.NoError(t, repo.CreateWorktree(otherPath, "feature/other", true))
+
+	store, err := NewMetadataStore(repo)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(testRepo.RepoDir, MetadataDir, MetadataFile), store.Path())
+	require.NoError(t, store.AddTags("feature/review", "review"))
+	require.NoError(t, store.SetNote("feature/review", "waiting on\nreview"))
+
+	t.Run("tags are populated", func(t *testing.T) {
+		result, err := NewLister(repo).ListWorktrees(ListOptions{})
+		require.NoError(t, err)
+
+		for _, wt := range result.Worktrees {
+			if wt.Branch == "feature/review" {
+				assert.Equal(t, []string{"review"}, wt.Tags)
+			} else {
+				assert.Empty(t, wt.Tags)
+			}
+		}
+
+		table := result.FormatAsTable()
+		assert.Contains(t, table, "TAGS")
+		assert.Contains(t, table, "review")
+	})
+
+	t.Run("filter by tag", func(t *testing.T) {
+		result, err := NewLister(repo).ListWorktrees(ListOptions{Tag: "review"})
+		require.NoError(t, err)
+		require.Len(t, result.Worktrees, 1)
+		assert.Equal(t, "feature/review", result.Worktrees[0].Branch)
+	})
+
+	t.Run("notes column", func(t *testing.T) {
+		result, err := NewLister(repo).ListWorktrees(ListOptions{})
+		require.NoError(t, err)
+		assert.NotContains(t, result.FormatAsTable(), "NOTE")
+
+		result, err = NewLister(repo).ListWorktrees(ListOptions{ShowNotes: true})
+		require.NoError(t, err)
+		table := result.FormatAsTable()
+		assert.Contains(t, table, "NOTE")
+		assert.Contains(t, table, "waiting on review")
+	})
+
+	t.Run("finder populates metadata", func(t *testing.T) {
+		info, err := NewFinder(repo).GetWorktreeInfo(reviewPath)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"review"}, info.Tags)
+		assert.Equal(t, "waiting on\nreview", info.Note)
+	})
+}
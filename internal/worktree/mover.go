@@ -17,10 +17,11 @@ type EditorDetector interface {
 
 // Mover handles worktree movement and editor integration
 type Mover struct {
-	repo     git.Repository
-	detector EditorDetector
-	finder   *Finder
-	creator  *Creator
+	repo        git.Repository
+	detector    EditorDetector
+	finder      *Finder
+	creator     *Creator
+	windowReuse bool // Default window behavior from editor.windowReuse
 }
 
 // NewMover creates a new worktree mover
@@ -33,12 +34,47 @@ func NewMover(repo git.Repository, detector EditorDetector) *Mover {
 	}
 }
 
+// SetLayout sets where worktrees are expected and created, and how their
+// directories are named
+func (m *Mover) SetLayout(layout Layout) {
+	m.finder.SetLayout(layout)
+	m.creator.SetLayout(layout)
+}
+
+// SetWindowReuse sets whether worktrees open in an existing window unless a
+// move asks for a specific window mode
+func (m *Mover) SetWindowReuse(reuse bool) {
+	m.windowReuse = reuse
+}
+
+// WindowMode selects how a move opens the editor window
+type WindowMode int
+
+const (
+	WindowDefault WindowMode = iota // Follow the mover's window reuse setting
+	WindowNew                       // Always open a new window
+	WindowReuse                     // Open in an existing window if the editor allows
+)
+
+// reuseWindow resolves mode against the configured default
+func (m *Mover) reuseWindow(mode WindowMode) bool {
+	switch mode {
+	case WindowNew:
+		return false
+	case WindowReuse:
+		return true
+	default:
+		return m.windowReuse
+	}
+}
+
 // MoveOptions contains options for moving to a worktree
 type MoveOptions struct {
 	BranchName    string
 	SwitchMode    bool   // Close current editor and switch
 	AutoCreate    bool   // Create worktree if it doesn't exist
 	EditorCommand string // Specific editor to use
+	Window        WindowMode
 }
 
 // CreateAndMoveOptions contains options for creating and moving to a worktree
@@ -48,15 +84,19 @@ type CreateAndMoveOptions struct {
 	NoCopy            bool
 	NoGitignoreUpdate bool
 	EditorCommand     string
+	Window            WindowMode
 }
 
 // MoveResult contains the result of a move operation
 type MoveResult struct {
-	BranchName   string    `json:"branchName"`
-	WorktreePath string    `json:"worktreePath"`
-	CreatedNew   bool      `json:"createdNew"`
-	EditorUsed   string    `json:"editorUsed"`
-	Timestamp    time.Time `json:"timestamp"`
+	BranchName   string `json:"branchName"`
+	WorktreePath string `json:"worktreePath"`
+	CreatedNew   bool   `json:"createdNew"`
+	EditorUsed   string `json:"editorUsed"`
+	// Editors that failed to open the worktree before EditorUsed did
+	FailedEditors []string  `json:"failedEditors,omitempty"`
+	Focused       bool      `json:"focused,omitempty"` // An existing window was focused instead of opening a new one
+	Timestamp     time.Time `json:"timestamp"`
 }
 
 // MoveToWorktree moves to an existing worktree or creates one if requested
@@ -92,11 +132,27 @@ func (m *Mover) MoveToWorktree(options MoveOptions) (*MoveResult, error) {
 	}
 
 	// Get editor to use
-	selectedEditor, err := m.selectEditor(options.EditorCommand)
+	editorCommand := m.editorFor(options.BranchName, options.EditorCommand)
+	selectedEditor, err := m.selectEditor(editorCommand)
 	if err != nil {
 		return nil, err
 	}
 
+	// Focus the window the worktree is already open in instead of opening a
+	// duplicate; the editors bring an open folder to the front
+	if !options.SwitchMode && m.isOpenIn(worktreePath, selectedEditor) {
+		if err := selectedEditor.Open(worktreePath); err == nil {
+			return &MoveResult{
+				BranchName:   options.BranchName,
+				WorktreePath: worktreePath,
+				CreatedNew:   createdNew,
+				EditorUsed:   selectedEditor.Name(),
+				Focused:      true,
+				Timestamp:    time.Now(),
+			}, nil
+		}
+	}
+
 	// Handle switch mode (quit current editor first)
 	if options.SwitchMode {
 		if selectedEditor.IsRunning() {
@@ -110,16 +166,19 @@ func (m *Mover) MoveToWorktree(options MoveOptions) (*MoveResult, error) {
 	}
 
 	// Open worktree in editor
-	if err := selectedEditor.OpenInNewWindow(worktreePath); err != nil {
-		return nil, fmt.Errorf("failed to open editor: %w", err)
+	usedEditor, failed, err := m.openEditor(selectedEditor, worktreePath, m.reuseWindow(options.Window), editorCommand == "")
+	if err != nil {
+		return nil, err
 	}
+	m.recordOpened(worktreePath, usedEditor)
 
 	return &MoveResult{
-		BranchName:   options.BranchName,
-		WorktreePath: worktreePath,
-		CreatedNew:   createdNew,
-		EditorUsed:   selectedEditor.Name(),
-		Timestamp:    time.Now(),
+		BranchName:    options.BranchName,
+		WorktreePath:  worktreePath,
+		CreatedNew:    createdNew,
+		EditorUsed:    usedEditor.Name(),
+		FailedEditors: failed,
+		Timestamp:     time.Now(),
 	}, nil
 }
 
@@ -140,25 +199,39 @@ func (m *Mover) CreateAndMove(options CreateAndMoveOptions) (*MoveResult, error)
 	}
 
 	// Get editor to use
-	selectedEditor, err := m.selectEditor(options.EditorCommand)
+	editorCommand := m.editorFor(createResult.BranchName, options.EditorCommand)
+	selectedEditor, err := m.selectEditor(editorCommand)
 	if err != nil {
 		return nil, err
 	}
 
 	// Open worktree in editor
-	if err := selectedEditor.OpenInNewWindow(createResult.WorktreePath); err != nil {
-		return nil, fmt.Errorf("failed to open editor: %w", err)
+	usedEditor, failed, err := m.openEditor(selectedEditor, createResult.WorktreePath, m.reuseWindow(options.Window), editorCommand == "")
+	if err != nil {
+		return nil, err
 	}
+	m.recordOpened(createResult.WorktreePath, usedEditor)
 
 	return &MoveResult{
-		BranchName:   createResult.BranchName,
-		WorktreePath: createResult.WorktreePath,
-		CreatedNew:   true,
-		EditorUsed:   selectedEditor.Name(),
-		Timestamp:    time.Now(),
+		BranchName:    createResult.BranchName,
+		WorktreePath:  createResult.WorktreePath,
+		CreatedNew:    true,
+		EditorUsed:    usedEditor.Name(),
+		FailedEditors: failed,
+		Timestamp:     time.Now(),
 	}, nil
 }
 
+// editorFor returns the editor command to open branch's worktree in: the
+// requested one, else the editor pinned in the worktree metadata. An empty
+// result leaves the choice to the configured editor order.
+func (m *Mover) editorFor(branch, requested string) string {
+	if requested != "" {
+		return requested
+	}
+	return loadMetadata(m.repo)[branch].Editor
+}
+
 // selectEditor selects the appropriate editor based on options
 func (m *Mover) selectEditor(editorCommand string) (editor.Editor, error) {
 	if editorCommand != "" {
@@ -182,6 +255,42 @@ func (m *Mover) selectEditor(editorCommand string) (editor.Editor, error) {
 	return bestEditor, nil
 }
 
+// openEditor opens path in selected, in a new window unless reuse is set.
+// With fallback, a failure moves on to the next available editor in
+// detection order. It returns the editor used and the names of the editors
+// that failed before it.
+func (m *Mover) openEditor(selected editor.Editor, path string, reuse, fallback bool) (editor.Editor, []string, error) {
+	open := func(ed editor.Editor) error {
+		if reuse {
+			return ed.Open(path)
+		}
+		return ed.OpenInNewWindow(path)
+	}
+
+	err := open(selected)
+	if err == nil {
+		return selected, nil, nil
+	}
+	if !fallback {
+		return nil, nil, fmt.Errorf("failed to open editor: %w", err)
+	}
+
+	firstErr := err
+	failed := []string{selected.Name()}
+	for _, candidate := range m.detector.DetectAvailable() {
+		if candidate.Command() == selected.Command() {
+			continue
+		}
+		if err := open(candidate); err != nil {
+			failed = append(failed, candidate.Name())
+			continue
+		}
+		return candidate, failed, nil
+	}
+
+	return nil, nil, fmt.Errorf("failed to open editor: %w", firstErr)
+}
+
 // GetAvailableEditors returns a list of available editors
 func (m *Mover) GetAvailableEditors() []editor.Editor {
 	return m.detector.DetectAvailable()
@@ -20,6 +20,9 @@ type MockEditor struct {
 	installed  bool
 	running    bool
 	openCalled bool
+	openedPath string
+	newWindows int
+	reuses     int
 	quitCalled bool
 	openError  error
 	quitError  error
@@ -43,11 +46,16 @@ func (m *MockEditor) IsRunning() bool             { return m.running }
 
 func (m *MockEditor) Open(path string) error {
 	m.openCalled = true
+	m.reuses++
 	return m.openError
 }
 
 func (m *MockEditor) OpenInNewWindow(path string) error {
 	m.openCalled = true
+	m.newWindows++
+	if m.openError == nil {
+		m.openedPath = path
+	}
 	return m.openError
 }
 
@@ -280,6 +288,242 @@ func TestMover_MoveToWorktree(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Contains(t, err.Error(), "failed to open editor")
 	})
+
+	t.Run("fall back to next editor when open fails", func(t *testing.T) {
+		primary := NewMockEditor("Primary Editor", "primary", 1, true)
+		primary.SetOpenError(assert.AnError)
+		secondary := NewMockEditor("Secondary Editor", "secondary", 2, true)
+
+		fallbackDetector := NewMockEditorDetector()
+		fallbackDetector.AddEditor(primary)
+		fallbackDetector.AddEditor(secondary)
+		fallbackMover := NewMover(repo, fallbackDetector)
+
+		result, err := fallbackMover.MoveToWorktree(MoveOptions{BranchName: "feature/test-move"})
+		require.NoError(t, err)
+		assert.True(t, primary.openCalled)
+		assert.Equal(t, result.WorktreePath, secondary.openedPath)
+		assert.Equal(t, "Secondary Editor", result.EditorUsed)
+		assert.Equal(t, []string{"Primary Editor"}, result.FailedEditors)
+	})
+
+	t.Run("no fallback when editor was requested", func(t *testing.T) {
+		primary := NewMockEditor("Primary Editor", "primary", 1, true)
+		primary.SetOpenError(assert.AnError)
+		secondary := NewMockEditor("Secondary Editor", "secondary", 2, true)
+
+		fallbackDetector := NewMockEditorDetector()
+		fallbackDetector.AddEditor(primary)
+		fallbackDetector.AddEditor(secondary)
+		fallbackMover := NewMover(repo, fallbackDetector)
+
+		result, err := fallbackMover.MoveToWorktree(MoveOptions{BranchName: "feature/test-move", EditorCommand: "primary"})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.False(t, secondary.openCalled)
+	})
+
+	t.Run("all editors fail", func(t *testing.T) {
+		primary := NewMockEditor("Primary Editor", "primary", 1, true)
+		primary.SetOpenError(assert.AnError)
+		secondary := NewMockEditor("Secondary Editor", "secondary", 2, true)
+		secondary.SetOpenError(assert.AnError)
+
+		fallbackDetector := NewMockEditorDetector()
+		fallbackDetector.AddEditor(primary)
+		fallbackDetector.AddEditor(secondary)
+		fallbackMover := NewMover(repo, fallbackDetector)
+
+		result, err := fallbackMover.MoveToWorktree(MoveOptions{BranchName: "feature/test-move"})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.True(t, secondary.openCalled)
+		assert.Contains(t, err.Error(), "failed to open editor")
+	})
+}
+
+func TestMover_EditorState(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "editor-state-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	branchName := "feature/state"
+	worktreePath := filepath.Join(testRepo.TempDir, "editor-state-test-feature-state")
+	require.NoError(t, repo.CreateWorktree(worktreePath, branchName, true))
+
+	gitDir, err := repo.GitDir(worktreePath)
+	require.NoError(t, err)
+	statePath := filepath.Join(gitDir, EditorStateFile)
+
+	mockEditor := NewMockEditor("Test Editor", "test-editor", 1, true)
+	detector := NewMockEditorDetector()
+	detector.AddEditor(mockEditor)
+	mover := NewMover(repo, detector)
+
+	t.Run("first move opens a new window and records it", func(t *testing.T) {
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName})
+		require.NoError(t, err)
+		assert.False(t, result.Focused)
+		assert.Equal(t, 1, mockEditor.newWindows)
+
+		state, err := LoadEditorState(statePath)
+		require.NoError(t, err)
+		require.NotNil(t, state)
+		assert.Equal(t, "test-editor", state.Editor)
+	})
+
+	t.Run("open worktree is focused instead of reopened", func(t *testing.T) {
+		mockEditor.SetRunning(true)
+		defer mockEditor.SetRunning(false)
+
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName})
+		require.NoError(t, err)
+		assert.True(t, result.Focused)
+		assert.Equal(t, 1, mockEditor.newWindows)
+	})
+
+	t.Run("stale state opens a new window", func(t *testing.T) {
+		// The editor was closed since the worktree was opened
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName})
+		require.NoError(t, err)
+		assert.False(t, result.Focused)
+		assert.Equal(t, 2, mockEditor.newWindows)
+	})
+
+	t.Run("state of another editor opens a new window", func(t *testing.T) {
+		otherEditor := NewMockEditor("Other Editor", "other-editor", 2, true)
+		otherEditor.SetRunning(true)
+		detector.AddEditor(otherEditor)
+
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName, EditorCommand: "other-editor"})
+		require.NoError(t, err)
+		assert.False(t, result.Focused)
+		assert.Equal(t, 1, otherEditor.newWindows)
+
+		state, err := LoadEditorState(statePath)
+		require.NoError(t, err)
+		assert.Equal(t, "other-editor", state.Editor)
+	})
+}
+
+func TestMover_PinnedEditor(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "pinned-editor-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	branchName := "docs/guide"
+	worktreePath := filepath.Join(testRepo.TempDir, "pinned-editor-test-docs-guide")
+	require.NoError(t, repo.CreateWorktree(worktreePath, branchName, true))
+
+	store, err := NewMetadataStore(repo)
+	require.NoError(t, err)
+
+	// The detector's order stands in for the global configuration
+	cursor := NewMockEditor("Cursor", "cursor", 1, true)
+	code := NewMockEditor("VS Code", "code", 2, true)
+	detector := NewMockEditorDetector()
+	detector.AddEditor(cursor)
+	detector.AddEditor(code)
+	mover := NewMover(repo, detector)
+
+	t.Run("configured order without a pin", func(t *testing.T) {
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName})
+		require.NoError(t, err)
+		assert.Equal(t, "Cursor", result.EditorUsed)
+	})
+
+	t.Run("pin overrides the configured order", func(t *testing.T) {
+		require.NoError(t, store.SetEditor(branchName, "code"))
+
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName})
+		require.NoError(t, err)
+		assert.Equal(t, "VS Code", result.EditorUsed)
+	})
+
+	t.Run("--editor overrides the pin", func(t *testing.T) {
+		result, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName, EditorCommand: "cursor"})
+		require.NoError(t, err)
+		assert.Equal(t, "Cursor", result.EditorUsed)
+	})
+
+	t.Run("pinned editor that is not installed fails", func(t *testing.T) {
+		detector.AddEditor(NewMockEditor("Zed", "zed", 3, false))
+		require.NoError(t, store.SetEditor(branchName, "zed"))
+
+		_, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not installed")
+	})
+}
+
+func TestMover_WindowMode(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "window-mode-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	branchName := "feature/window"
+	worktreePath := filepath.Join(testRepo.TempDir, "window-mode-test-feature-window")
+	require.NoError(t, repo.CreateWorktree(worktreePath, branchName, true))
+
+	tests := []struct {
+		name        string
+		configReuse bool
+		mode        WindowMode
+		wantReuse   bool
+	}{
+		{"default opens a new window", false, WindowDefault, false},
+		{"default follows windowReuse", true, WindowDefault, true},
+		{"--new-window overrides windowReuse", true, WindowNew, false},
+		{"--reuse-window overrides config", false, WindowReuse, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEditor := NewMockEditor("Test Editor", "test-editor", 1, true)
+			detector := NewMockEditorDetector()
+			detector.AddEditor(mockEditor)
+
+			mover := NewMover(repo, detector)
+			mover.SetWindowReuse(tt.configReuse)
+
+			_, err := mover.MoveToWorktree(MoveOptions{BranchName: branchName, Window: tt.mode})
+			require.NoError(t, err)
+
+			if tt.wantReuse {
+				assert.Equal(t, 1, mockEditor.reuses)
+				assert.Zero(t, mockEditor.newWindows)
+			} else {
+				assert.Zero(t, mockEditor.reuses)
+				assert.Equal(t, 1, mockEditor.newWindows)
+			}
+		})
+	}
+}
+
+func TestLoadEditorState(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		state, err := LoadEditorState(filepath.Join(t.TempDir(), EditorStateFile))
+		require.NoError(t, err)
+		assert.Nil(t, state)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), EditorStateFile)
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0644))
+
+		_, err := LoadEditorState(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), EditorStateFile)
+		require.NoError(t, SaveEditorState(path, NewMockEditor("Test Editor", "test-editor", 1, true)))
+
+		state, err := LoadEditorState(path)
+		require.NoError(t, err)
+		assert.Equal(t, "test-editor", state.Editor)
+		assert.False(t, state.OpenedAt.IsZero())
+	})
 }
 
 func TestMover_CreateAndMove(t *testing.T) {
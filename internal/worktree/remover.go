@@ -3,6 +3,7 @@ package worktree
 import (
 	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -53,6 +54,8 @@ type RemovalPlan struct {
 type Remover struct {
 	repo   git.Repository
 	finder *Finder
+	layout Layout
+	input  *bufio.Reader // Answers to confirmation prompts; stdin when nil
 }
 
 // NewRemover creates a new Remover instance
@@ -63,6 +66,18 @@ func NewRemover(repo git.Repository) *Remover {
 	}
 }
 
+// SetLayout sets where worktrees are expected and how their directories are
+// named
+func (r *Remover) SetLayout(layout Layout) {
+	r.layout = layout
+	r.finder.SetLayout(layout)
+}
+
+// SetInput sets where answers to confirmation prompts are read from
+func (r *Remover) SetInput(input io.Reader) {
+	r.input = bufio.NewReader(input)
+}
+
 // RemoveWorktree removes a worktree and optionally its associated branches
 func (r *Remover) RemoveWorktree(options RemoveOptions) (*RemovalResult, error) {
 	// Validate the removal operation
@@ -106,6 +121,10 @@ func (r *Remover) RemoveWorktree(options RemoveOptions) (*RemovalResult, error)
 			return nil, fmt.Errorf("failed to remove worktree: %w", err)
 		}
 		result.WorktreeRemoved = true
+
+		if root, err := r.repo.GetRoot(); err == nil {
+			r.layout.removeEmptyNamespaceDirs(root, validation.WorktreePath)
+		}
 	}
 
 	// Remove local branch if requested
@@ -145,32 +164,15 @@ func (r *Remover) ValidateRemoval(branchName string) (*RemovalValidation, error)
 	}
 
 	// Check if this is the main repository
-	currentBranch, err := r.repo.GetCurrentBranch()
+	isMain, err := isMainRepository(r.repo, branchName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current branch: %w", err)
+		return nil, err
 	}
-
-	if branchName == currentBranch {
-		// Check if we're in the main repository
-		worktrees, err := r.repo.ListWorktrees()
-		if err != nil {
-			return nil, fmt.Errorf("failed to list worktrees: %w", err)
-		}
-
-		repoRoot, err := r.repo.GetRoot()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get repository root: %w", err)
-		}
-
-		// Find if current directory is the main repository
-		for _, wt := range worktrees {
-			if wt.Path == repoRoot && wt.Branch == branchName {
-				validation.IsMainRepository = true
-				validation.CanRemove = false
-				validation.Warnings = append(validation.Warnings, "Cannot remove main repository worktree")
-				return validation, nil
-			}
-		}
+	if isMain {
+		validation.IsMainRepository = true
+		validation.CanRemove = false
+		validation.Warnings = append(validation.Warnings, "Cannot remove main repository worktree")
+		return validation, nil
 	}
 
 	// Find the worktree path
@@ -197,7 +199,7 @@ func (r *Remover) ValidateRemoval(branchName string) (*RemovalValidation, error)
 
 	// Check for uncommitted changes
 	if validation.WorktreeExists {
-		hasChanges, err := r.hasUncommittedChanges(worktreePath)
+		hasChanges, err := r.repo.HasUncommittedChanges(worktreePath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check for uncommitted changes: %w", err)
 		}
@@ -275,34 +277,48 @@ func (r *Remover) ConfirmRemoval(plan *RemovalPlan, skipConfirm bool) bool {
 	return r.promptUser("\nDo you want to continue?")
 }
 
-// hasUncommittedChanges checks if a worktree has uncommitted changes
-func (r *Remover) hasUncommittedChanges(worktreePath string) (bool, error) {
-	// Check if there are any files in the worktree directory
-	// This is a simplified check - in a real implementation, we'd use git status
-	entries, err := os.ReadDir(worktreePath)
-	if err != nil {
-		return false, err
-	}
+// promptUser prompts the user for yes/no confirmation
+func (r *Remover) promptUser(message string) bool {
+	fmt.Printf("%s (y/N): ", message)
 
-	// Look for non-git files
-	for _, entry := range entries {
-		if entry.Name() != ".git" && !strings.HasPrefix(entry.Name(), ".git") {
-			return true, nil
-		}
+	input := r.input
+	if input == nil {
+		input = bufio.NewReader(os.Stdin)
+	}
+	line, err := input.ReadString('\n')
+	if err != nil && line == "" {
+		return false
 	}
 
-	return false, nil
+	response := strings.ToLower(strings.TrimSpace(line))
+	return response == "y" || response == "yes"
 }
 
-// promptUser prompts the user for yes/no confirmation
-func (r *Remover) promptUser(message string) bool {
-	fmt.Printf("%s (y/N): ", message)
+// isMainRepository reports whether branchName is the branch checked out in
+// the main repository, whose worktree must never be removed or moved
+func isMainRepository(repo git.Repository, branchName string) (bool, error) {
+	currentBranch, err := repo.GetCurrentBranch()
+	if err != nil {
+		return false, fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if branchName != currentBranch {
+		return false, nil
+	}
+
+	worktrees, err := repo.ListWorktreeEntries()
+	if err != nil {
+		return false, fmt.Errorf("failed to list worktrees: %w", err)
+	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-	if scanner.Scan() {
-		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
-		return response == "y" || response == "yes"
+	repoRoot, err := repo.GetRoot()
+	if err != nil {
+		return false, fmt.Errorf("failed to get repository root: %w", err)
 	}
 
-	return false
+	for _, wt := range worktrees {
+		if wt.Path == repoRoot && wt.Branch == branchName {
+			return true, nil
+		}
+	}
+	return false, nil
 }
@@ -2,7 +2,9 @@ package worktree
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/keisukeshimizu/hatcher/internal/git"
@@ -220,19 +222,28 @@ func TestRemover_ValidateRemoval(t *testing.T) {
 		err := repo.CreateWorktree(worktreePath, branchName, true)
 		require.NoError(t, err)
 
-		// Create uncommitted changes
+		// Commit a baseline, so only the staged change below is uncommitted
 		testFile := filepath.Join(worktreePath, "uncommitted.txt")
-		err = os.WriteFile(testFile, []byte("uncommitted content"), 0644)
+		require.NoError(t, os.WriteFile(testFile, []byte("committed content"), 0644))
+		runGit(t, worktreePath, "add", "uncommitted.txt")
+		runGit(t, worktreePath, "commit", "-m", "Add baseline")
+
+		validation, err := remover.ValidateRemoval(branchName)
 		require.NoError(t, err)
+		assert.Empty(t, validation.Warnings)
+
+		// Create uncommitted changes
+		require.NoError(t, os.WriteFile(testFile, []byte("uncommitted content"), 0644))
+		runGit(t, worktreePath, "add", "uncommitted.txt")
 
 		// Validate removal
-		validation, err := remover.ValidateRemoval(branchName)
+		validation, err = remover.ValidateRemoval(branchName)
 		require.NoError(t, err)
 		assert.NotNil(t, validation)
 
 		// Should warn about uncommitted changes
 		assert.True(t, validation.CanRemove) // Can still remove with force
-		assert.NotEmpty(t, validation.Warnings)
+		assert.Equal(t, []string{"Worktree has uncommitted changes"}, validation.Warnings)
 	})
 
 	t.Run("validate removal of non-existent worktree", func(t *testing.T) {
@@ -247,6 +258,15 @@ func TestRemover_ValidateRemoval(t *testing.T) {
 	})
 }
 
+// runGit runs a git command in dir
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+}
+
 func TestRemover_GetRemovalPlan(t *testing.T) {
 	// Create test repository
 	testRepo := testutil.NewTestGitRepository(t, "plan-test")
@@ -356,4 +376,45 @@ func TestRemover_ConfirmRemoval(t *testing.T) {
 		confirmed := remover.ConfirmRemoval(plan, false)
 		assert.False(t, confirmed) // Simulated user decline
 	})
+	t.Run("answers read from the input", func(t *testing.T) {
+		plan := &RemovalPlan{
+			BranchName:         "feature/test",
+			WorktreePath:       "/path/to/worktree",
+			WillRemoveWorktree: true,
+			Description:        "Remove worktree only",
+			Warnings:           []string{"Worktree has uncommitted changes"},
+		}
+
+		for input, want := range map[string]bool{
+			"y\n":   true,
+			"YES\n": true,
+			" y ":   true,
+			"n\n":   false,
+			"\n":    false,
+			"maybe": false,
+			"":      false,
+		} {
+			remover := NewRemover(repo)
+			remover.SetInput(strings.NewReader(input))
+			assert.Equal(t, want, remover.ConfirmRemoval(plan, false), "input %q", input)
+		}
+	})
+
+	t.Run("skip confirmation does not read the input", func(t *testing.T) {
+		input := strings.NewReader("n\n")
+		remover := NewRemover(repo)
+		remover.SetInput(input)
+
+		assert.True(t, remover.ConfirmRemoval(&RemovalPlan{Description: "Remove worktree only"}, true))
+		assert.Equal(t, 2, input.Len())
+	})
+
+	t.Run("one answer per prompt", func(t *testing.T) {
+		remover := NewRemover(repo)
+		remover.SetInput(strings.NewReader("y\nn\n"))
+		plan := &RemovalPlan{Description: "Remove worktree only"}
+
+		assert.True(t, remover.ConfirmRemoval(plan, false))
+		assert.False(t, remover.ConfirmRemoval(plan, false))
+	})
 }
@@ -0,0 +1,104 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+// RenameResult contains the result of a worktree rename operation
+type RenameResult struct {
+	OldBranch string // Branch name before the rename
+	NewBranch string // Branch name after the rename
+	OldPath   string // Worktree path before the rename
+	NewPath   string // Worktree path after the rename
+}
+
+// Renamer handles renaming a branch together with its worktree
+type Renamer struct {
+	repo   git.Repository
+	finder *Finder
+	layout Layout
+}
+
+// NewRenamer creates a new Renamer instance
+func NewRenamer(repo git.Repository) *Renamer {
+	return &Renamer{
+		repo:   repo,
+		finder: NewFinder(repo),
+	}
+}
+
+// SetLayout sets where worktrees are moved to and how their directories are
+// named
+func (r *Renamer) SetLayout(layout Layout) {
+	r.layout = layout
+	r.finder.SetLayout(layout)
+}
+
+// Rename renames oldBranch to newBranch and moves its worktree to the path
+// hatcher generates for newBranch
+func (r *Renamer) Rename(oldBranch, newBranch string) (*RenameResult, error) {
+	if err := ValidateBranchName(newBranch); err != nil {
+		return nil, fmt.Errorf("invalid branch name: %w", err)
+	}
+
+	isMain, err := isMainRepository(r.repo, oldBranch)
+	if err != nil {
+		return nil, err
+	}
+	if isMain {
+		return nil, fmt.Errorf("cannot rename main repository worktree")
+	}
+
+	oldPath, found, err := r.finder.FindWorktree(oldBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find worktree: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("worktree not found for branch '%s'", oldBranch)
+	}
+
+	exists, err := r.repo.BranchExists(newBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check local branch existence: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("branch already exists: %s", newBranch)
+	}
+
+	root, err := r.repo.GetRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository root: %w", err)
+	}
+	newPath := r.layout.WorktreePath(root, r.repo.GetProjectName(), newBranch)
+	if _, err := os.Lstat(newPath); err == nil {
+		return nil, fmt.Errorf("directory already exists: %s", newPath)
+	}
+
+	if err := r.repo.RenameBranch(oldBranch, newBranch); err != nil {
+		return nil, err
+	}
+
+	// Nested sanitizers and base directories may need parents git does not
+	// create
+	if err := os.MkdirAll(filepath.Dir(newPath), 0755); err != nil {
+		r.repo.RenameBranch(newBranch, oldBranch)
+		return nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	if err := r.repo.MoveWorktree(oldPath, newPath); err != nil {
+		// Keep the branch and its worktree consistent
+		r.repo.RenameBranch(newBranch, oldBranch)
+		return nil, err
+	}
+	r.layout.removeEmptyNamespaceDirs(root, oldPath)
+
+	return &RenameResult{
+		OldBranch: oldBranch,
+		NewBranch: newBranch,
+		OldPath:   oldPath,
+		NewPath:   newPath,
+	}, nil
+}
@@ -0,0 +1,78 @@
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenamer_Rename(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "renamer-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	renamer := NewRenamer(repo)
+
+	t.Run("renames the branch and moves its worktree", func(t *testing.T) {
+		oldPath := filepath.Join(testRepo.TempDir, "renamer-test-feature-old")
+		require.NoError(t, repo.CreateWorktree(oldPath, "feature/old", true))
+
+		result, err := renamer.Rename("feature/old", "feature/new")
+		require.NoError(t, err)
+
+		newPath := filepath.Join(testRepo.TempDir, "renamer-test-feature-new")
+		assert.Equal(t, oldPath, result.OldPath)
+		assert.Equal(t, newPath, result.NewPath)
+		assert.NoDirExists(t, oldPath)
+		assert.DirExists(t, newPath)
+
+		exists, err := repo.BranchExists("feature/old")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		path, found, err := NewFinder(repo).FindWorktree("feature/new")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, newPath, path)
+	})
+
+	t.Run("refuses the main repository", func(t *testing.T) {
+		currentBranch := testRepo.GetCurrentBranch()
+
+		_, err := renamer.Rename(currentBranch, "feature/renamed-main")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "main repository")
+
+		exists, err := repo.BranchExists(currentBranch)
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("refuses an existing destination", func(t *testing.T) {
+		oldPath := filepath.Join(testRepo.TempDir, "renamer-test-feature-taken")
+		require.NoError(t, repo.CreateWorktree(oldPath, "feature/taken", true))
+		occupied := filepath.Join(testRepo.TempDir, "renamer-test-feature-occupied")
+		require.NoError(t, os.MkdirAll(occupied, 0755))
+
+		_, err := renamer.Rename("feature/taken", "feature/occupied")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+
+		// Nothing was renamed
+		exists, err := repo.BranchExists("feature/taken")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.DirExists(t, oldPath)
+	})
+
+	t.Run("unknown branch", func(t *testing.T) {
+		_, err := renamer.Rename("feature/missing", "feature/other")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
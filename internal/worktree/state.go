@@ -0,0 +1,270 @@
+package worktree
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+// StateVersion is the current version of the exported state format
+const StateVersion = 1
+
+// State describes the hatcher worktrees of a repository so they can be
+// recreated elsewhere
+type State struct {
+	Version   int          `json:"version"`
+	Project   string       `json:"project"`
+	Worktrees []StateEntry `json:"worktrees"`
+}
+
+// StateEntry describes a single exported worktree
+type StateEntry struct {
+	Branch  string   `json:"branch"`
+	BaseRef string   `json:"baseRef,omitempty"`
+	Head    string   `json:"head,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	Note    string   `json:"note,omitempty"`
+}
+
+// ImportOptions contains options for importing state
+type ImportOptions struct {
+	DryRun bool
+}
+
+// ImportEntryResult describes what happened to a single state entry
+type ImportEntryResult struct {
+	Branch string `json:"branch"`
+	Path   string `json:"path,omitempty"`
+	Ref    string `json:"ref,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ImportResult contains the result of importing state
+type ImportResult struct {
+	Created []ImportEntryResult `json:"created"`
+	Skipped []ImportEntryResult `json:"skipped"`
+}
+
+// StateManager exports and imports hatcher worktree state
+type StateManager struct {
+	repo   git.Repository
+	layout Layout
+}
+
+// NewStateManager creates a new state manager
+func NewStateManager(repo git.Repository) *StateManager {
+	return &StateManager{
+		repo: repo,
+	}
+}
+
+// SetLayout sets how hatcher-managed worktrees are recognized and where
+// imported ones are created
+func (m *StateManager) SetLayout(layout Layout) {
+	m.layout = layout
+}
+
+// Export collects the state of all hatcher-managed worktrees
+func (m *StateManager) Export() (*State, error) {
+	gitWorktrees, err := m.repo.ListWorktreeEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	root, err := m.repo.GetRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository root: %w", err)
+	}
+	projectName := m.repo.GetProjectName()
+	metadata := loadMetadata(m.repo)
+
+	state := &State{
+		Version:   StateVersion,
+		Project:   projectName,
+		Worktrees: []StateEntry{},
+	}
+
+	for _, gitWt := range gitWorktrees {
+		if gitWt.Branch == "" {
+			continue
+		}
+		if _, ok := m.layout.hatcherBranchDir(root, gitWt.Path, projectName); !ok {
+			continue
+		}
+
+		// Prefer the upstream branch as base since local commits may not
+		// exist on the machine the state is imported on
+		baseRef, err := m.repo.GetUpstream(gitWt.Branch)
+		if err != nil {
+			return nil, err
+		}
+
+		meta := metadata[gitWt.Branch]
+		state.Worktrees = append(state.Worktrees, StateEntry{
+			Branch:  gitWt.Branch,
+			BaseRef: baseRef,
+			Head:    gitWt.Head,
+			Tags:    meta.Tags,
+			Note:    meta.Note,
+		})
+	}
+
+	return state, nil
+}
+
+// Import recreates the worktrees described by state. Existing worktrees are
+// left untouched and entries whose refs cannot be resolved are skipped.
+func (m *StateManager) Import(state *State, opts ImportOptions) (*ImportResult, error) {
+	if state.Version > StateVersion {
+		return nil, fmt.Errorf("unsupported state version %d (max %d)", state.Version, StateVersion)
+	}
+
+	root, err := m.repo.GetRoot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	finder := NewFinder(m.repo)
+	finder.SetLayout(m.layout)
+	projectName := m.repo.GetProjectName()
+	result := &ImportResult{
+		Created: []ImportEntryResult{},
+		Skipped: []ImportEntryResult{},
+	}
+
+	var store *MetadataStore
+	if !opts.DryRun {
+		if store, err = NewMetadataStore(m.repo); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range state.Worktrees {
+		entryResult := ImportEntryResult{Branch: entry.Branch}
+
+		if err := ValidateBranchName(entry.Branch); err != nil {
+			entryResult.Reason = err.Error()
+			result.Skipped = append(result.Skipped, entryResult)
+			continue
+		}
+
+		if path, exists, err := finder.FindWorktree(entry.Branch); err != nil {
+			return nil, err
+		} else if exists {
+			entryResult.Path = path
+			entryResult.Reason = "worktree already exists"
+			result.Skipped = append(result.Skipped, entryResult)
+			continue
+		}
+
+		entryResult.Path = m.layout.WorktreePath(root, projectName, entry.Branch)
+		if _, err := os.Stat(entryResult.Path); err == nil {
+			entryResult.Reason = "directory already exists"
+			result.Skipped = append(result.Skipped, entryResult)
+			continue
+		}
+
+		// Validate refs before creating anything
+		ref, newBranch, err := m.resolveEntryRef(entry)
+		if err != nil {
+			return nil, err
+		}
+		if ref == "" {
+			entryResult.Reason = "no existing ref found for branch"
+			result.Skipped = append(result.Skipped, entryResult)
+			continue
+		}
+		entryResult.Ref = ref
+
+		if !opts.DryRun {
+			if newBranch {
+				err = m.repo.CreateWorktreeFromRef(entryResult.Path, entry.Branch, ref)
+			} else {
+				err = m.repo.CreateWorktree(entryResult.Path, entry.Branch, false)
+			}
+			if err != nil {
+				entryResult.Reason = err.Error()
+				result.Skipped = append(result.Skipped, entryResult)
+				continue
+			}
+
+			if len(entry.Tags) > 0 {
+				if err := store.AddTags(entry.Branch, entry.Tags...); err != nil {
+					return nil, err
+				}
+			}
+			if entry.Note != "" {
+				if err := store.SetNote(entry.Branch, entry.Note); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		result.Created = append(result.Created, entryResult)
+	}
+
+	return result, nil
+}
+
+// resolveEntryRef determines what a worktree should be created from. It
+// returns the ref and whether a new branch has to be created from it; an
+// empty ref means nothing usable exists.
+func (m *StateManager) resolveEntryRef(entry StateEntry) (string, bool, error) {
+	// An existing local or remote branch is checked out directly
+	localExists, err := m.repo.BranchExists(entry.Branch)
+	if err != nil {
+		return "", false, err
+	}
+	if localExists {
+		return entry.Branch, false, nil
+	}
+
+	remoteExists, err := m.repo.RemoteBranchExists(entry.Branch)
+	if err != nil {
+		return "", false, err
+	}
+	if remoteExists {
+		return "origin/" + entry.Branch, false, nil
+	}
+
+	for _, ref := range []string{entry.BaseRef, entry.Head} {
+		if ref == "" {
+			continue
+		}
+		exists, err := m.repo.RefExists(ref)
+		if err != nil {
+			return "", false, err
+		}
+		if exists {
+			return ref, true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// LoadState reads exported state from a file
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+
+	return &state, nil
+}
+
+// FormatAsJSON formats the state as JSON
+func (s *State) FormatAsJSON() (string, error) {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return string(data) + "\n", nil
+}
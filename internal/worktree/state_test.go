@@ -0,0 +1,98 @@
+package worktree
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateManager_ExportImport(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "state-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	baseBranch := testRepo.GetCurrentBranch()
+	worktreePath := filepath.Join(testRepo.TempDir, "state-test-feature-export")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/export", true))
+
+	store, err := NewMetadataStore(repo)
+	require.NoError(t, err)
+	require.NoError(t, store.AddTags("feature/export", "review"))
+	require.NoError(t, store.SetNote("feature/export", "waiting on review"))
+
+	manager := NewStateManager(repo)
+
+	t.Run("export hatcher worktrees", func(t *testing.T) {
+		state, err := manager.Export()
+		require.NoError(t, err)
+
+		assert.Equal(t, StateVersion, state.Version)
+		assert.Equal(t, "state-test", state.Project)
+		require.Len(t, state.Worktrees, 1)
+
+		entry := state.Worktrees[0]
+		assert.Equal(t, "feature/export", entry.Branch)
+		assert.NotEmpty(t, entry.Head)
+		assert.Equal(t, []string{"review"}, entry.Tags)
+		assert.Equal(t, "waiting on review", entry.Note)
+	})
+
+	t.Run("import creates missing and skips existing", func(t *testing.T) {
+		state := &State{
+			Version: StateVersion,
+			Project: "state-test",
+			Worktrees: []StateEntry{
+				{Branch: "feature/export"},
+				{Branch: "feature/imported", BaseRef: baseBranch, Tags: []string{"imported"}},
+				{Branch: "feature/missing", BaseRef: "does-not-exist"},
+			},
+		}
+
+		// Dry run reports the plan without creating anything
+		result, err := manager.Import(state, ImportOptions{DryRun: true})
+		require.NoError(t, err)
+		require.Len(t, result.Created, 1)
+		assert.NoDirExists(t, result.Created[0].Path)
+
+		result, err = manager.Import(state, ImportOptions{})
+		require.NoError(t, err)
+
+		require.Len(t, result.Created, 1)
+		assert.Equal(t, "feature/imported", result.Created[0].Branch)
+		assert.Equal(t, baseBranch, result.Created[0].Ref)
+		assert.DirExists(t, result.Created[0].Path)
+
+		require.Len(t, result.Skipped, 2)
+		assert.Equal(t, "feature/export", result.Skipped[0].Branch)
+		assert.Equal(t, "worktree already exists", result.Skipped[0].Reason)
+		assert.Equal(t, "feature/missing", result.Skipped[1].Branch)
+
+		meta, err := store.Get("feature/imported")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"imported"}, meta.Tags)
+	})
+
+	t.Run("import recreates worktree for existing branch", func(t *testing.T) {
+		require.NoError(t, repo.RemoveWorktree(worktreePath, true))
+
+		state := &State{
+			Version:   StateVersion,
+			Worktrees: []StateEntry{{Branch: "feature/export"}},
+		}
+
+		result, err := manager.Import(state, ImportOptions{})
+		require.NoError(t, err)
+		require.Len(t, result.Created, 1)
+		assert.Equal(t, "feature/export", result.Created[0].Ref)
+		assert.DirExists(t, worktreePath)
+	})
+
+	t.Run("newer state version is rejected", func(t *testing.T) {
+		_, err := manager.Import(&State{Version: StateVersion + 1}, ImportOptions{})
+		assert.Error(t, err)
+	})
+}
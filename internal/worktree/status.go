@@ -0,0 +1,111 @@
+package worktree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+// StatusReport summarizes a single worktree
+type StatusReport struct {
+	Branch           string           `json:"branch"`
+	Path             string           `json:"path"`
+	Head             string           `json:"head"` // Abbreviated commit checked out
+	IsMain           bool             `json:"isMain"`
+	IsHatcherManaged bool             `json:"isHatcherManaged"`
+	Base             string           `json:"base,omitempty"` // Default branch Ahead and Behind count against; empty when unknown
+	Ahead            int              `json:"ahead"`
+	Behind           int              `json:"behind"`
+	Dirty            bool             `json:"dirty"`
+	AutoCopy         []AutoCopyStatus `json:"autoCopy,omitempty"`
+}
+
+// AutoCopyStatus reports whether a configured auto-copy path exists in a
+// worktree
+type AutoCopyStatus struct {
+	Path    string `json:"path"`
+	Present bool   `json:"present"`
+}
+
+// shortHashLength is the length commits are abbreviated to in reports
+const shortHashLength = 7
+
+// StatusReporter builds status reports of worktrees
+type StatusReporter struct {
+	repo   git.Repository
+	finder *Finder
+}
+
+// NewStatusReporter creates a new StatusReporter instance
+func NewStatusReporter(repo git.Repository) *StatusReporter {
+	return &StatusReporter{
+		repo:   repo,
+		finder: NewFinder(repo),
+	}
+}
+
+// SetLayout sets where worktrees are expected and how their directories are
+// named
+func (s *StatusReporter) SetLayout(layout Layout) {
+	s.finder.SetLayout(layout)
+}
+
+// Status reports on the worktree of branch, checking which of the
+// autoCopyPaths, relative to the worktree root, are present
+func (s *StatusReporter) Status(branch string, autoCopyPaths []string) (*StatusReport, error) {
+	path, found, err := s.finder.FindWorktree(branch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find worktree: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("worktree not found for branch '%s'", branch)
+	}
+
+	info, err := s.finder.GetWorktreeInfo(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mainPath, err := mainWorktreePath(s.repo)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &StatusReport{
+		Branch:           info.Branch,
+		Path:             info.Path,
+		Head:             info.Head,
+		IsMain:           sameDir(info.Path, mainPath),
+		IsHatcherManaged: info.IsHatcherManaged,
+	}
+	if len(report.Head) > shortHashLength {
+		report.Head = report.Head[:shortHashLength]
+	}
+
+	// Without a default branch or a shared history there is nothing to
+	// count against
+	if base, err := s.repo.GetDefaultBranch(); err == nil && base != info.Branch {
+		ahead, behind, err := s.repo.GetAheadBehind(info.Head, base)
+		switch {
+		case errors.Is(err, git.ErrNoCommonAncestor):
+		case err != nil:
+			return nil, err
+		default:
+			report.Base, report.Ahead, report.Behind = base, ahead, behind
+		}
+	}
+
+	report.Dirty, err = s.repo.HasUncommittedChanges(info.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, copyPath := range autoCopyPaths {
+		_, err := os.Lstat(filepath.Join(info.Path, filepath.FromSlash(copyPath)))
+		report.AutoCopy = append(report.AutoCopy, AutoCopyStatus{Path: copyPath, Present: err == nil})
+	}
+	return report, nil
+}
@@ -0,0 +1,80 @@
+package worktree
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusReporter_Status(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "status-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+	mainBranch := testRepo.GetCurrentBranch()
+
+	commit := func(dir, file string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(file), 0644))
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", "Add " + file}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			require.NoError(t, cmd.Run())
+		}
+	}
+
+	worktreePath := filepath.Join(testRepo.TempDir, "status-test-feature-status")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/status", true))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, ".env"), []byte("SECRET=1"), 0644))
+
+	// Two commits on the branch, one on the default branch
+	commit(worktreePath, "one.txt")
+	commit(worktreePath, "two.txt")
+	commit(testRepo.RepoDir, "main.txt")
+
+	reporter := NewStatusReporter(repo)
+
+	t.Run("clean worktree", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(testRepo.RepoDir, ".git", "info", "exclude"), []byte(".env\n"), 0644))
+
+		report, err := reporter.Status("feature/status", []string{".env", ".ai"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "feature/status", report.Branch)
+		assert.Equal(t, worktreePath, report.Path)
+		assert.Len(t, report.Head, 7)
+		assert.True(t, report.IsHatcherManaged)
+		assert.False(t, report.IsMain)
+		assert.Equal(t, mainBranch, report.Base)
+		assert.Equal(t, 2, report.Ahead)
+		assert.Equal(t, 1, report.Behind)
+		assert.False(t, report.Dirty)
+		assert.Equal(t, []AutoCopyStatus{{Path: ".env", Present: true}, {Path: ".ai", Present: false}}, report.AutoCopy)
+	})
+
+	t.Run("dirty worktree", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "one.txt"), []byte("changed"), 0644))
+
+		report, err := reporter.Status("feature/status", nil)
+		require.NoError(t, err)
+		assert.True(t, report.Dirty)
+		assert.Empty(t, report.AutoCopy)
+	})
+
+	t.Run("main worktree", func(t *testing.T) {
+		report, err := reporter.Status(mainBranch, nil)
+		require.NoError(t, err)
+		assert.True(t, report.IsMain)
+		assert.Empty(t, report.Base)
+	})
+
+	t.Run("branch without a worktree", func(t *testing.T) {
+		_, err := reporter.Status("feature/missing", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "worktree not found for branch 'feature/missing'")
+	})
+}
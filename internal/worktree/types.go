@@ -16,6 +16,9 @@ type WorktreeInfo struct {
 	IsMain           bool               `json:"isMain"`
 	IsHatcherManaged bool               `json:"isHatcherManaged"`
 	Editor           string             `json:"editor,omitempty"`
+	Tags             []string           `json:"tags,omitempty"`
+	Note             string             `json:"note,omitempty"`
+	LastModified     time.Time          `json:"lastModified,omitempty"` // Set when sorting by mtime or filtering stale worktrees
 }
 
 // WorktreeStatus represents the status of a worktree (alias for compatibility)
@@ -0,0 +1,158 @@
+package worktree
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/keisukeshimizu/hatcher/internal/fileid"
+	"github.com/keisukeshimizu/hatcher/internal/git"
+)
+
+// UsageOptions contains options for measuring disk usage
+type UsageOptions struct {
+	ShowAll bool // Include worktrees not managed by Hatcher
+}
+
+// WorktreeUsage describes the disk usage of a single worktree
+type WorktreeUsage struct {
+	Branch        string `json:"branch"`
+	Path          string `json:"path"`
+	Files         int    `json:"files"`
+	LogicalBytes  int64  `json:"logicalBytes"`  // Sum of file sizes
+	PhysicalBytes int64  `json:"physicalBytes"` // Bytes not shared with a worktree listed before it
+}
+
+// UsageReport summarizes disk usage across worktrees
+type UsageReport struct {
+	Worktrees     []WorktreeUsage `json:"worktrees"`
+	LogicalBytes  int64           `json:"logicalBytes"`
+	PhysicalBytes int64           `json:"physicalBytes"`
+	SavedBytes    int64           `json:"savedBytes"`
+}
+
+// UsageCalculator measures disk usage of worktrees
+type UsageCalculator struct {
+	repo   git.Repository
+	layout Layout
+}
+
+// NewUsageCalculator creates a new usage calculator
+func NewUsageCalculator(repo git.Repository) *UsageCalculator {
+	return &UsageCalculator{
+		repo: repo,
+	}
+}
+
+// SetLayout sets how hatcher-managed worktrees are recognized
+func (c *UsageCalculator) SetLayout(layout Layout) {
+	c.layout = layout
+}
+
+// Calculate measures the disk usage of each worktree. Files that share an
+// inode (hardlinks) are only counted physically the first time they are
+// seen, so the difference between logical and physical bytes is the space
+// saved by sharing.
+func (c *UsageCalculator) Calculate(options UsageOptions) (*UsageReport, error) {
+	lister := NewLister(c.repo)
+	lister.SetLayout(c.layout)
+	listed, err := lister.ListWorktrees(ListOptions{ShowAll: options.ShowAll})
+	if err != nil {
+		return nil, err
+	}
+
+	report := &UsageReport{Worktrees: []WorktreeUsage{}}
+	seen := make(map[fileid.ID]bool)
+
+	for _, wt := range listed.Worktrees {
+		usage, err := measureWorktree(wt, seen)
+		if err != nil {
+			return nil, err
+		}
+		report.Worktrees = append(report.Worktrees, usage)
+		report.LogicalBytes += usage.LogicalBytes
+		report.PhysicalBytes += usage.PhysicalBytes
+	}
+	report.SavedBytes = report.LogicalBytes - report.PhysicalBytes
+
+	return report, nil
+}
+
+// measureWorktree sums the sizes of the files in a worktree, skipping its
+// git metadata
+func measureWorktree(wt WorktreeInfo, seen map[fileid.ID]bool) (WorktreeUsage, error) {
+	usage := WorktreeUsage{
+		Branch: wt.Branch,
+		Path:   wt.Path,
+	}
+
+	err := filepath.Walk(wt.Path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if filepath.Dir(path) == wt.Path && info.Name() == ".git" {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		usage.Files++
+		usage.LogicalBytes += info.Size()
+
+		if id, ok := fileid.Of(info); ok {
+			if seen[id] {
+				return nil // Shared with a file counted already
+			}
+			seen[id] = true
+		}
+		usage.PhysicalBytes += info.Size()
+
+		return nil
+	})
+	if err != nil {
+		return usage, fmt.Errorf("failed to measure worktree %s: %w", wt.Path, err)
+	}
+
+	return usage, nil
+}
+
+// FormatAsTable formats the report as a table
+func (r *UsageReport) FormatAsTable() string {
+	if len(r.Worktrees) == 0 {
+		return "No worktrees found.\n"
+	}
+
+	var output bytes.Buffer
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "BRANCH\tFILES\tSIZE\tUNIQUE")
+	fmt.Fprintln(w, "------\t-----\t----\t------")
+	for _, wt := range r.Worktrees {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", wt.Branch, wt.Files, formatMB(wt.LogicalBytes), formatMB(wt.PhysicalBytes))
+	}
+	w.Flush()
+
+	fmt.Fprintf(&output, "\nTotal: %s on disk, %s saved by shared files\n", formatMB(r.PhysicalBytes), formatMB(r.SavedBytes))
+	return output.String()
+}
+
+// FormatAsJSON formats the report as JSON
+func (r *UsageReport) FormatAsJSON() string {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Sprintf(`{"error": "failed to marshal JSON: %s"}`, err.Error())
+	}
+	return string(data) + "\n"
+}
+
+// formatMB formats a byte count in megabytes, e.g. "3.4 MB"
+func formatMB(size int64) string {
+	return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
@@ -0,0 +1,62 @@
+//go:build !windows
+
+package worktree
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/keisukeshimizu/hatcher/internal/git"
+	"github.com/keisukeshimizu/hatcher/test/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUsageCalculator_Calculate(t *testing.T) {
+	testRepo := testutil.NewTestGitRepository(t, "usage-test")
+	repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+	require.NoError(t, err)
+
+	worktreePath := filepath.Join(testRepo.TempDir, "usage-test-feature-du")
+	require.NoError(t, repo.CreateWorktree(worktreePath, "feature/du", true))
+
+	// One file shared through a hardlink, one copied normally
+	shared := strings.Repeat("s", 4096)
+	testRepo.CreateFile(".ai/shared.md", shared)
+	require.NoError(t, os.MkdirAll(filepath.Join(worktreePath, ".ai"), 0755))
+	require.NoError(t, os.Link(filepath.Join(testRepo.RepoDir, ".ai/shared.md"), filepath.Join(worktreePath, ".ai/shared.md")))
+	require.NoError(t, os.WriteFile(filepath.Join(worktreePath, "copied.md"), []byte("copied"), 0644))
+
+	report, err := NewUsageCalculator(repo).Calculate(UsageOptions{})
+	require.NoError(t, err)
+	require.Len(t, report.Worktrees, 2)
+
+	main, feature := report.Worktrees[0], report.Worktrees[1]
+	assert.Equal(t, testRepo.RepoDir, main.Path)
+	assert.Equal(t, main.LogicalBytes, main.PhysicalBytes, "the first worktree owns all its files")
+
+	assert.Equal(t, "feature/du", feature.Branch)
+	assert.Equal(t, int64(len(shared)), feature.LogicalBytes-feature.PhysicalBytes)
+	assert.Equal(t, int64(len(shared)), report.SavedBytes)
+	assert.Equal(t, report.LogicalBytes-report.SavedBytes, report.PhysicalBytes)
+
+	t.Run("git metadata is not counted", func(t *testing.T) {
+		var files int
+		err := filepath.Walk(worktreePath, func(path string, info os.FileInfo, err error) error {
+			if info.Mode().IsRegular() && filepath.Base(path) != ".git" {
+				files++
+			}
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, files, feature.Files)
+	})
+
+	t.Run("table output", func(t *testing.T) {
+		output := report.FormatAsTable()
+		assert.Contains(t, output, "feature/du")
+		assert.Contains(t, output, "saved by shared files")
+	})
+}
@@ -2,8 +2,10 @@ package worktree
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 )
 
 // NormalizePath normalizes a path for cross-platform comparison
@@ -19,12 +21,49 @@ func PathsEqual(path1, path2 string) bool {
 	return NormalizePath(path1) == NormalizePath(path2)
 }
 
-// GenerateWorktreePath generates the full path for a worktree
+// Layout decides where worktrees are created and how branch names become
+// their directory names. The zero Layout creates worktrees next to the
+// repository with the default sanitizer.
+type Layout struct {
+	// BaseDir is the directory new worktrees are created in. "~" stands for
+	// the home directory and a relative dir is taken relative to the
+	// repository's parent directory; empty keeps worktrees next to the
+	// repository.
+	BaseDir   string
+	Sanitizer Sanitizer
+}
+
+// GenerateWorktreePath generates the full path for a worktree next to the
+// repository, using the default layout
 func GenerateWorktreePath(repoRoot, projectName, branchName string) string {
-	branchNameSafe := SanitizeBranchName(branchName)
+	return Layout{}.WorktreePath(repoRoot, projectName, branchName)
+}
+
+// WorktreePath generates the full path for a worktree: a sibling of the
+// repository, or a directory in the base directory
+func (l Layout) WorktreePath(repoRoot, projectName, branchName string) string {
+	branchNameSafe := l.Sanitizer.Sanitize(branchName)
 	dirName := fmt.Sprintf("%s-%s", projectName, branchNameSafe)
+	return filepath.Join(l.baseDir(repoRoot), dirName)
+}
+
+// baseDir returns the directory worktrees of the repository at repoRoot are
+// created in
+func (l Layout) baseDir(repoRoot string) string {
+	dir := l.BaseDir
 	parentDir := filepath.Dir(repoRoot)
-	return filepath.Join(parentDir, dirName)
+	if dir == "" {
+		return parentDir
+	}
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dir = filepath.Join(home, strings.TrimPrefix(dir, "~"))
+		}
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(parentDir, dir)
+	}
+	return filepath.Clean(dir)
 }
 
 // IsHatcherWorktree checks if a worktree was created by Hatcher based on naming convention
@@ -34,36 +73,117 @@ func IsHatcherWorktree(worktreePath, projectName string) bool {
 	return strings.HasPrefix(dirName, expectedPrefix)
 }
 
-// SanitizeBranchName converts a branch name to a filesystem-safe format
+// Sanitizer converts branch names to worktree directory names
+type Sanitizer struct {
+	Separator string // Replaces unsafe characters; "-" when empty
+	Nested    bool   // Keep "/" so branch namespaces become nested directories
+}
+
+// unsafeNameChars are replaced in directory names, along with "/" unless the
+// sanitizer is nested
+const unsafeNameChars = ` @#:*?"<>|\`
+
+// ValidateSeparator checks that separator can replace unsafe characters in
+// directory names. An empty separator selects the default "-".
+func ValidateSeparator(separator string) error {
+	if separator == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(separator) != 1 {
+		return fmt.Errorf("sanitize separator must be a single character: %q", separator)
+	}
+	if strings.ContainsAny(separator, unsafeNameChars+"/") {
+		return fmt.Errorf("sanitize separator must be safe in directory names: %q", separator)
+	}
+	return nil
+}
+
+// SanitizeBranchName converts a branch name to a filesystem-safe format using
+// the default sanitizer
 func SanitizeBranchName(branch string) string {
-	// Replace / with -
-	safe := strings.ReplaceAll(branch, "/", "-")
-
-	// Replace other problematic characters
-	replacements := map[string]string{
-		" ":  "-",
-		"@":  "-",
-		"#":  "-",
-		":":  "-",
-		"*":  "-",
-		"?":  "-",
-		"\"": "-",
-		"<":  "-",
-		">":  "-",
-		"|":  "-",
+	return Sanitizer{}.Sanitize(branch)
+}
+
+// Sanitize converts a branch name to a filesystem-safe directory name
+func (s Sanitizer) Sanitize(branch string) string {
+	if !s.Nested {
+		return s.sanitizeSegment(strings.ReplaceAll(branch, "/", s.separator()))
 	}
 
-	for old, new := range replacements {
-		safe = strings.ReplaceAll(safe, old, new)
+	// Each namespace becomes a directory; empty segments are dropped
+	var segments []string
+	for _, segment := range strings.Split(branch, "/") {
+		if safe := s.sanitizeSegment(segment); safe != "" && safe != "." {
+			segments = append(segments, safe)
+		}
 	}
+	return strings.Join(segments, "/")
+}
 
-	// Remove leading/trailing dashes
-	safe = strings.Trim(safe, "-")
+// sanitizeSegment replaces unsafe characters in a single path segment,
+// trimming and collapsing separators
+func (s Sanitizer) sanitizeSegment(segment string) string {
+	separator := s.separator()
+	replacement, _ := utf8.DecodeRuneInString(separator)
 
-	// Collapse multiple consecutive dashes
-	for strings.Contains(safe, "--") {
-		safe = strings.ReplaceAll(safe, "--", "-")
+	safe := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(unsafeNameChars, r) {
+			return replacement
+		}
+		return r
+	}, segment)
+
+	// Collapse multiple consecutive separators
+	for strings.Contains(safe, separator+separator) {
+		safe = strings.ReplaceAll(safe, separator+separator, separator)
 	}
 
-	return safe
+	// Remove leading/trailing separators
+	return strings.Trim(safe, separator)
+}
+
+// separator returns the replacement for unsafe characters
+func (s Sanitizer) separator() string {
+	if s.Separator == "" {
+		return "-"
+	}
+	return s.Separator
+}
+
+// hatcherBranchDir returns the sanitized branch part of a hatcher worktree
+// path: the path below the base directory, or the repository's parent
+// directory for worktrees created before a base was configured, without the
+// project prefix, which spans several directories for nested sanitizers.
+// Worktrees elsewhere are matched by their base name.
+func (l Layout) hatcherBranchDir(repoRoot, worktreePath, projectName string) (string, bool) {
+	prefix := projectName + "-"
+
+	for _, dir := range []string{l.baseDir(repoRoot), filepath.Dir(repoRoot)} {
+		if rel, err := filepath.Rel(dir, worktreePath); err == nil {
+			rel = filepath.ToSlash(rel)
+			if strings.HasPrefix(rel, prefix) {
+				return strings.TrimPrefix(rel, prefix), true
+			}
+		}
+	}
+
+	if dirName := filepath.Base(worktreePath); strings.HasPrefix(dirName, prefix) {
+		return strings.TrimPrefix(dirName, prefix), true
+	}
+	return "", false
+}
+
+// removeEmptyNamespaceDirs removes the directories a nested sanitizer created
+// above worktreePath once they are empty, up to the base directory
+func (l Layout) removeEmptyNamespaceDirs(repoRoot, worktreePath string) {
+	parentDir := l.baseDir(repoRoot)
+	if !strings.HasPrefix(worktreePath, parentDir+string(filepath.Separator)) {
+		parentDir = filepath.Dir(repoRoot)
+	}
+	for dir := filepath.Dir(worktreePath); strings.HasPrefix(dir, parentDir+string(filepath.Separator)); dir = filepath.Dir(dir) {
+		// Only empty directories can be removed
+		if os.Remove(dir) != nil {
+			return
+		}
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/keisukeshimizu/hatcher/internal/git"
 	"github.com/keisukeshimizu/hatcher/test/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -267,9 +268,12 @@ func TestMoveCommandWorkflow(t *testing.T) {
 			assert.Contains(t, stdout, "✅ Found worktree")
 		}
 
-		// Step 3: Move back to main (if main worktree exists)
-		currentBranch := "main" // Assume main branch
-		err = cliHelper.ExecuteCommand(rootCmd, "move", currentBranch)
+		// Step 3: Move back to the main worktree on the default branch
+		repo, err := git.NewRepositoryFromPath(testRepo.RepoDir)
+		require.NoError(t, err)
+		defaultBranch, err := repo.GetDefaultBranch()
+		require.NoError(t, err)
+		err = cliHelper.ExecuteCommand(rootCmd, "move", defaultBranch)
 
 		// This tests moving between different worktrees
 		if err == nil {
@@ -70,20 +70,14 @@ func TestPathTraversalPrevention(t *testing.T) {
 
 		copier := autocopy.NewAutoCopier(repo, config, autocopy.AutoCopierOptions{})
 
-		// Copy operation should handle symlinks safely
+		// Links leading outside the repository are skipped rather than
+		// exposing their target's content
 		err = copier.Run(testRepo.RepoDir, destDir)
-		// Should either succeed (copying the link itself) or fail safely
-		// Should NOT copy the target file content
+		require.NoError(t, err)
 
 		destLinkPath := filepath.Join(destDir, "malicious_link")
-		if _, err := os.Stat(destLinkPath); err == nil {
-			// If the symlink was copied, verify it doesn't expose sensitive data
-			content, err := os.ReadFile(destLinkPath)
-			if err == nil {
-				// Should not contain actual /etc/passwd content
-				assert.NotContains(t, string(content), "root:", "Should not expose system files")
-			}
-		}
+		_, err = os.Lstat(destLinkPath)
+		assert.True(t, os.IsNotExist(err), "Should not copy links to system files")
 	})
 
 	t.Run("prevent overwriting system files", func(t *testing.T) {